@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
@@ -22,16 +23,79 @@ func cleanupFunc (db *sqlx.DB) {
 	}
 }
 
-func buildApp(cfg config.Config) (*gin.Engine, *sqlx.DB, error) {
+func closeReplicas(replicas *infrastructure.ReplicaSet) {
+	if replicas == nil {
+		return
+	}
+	log.Println("Closing read replica connections...")
+	if err := replicas.Close(); err != nil {
+		log.Printf("Error while closing read replicas: %v", err)
+	}
+}
+
+func buildApp(cfg config.Config) (*gin.Engine, func(), error) {
+	cacheControlRoutes, err := controllers.ParseCacheControlRules(cfg.CacheControlRoutes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read CACHE_CONTROL_ROUTES: %w", err)
+	}
 	db, err := infrastructure.NewConnection(cfg)
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not connect to database: %w", err)
 	}
+	if cfg.AutoMigrate {
+		log.Println("Applying pending migrations...")
+		if err := migrateUp(db); err != nil {
+			cleanupFunc(db)
+			return nil, nil, fmt.Errorf("could not migrate database: %w", err)
+		}
+	}
+	replicas, err := infrastructure.ConnectReplicas(cfg, db)
+	if err != nil {
+		cleanupFunc(db)
+		return nil, nil, fmt.Errorf("could not connect to read replicas: %w", err)
+	}
+	cleanup := func() {
+		closeReplicas(replicas)
+		cleanupFunc(db)
+	}
 	log.Println("Initializing dependencies...")
-	eventRepository := infrastructure.NewEventRepository(db)
-	sportRepository := infrastructure.NewSportRepository(db)
-	venueRepository := infrastructure.NewVenueRepository(db)
-	teamRepository := infrastructure.NewTeamRepository(db)
+	var eventRepository services.EventRepositoryInterface = infrastructure.NewEventRepository(db)
+	var sportRepository services.SportRepositoryInterface = infrastructure.NewSportRepository(db)
+	var venueRepository services.VenueRepositoryInterface = infrastructure.NewVenueRepository(db)
+	var teamRepository services.TeamRepositoryInterface = infrastructure.NewTeamRepository(db)
+	var eventChangeRepository services.EventChangeRepositoryInterface = infrastructure.NewEventChangeRepository(db)
+	var seriesRepository services.SeriesRepositoryInterface = infrastructure.NewSeriesRepository(db)
+	var rescheduleRepository services.EventRescheduleRepositoryInterface = infrastructure.NewEventRescheduleRepository(db)
+	var broadcastRepository services.BroadcastRepositoryInterface = infrastructure.NewBroadcastRepository(db)
+	searchRepository := infrastructure.NewSearchRepository(db)
+	var purgeRepository services.PurgeRepositoryInterface = infrastructure.NewPurgeRepository(db)
+	auditRepository := infrastructure.NewAuditRepository(db)
+	var lastModifiedRepository services.LastModifiedRepositoryInterface = infrastructure.NewLastModifiedRepository(db)
+	var transactor services.Transactor = infrastructure.NewTransactor(db)
+	if replicas != nil {
+		eventRepository = infrastructure.NewRoutedEventRepository(replicas)
+		sportRepository = infrastructure.NewRoutedSportRepository(replicas)
+		venueRepository = infrastructure.NewRoutedVenueRepository(replicas)
+		teamRepository = infrastructure.NewRoutedTeamRepository(replicas)
+		eventChangeRepository = infrastructure.NewRoutedEventChangeRepository(replicas)
+		seriesRepository = infrastructure.NewRoutedSeriesRepository(replicas)
+		rescheduleRepository = infrastructure.NewRoutedEventRescheduleRepository(replicas)
+		broadcastRepository = infrastructure.NewRoutedBroadcastRepository(replicas)
+		lastModifiedRepository = infrastructure.NewRoutedLastModifiedRepository(replicas)
+		transactor = infrastructure.NewRoutedTransactor(replicas)
+	}
+	if cfg.QueryCacheEnabled {
+		cache := infrastructure.NewRepositoryCache(cfg.QueryCacheSize, time.Duration(cfg.QueryCacheTTLSeconds)*time.Second)
+		eventRepository = infrastructure.NewCachedEventRepository(eventRepository, cache)
+		sportRepository = infrastructure.NewCachedSportRepository(sportRepository, cache)
+		venueRepository = infrastructure.NewCachedVenueRepository(venueRepository, cache)
+		teamRepository = infrastructure.NewCachedTeamRepository(teamRepository, cache)
+		seriesRepository = infrastructure.NewCachedSeriesRepository(seriesRepository, cache)
+		broadcastRepository = infrastructure.NewCachedBroadcastRepository(broadcastRepository, cache)
+		purgeRepository = infrastructure.NewCachedPurgeRepository(purgeRepository, cache)
+		transactor = infrastructure.NewCachedTransactor(transactor, cache)
+		startCacheStatsLog(cache, cfg.QueryCacheStatsIntervalMinutes)
+	}
 	eventService := services.NewEventService(
 		eventRepository,
 		cfg.DefaultPage,
@@ -39,25 +103,69 @@ func buildApp(cfg config.Config) (*gin.Engine, *sqlx.DB, error) {
 		sportRepository,
 		teamRepository,
 		venueRepository,
+		eventChangeRepository,
+		rescheduleRepository,
+		transactor,
 	)
 	sportService := services.NewSportService(
 		sportRepository,
 		eventRepository,
+		transactor,
 	)
 	venueService := services.NewVenueService(
 		venueRepository,
 		eventRepository,
+		transactor,
 	)
 	teamService := services.NewTeamService(
 		teamRepository,
 		eventRepository,
+		transactor,
+	)
+	seriesService := services.NewSeriesService(
+		seriesRepository,
+		eventRepository,
+		venueRepository,
+		eventService,
+		cfg.SeriesHorizonDays,
+		transactor,
+	)
+	broadcastService := services.NewBroadcastService(
+		broadcastRepository,
+		eventRepository,
+	)
+	searchService := services.NewSearchService(searchRepository)
+	feedService := services.NewFeedService(
+		eventChangeRepository,
+		cfg.FeedLimit,
+	)
+	purgeService := services.NewPurgeService(
+		purgeRepository,
+		cfg.SoftDeleteRetentionDays,
+	)
+	auditService := services.NewAuditService(
+		auditRepository,
+		eventRepository,
 	)
+	lastModifiedService := services.NewLastModifiedService(lastModifiedRepository)
+	startPurgeJob(purgeService, cfg.PurgeIntervalMinutes)
 	sportHandler := controllers.NewSportHandler(sportService)
 	eventHandler := controllers.NewEventHandler(eventService)
 	venueHandler := controllers.NewVenueHandler(venueService)
 	teamHandler := controllers.NewTeamHandler(teamService)
+	feedHandler := controllers.NewFeedHandler(feedService)
+	seriesHandler := controllers.NewSeriesHandler(seriesService)
+	broadcastHandler := controllers.NewBroadcastHandler(broadcastService)
+	searchHandler := controllers.NewSearchHandler(searchService)
+	auditHandler := controllers.NewAuditHandler(auditService)
 	log.Println("Setting up routes...")
-	router := controllers.NewRouter(eventHandler, sportHandler, venueHandler, teamHandler)
+	router := controllers.NewRouter(eventHandler, sportHandler, venueHandler, teamHandler, feedHandler, seriesHandler,
+		broadcastHandler, searchHandler, auditHandler, lastModifiedService, controllers.RouterOptions{
+			RequireIfMatch:     cfg.RequireIfMatch,
+			CacheControl:       cfg.CacheControl,
+			CacheControlRoutes: cacheControlRoutes,
+			WithoutPostgres:    cfg.DBDriver == "sqlite",
+		})
 	server := router.InitServer()
-	return server, db, nil
+	return server, cleanup, nil
 }
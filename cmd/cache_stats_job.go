@@ -0,0 +1,29 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/vsennikov/sports-event-calendar/infrastructure"
+)
+
+// startCacheStatsLog logs the query cache statistics every intervalMinutes in
+// the background. An interval of 0 or less disables it.
+func startCacheStatsLog(cache *infrastructure.RepositoryCache, intervalMinutes int) {
+	if intervalMinutes <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			logCacheStats(cache)
+		}
+	}()
+}
+
+func logCacheStats(cache *infrastructure.RepositoryCache) {
+	stats := cache.Stats()
+	log.Printf("Query cache: %d hits, %d misses (%.1f%% hit ratio), %d evictions, %d invalidations, %d entries",
+		stats.Hits, stats.Misses, 100*stats.HitRatio(), stats.Evictions, stats.Invalidations, stats.Entries)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/vsennikov/sports-event-calendar/config"
+)
+
+const configUsage = "usage: config print"
+
+// runConfig runs the config subcommand: print writes the effective
+// configuration to stdout as a YAML config file, secrets redacted. The
+// configuration is printed even when invalid, loadErr then being returned
+// after it.
+func runConfig(cfg config.Config, loadErr error, args []string) error {
+	if len(args) != 1 || args[0] != "print" {
+		return errors.New(configUsage)
+	}
+	if err := cfg.WriteYAML(os.Stdout); err != nil {
+		return err
+	}
+	return loadErr
+}
@@ -2,20 +2,44 @@ package main
 
 import (
 	"log"
+	"os"
+	_ "time/tzdata"
 
 	"github.com/vsennikov/sports-event-calendar/config"
 )
 
 func main() {
 	cfg, err := config.Load()
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if err := runConfig(cfg, err, os.Args[2:]); err != nil {
+			log.Fatalf("Config failed: %v", err)
+		}
+		return
+	}
 	if err != nil {
 		log.Fatalf("Could not load configuration: %v", err)
 	}
-	router, db, err := buildApp(cfg)
+	cfg.Log()
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			if err := runMigrate(cfg, os.Args[2:]); err != nil {
+				log.Fatalf("Migration failed: %v", err)
+			}
+		case "seed":
+			if err := runSeed(cfg, os.Args[2:]); err != nil {
+				log.Fatalf("Seeding failed: %v", err)
+			}
+		default:
+			log.Fatalf("Unknown command %q", os.Args[1])
+		}
+		return
+	}
+	router, cleanup, err := buildApp(cfg)
 	if err != nil {
 		log.Fatalf("Could not build application: %v", err)
 	}
-	defer cleanupFunc(db)
+	defer cleanup()
 	log.Printf("Server starting on http://localhost:%s", cfg.AppPort)
 	if err := router.Run(":" + cfg.AppPort); err != nil {
 		log.Fatalf("FATAL: Could not start server: %v", err)
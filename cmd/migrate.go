@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/config"
+	"github.com/vsennikov/sports-event-calendar/infrastructure"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status | goto <version>"
+
+// runMigrate runs the migrate subcommand against the configured database:
+// up applies the pending migrations, down reverts the last steps (1 by
+// default), goto moves to a version and status lists them all.
+func runMigrate(cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	steps := 1
+	version := 0
+	switch args[0] {
+	case "up", "status":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+	case "down":
+		if len(args) > 2 {
+			return errors.New(migrateUsage)
+		}
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return errors.New("steps must be a positive number")
+			}
+			steps = n
+		}
+	case "goto":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 {
+			return errors.New("version must be a number")
+		}
+		version = n
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	db, err := infrastructure.NewConnection(cfg)
+	if err != nil {
+		return fmt.Errorf("could not connect to database: %w", err)
+	}
+	defer cleanupFunc(db)
+	migrator, err := infrastructure.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	var count int
+	switch args[0] {
+	case "up":
+		count, err = migrator.Up(ctx)
+	case "down":
+		count, err = migrator.Down(ctx, steps)
+	case "goto":
+		count, err = migrator.Goto(ctx, version)
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("Ran %d migrations", count)
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, migrator *infrastructure.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		state := "pending"
+		if status.AppliedAt != nil {
+			state = "applied " + status.AppliedAt.UTC().Format("2006-01-02 15:04:05Z")
+		}
+		fmt.Printf("%04d  %-30s  %s\n", status.Version, status.Name, state)
+	}
+	return nil
+}
+
+func migrateUp(db *sqlx.DB) error {
+	migrator, err := infrastructure.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Up(context.Background())
+	return err
+}
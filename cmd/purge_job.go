@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// startPurgeJob hard-deletes the expired soft-deleted rows now and then every
+// intervalMinutes in the background. An interval of 0 or less disables it.
+func startPurgeJob(purgeService *services.PurgeService, intervalMinutes int) {
+	if intervalMinutes <= 0 {
+		log.Println("Purge job disabled")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+		defer ticker.Stop()
+		for {
+			runPurge(purgeService)
+			<-ticker.C
+		}
+	}()
+}
+
+func runPurge(purgeService *services.PurgeService) {
+	result, err := purgeService.Purge(context.Background(), time.Now())
+	if err != nil {
+		log.Printf("Purge job failed: %v", err)
+		return
+	}
+	log.Printf("Purged deleted rows: %d events, %d series, %d teams, %d venues, %d sports",
+		result.Events, result.Series, result.Teams, result.Venues, result.Sports)
+}
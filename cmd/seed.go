@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/vsennikov/sports-event-calendar/config"
+	"github.com/vsennikov/sports-event-calendar/infrastructure"
+)
+
+const seedUsage = "usage: seed fixtures <name|file> | seed generate [-sports n] [-teams n] [-venues n] [-events n] [-seed n] [-anchor YYYY-MM-DD]"
+
+// runSeed runs the seed subcommand: fixtures loads a named fixture set or a
+// YAML/JSON file, generate builds a dataset from a random seed.
+func runSeed(cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(seedUsage)
+	}
+	var dataset *infrastructure.SeedDataset
+	var err error
+	switch args[0] {
+	case "fixtures":
+		if len(args) != 2 {
+			return errors.New(seedUsage)
+		}
+		dataset, err = infrastructure.LoadFixtureSet(args[1])
+	case "generate":
+		dataset, err = generateSeedDataset(args[1:])
+	default:
+		return errors.New(seedUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	db, err := infrastructure.NewConnection(cfg)
+	if err != nil {
+		return fmt.Errorf("could not connect to database: %w", err)
+	}
+	defer cleanupFunc(db)
+	start := time.Now()
+	result, err := infrastructure.NewSeeder(db).Seed(context.Background(), dataset)
+	if err != nil {
+		return err
+	}
+	log.Printf("Seeded %d sports, %d venues, %d teams, %d events and %d broadcasts in %s",
+		result.Sports, result.Venues, result.Teams, result.Events, result.Broadcasts,
+		time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+func generateSeedDataset(args []string) (*infrastructure.SeedDataset, error) {
+	flags := flag.NewFlagSet("seed generate", flag.ContinueOnError)
+	params := infrastructure.GenerateParams{}
+	flags.IntVar(&params.Sports, "sports", 2, "number of sports")
+	flags.IntVar(&params.Teams, "teams", 20, "number of teams, spread over the sports")
+	flags.IntVar(&params.Venues, "venues", 10, "number of venues")
+	flags.IntVar(&params.Events, "events", 1000, "number of events")
+	flags.Uint64Var(&params.Seed, "seed", 1, "random seed; the same seed and anchor give the same data")
+	anchor := flags.String("anchor", time.Now().UTC().Format(time.DateOnly),
+		"middle of the schedule; events before it have results")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, errors.New(seedUsage)
+	}
+	anchorDate, err := time.Parse(time.DateOnly, *anchor)
+	if err != nil {
+		return nil, fmt.Errorf("anchor must be a date like 2025-06-01")
+	}
+	params.Anchor = anchorDate
+	return infrastructure.GenerateDataset(params)
+}
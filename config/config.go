@@ -1,45 +1,156 @@
 package config
 
 import (
-	"log"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
+// Config is the configuration of the app. Every setting is read, from lowest
+// to highest precedence, from its default, the config file named by
+// CONFIG_FILE and the environment variable named as its key in upper case,
+// e.g. DB_HOST for db_host. Settings tagged secret are redacted whenever the
+// configuration is shown.
 type Config struct {
-	AppPort string `mapstructure:"app_port"`
-	DBHost     string `mapstructure:"db_host"`
-	DBPort     string `mapstructure:"db_port"`
-	DBUser     string `mapstructure:"db_user"`
-	DBPassword string `mapstructure:"db_password"`
-	DBName     string `mapstructure:"db_name"`
-	DefaultPage  int `mapstructure:"default_page"`
-	DefaultLimit int `mapstructure:"default_limit"`
+	AppPort  string `mapstructure:"app_port"`
+	DBDriver string `mapstructure:"db_driver"`
+	DBHost   string `mapstructure:"db_host"`
+	DBPort   string `mapstructure:"db_port"`
+	DBUser   string `mapstructure:"db_user"`
+	// DBPassword can also be read from the file DBPasswordFile names, e.g. a
+	// Docker or Kubernetes secret.
+	DBPassword     string `mapstructure:"db_password" secret:"true"`
+	DBPasswordFile string `mapstructure:"db_password_file"`
+	DBName         string `mapstructure:"db_name"`
+	DBSSLMode      string `mapstructure:"db_sslmode"`
+	// The pool and timeout settings apply to the primary database and its
+	// replicas alike. 0 means no limit, except that DBMaxIdleConns 0 keeps
+	// no idle connections.
+	DBMaxOpenConns            int `mapstructure:"db_max_open_conns"`
+	DBMaxIdleConns            int `mapstructure:"db_max_idle_conns"`
+	DBConnMaxLifetimeSeconds  int `mapstructure:"db_conn_max_lifetime_seconds"`
+	DBConnMaxIdleTimeSeconds  int `mapstructure:"db_conn_max_idle_time_seconds"`
+	DBConnectTimeoutSeconds   int `mapstructure:"db_connect_timeout_seconds"`
+	DBStatementTimeoutSeconds int `mapstructure:"db_statement_timeout_seconds"`
+	// DBReplicaDSNs lists the DSNs of read replicas, separated by commas.
+	// They hold passwords, so they can be read from DBReplicaDSNsFile too.
+	DBReplicaDSNs                  string `mapstructure:"db_replica_dsns" secret:"true"`
+	DBReplicaDSNsFile              string `mapstructure:"db_replica_dsns_file"`
+	DBReplicaHealthCheckSeconds    int    `mapstructure:"db_replica_health_check_seconds"`
+	DefaultPage                    int    `mapstructure:"default_page"`
+	DefaultLimit                   int    `mapstructure:"default_limit"`
+	FeedLimit                      int    `mapstructure:"feed_limit"`
+	SeriesHorizonDays              int    `mapstructure:"series_horizon_days"`
+	SoftDeleteRetentionDays        int    `mapstructure:"soft_delete_retention_days"`
+	PurgeIntervalMinutes           int    `mapstructure:"purge_interval_minutes"`
+	AutoMigrate                    bool   `mapstructure:"auto_migrate"`
+	RequireIfMatch                 bool   `mapstructure:"require_if_match"`
+	CacheControl                   string `mapstructure:"cache_control"`
+	CacheControlRoutes             string `mapstructure:"cache_control_routes"`
+	QueryCacheEnabled              bool   `mapstructure:"query_cache_enabled"`
+	QueryCacheSize                 int    `mapstructure:"query_cache_size"`
+	QueryCacheTTLSeconds           int    `mapstructure:"query_cache_ttl_seconds"`
+	QueryCacheStatsIntervalMinutes int    `mapstructure:"query_cache_stats_interval_minutes"`
 }
 
+// ConfigFileEnv names the environment variable holding the path of the
+// config file, a YAML (.yaml, .yml) or TOML (.toml) file of settings keyed
+// as in Config.
+const ConfigFileEnv = "CONFIG_FILE"
+
+var defaults = map[string]interface{}{
+	"app_port":                           "8080",
+	"db_driver":                          "postgres",
+	"db_host":                            "localhost",
+	"db_port":                            "5432",
+	"db_sslmode":                         "disable",
+	"db_max_open_conns":                  25,
+	"db_max_idle_conns":                  25,
+	"db_conn_max_lifetime_seconds":       300,
+	"db_connect_timeout_seconds":         10,
+	"db_replica_health_check_seconds":    5,
+	"default_page":                       1,
+	"default_limit":                      10,
+	"feed_limit":                         50,
+	"series_horizon_days":                180,
+	"soft_delete_retention_days":         30,
+	"purge_interval_minutes":             60,
+	"cache_control":                      "no-cache",
+	"query_cache_size":                   1000,
+	"query_cache_ttl_seconds":            30,
+	"query_cache_stats_interval_minutes": 5,
+}
+
+// Load reads the configuration and validates it. On validation errors it
+// still returns what it read, for `config print` to show.
 func Load() (config Config, err error) {
 	v := viper.New()
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+	for _, key := range keys() {
+		v.BindEnv(key, strings.ToUpper(key))
+	}
+	if path := os.Getenv(ConfigFileEnv); path != "" {
+		v.SetConfigFile(path)
+		if err = v.ReadInConfig(); err != nil {
+			return config, fmt.Errorf("could not read config file %s: %w", path, err)
+		}
+		// Settings Config does not have are most likely misspelt.
+		for _, key := range v.AllKeys() {
+			if !slices.Contains(keys(), key) {
+				return config, fmt.Errorf("config file %s: unknown setting %s", path, key)
+			}
+		}
+	}
 
-	v.SetDefault("app_port", "8080")
+	if err = v.Unmarshal(&config); err != nil {
+		return config, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if err = config.readSecretFiles(); err != nil {
+		return config, err
+	}
+	return config, config.Validate()
+}
 
-	v.BindEnv("app_port", "APP_PORT")
-	v.BindEnv("db_host", "DB_HOST")
-	v.BindEnv("db_port", "DB_PORT")
-	v.BindEnv("db_user", "DB_USER")
-	v.BindEnv("db_password", "DB_PASSWORD")
-	v.BindEnv("db_name", "DB_NAME")
-	v.BindEnv("default_page", "DEFAULT_PAGE")
-	v.BindEnv("default_limit", "DEFAULT_LIMIT")
+// readSecretFiles reads the secrets given as files. A secret given both ways
+// is an error rather than a guess at which one was meant.
+func (c *Config) readSecretFiles() error {
+	secrets := []struct {
+		key   string
+		value *string
+		file  string
+	}{
+		{"db_password", &c.DBPassword, c.DBPasswordFile},
+		{"db_replica_dsns", &c.DBReplicaDSNs, c.DBReplicaDSNsFile},
+	}
+	for _, secret := range secrets {
+		if secret.file == "" {
+			continue
+		}
+		if *secret.value != "" {
+			return fmt.Errorf("%s (%s) and %s_file (%s_FILE) are both set; set only one",
+				secret.key, strings.ToUpper(secret.key), secret.key, strings.ToUpper(secret.key))
+		}
+		content, err := os.ReadFile(secret.file)
+		if err != nil {
+			return fmt.Errorf("could not read %s_file (%s_FILE): %w", secret.key, strings.ToUpper(secret.key), err)
+		}
+		*secret.value = strings.TrimRight(string(content), "\r\n")
+	}
+	return nil
+}
 
-	if err = v.Unmarshal(&config); err != nil {
-		return
-	}
-	log.Printf("AppPort: %s", config.AppPort)
-	log.Printf("DBHost: %s", config.DBHost)
-	log.Printf("DBUser: %s", config.DBUser)
-	log.Printf("DBPort: %s", config.DBPort)
-	log.Printf("DBName: %s", config.DBName)
-	log.Printf("default_page: %d", config.DefaultPage)
-	log.Printf("default_limit: %d", config.DefaultLimit)
-	return
-}
\ No newline at end of file
+// keys are the keys of the settings, in the order of Config.
+func keys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, t.NumField())
+	for i := range keys {
+		keys[i] = t.Field(i).Tag.Get("mapstructure")
+	}
+	return keys
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Layers(t *testing.T) {
+	for _, file := range []struct{ name, content string }{
+		{"config.yaml", "db_user: calendar\ndb_name: from_file\ndefault_limit: 20\nfeed_limit: 40\n"},
+		{"config.toml", "db_user = \"calendar\"\ndb_name = \"from_file\"\ndefault_limit = 20\nfeed_limit = 40\n"},
+	} {
+		t.Run(file.name, func(t *testing.T) {
+			t.Setenv(ConfigFileEnv, writeFile(t, file.name, file.content))
+			t.Setenv("DB_DRIVER", "")
+			t.Setenv("DEFAULT_LIMIT", "30")
+
+			cfg, err := Load()
+			require.NoError(t, err)
+			assert.Equal(t, "postgres", cfg.DBDriver, "defaults")
+			assert.Equal(t, "from_file", cfg.DBName, "the file overrides defaults")
+			assert.Equal(t, 40, cfg.FeedLimit)
+			assert.Equal(t, 30, cfg.DefaultLimit, "the environment overrides the file")
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Setenv("DB_USER", "calendar")
+	t.Setenv("DB_NAME", "sport_calendar")
+
+	t.Setenv(ConfigFileEnv, writeFile(t, "config.yaml", "db_nmae: typo\n"))
+	_, err := Load()
+	assert.ErrorContains(t, err, "unknown setting db_nmae")
+
+	t.Setenv(ConfigFileEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+	_, err = Load()
+	assert.ErrorContains(t, err, "could not read config file")
+
+	t.Setenv(ConfigFileEnv, "")
+	t.Setenv("DEFAULT_LIMIT", "ten")
+	_, err = Load()
+	assert.ErrorContains(t, err, "default_limit")
+}
+
+func TestLoad_SecretFiles(t *testing.T) {
+	t.Setenv("DB_USER", "calendar")
+	t.Setenv("DB_NAME", "sport_calendar")
+	t.Setenv("DB_PASSWORD", "")
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "password", "s3cret\n"))
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", cfg.DBPassword)
+
+	t.Setenv("DB_PASSWORD", "other")
+	_, err = Load()
+	assert.ErrorContains(t, err, "db_password (DB_PASSWORD) and db_password_file (DB_PASSWORD_FILE) are both set")
+
+	t.Setenv("DB_PASSWORD", "")
+	t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+	_, err = Load()
+	assert.ErrorContains(t, err, "could not read db_password_file")
+}
+
+func validConfig() Config {
+	return Config{
+		AppPort: "8080", DBDriver: "postgres", DBHost: "localhost", DBPort: "5432", DBUser: "calendar",
+		DBName: "sport_calendar", DBSSLMode: "disable", DefaultPage: 1, DefaultLimit: 10, FeedLimit: 50,
+		SeriesHorizonDays: 180,
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, validConfig().Validate())
+
+	cfg := validConfig()
+	cfg.AppPort = "http"
+	cfg.DBUser = ""
+	cfg.DBSSLMode = "on"
+	cfg.DefaultLimit = 0
+	cfg.QueryCacheEnabled = true
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, `invalid configuration:
+  app_port (APP_PORT) must be a port number, got "http"
+  db_user (DB_USER) must be set
+  db_sslmode (DB_SSLMODE) must be one of disable, allow, prefer, require, verify-ca, verify-full, got "on"
+  default_limit (DEFAULT_LIMIT) must be at least 1, got 0
+  query_cache_size (QUERY_CACHE_SIZE) must be at least 1, got 0
+  query_cache_ttl_seconds (QUERY_CACHE_TTL_SECONDS) must be at least 1, got 0`, err.Error())
+
+	cfg = validConfig()
+	cfg.DBDriver, cfg.DBHost, cfg.DBUser = "sqlite", "", ""
+	require.NoError(t, cfg.Validate(), "sqlite needs no server settings")
+	cfg.DBReplicaDSNs = "host=replica"
+	assert.ErrorContains(t, cfg.Validate(), "read replicas need the postgres db_driver")
+}
+
+func TestWriteYAML_RedactsSecrets(t *testing.T) {
+	cfg := validConfig()
+	cfg.DBPassword = "s3cret"
+	cfg.DBReplicaDSNs = "host=replica password=s3cret"
+
+	var out bytes.Buffer
+	require.NoError(t, cfg.WriteYAML(&out))
+	assert.NotContains(t, out.String(), "s3cret")
+	assert.Contains(t, out.String(), "db_password: '[REDACTED]'\n")
+	assert.Contains(t, out.String(), "app_port: \"8080\"\n")
+
+	// What is printed loads back, but for the secrets.
+	t.Setenv(ConfigFileEnv, writeFile(t, "config.yaml", out.String()))
+	loaded, err := Load()
+	require.NoError(t, err)
+	cfg.DBPassword, cfg.DBReplicaDSNs = redacted, redacted
+	assert.Equal(t, cfg, loaded)
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces the value of secret settings that are set.
+const redacted = "[REDACTED]"
+
+// Setting is one setting of a Config, keyed as in the config file.
+type Setting struct {
+	Key   string
+	Value interface{}
+}
+
+// Settings returns the settings of c in the order of Config, with the
+// secrets redacted.
+func (c Config) Settings() []Setting {
+	t, v := reflect.TypeOf(c), reflect.ValueOf(c)
+	settings := make([]Setting, t.NumField())
+	for i := range settings {
+		field := t.Field(i)
+		settings[i] = Setting{Key: field.Tag.Get("mapstructure"), Value: v.Field(i).Interface()}
+		if field.Tag.Get("secret") == "true" && !v.Field(i).IsZero() {
+			settings[i].Value = redacted
+		}
+	}
+	return settings
+}
+
+// Log logs the settings of c, secrets redacted.
+func (c Config) Log() {
+	for _, setting := range c.Settings() {
+		log.Printf("%s: %v", setting.Key, setting.Value)
+	}
+}
+
+// WriteYAML writes the settings of c as a YAML config file, secrets
+// redacted.
+func (c Config) WriteYAML(w io.Writer) error {
+	var doc yaml.Node
+	doc.Kind = yaml.MappingNode
+	for _, setting := range c.Settings() {
+		var key, value yaml.Node
+		if err := key.Encode(setting.Key); err != nil {
+			return err
+		}
+		if err := value.Encode(setting.Value); err != nil {
+			return fmt.Errorf("cannot encode %s: %w", setting.Key, err)
+		}
+		doc.Content = append(doc.Content, &key, &value)
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	dbDrivers  = []string{"postgres", "sqlite"}
+	dbSSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+)
+
+// Validate reports every setting that is invalid, each named by its key and
+// its environment variable, so that a misconfigured app refuses to start
+// with all of them listed rather than failing later on the first.
+func (c Config) Validate() error {
+	var v validator
+	v.port("app_port", c.AppPort)
+	v.oneOf("db_driver", c.DBDriver, dbDrivers)
+	v.required("db_name", c.DBName)
+	if c.DBDriver == "postgres" {
+		v.required("db_host", c.DBHost)
+		v.port("db_port", c.DBPort)
+		v.required("db_user", c.DBUser)
+		v.oneOf("db_sslmode", c.DBSSLMode, dbSSLModes)
+	}
+	v.atLeast("db_max_open_conns", c.DBMaxOpenConns, 0)
+	v.atLeast("db_max_idle_conns", c.DBMaxIdleConns, 0)
+	v.atLeast("db_conn_max_lifetime_seconds", c.DBConnMaxLifetimeSeconds, 0)
+	v.atLeast("db_conn_max_idle_time_seconds", c.DBConnMaxIdleTimeSeconds, 0)
+	v.atLeast("db_connect_timeout_seconds", c.DBConnectTimeoutSeconds, 0)
+	v.atLeast("db_statement_timeout_seconds", c.DBStatementTimeoutSeconds, 0)
+	if c.DBDriver == "sqlite" && strings.TrimSpace(strings.ReplaceAll(c.DBReplicaDSNs, ",", "")) != "" {
+		v.invalid("db_replica_dsns", "read replicas need the postgres db_driver")
+	}
+	v.atLeast("db_replica_health_check_seconds", c.DBReplicaHealthCheckSeconds, 0)
+	v.atLeast("default_page", c.DefaultPage, 1)
+	v.atLeast("default_limit", c.DefaultLimit, 1)
+	v.atLeast("feed_limit", c.FeedLimit, 1)
+	v.atLeast("series_horizon_days", c.SeriesHorizonDays, 1)
+	v.atLeast("soft_delete_retention_days", c.SoftDeleteRetentionDays, 0)
+	if c.QueryCacheEnabled {
+		v.atLeast("query_cache_size", c.QueryCacheSize, 1)
+		v.atLeast("query_cache_ttl_seconds", c.QueryCacheTTLSeconds, 1)
+	}
+	if len(v.errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(v.errs...))
+	}
+	return nil
+}
+
+// validator collects the problems found with the settings.
+type validator struct {
+	errs []error
+}
+
+func (v *validator) invalid(key, format string, args ...interface{}) {
+	v.errs = append(v.errs, fmt.Errorf("  %s (%s) %s", key, strings.ToUpper(key), fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.invalid(key, "must be set")
+	}
+}
+
+func (v *validator) port(key, value string) {
+	if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+		v.invalid(key, "must be a port number, got %q", value)
+	}
+}
+
+func (v *validator) oneOf(key, value string, allowed []string) {
+	if !slices.Contains(allowed, value) {
+		v.invalid(key, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+	}
+}
+
+func (v *validator) atLeast(key string, value, min int) {
+	if value < min {
+		v.invalid(key, "must be at least %d, got %d", min, value)
+	}
+}
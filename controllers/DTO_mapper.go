@@ -1,24 +1,45 @@
 package controllers
 
-import "github.com/vsennikov/sports-event-calendar/services"
+import (
+	"fmt"
+	"time"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
 
 func toDTOEvent(event services.Event) EventDTO{
 	var venue *venueDTO
+	var originalDatetime *time.Time
 
 	if event.Venue.ID != 0 {
-		venue = &venueDTO{
-			ID: event.Venue.ID,
-			Name: event.Venue.Name,
-			City: event.Venue.City,
-			CountryCode: event.Venue.CountryCode,
-		}
+		v := toDTOVenue(event.Venue)
+		venue = &v
 	}
+	if event.OriginalDatetime != nil {
+		utc := event.OriginalDatetime.UTC()
+		originalDatetime = &utc
+	}
+	zoneName, loc := eventLocation(event)
 	return EventDTO{
 		ID: event.ID,
-		EventDatetime: event.EventDatetime,
+		EventDatetime: event.EventDatetime.UTC(),
+		EndDatetime: event.EndDatetime.UTC(),
+		LocalDatetime: event.EventDatetime.In(loc),
+		DurationMinutes: int(event.EndDatetime.Sub(event.EventDatetime).Minutes()),
+		AllowVenueOverlap: event.AllowVenueOverlap,
+		TimeZone: zoneName,
 		Description: event.Description,
 		HomeScore: event.HomeScore,
 		AwayScore: event.AwayScore,
+		Attendance: event.Attendance,
+		SeriesID: event.SeriesID,
+		Rescheduled: event.OriginalDatetime != nil,
+		OriginalDatetime: originalDatetime,
+		Broadcasts: toDTOBroadcasts(event.Broadcasts),
+		Version:   event.Version,
+		CreatedAt: utcTimeOrNil(event.CreatedAt),
+		UpdatedAt: utcTimeOrNil(event.UpdatedAt),
+		DeletedAt: utcTimePtr(event.DeletedAt),
 		
 		Sport: sportDTO{
 			ID: event.Sport.ID,
@@ -27,24 +48,63 @@ func toDTOEvent(event services.Event) EventDTO{
 
 		Venue: venue,
 
-		HomeTeam: teamDTO{
-			ID: event.HomeTeam.ID,
-			Name: event.HomeTeam.Name,
-			City: event.HomeTeam.City,
-		},
+		HomeTeam: toDTOTeam(event.HomeTeam),
 
-		AwayTeam: teamDTO{
-			ID: event.AwayTeam.ID,
-			Name: event.AwayTeam.Name,
-			City: event.AwayTeam.City,
-		},
+		AwayTeam: toDTOTeam(event.AwayTeam),
+	}
+}
+
+// eventLocation returns the time zone an event is played in: the venue's
+// zone when known, UTC otherwise.
+func eventLocation(event services.Event) (string, *time.Location) {
+	if event.Venue.TimeZone != "" {
+		if loc, err := services.LoadTimeZone(event.Venue.TimeZone); err == nil {
+			return event.Venue.TimeZone, loc
+		}
+	}
+	return services.DefaultTimeZone, time.UTC
+}
+
+func toDTOSeries(series services.EventSeries) seriesDTO {
+	exceptionDates := make([]string, 0, len(series.ExceptionDates))
+	for _, date := range series.ExceptionDates {
+		exceptionDates = append(exceptionDates, date.Format("2006-01-02"))
+	}
+	startDatetime := series.StartDatetime
+	if loc, err := services.LoadTimeZone(series.TimeZone); err == nil {
+		startDatetime = startDatetime.In(loc)
+	}
+	return seriesDTO{
+		ID:                series.ID,
+		RRule:             series.RRule,
+		StartDatetime:     startDatetime,
+		TimeZone:          series.TimeZone,
+		MaterializedUntil: series.MaterializedUntil,
+		ExceptionDates:    exceptionDates,
+		Description:       series.Description,
+		SportID:           series.SportID,
+		VenueID:           series.VenueID,
+		HomeTeamID:        series.HomeTeamID,
+		AwayTeamID:        series.AwayTeamID,
+		Version:           series.Version,
+		CreatedAt:         utcTimeOrNil(series.CreatedAt),
+		UpdatedAt:         utcTimeOrNil(series.UpdatedAt),
+		DeletedAt:         utcTimePtr(series.DeletedAt),
 	}
 }
 
 func toDTOSport(sport services.Sport) sportDTO {
+	minRestMinutes := sport.MinRestMinutes
 	return sportDTO{
 		ID: sport.ID,
 		Name: sport.Name,
+		DefaultDurationMinutes: sport.DefaultDurationMinutes,
+		MinRestMinutes: &minRestMinutes,
+		RestConflictPolicy: sport.RestConflictPolicy,
+		Version:   sport.Version,
+		CreatedAt: utcTimeOrNil(sport.CreatedAt),
+		UpdatedAt: utcTimeOrNil(sport.UpdatedAt),
+		DeletedAt: utcTimePtr(sport.DeletedAt),
 	}
 }
 
@@ -54,6 +114,17 @@ func toDTOVenue(venue services.Venue) venueDTO {
 		Name: venue.Name,
 		City: venue.City,
 		CountryCode: venue.CountryCode,
+		TimeZone: venue.TimeZone,
+		Address: venue.Address,
+		PostalCode: venue.PostalCode,
+		Latitude: venue.Latitude,
+		Longitude: venue.Longitude,
+		Capacity: venue.Capacity,
+		DistanceKm: venue.DistanceKm,
+		Version:   venue.Version,
+		CreatedAt: utcTimeOrNil(venue.CreatedAt),
+		UpdatedAt: utcTimeOrNil(venue.UpdatedAt),
+		DeletedAt: utcTimePtr(venue.DeletedAt),
 	}
 }
 
@@ -62,5 +133,209 @@ func toDTOTeam(team services.Team) teamDTO {
 		ID: team.ID,
 		Name: team.Name,
 		City: team.City,
+		ShortName: team.ShortName,
+		Code: team.Code,
+		Aliases: team.Aliases,
+		Version:   team.Version,
+		CreatedAt: utcTimeOrNil(team.CreatedAt),
+		UpdatedAt: utcTimeOrNil(team.UpdatedAt),
+		DeletedAt: utcTimePtr(team.DeletedAt),
+	}
+}
+
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// utcTimeOrNil leaves out the timestamps that were not loaded, such as those
+// of the teams embedded in an event.
+func utcTimeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return utcTimePtr(&t)
+}
+const atomTagPrefix = "tag:sports-event-calendar,2025:"
+
+func toAtomResultEntry(change services.EventChange, baseURL string) atomEntry {
+	title := fmt.Sprintf("%s vs %s", change.HomeTeam.Name, change.AwayTeam.Name)
+	if change.HomeScore != nil && change.AwayScore != nil {
+		title = fmt.Sprintf("%s %d-%d %s",
+			change.HomeTeam.Name, *change.HomeScore, *change.AwayScore, change.AwayTeam.Name)
+	}
+	return atomEntry{
+		ID:      fmt.Sprintf("%sevents/%d/result", atomTagPrefix, change.EventID),
+		Title:   title,
+		Updated: change.ChangedAt.UTC().Format(time.RFC3339),
+		Summary: fmt.Sprintf("%s, played %s",
+			change.Sport.Name, change.EventDatetime.UTC().Format("2006-01-02 15:04 MST")),
+		Links: []atomLink{
+			{Rel: "alternate", Href: fmt.Sprintf("%s/api/v1/events/%d", baseURL, change.EventID)},
+		},
+		Categories: []atomCategory{{Term: change.Sport.Name}},
+	}
+}
+
+func toAtomChangeEntry(change services.EventChange, baseURL string) atomEntry {
+	var title string
+	kickoff := change.EventDatetime.UTC().Format("2006-01-02 15:04 MST")
+	matchup := fmt.Sprintf("%s vs %s", change.HomeTeam.Name, change.AwayTeam.Name)
+
+	switch change.ChangeType {
+	case services.EventChangeRescheduled:
+		title = fmt.Sprintf("Rescheduled: %s", matchup)
+	case services.EventChangeCancelled:
+		title = fmt.Sprintf("Cancelled: %s", matchup)
+	default:
+		title = fmt.Sprintf("New fixture: %s", matchup)
+	}
+	return atomEntry{
+		ID:      fmt.Sprintf("%sevent-changes/%d", atomTagPrefix, change.ID),
+		Title:   title,
+		Updated: change.ChangedAt.UTC().Format(time.RFC3339),
+		Summary: fmt.Sprintf("%s, kick-off %s", change.Sport.Name, kickoff),
+		Links: []atomLink{
+			{Rel: "alternate", Href: fmt.Sprintf("%s/api/v1/events/%d", baseURL, change.EventID)},
+		},
+		Categories: []atomCategory{{Term: change.ChangeType}, {Term: change.Sport.Name}},
 	}
-}
\ No newline at end of file
+}
+
+func toDTOReschedule(reschedule services.EventReschedule) rescheduleDTO {
+	return rescheduleDTO{
+		ID:               reschedule.ID,
+		EventID:          reschedule.EventID,
+		PreviousDatetime: reschedule.PreviousDatetime.UTC(),
+		NewDatetime:      reschedule.NewDatetime.UTC(),
+		PreviousVenueID:  reschedule.PreviousVenueID,
+		NewVenueID:       reschedule.NewVenueID,
+		Reason:           reschedule.Reason,
+		ChangedAt:        reschedule.ChangedAt.UTC(),
+	}
+}
+
+func toDTOBroadcast(broadcast services.Broadcast) broadcastDTO {
+	return broadcastDTO{
+		ID:            broadcast.ID,
+		EventID:       broadcast.EventID,
+		Broadcaster:   broadcast.Broadcaster,
+		Channel:       broadcast.Channel,
+		CountryCode:   broadcast.CountryCode,
+		URL:           broadcast.URL,
+		StartDatetime: broadcast.StartDatetime.UTC(),
+		Version:       broadcast.Version,
+		CreatedAt:     utcTimeOrNil(broadcast.CreatedAt),
+		UpdatedAt:     utcTimeOrNil(broadcast.UpdatedAt),
+	}
+}
+
+func toDTOBroadcasts(broadcasts []services.Broadcast) []broadcastDTO {
+	broadcastDTOs := make([]broadcastDTO, 0, len(broadcasts))
+	for _, broadcast := range broadcasts {
+		broadcastDTOs = append(broadcastDTOs, toDTOBroadcast(broadcast))
+	}
+	return broadcastDTOs
+}
+
+func toDTOTeamConflict(conflict services.TeamConflict) teamConflictDTO {
+	return teamConflictDTO{
+		TeamID:                   conflict.TeamID,
+		EventID:                  conflict.Event.ID,
+		EventDatetime:            conflict.Event.EventDatetime.UTC(),
+		ConflictingEventID:       conflict.ConflictingEvent.ID,
+		ConflictingEventDatetime: conflict.ConflictingEvent.EventDatetime.UTC(),
+		GapMinutes:               conflict.GapMinutes,
+		MinRestMinutes:           conflict.MinRestMinutes,
+		Overlapping:              conflict.Overlapping,
+		Message:                  conflict.Message(),
+	}
+}
+
+func toDTOAttendanceStats(stats services.AttendanceStats) attendanceStatsDTO {
+	return attendanceStatsDTO{
+		EventCount: stats.EventCount,
+		TotalAttendance: stats.TotalAttendance,
+		AverageAttendance: stats.AverageAttendance,
+		PeakAttendance: stats.PeakAttendance,
+		PeakEventID: stats.PeakEventID,
+		AverageUtilization: stats.AverageUtilization,
+	}
+}
+
+func toDTOSearchResults(query string, results services.SearchResults) searchResultsDTO {
+	return searchResultsDTO{
+		Query:  query,
+		Events: toDTOSearchHits(results.Events),
+		Teams:  toDTOSearchHits(results.Teams),
+		Venues: toDTOSearchHits(results.Venues),
+	}
+}
+
+func toDTOSearchHits(hits []services.SearchHit) []searchHitDTO {
+	hitDTOs := make([]searchHitDTO, 0, len(hits))
+	for _, hit := range hits {
+		var eventDatetime *time.Time
+		if hit.EventDatetime != nil {
+			utc := hit.EventDatetime.UTC()
+			eventDatetime = &utc
+		}
+		hitDTOs = append(hitDTOs, searchHitDTO{
+			Type:          hit.Type,
+			ID:            hit.ID,
+			Title:         hit.Title,
+			Detail:        hit.Detail,
+			EventDatetime: eventDatetime,
+			Rank:          hit.Rank,
+		})
+	}
+	return hitDTOs
+}
+
+func toDTOTeamDuplicates(candidates []services.TeamDuplicateCandidate) []teamDuplicateDTO {
+	duplicateDTOs := make([]teamDuplicateDTO, 0, len(candidates))
+	for _, candidate := range candidates {
+		duplicateDTOs = append(duplicateDTOs, teamDuplicateDTO{
+			First:          toDTOTeam(candidate.First),
+			Second:         toDTOTeam(candidate.Second),
+			NameSimilarity: candidate.NameSimilarity,
+			CitySimilarity: candidate.CitySimilarity,
+			Similarity:     candidate.Similarity,
+		})
+	}
+	return duplicateDTOs
+}
+
+func toDTOVenueDuplicates(candidates []services.VenueDuplicateCandidate) []venueDuplicateDTO {
+	duplicateDTOs := make([]venueDuplicateDTO, 0, len(candidates))
+	for _, candidate := range candidates {
+		duplicateDTOs = append(duplicateDTOs, venueDuplicateDTO{
+			First:          toDTOVenue(candidate.First),
+			Second:         toDTOVenue(candidate.Second),
+			NameSimilarity: candidate.NameSimilarity,
+			CitySimilarity: candidate.CitySimilarity,
+			Similarity:     candidate.Similarity,
+		})
+	}
+	return duplicateDTOs
+}
+
+func toDTOAuditEntries(entries []services.AuditEntry) []auditEntryDTO {
+	entryDTOs := make([]auditEntryDTO, 0, len(entries))
+	for _, entry := range entries {
+		entryDTOs = append(entryDTOs, auditEntryDTO{
+			ID:         entry.ID,
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Action:     entry.Action,
+			Actor:      entry.Actor,
+			ChangedAt:  entry.ChangedAt.UTC(),
+			Before:     entry.Before,
+			After:      entry.After,
+		})
+	}
+	return entryDTOs
+}
@@ -1,33 +1,214 @@
 package controllers
 
-import "time"
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
 
 type sportDTO struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID                     int    `json:"id"`
+	Name                   string `json:"name"`
+	DefaultDurationMinutes int    `json:"default_duration_minutes,omitempty"`
+	MinRestMinutes         *int   `json:"min_rest_minutes,omitempty"`
+	RestConflictPolicy     string `json:"rest_conflict_policy,omitempty"`
+	Version                int    `json:"version,omitempty"`
+	CreatedAt              *time.Time `json:"created_at,omitempty"`
+	UpdatedAt              *time.Time `json:"updated_at,omitempty"`
+	DeletedAt              *time.Time `json:"deleted_at,omitempty"`
 }
 
 type venueDTO struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	City        string `json:"city"`
-	CountryCode string `json:"country_code"`
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	City        string   `json:"city"`
+	CountryCode string   `json:"country_code"`
+	TimeZone    string   `json:"time_zone"`
+	Address     *string  `json:"address,omitempty"`
+	PostalCode  *string  `json:"postal_code,omitempty"`
+	Latitude    *float64 `json:"latitude,omitempty"`
+	Longitude   *float64 `json:"longitude,omitempty"`
+	Capacity    *int     `json:"capacity,omitempty"`
+	DistanceKm  *float64 `json:"distance_km,omitempty"`
+	Version     int      `json:"version,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
 }
 
 type teamDTO struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-	City string `json:"city"`
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	City      string   `json:"city"`
+	ShortName *string  `json:"short_name,omitempty"`
+	Code      *string  `json:"code,omitempty"`
+	Aliases   []string `json:"aliases,omitempty"`
+	Version   int      `json:"version,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type EventDTO struct {
 	ID            int        `json:"id"`
 	EventDatetime time.Time  `json:"event_datetime"`
+	EndDatetime   time.Time  `json:"end_datetime"`
+	LocalDatetime time.Time  `json:"local_datetime"`
+	DurationMinutes int      `json:"duration_minutes"`
+	AllowVenueOverlap bool   `json:"allow_venue_overlap"`
+	TimeZone      string     `json:"time_zone"`
 	Description   *string    `json:"description,omitempty"`
 	HomeScore     *int       `json:"home_score,omitempty"`
 	AwayScore     *int       `json:"away_score,omitempty"`
+	Attendance    *int       `json:"attendance,omitempty"`
+	SeriesID      *int       `json:"series_id,omitempty"`
+	Rescheduled   bool       `json:"rescheduled"`
+	OriginalDatetime *time.Time `json:"original_datetime,omitempty"`
 	Sport         sportDTO   `json:"sport"`
 	Venue         *venueDTO  `json:"venue,omitempty"`
 	HomeTeam      teamDTO    `json:"home_team"`
 	AwayTeam      teamDTO    `json:"away_team"`
+	Broadcasts    []broadcastDTO `json:"broadcasts"`
+	Version       int            `json:"version,omitempty"`
+	CreatedAt     *time.Time     `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time     `json:"updated_at,omitempty"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+}
+
+type broadcastDTO struct {
+	ID            int       `json:"id"`
+	EventID       int       `json:"event_id"`
+	Broadcaster   string    `json:"broadcaster"`
+	Channel       *string   `json:"channel,omitempty"`
+	CountryCode   string    `json:"country_code"`
+	URL           *string   `json:"url,omitempty"`
+	StartDatetime time.Time `json:"start_datetime"`
+	Version       int       `json:"version,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+type rescheduleDTO struct {
+	ID               int       `json:"id"`
+	EventID          int       `json:"event_id"`
+	PreviousDatetime time.Time `json:"previous_datetime"`
+	NewDatetime      time.Time `json:"new_datetime"`
+	PreviousVenueID  *int      `json:"previous_venue_id,omitempty"`
+	NewVenueID       *int      `json:"new_venue_id,omitempty"`
+	Reason           *string   `json:"reason,omitempty"`
+	ChangedAt        time.Time `json:"changed_at"`
+}
+
+type teamConflictDTO struct {
+	TeamID                   int       `json:"team_id"`
+	EventID                  int       `json:"event_id,omitempty"`
+	EventDatetime            time.Time `json:"event_datetime"`
+	ConflictingEventID       int       `json:"conflicting_event_id,omitempty"`
+	ConflictingEventDatetime time.Time `json:"conflicting_event_datetime"`
+	GapMinutes               int       `json:"gap_minutes"`
+	MinRestMinutes           int       `json:"min_rest_minutes"`
+	Overlapping              bool      `json:"overlapping"`
+	Message                  string    `json:"message"`
+}
+
+type attendanceStatsDTO struct {
+	EventCount         int      `json:"event_count"`
+	TotalAttendance    int      `json:"total_attendance"`
+	AverageAttendance  float64  `json:"average_attendance"`
+	PeakAttendance     int      `json:"peak_attendance"`
+	PeakEventID        *int     `json:"peak_event_id,omitempty"`
+	AverageUtilization *float64 `json:"average_utilization,omitempty"`
+}
+
+type seriesDTO struct {
+	ID                int        `json:"id"`
+	RRule             string     `json:"rrule"`
+	StartDatetime     time.Time  `json:"start_datetime"`
+	TimeZone          string     `json:"time_zone"`
+	MaterializedUntil *time.Time `json:"materialized_until,omitempty"`
+	ExceptionDates    []string   `json:"exception_dates"`
+	Description       *string    `json:"description,omitempty"`
+	SportID           int        `json:"sport_id"`
+	VenueID           *int       `json:"venue_id,omitempty"`
+	HomeTeamID        int        `json:"home_team_id"`
+	AwayTeamID        int        `json:"away_team_id"`
+	Version           int        `json:"version,omitempty"`
+	CreatedAt         *time.Time `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type searchHitDTO struct {
+	Type          string     `json:"type"`
+	ID            int        `json:"id"`
+	Title         string     `json:"title"`
+	Detail        *string    `json:"detail,omitempty"`
+	EventDatetime *time.Time `json:"event_datetime,omitempty"`
+	Rank          float64    `json:"rank"`
+}
+
+type searchResultsDTO struct {
+	Query  string         `json:"query"`
+	Events []searchHitDTO `json:"events"`
+	Teams  []searchHitDTO `json:"teams"`
+	Venues []searchHitDTO `json:"venues"`
+}
+
+type teamDuplicateDTO struct {
+	First          teamDTO `json:"first"`
+	Second         teamDTO `json:"second"`
+	NameSimilarity float64 `json:"name_similarity"`
+	CitySimilarity float64 `json:"city_similarity"`
+	Similarity     float64 `json:"similarity"`
+}
+
+type venueDuplicateDTO struct {
+	First          venueDTO `json:"first"`
+	Second         venueDTO `json:"second"`
+	NameSimilarity float64  `json:"name_similarity"`
+	CitySimilarity float64  `json:"city_similarity"`
+	Similarity     float64  `json:"similarity"`
+}
+
+type auditEntryDTO struct {
+	ID         int             `json:"id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   int             `json:"entity_id"`
+	Action     string          `json:"action"`
+	Actor      string          `json:"actor"`
+	ChangedAt  time.Time       `json:"changed_at"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
 }
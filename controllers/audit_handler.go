@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// The X-Actor header names the caller recorded in the audit log for a write.
+const (
+	actorHeader    = "X-Actor"
+	anonymousActor = "anonymous"
+	maxActorLength = 100
+)
+
+type AuditHandler struct {
+	auditService services.AuditServiceInterface
+}
+
+func NewAuditHandler(s services.AuditServiceInterface) *AuditHandler {
+	return &AuditHandler{auditService: s}
+}
+
+// ActorMiddleware puts the caller named by the X-Actor header on the request
+// context, so that the audit log can tell who made a change. Requests without
+// one are recorded as anonymous.
+func ActorMiddleware(c *gin.Context) {
+	actor := strings.TrimSpace(c.GetHeader(actorHeader))
+	if actor == "" {
+		actor = anonymousActor
+	}
+	if len(actor) > maxActorLength {
+		actor = actor[:maxActorLength]
+	}
+	c.Request = c.Request.WithContext(services.WithActor(c.Request.Context(), actor))
+	c.Next()
+}
+
+// HandleListAudit answers ?entity= and ?id= with the matching audit entries,
+// newest first. ?limit= caps the number of entries.
+func (h *AuditHandler) HandleListAudit(c *gin.Context) {
+	var req services.AuditRequest
+
+	if entity := c.Query("entity"); entity != "" {
+		req.EntityType = &entity
+	}
+	if idStr := c.Query("id"); idStr != "" {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "id must be a number"})
+			return
+		}
+		req.EntityID = &id
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a number"})
+			return
+		}
+		req.Limit = limit
+	}
+	entries, err := h.auditService.ListEntries(c.Request.Context(), req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toDTOAuditEntries(entries))
+}
+
+// HandleEventHistory lists every audit entry of an event, newest first, also
+// after the event was deleted.
+func (h *AuditHandler) HandleEventHistory(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+	entries, err := h.auditService.GetEventHistory(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, toDTOAuditEntries(entries))
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// MockAuditService is a mock implementation of AuditServiceInterface
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) ListEntries(ctx context.Context, req services.AuditRequest) ([]services.AuditEntry, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.AuditEntry), args.Error(1)
+}
+
+func (m *MockAuditService) GetEventHistory(ctx context.Context, eventID int) ([]services.AuditEntry, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.AuditEntry), args.Error(1)
+}
+
+func TestAuditHandler_HandleListAudit(t *testing.T) {
+	entity := "event"
+	unknown := "league"
+	id := 4
+	entries := []services.AuditEntry{{
+		ID:         1,
+		EntityType: services.AuditEntityEvent,
+		EntityID:   id,
+		Action:     services.AuditActionUpdate,
+		Actor:      "alice",
+		ChangedAt:  time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC),
+		Before:     json.RawMessage(`{"description":"Derby"}`),
+		After:      json.RawMessage(`{"description":"City derby"}`),
+	}}
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		mockRequest    *services.AuditRequest
+		mockEntries    []services.AuditEntry
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "entries of one event",
+			queryParams:    "?entity=event&id=4&limit=10",
+			mockRequest:    &services.AuditRequest{EntityType: &entity, EntityID: &id, Limit: 10},
+			mockEntries:    entries,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid id",
+			queryParams:    "?entity=event&id=four",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown entity",
+			queryParams:    "?entity=league",
+			mockRequest:    &services.AuditRequest{EntityType: &unknown},
+			mockError:      fmt.Errorf("validation error: entity must be one of event, series, sport, team or venue"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			queryParams:    "",
+			mockRequest:    &services.AuditRequest{},
+			mockError:      fmt.Errorf("failed to list audit entries: connection lost"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAuditService)
+			handler := NewAuditHandler(mockService)
+
+			router := setupRouter()
+			router.GET("/audit", handler.HandleListAudit)
+
+			if tt.mockRequest != nil {
+				mockService.On("ListEntries", mock.Anything, *tt.mockRequest).Return(tt.mockEntries, tt.mockError)
+			}
+
+			req := httptest.NewRequest("GET", "/audit"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response []auditEntryDTO
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Len(t, response, 1)
+				assert.Equal(t, "alice", response[0].Actor)
+				assert.JSONEq(t, `{"description":"City derby"}`, string(response[0].After))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuditHandler_HandleEventHistory(t *testing.T) {
+	mockService := new(MockAuditService)
+	handler := NewAuditHandler(mockService)
+
+	router := setupRouter()
+	router.GET("/events/:id/history", handler.HandleEventHistory)
+
+	mockService.On("GetEventHistory", mock.Anything, 4).Return([]services.AuditEntry{}, nil)
+	mockService.On("GetEventHistory", mock.Anything, 99).
+		Return(nil, fmt.Errorf("database error: %w", sql.ErrNoRows))
+
+	for path, status := range map[string]int{
+		"/events/4/history":   http.StatusOK,
+		"/events/99/history":  http.StatusNotFound,
+		"/events/abc/history": http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, status, w.Code, path)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestActorMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "named caller", header: "  alice ", expected: "alice"},
+		{name: "no header", header: "", expected: "anonymous"},
+		{name: "long name is cut", header: strings.Repeat("a", 150), expected: strings.Repeat("a", maxActorLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor string
+			router := setupRouter()
+			router.Use(ActorMiddleware)
+			router.GET("/whoami", func(c *gin.Context) {
+				actor = services.ActorFromContext(c.Request.Context())
+			})
+
+			req := httptest.NewRequest("GET", "/whoami", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Actor", tt.header)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expected, actor)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+type BroadcastHandler struct {
+	broadcastService services.BroadcastServiceInterface
+}
+
+func NewBroadcastHandler(b services.BroadcastServiceInterface) *BroadcastHandler {
+	return &BroadcastHandler{broadcastService: b}
+}
+
+func (h *BroadcastHandler) HandleCreateBroadcast(c *gin.Context) {
+	var req services.CreateBroadcastRequest
+
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	newID, err := h.broadcastService.CreateBroadcast(c.Request.Context(), eventID, req)
+	if err != nil {
+		respondBroadcastError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": newID})
+}
+
+// HandleListBroadcasts lists an event's broadcasts, optionally only those in
+// ?country=.
+func (h *BroadcastHandler) HandleListBroadcasts(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+	broadcasts, err := h.broadcastService.ListBroadcasts(c.Request.Context(), eventID, c.Query("country"))
+	if err != nil {
+		respondBroadcastError(c, err)
+		return
+	}
+	respondList(c, toDTOBroadcasts(broadcasts))
+}
+
+func (h *BroadcastHandler) HandleGetBroadcast(c *gin.Context) {
+	eventID, id, ok := parseBroadcastIDs(c)
+	if !ok {
+		return
+	}
+	broadcast, err := h.broadcastService.GetBroadcast(c.Request.Context(), eventID, id)
+	if err != nil {
+		respondBroadcastError(c, err)
+		return
+	}
+	respondResource(c, broadcast.Version, broadcast.UpdatedAt, toDTOBroadcast(*broadcast))
+}
+
+func (h *BroadcastHandler) HandleUpdateBroadcast(c *gin.Context) {
+	var req services.UpdateBroadcastRequest
+
+	eventID, id, ok := parseBroadcastIDs(c)
+	if !ok {
+		return
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.broadcastService.UpdateBroadcast(c.Request.Context(), eventID, id, req); err != nil {
+		respondBroadcastError(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *BroadcastHandler) HandleDeleteBroadcast(c *gin.Context) {
+	eventID, id, ok := parseBroadcastIDs(c)
+	if !ok {
+		return
+	}
+	if err := h.broadcastService.DeleteBroadcast(c.Request.Context(), eventID, id); err != nil {
+		respondBroadcastError(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func parseBroadcastIDs(c *gin.Context) (int, int, bool) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return 0, 0, false
+	}
+	id, err := strconv.Atoi(c.Param("broadcastId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid broadcast ID format"})
+		return 0, 0, false
+	}
+	return eventID, id, true
+}
+
+func respondBroadcastError(c *gin.Context, err error) {
+	if respondPreconditionFailed(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "validation error"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// respondResource writes a single record with its version as the ETag and
+// its last update as Last-Modified, or a 304 when the client has it already.
+func respondResource(c *gin.Context, version int, updatedAt time.Time, body interface{}) {
+	if notModified(c, versionETag(version), updatedAt) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// lastModifiedKey holds, on the gin context of a list request, when the
+// listed type of record last changed; see ListLastModified.
+const lastModifiedKey = "listLastModified"
+
+// ListLastModified dates the list of a route by the newest change to the
+// records of entityType, for respondList to send as Last-Modified. It runs
+// before the list is read, so that a change made meanwhile can only make the
+// date too old, never too new.
+func ListLastModified(s services.LastModifiedServiceInterface, entityType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lastModified, err := s.LastModified(c.Request.Context(), entityType)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Set(lastModifiedKey, lastModified)
+		c.Next()
+	}
+}
+
+// respondList writes a list with a hash of its JSON as the ETag, so that any
+// change to the listed records or to which records are listed shows in it,
+// and the date ListLastModified found, if it ran, as Last-Modified.
+func respondList(c *gin.Context, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	sum := sha256.Sum256(data)
+	if notModified(c, `"`+hex.EncodeToString(sum[:16])+`"`, c.GetTime(lastModifiedKey)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// notModified sets the ETag and Last-Modified headers of a response, leaving
+// out those that are empty, and reports whether the request's validators show
+// that the client's copy is current. As RFC 9110 asks, If-Modified-Since is
+// only looked at without an If-None-Match.
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if etag != "" {
+		c.Header("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		lastModified = lastModified.UTC().Truncate(time.Second)
+		c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		return etag != "" && etagListMatches(ifNoneMatch, etag)
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	ims, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	return err == nil && !lastModified.After(ims)
+}
+
+// etagListMatches reports whether an If-None-Match list names etag, comparing
+// weakly as the header asks.
+func etagListMatches(list, etag string) bool {
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheControlMiddleware sets the Cache-Control header of successful GET and
+// HEAD responses to the directives of the rule for their route, or to
+// defaults. A rule applies to the route it names and to the routes below it;
+// the longest one wins.
+func CacheControlMiddleware(defaults string, rules map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+		directives, matched := defaults, ""
+		route := c.FullPath()
+		for prefix, ruleDirectives := range rules {
+			if len(prefix) > len(matched) && (route == prefix || strings.HasPrefix(route, prefix+"/")) {
+				directives, matched = ruleDirectives, prefix
+			}
+		}
+		if directives != "" {
+			c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, directives: directives}
+		}
+		c.Next()
+	}
+}
+
+// cacheControlWriter adds Cache-Control to a response unless it is an error,
+// which caches should not keep.
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	directives string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if code < http.StatusBadRequest {
+		w.Header().Set("Cache-Control", w.directives)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// ParseCacheControlRules reads per-route Cache-Control rules written as
+// route=directives pairs separated by semicolons, e.g.
+// "/api/v1/sports=public, max-age=300;/api/v1/events/:id=no-cache".
+func ParseCacheControlRules(spec string) (map[string]string, error) {
+	rules := map[string]string{}
+	for _, rule := range strings.Split(spec, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		route, directives, ok := strings.Cut(rule, "=")
+		route, directives = strings.TrimSpace(route), strings.TrimSpace(directives)
+		if !ok || !strings.HasPrefix(route, "/") || directives == "" {
+			return nil, fmt.Errorf("invalid cache control rule %q: want /route=directives", rule)
+		}
+		rules[strings.TrimSuffix(route, "/")] = directives
+	}
+	return rules, nil
+}
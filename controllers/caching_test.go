@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/config"
+	"github.com/vsennikov/sports-event-calendar/infrastructure"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// MockLastModifiedService is a mock implementation of
+// LastModifiedServiceInterface
+type MockLastModifiedService struct {
+	mock.Mock
+}
+
+func (m *MockLastModifiedService) LastModified(ctx context.Context, entityType string) (time.Time, error) {
+	args := m.Called(ctx, entityType)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func TestSportHandler_ConditionalGets(t *testing.T) {
+	updatedAt := time.Date(2025, 6, 1, 12, 30, 15, 500, time.UTC)
+	lastModified := "Sun, 01 Jun 2025 12:30:15 GMT"
+	sport := &services.Sport{ID: 1, Name: "Rugby", Version: 4, CreatedAt: updatedAt, UpdatedAt: updatedAt}
+
+	tests := []struct {
+		name           string
+		path           string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{name: "detail without validators", path: "/sports/1", expectedStatus: http.StatusOK},
+		{name: "detail with current ETag", path: "/sports/1",
+			headers: map[string]string{"If-None-Match": `"3", "4"`}, expectedStatus: http.StatusNotModified},
+		{name: "detail with weak current ETag", path: "/sports/1",
+			headers: map[string]string{"If-None-Match": `W/"4"`}, expectedStatus: http.StatusNotModified},
+		{name: "detail with stale ETag", path: "/sports/1",
+			headers: map[string]string{"If-None-Match": `"3"`}, expectedStatus: http.StatusOK},
+		{name: "detail not modified since", path: "/sports/1",
+			headers: map[string]string{"If-Modified-Since": lastModified}, expectedStatus: http.StatusNotModified},
+		{name: "detail modified since", path: "/sports/1",
+			headers: map[string]string{"If-Modified-Since": "Sun, 01 Jun 2025 12:30:14 GMT"}, expectedStatus: http.StatusOK},
+		{name: "stale ETag outweighs If-Modified-Since", path: "/sports/1",
+			headers: map[string]string{"If-None-Match": `"3"`, "If-Modified-Since": lastModified}, expectedStatus: http.StatusOK},
+		{name: "list not modified since", path: "/sports",
+			headers: map[string]string{"If-Modified-Since": lastModified}, expectedStatus: http.StatusNotModified},
+		{name: "list modified since", path: "/sports",
+			headers: map[string]string{"If-Modified-Since": "Sun, 01 Jun 2025 12:30:14 GMT"}, expectedStatus: http.StatusOK},
+		{name: "list with any ETag", path: "/sports",
+			headers: map[string]string{"If-None-Match": "*"}, expectedStatus: http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSportService)
+			mockService.On("GetSportByID", mock.Anything, 1).Return(sport, nil)
+			mockService.On("ListSports", mock.Anything).Return([]services.Sport{*sport}, nil)
+			mockLastModified := new(MockLastModifiedService)
+			mockLastModified.On("LastModified", mock.Anything, services.AuditEntitySport).Return(updatedAt, nil)
+			handler := NewSportHandler(mockService)
+			router := setupRouter()
+			router.GET("/sports", ListLastModified(mockLastModified, services.AuditEntitySport), handler.HandleListSports)
+			router.GET("/sports/:id", handler.HandleGetSportByID)
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, lastModified, w.Header().Get("Last-Modified"))
+			assert.NotEmpty(t, w.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("list ETag follows the listed records", func(t *testing.T) {
+		listETag := func(sports []services.Sport) string {
+			mockService := new(MockSportService)
+			mockService.On("ListSports", mock.Anything).Return(sports, nil)
+			router := setupRouter()
+			router.GET("/sports", NewSportHandler(mockService).HandleListSports)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/sports", nil))
+			require.Equal(t, http.StatusOK, w.Code)
+			return w.Header().Get("ETag")
+		}
+
+		other := services.Sport{ID: 2, Name: "Polo", Version: 1, UpdatedAt: updatedAt.Add(-time.Hour)}
+		assert.Equal(t, listETag([]services.Sport{*sport, other}), listETag([]services.Sport{*sport, other}))
+		assert.NotEqual(t, listETag([]services.Sport{*sport, other}), listETag([]services.Sport{*sport}))
+	})
+}
+
+func TestListLastModified_Deletes(t *testing.T) {
+	ctx := context.Background()
+	db, err := infrastructure.NewConnection(config.Config{
+		DBDriver: "sqlite", DBName: filepath.Join(t.TempDir(), "calendar.db"), DBMaxIdleConns: 2,
+	})
+	require.NoError(t, err)
+	defer db.Close()
+	migrator, err := infrastructure.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	sportService := services.NewSportService(infrastructure.NewSportRepository(db),
+		infrastructure.NewEventRepository(db), infrastructure.NewTransactor(db))
+	router := setupRouter()
+	router.GET("/sports", ListLastModified(
+		services.NewLastModifiedService(infrastructure.NewLastModifiedRepository(db)), services.AuditEntitySport),
+		NewSportHandler(sportService).HandleListSports)
+	list := func(ifModifiedSince string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/sports", nil)
+		if ifModifiedSince != "" {
+			req.Header.Set("If-Modified-Since", ifModifiedSince)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	_, err = sportService.CreateSport(ctx, services.SportRequest{Name: "Football"})
+	require.NoError(t, err)
+	rugbyID, err := sportService.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+	require.NoError(t, err)
+	before := list("").Header().Get("Last-Modified")
+	require.NotEmpty(t, before)
+	assert.Equal(t, http.StatusNotModified, list(before).Code)
+
+	// Last-Modified has whole seconds
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+	require.NoError(t, sportService.DeleteSport(ctx, rugbyID))
+
+	w := list(before)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, before, w.Header().Get("Last-Modified"))
+	assert.NotContains(t, w.Body.String(), "Rugby")
+}
+
+func TestCacheControlMiddleware(t *testing.T) {
+	rules := map[string]string{
+		"/api/v1/sports":     "public, max-age=300",
+		"/api/v1/events":     "public, max-age=30",
+		"/api/v1/events/:id": "private, max-age=5",
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		status   int
+		expected string
+	}{
+		{name: "route rule", method: "GET", path: "/api/v1/sports", status: http.StatusOK, expected: "public, max-age=300"},
+		{name: "rule of a parent route", method: "GET", path: "/api/v1/sports/1", status: http.StatusOK, expected: "public, max-age=300"},
+		{name: "longest rule wins", method: "GET", path: "/api/v1/events/7", status: http.StatusOK, expected: "private, max-age=5"},
+		{name: "not modified", method: "GET", path: "/api/v1/events", status: http.StatusNotModified, expected: "public, max-age=30"},
+		{name: "default", method: "GET", path: "/api/v1/teams", status: http.StatusOK, expected: "no-cache"},
+		{name: "errors are not cached", method: "GET", path: "/api/v1/sports/1", status: http.StatusNotFound},
+		{name: "writes are not cached", method: "PATCH", path: "/api/v1/sports/1", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter()
+			router.Use(CacheControlMiddleware("no-cache", rules))
+			respond := func(c *gin.Context) { c.Status(tt.status) }
+			for _, path := range []string{"/api/v1/sports", "/api/v1/sports/:id", "/api/v1/events",
+				"/api/v1/events/:id", "/api/v1/teams"} {
+				router.GET(path, respond)
+				router.PATCH(path, respond)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.expected, w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestParseCacheControlRules(t *testing.T) {
+	rules, err := ParseCacheControlRules(" /api/v1/sports/ = public, max-age=300 ;/api/v1/events/:id=no-cache;")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"/api/v1/sports":     "public, max-age=300",
+		"/api/v1/events/:id": "no-cache",
+	}, rules)
+
+	rules, err = ParseCacheControlRules("")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	for _, spec := range []string{"public, max-age=300", "api/v1/sports=no-cache", "/api/v1/sports="} {
+		_, err := ParseCacheControlRules(spec)
+		assert.Error(t, err, spec)
+	}
+}
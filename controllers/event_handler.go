@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -26,12 +27,19 @@ func (h *EventHandler) HandleCreateEvent(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	newID, err := h.eventService.CreateEvent(c.Request.Context(), req)
+	newID, warnings, err := h.eventService.CreateEvent(c.Request.Context(), req)
 	if err != nil {
+		if respondScheduleConflict(c, err) {
+			return
+		}
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"id": newID})
+	c.JSON(http.StatusCreated, gin.H{"id": newID, "warnings": nonNilWarnings(warnings)})
 }
 
 func (h *EventHandler) HandleGetEventByID(c *gin.Context) {
@@ -41,7 +49,11 @@ func (h *EventHandler) HandleGetEventByID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
 		return
 	}
-	event, err := h.eventService.GetEventByID(c.Request.Context(), id)
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	event, err := h.eventService.GetEventByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -51,31 +63,44 @@ func (h *EventHandler) HandleGetEventByID(c *gin.Context) {
 		return
 	}
 	eventDTO := toDTOEvent(*event)
-	c.JSON(http.StatusOK, eventDTO)
+	respondResource(c, event.Version, event.UpdatedAt, eventDTO)
 }
 
 func (h *EventHandler) HandleListEvents(c *gin.Context) {
-	var req services.ListEventsRequest
+	req, ok := bindListEventsRequest(c)
+	if !ok {
+		return
+	}
+	h.listEvents(c, req)
+}
 
-	page, _ := strconv.Atoi(c.Query("page"))
-	req.Page = page
-	limit, _ := strconv.Atoi(c.Query("limit"))
-	req.Limit = limit
-	if sportIDStr := c.Query("sport_id"); sportIDStr != "" {
-		if sportID, err := strconv.Atoi(sportIDStr); err == nil {
-			req.SportID = &sportID
-		}
+// HandleListNearbyEvents lists the events at venues within radius_km of
+// lat/lon, combinable with the filters of HandleListEvents.
+func (h *EventHandler) HandleListNearbyEvents(c *gin.Context) {
+	req, ok := bindListEventsRequest(c)
+	if !ok {
+		return
 	}
-	date_from := c.Query("date_from")
-	if (date_from != "") {
-		parsedTime, err := time.Parse("2006-01-02", date_from)
-		if (err == nil) {
-			req.DateFrom = &parsedTime
-		}
+	point, radiusKm, ok := bindNearbyQuery(c)
+	if !ok {
+		return
 	}
+	req.Near = &point
+	req.RadiusKm = radiusKm
+	h.listEvents(c, req)
+}
 
-	events, pagination, err := h.eventService.ListEvents(c.Request.Context(), req)
+func (h *EventHandler) listEvents(c *gin.Context, req services.ListEventsRequest) {
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	events, pagination, err := h.eventService.ListEvents(ctx, req)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -83,12 +108,90 @@ func (h *EventHandler) HandleListEvents(c *gin.Context) {
 	for _, e := range events {
 		eventDTOs = append(eventDTOs, toDTOEvent(e))
 	}
-	c.JSON(http.StatusOK, gin.H{
+	respondList(c, gin.H{
 		"pagination": pagination,
 		"events":     eventDTOs,
 	})
 }
 
+// bindListEventsRequest reads the paging and filter query parameters shared
+// by the event list endpoints.
+func bindListEventsRequest(c *gin.Context) (services.ListEventsRequest, bool) {
+	var req services.ListEventsRequest
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	req.Page = page
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	req.Limit = limit
+	sportID, ok := bindQueryID(c, "sport_id")
+	if !ok {
+		return req, false
+	}
+	req.SportID = sportID
+	seriesID, ok := bindQueryID(c, "series_id")
+	if !ok {
+		return req, false
+	}
+	req.SeriesID = seriesID
+	if country := c.Query("broadcast_country"); country != "" {
+		req.BroadcastCountry = &country
+	}
+	from, to, ok := bindDateRange(c)
+	if !ok {
+		return req, false
+	}
+	req.DateFrom = from
+	req.DateTo = to
+	return req, true
+}
+
+// bindDateRange reads the date_from/date_to day filters, interpreted in the
+// tz time zone. The returned upper bound is exclusive: the day after date_to.
+func bindDateRange(c *gin.Context) (*time.Time, *time.Time, bool) {
+	var from, to *time.Time
+
+	loc, err := services.LoadTimeZone(c.Query("tz"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, nil, false
+	}
+	date_from := c.Query("date_from")
+	if (date_from != "") {
+		parsedTime, err := time.ParseInLocation("2006-01-02", date_from, loc)
+		if (err != nil) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date_from, expected YYYY-MM-DD"})
+			return nil, nil, false
+		}
+		from = &parsedTime
+	}
+	date_to := c.Query("date_to")
+	if (date_to != "") {
+		parsedTime, err := time.ParseInLocation("2006-01-02", date_to, loc)
+		if (err != nil) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date_to, expected YYYY-MM-DD"})
+			return nil, nil, false
+		}
+		endOfDay := parsedTime.AddDate(0, 0, 1)
+		to = &endOfDay
+	}
+	return from, to, true
+}
+
+// bindQueryID reads the optional ID filter name from the query string; nil
+// stands for no filter.
+func bindQueryID(c *gin.Context, name string) (*int, bool) {
+	idStr := c.Query(name)
+	if idStr == "" {
+		return nil, true
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return nil, false
+	}
+	return &id, true
+}
+
 func (h *EventHandler) HandleUpdateEvent(c *gin.Context) {
 	var req services.UpdateEventRequest
 	
@@ -102,12 +205,22 @@ func (h *EventHandler) HandleUpdateEvent(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	err = h.eventService.UpdateEvent(c.Request.Context(), id, req)
+	warnings, err := h.eventService.UpdateEvent(c.Request.Context(), id, req)
 	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
+		if respondScheduleConflict(c, err) {
+			return
+		}
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.Status(http.StatusOK)
+	c.JSON(http.StatusOK, gin.H{"warnings": nonNilWarnings(warnings)})
 }
 
 func (h *EventHandler) HandleDeleteEvent(c *gin.Context) {
@@ -119,8 +232,86 @@ func (h *EventHandler) HandleDeleteEvent(c *gin.Context) {
 	}
 	err = h.eventService.DeleteEvent(c.Request.Context(), id)
 	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusOK)
 }
+
+// HandleRestoreEvent undeletes a soft-deleted event and returns it; taking
+// back a venue slot booked in the meantime is a 409.
+func (h *EventHandler) HandleRestoreEvent(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+	event, err := h.eventService.RestoreEvent(c.Request.Context(), id)
+	if err != nil {
+		if respondScheduleConflict(c, err) {
+			return
+		}
+		respondMergeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDTOEvent(*event))
+}
+
+func (h *EventHandler) HandleListReschedules(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return
+	}
+	reschedules, err := h.eventService.ListReschedules(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	rescheduleDTOs := make([]rescheduleDTO, 0, len(reschedules))
+	for _, reschedule := range reschedules {
+		rescheduleDTOs = append(rescheduleDTOs, toDTOReschedule(reschedule))
+	}
+	c.JSON(http.StatusOK, rescheduleDTOs)
+}
+
+// respondScheduleConflict answers venue double-bookings and rejected team
+// rest conflicts with 409, naming the clashing events when the service could
+// identify them. It reports whether err was such a conflict.
+func respondScheduleConflict(c *gin.Context, err error) bool {
+	body := gin.H{"error": err.Error()}
+	var venueConflict *services.VenueConflictError
+	var teamConflict *services.TeamConflictError
+
+	switch {
+	case errors.As(err, &venueConflict):
+		body["conflicting_event_id"] = venueConflict.ConflictingEvent.ID
+	case errors.As(err, &teamConflict):
+		conflicts := make([]teamConflictDTO, 0, len(teamConflict.Conflicts))
+		for _, conflict := range teamConflict.Conflicts {
+			conflicts = append(conflicts, toDTOTeamConflict(conflict))
+		}
+		body["conflicts"] = conflicts
+	case errors.Is(err, services.ErrVenueConflict), errors.Is(err, services.ErrTeamConflict):
+	default:
+		return false
+	}
+	c.JSON(http.StatusConflict, body)
+	return true
+}
+
+func nonNilWarnings(warnings []string) []string {
+	if warnings == nil {
+		return []string{}
+	}
+	return warnings
+}
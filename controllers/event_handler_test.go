@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -30,9 +31,12 @@ func (m *MockEventService) GetEventByID(ctx context.Context, id int) (*services.
 	return args.Get(0).(*services.Event), args.Error(1)
 }
 
-func (m *MockEventService) CreateEvent(ctx context.Context, req services.EventCreateRequest) (int, error) {
+func (m *MockEventService) CreateEvent(ctx context.Context, req services.EventCreateRequest) (int, []string, error) {
 	args := m.Called(ctx, req)
-	return args.Int(0), args.Error(1)
+	if args.Get(1) == nil {
+		return args.Int(0), nil, args.Error(2)
+	}
+	return args.Int(0), args.Get(1).([]string), args.Error(2)
 }
 
 func (m *MockEventService) ListEvents(ctx context.Context, req services.ListEventsRequest) ([]services.Event, *services.Pagination, error) {
@@ -48,9 +52,12 @@ func (m *MockEventService) ListEvents(ctx context.Context, req services.ListEven
 	return events, pagination, args.Error(2)
 }
 
-func (m *MockEventService) UpdateEvent(ctx context.Context, id int, req services.UpdateEventRequest) error {
+func (m *MockEventService) UpdateEvent(ctx context.Context, id int, req services.UpdateEventRequest) ([]string, error) {
 	args := m.Called(ctx, id, req)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
 }
 
 func (m *MockEventService) DeleteEvent(ctx context.Context, id int) error {
@@ -58,6 +65,22 @@ func (m *MockEventService) DeleteEvent(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *MockEventService) RestoreEvent(ctx context.Context, id int) (*services.Event, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.Event), args.Error(1)
+}
+
+func (m *MockEventService) ListReschedules(ctx context.Context, id int) ([]services.EventReschedule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.EventReschedule), args.Error(1)
+}
+
 func setupRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -68,6 +91,7 @@ func TestEventHandler_HandleCreateEvent(t *testing.T) {
 		name           string
 		requestBody    interface{}
 		mockID         int
+		mockWarnings   []string
 		mockError      error
 		expectedStatus int
 	}{
@@ -102,6 +126,62 @@ func TestEventHandler_HandleCreateEvent(t *testing.T) {
 			mockError:      sql.ErrNoRows,
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name: "both event_datetime and local_datetime",
+			requestBody: services.EventCreateRequest{
+				EventDatetime: time.Now().Add(24 * time.Hour),
+				LocalDatetime: stringPtr("2030-06-01T18:00:00"),
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockID:         0,
+			mockError:      fmt.Errorf("validation error: use either event_datetime or local_datetime, not both"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "venue double-booked",
+			requestBody: services.EventCreateRequest{
+				EventDatetime: time.Now().Add(24 * time.Hour),
+				SportID:       1,
+				VenueID:       intPtr(3),
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockID:         0,
+			mockError:      &services.VenueConflictError{VenueID: 3, ConflictingEvent: services.Event{ID: 42}},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "created with rest warnings",
+			requestBody: services.EventCreateRequest{
+				EventDatetime: time.Now().Add(24 * time.Hour),
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockID:         7,
+			mockWarnings:   []string{"team 1 has only 60 minutes rest between event 5 and the new event (minimum 1440)"},
+			mockError:      nil,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "team rest conflict",
+			requestBody: services.EventCreateRequest{
+				EventDatetime: time.Now().Add(24 * time.Hour),
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockID: 0,
+			mockError: &services.TeamConflictError{Conflicts: []services.TeamConflict{{
+				TeamID:           1,
+				Event:            services.Event{ID: 42},
+				ConflictingEvent: services.Event{},
+				Overlapping:      true,
+			}}},
+			expectedStatus: http.StatusConflict,
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,7 +206,7 @@ func TestEventHandler_HandleCreateEvent(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			if tt.name != "invalid request body" {
-				mockService.On("CreateEvent", mock.Anything, mock.AnythingOfType("EventCreateRequest")).Return(tt.mockID, tt.mockError)
+				mockService.On("CreateEvent", mock.Anything, mock.AnythingOfType("EventCreateRequest")).Return(tt.mockID, tt.mockWarnings, tt.mockError)
 			}
 
 			router.ServeHTTP(w, req)
@@ -134,10 +214,30 @@ func TestEventHandler_HandleCreateEvent(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, w.Code)
 
 			if tt.expectedStatus == http.StatusCreated {
-				var response map[string]int
+				var response struct {
+					ID       int      `json:"id"`
+					Warnings []string `json:"warnings"`
+				}
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.mockID, response["id"])
+				assert.Equal(t, tt.mockID, response.ID)
+				assert.Len(t, response.Warnings, len(tt.mockWarnings))
+			}
+			if tt.name == "venue double-booked" {
+				var response map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, float64(42), response["conflicting_event_id"])
+			}
+			if tt.name == "team rest conflict" {
+				var response struct {
+					Conflicts []teamConflictDTO `json:"conflicts"`
+				}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Len(t, response.Conflicts, 1)
+				assert.Equal(t, 42, response.Conflicts[0].EventID)
+				assert.True(t, response.Conflicts[0].Overlapping)
 			}
 
 			if tt.name != "invalid request body" {
@@ -148,6 +248,8 @@ func TestEventHandler_HandleCreateEvent(t *testing.T) {
 }
 
 func TestEventHandler_HandleGetEventByID(t *testing.T) {
+	kickoff := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Minute)
+
 	tests := []struct {
 		name           string
 		eventID        string
@@ -160,7 +262,8 @@ func TestEventHandler_HandleGetEventByID(t *testing.T) {
 			eventID: "1",
 			mockEvent: &services.Event{
 				ID:            1,
-				EventDatetime: time.Now().Add(24 * time.Hour),
+				EventDatetime: kickoff,
+				EndDatetime:   kickoff.Add(105 * time.Minute),
 				Sport:         services.Sport{ID: 1, Name: "Football"},
 				HomeTeam:      services.Team{ID: 1, Name: "Team A", City: "City A"},
 				AwayTeam:      services.Team{ID: 2, Name: "Team B", City: "City B"},
@@ -216,6 +319,8 @@ func TestEventHandler_HandleGetEventByID(t *testing.T) {
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				assert.NoError(t, err)
 				assert.Equal(t, tt.mockEvent.ID, response.ID)
+				assert.True(t, response.EndDatetime.Equal(kickoff.Add(105*time.Minute)))
+				assert.Equal(t, 105, response.DurationMinutes)
 			}
 
 			if tt.name != "invalid ID format" {
@@ -233,6 +338,7 @@ func TestEventHandler_HandleListEvents(t *testing.T) {
 		mockPagination *services.Pagination
 		mockError      error
 		expectedStatus int
+		expectedError  string
 	}{
 		{
 			name:        "successful list",
@@ -265,6 +371,50 @@ func TestEventHandler_HandleListEvents(t *testing.T) {
 			mockError:      nil,
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:        "with time zone and date range",
+			queryParams: "?tz=Europe/Vienna&date_from=2025-12-10&date_to=2025-12-10",
+			mockEvents: []services.Event{
+				{ID: 1, EventDatetime: time.Now().Add(24 * time.Hour)},
+			},
+			mockPagination: &services.Pagination{
+				TotalItems:  1,
+				TotalPages:  1,
+				CurrentPage: 1,
+				PageSize:    10,
+			},
+			mockError:      nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid time zone",
+			queryParams:    "?tz=Mars/Olympus",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid sport_id",
+			queryParams:    "?sport_id=football",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid sport_id",
+		},
+		{
+			name:           "invalid series_id",
+			queryParams:    "?series_id=1.5",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid series_id",
+		},
+		{
+			name:           "invalid date_from",
+			queryParams:    "?date_from=10.12.2025",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid date_from",
+		},
+		{
+			name:           "invalid date_to",
+			queryParams:    "?date_from=2025-12-10&date_to=2025-12-32",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid date_to",
+		},
 		{
 			name:           "service error",
 			queryParams:    "",
@@ -286,11 +436,16 @@ func TestEventHandler_HandleListEvents(t *testing.T) {
 			req := httptest.NewRequest("GET", "/events"+tt.queryParams, nil)
 			w := httptest.NewRecorder()
 
-			mockService.On("ListEvents", mock.Anything, mock.AnythingOfType("ListEventsRequest")).Return(tt.mockEvents, tt.mockPagination, tt.mockError)
+			if tt.expectedStatus != http.StatusBadRequest {
+				mockService.On("ListEvents", mock.Anything, mock.AnythingOfType("ListEventsRequest")).Return(tt.mockEvents, tt.mockPagination, tt.mockError)
+			}
 
 			router.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
 
 			if tt.expectedStatus == http.StatusOK {
 				var response map[string]interface{}
@@ -305,6 +460,140 @@ func TestEventHandler_HandleListEvents(t *testing.T) {
 	}
 }
 
+func TestEventHandler_HandleListEvents_TimeZoneDayBoundaries(t *testing.T) {
+	mockService := new(MockEventService)
+	handler := NewEventHandler(mockService)
+
+	router := setupRouter()
+	router.GET("/events", handler.HandleListEvents)
+
+	req := httptest.NewRequest("GET", "/events?tz=Europe/Vienna&date_from=2025-12-10&date_to=2025-12-10", nil)
+	w := httptest.NewRecorder()
+
+	expectedFrom := time.Date(2025, 12, 9, 23, 0, 0, 0, time.UTC)
+	expectedTo := time.Date(2025, 12, 10, 23, 0, 0, 0, time.UTC)
+	mockService.On("ListEvents", mock.Anything, mock.MatchedBy(func(r services.ListEventsRequest) bool {
+		return r.DateFrom != nil && r.DateFrom.Equal(expectedFrom) &&
+			r.DateTo != nil && r.DateTo.Equal(expectedTo)
+	})).Return([]services.Event{}, &services.Pagination{CurrentPage: 1, PageSize: 10}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestEventHandler_HandleListEvents_BroadcastCountry(t *testing.T) {
+	mockService := new(MockEventService)
+	handler := NewEventHandler(mockService)
+
+	router := setupRouter()
+	router.GET("/events", handler.HandleListEvents)
+
+	req := httptest.NewRequest("GET", "/events?broadcast_country=AT", nil)
+	w := httptest.NewRecorder()
+
+	kickoff := time.Date(2025, 10, 1, 19, 0, 0, 0, time.UTC)
+	event := services.Event{
+		ID:            1,
+		EventDatetime: kickoff,
+		EndDatetime:   kickoff.Add(2 * time.Hour),
+		Broadcasts: []services.Broadcast{
+			{ID: 1, EventID: 1, Broadcaster: "ORF", Channel: stringPtr("ORF 1"), CountryCode: "AT", StartDatetime: kickoff},
+		},
+	}
+	mockService.On("ListEvents", mock.Anything, mock.MatchedBy(func(r services.ListEventsRequest) bool {
+		return r.BroadcastCountry != nil && *r.BroadcastCountry == "AT"
+	})).Return([]services.Event{event}, &services.Pagination{TotalItems: 1, TotalPages: 1, CurrentPage: 1, PageSize: 10}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Events []EventDTO `json:"events"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Events, 1) && assert.Len(t, response.Events[0].Broadcasts, 1) {
+		assert.Equal(t, "ORF", response.Events[0].Broadcasts[0].Broadcaster)
+		assert.Equal(t, "AT", response.Events[0].Broadcasts[0].CountryCode)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestEventHandler_HandleListNearbyEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "successful list",
+			queryParams:    "?lat=48.2082&lon=16.3738&radius_km=10&sport_id=1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing lat",
+			queryParams:    "?lon=16.3738",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid radius",
+			queryParams:    "?lat=48.2082&lon=16.3738&radius_km=far",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "out of range coordinates",
+			queryParams:    "?lat=95&lon=16.3738",
+			mockError:      fmt.Errorf("validation error: latitude must be between -90 and 90"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockEventService)
+			handler := NewEventHandler(mockService)
+
+			router := setupRouter()
+			router.GET("/events/nearby", handler.HandleListNearbyEvents)
+
+			req := httptest.NewRequest("GET", "/events/nearby"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			if tt.expectedStatus == http.StatusOK || tt.mockError != nil {
+				distance := 1.7
+				events := []services.Event{{ID: 1, Venue: services.Venue{ID: 3, Name: "Ernst Happel Stadion", DistanceKm: &distance}}}
+				if tt.mockError != nil {
+					events = nil
+				}
+				mockService.On("ListEvents", mock.Anything, mock.MatchedBy(func(r services.ListEventsRequest) bool {
+					return r.Near != nil
+				})).Return(events, &services.Pagination{CurrentPage: 1, PageSize: 10}, tt.mockError)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response struct {
+					Events []EventDTO `json:"events"`
+				}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				if assert.Len(t, response.Events, 1) && assert.NotNil(t, response.Events[0].Venue) {
+					assert.Equal(t, 1.7, *response.Events[0].Venue.DistanceKm)
+				}
+				listReq := mockService.Calls[0].Arguments.Get(1).(services.ListEventsRequest)
+				assert.Equal(t, 48.2082, listReq.Near.Latitude)
+				assert.Equal(t, 10.0, listReq.RadiusKm)
+				assert.Equal(t, 1, *listReq.SportID)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestEventHandler_HandleUpdateEvent(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -345,6 +634,24 @@ func TestEventHandler_HandleUpdateEvent(t *testing.T) {
 			mockError:      sql.ErrNoRows,
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:    "venue double-booked",
+			eventID: "1",
+			requestBody: services.UpdateEventRequest{
+				VenueID: intPtr(3),
+			},
+			mockError:      fmt.Errorf("failed to update event: %w", services.ErrVenueConflict),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:    "attendance over capacity",
+			eventID: "1",
+			requestBody: services.UpdateEventRequest{
+				Attendance: intPtr(60000),
+			},
+			mockError:      fmt.Errorf("validation error: attendance 60000 exceeds the capacity 53400 of venue 2"),
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -370,7 +677,7 @@ func TestEventHandler_HandleUpdateEvent(t *testing.T) {
 
 			if tt.name != "invalid ID format" && tt.name != "invalid request body" {
 				id, _ := parseID(tt.eventID)
-				mockService.On("UpdateEvent", mock.Anything, id, mock.AnythingOfType("UpdateEventRequest")).Return(tt.mockError)
+				mockService.On("UpdateEvent", mock.Anything, id, mock.AnythingOfType("UpdateEventRequest")).Return(nil, tt.mockError)
 			}
 
 			router.ServeHTTP(w, req)
@@ -438,6 +745,69 @@ func TestEventHandler_HandleDeleteEvent(t *testing.T) {
 	}
 }
 
+func TestEventHandler_HandleListReschedules(t *testing.T) {
+	original := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	reason := "Waterlogged pitch"
+	tests := []struct {
+		name            string
+		eventID         string
+		mockReschedules []services.EventReschedule
+		mockError       error
+		expectedStatus  int
+	}{
+		{
+			name:    "successful retrieval",
+			eventID: "1",
+			mockReschedules: []services.EventReschedule{
+				{ID: 1, EventID: 1, PreviousDatetime: original, NewDatetime: original.Add(24 * time.Hour), Reason: &reason},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid ID format",
+			eventID:        "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "event not found",
+			eventID:        "999",
+			mockError:      fmt.Errorf("database error: %w", sql.ErrNoRows),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockEventService)
+			handler := NewEventHandler(mockService)
+
+			router := setupRouter()
+			router.GET("/events/:id/reschedules", handler.HandleListReschedules)
+
+			req := httptest.NewRequest("GET", "/events/"+tt.eventID+"/reschedules", nil)
+			w := httptest.NewRecorder()
+
+			if tt.name != "invalid ID format" {
+				id, _ := parseID(tt.eventID)
+				mockService.On("ListReschedules", mock.Anything, id).Return(tt.mockReschedules, tt.mockError)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response []rescheduleDTO
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Len(t, response, 1)
+				assert.Equal(t, reason, *response[0].Reason)
+				assert.True(t, response[0].PreviousDatetime.Equal(original))
+			}
+		})
+	}
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+const atomContentType = "application/atom+xml; charset=utf-8"
+
+type FeedHandler struct {
+	feedService services.FeedServiceInterface
+}
+
+func NewFeedHandler(s services.FeedServiceInterface) *FeedHandler {
+	return &FeedHandler{feedService: s}
+}
+
+func (h *FeedHandler) HandleResultsFeed(c *gin.Context) {
+	req, ok := bindFeedRequest(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.feedService.ListResults(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	baseURL := requestBaseURL(c)
+	entries := make([]atomEntry, 0, len(results))
+	for _, r := range results {
+		entries = append(entries, toAtomResultEntry(r, baseURL))
+	}
+	writeAtomFeed(c, "results", "Latest results", results, entries)
+}
+
+func (h *FeedHandler) HandleChangesFeed(c *gin.Context) {
+	req, ok := bindFeedRequest(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.feedService.ListChanges(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	baseURL := requestBaseURL(c)
+	entries := make([]atomEntry, 0, len(changes))
+	for _, change := range changes {
+		entries = append(entries, toAtomChangeEntry(change, baseURL))
+	}
+	writeAtomFeed(c, "changes", "Schedule changes", changes, entries)
+}
+
+func bindFeedRequest(c *gin.Context) (services.FeedRequest, bool) {
+	var req services.FeedRequest
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	req.Limit = limit
+	sportID, ok := bindQueryID(c, "sport_id")
+	if !ok {
+		return req, false
+	}
+	req.SportID = sportID
+	teamID, ok := bindQueryID(c, "team_id")
+	if !ok {
+		return req, false
+	}
+	req.TeamID = teamID
+	return req, true
+}
+
+// writeAtomFeed renders the feed, answering 304 Not Modified when nothing
+// changed since the client's If-Modified-Since timestamp.
+func writeAtomFeed(c *gin.Context, name, title string, changes []services.EventChange, entries []atomEntry) {
+	var lastModified time.Time
+	for _, change := range changes {
+		if change.ChangedAt.After(lastModified) {
+			lastModified = change.ChangedAt
+		}
+	}
+	updated := time.Now()
+	if !lastModified.IsZero() {
+		updated = lastModified.UTC().Truncate(time.Second)
+	}
+	if notModified(c, "", lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	selfURL := requestBaseURL(c) + c.Request.URL.RequestURI()
+	feed := atomFeed{
+		ID:      atomTagPrefix + "feeds/" + name + canonicalFeedQuery(c),
+		Title:   title,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links:   []atomLink{{Rel: "self", Href: selfURL}},
+		Author:  atomAuthor{Name: "Sports Event Calendar"},
+		Entries: entries,
+	}
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, atomContentType, append([]byte(xml.Header), body...))
+}
+
+// canonicalFeedQuery keeps the feed ID stable for a given filter regardless
+// of parameter order or paging hints such as limit.
+func canonicalFeedQuery(c *gin.Context) string {
+	query := c.Request.URL.Query()
+	filters := url.Values{}
+	for _, key := range []string{"sport_id", "team_id"} {
+		if value := query.Get(key); value != "" {
+			filters.Set(key, value)
+		}
+	}
+	if len(filters) == 0 {
+		return ""
+	}
+	return "?" + filters.Encode()
+}
+
+func requestBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
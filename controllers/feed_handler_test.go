@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// MockFeedService is a mock implementation of FeedServiceInterface
+type MockFeedService struct {
+	mock.Mock
+}
+
+func (m *MockFeedService) ListResults(ctx context.Context, req services.FeedRequest) ([]services.EventChange, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.EventChange), args.Error(1)
+}
+
+func (m *MockFeedService) ListChanges(ctx context.Context, req services.FeedRequest) ([]services.EventChange, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.EventChange), args.Error(1)
+}
+
+func TestFeedHandler_HandleResultsFeed(t *testing.T) {
+	changedAt := time.Date(2025, 10, 1, 21, 0, 0, 0, time.UTC)
+	results := []services.EventChange{
+		{
+			ID:            7,
+			EventID:       1,
+			ChangeType:    services.EventChangeScored,
+			ChangedAt:     changedAt,
+			EventDatetime: changedAt.Add(-2 * time.Hour),
+			HomeScore:     intPtr(2),
+			AwayScore:     intPtr(1),
+			Sport:         services.Sport{ID: 1, Name: "Football"},
+			HomeTeam:      services.Team{ID: 1, Name: "Red Bull Salzburg"},
+			AwayTeam:      services.Team{ID: 2, Name: "Manchester City"},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		queryParams     string
+		ifModifiedSince string
+		mockResults     []services.EventChange
+		mockError       error
+		expectedStatus  int
+		expectedError   string
+	}{
+		{
+			name:           "successful feed",
+			queryParams:    "?sport_id=1&team_id=2",
+			mockResults:    results,
+			mockError:      nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "not modified since",
+			queryParams:     "",
+			ifModifiedSince: changedAt.Format(http.TimeFormat),
+			mockResults:     results,
+			mockError:       nil,
+			expectedStatus:  http.StatusNotModified,
+		},
+		{
+			name:            "modified since",
+			queryParams:     "",
+			ifModifiedSince: changedAt.Add(-time.Minute).Format(http.TimeFormat),
+			mockResults:     results,
+			mockError:       nil,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:           "invalid sport_id",
+			queryParams:    "?sport_id=football",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid sport_id",
+		},
+		{
+			name:           "invalid team_id",
+			queryParams:    "?sport_id=1&team_id=salzburg",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid team_id",
+		},
+		{
+			name:           "service error",
+			queryParams:    "",
+			mockResults:    nil,
+			mockError:      errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFeedService)
+			handler := NewFeedHandler(mockService)
+
+			router := setupRouter()
+			router.GET("/feeds/results.atom", handler.HandleResultsFeed)
+
+			req := httptest.NewRequest("GET", "/feeds/results.atom"+tt.queryParams, nil)
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			w := httptest.NewRecorder()
+
+			if tt.expectedStatus != http.StatusBadRequest {
+				mockService.On("ListResults", mock.Anything, mock.AnythingOfType("FeedRequest")).Return(tt.mockResults, tt.mockError)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, atomContentType, w.Header().Get("Content-Type"))
+				assert.Equal(t, changedAt.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+
+				var feed atomFeed
+				err := xml.Unmarshal(w.Body.Bytes(), &feed)
+				assert.NoError(t, err)
+				assert.Equal(t, "2025-10-01T21:00:00Z", feed.Updated)
+				if assert.Len(t, feed.Entries, 1) {
+					assert.Equal(t, "Red Bull Salzburg 2-1 Manchester City", feed.Entries[0].Title)
+					assert.Equal(t, atomTagPrefix+"events/1/result", feed.Entries[0].ID)
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFeedHandler_HandleChangesFeed(t *testing.T) {
+	changes := []services.EventChange{
+		{
+			ID:         3,
+			EventID:    2,
+			ChangeType: services.EventChangeCancelled,
+			ChangedAt:  time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC),
+			HomeTeam:   services.Team{ID: 3, Name: "Paris Saint-Germain"},
+			AwayTeam:   services.Team{ID: 1, Name: "Red Bull Salzburg"},
+		},
+	}
+
+	mockService := new(MockFeedService)
+	handler := NewFeedHandler(mockService)
+
+	router := setupRouter()
+	router.GET("/feeds/changes.atom", handler.HandleChangesFeed)
+
+	req := httptest.NewRequest("GET", "/feeds/changes.atom?team_id=1", nil)
+	w := httptest.NewRecorder()
+
+	teamID := 1
+	mockService.On("ListChanges", mock.Anything, services.FeedRequest{TeamID: &teamID}).Return(changes, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var feed atomFeed
+	err := xml.Unmarshal(w.Body.Bytes(), &feed)
+	assert.NoError(t, err)
+	assert.Equal(t, atomTagPrefix+"feeds/changes?team_id=1", feed.ID)
+	if assert.Len(t, feed.Entries, 1) {
+		assert.Equal(t, "Cancelled: Paris Saint-Germain vs Red Bull Salzburg", feed.Entries[0].Title)
+		assert.Equal(t, atomTagPrefix+"event-changes/3", feed.Entries[0].ID)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
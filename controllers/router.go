@@ -1,7 +1,10 @@
 package controllers
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/vsennikov/sports-event-calendar/services"
 )
 
 type Router struct {
@@ -9,14 +12,42 @@ type Router struct {
 	sportHandler *SportHandler
 	venueHandler *VenueHandler
 	teamHandler *TeamHandler
+	feedHandler *FeedHandler
+	seriesHandler *SeriesHandler
+	broadcastHandler *BroadcastHandler
+	searchHandler *SearchHandler
+	auditHandler *AuditHandler
+	lastModifiedService services.LastModifiedServiceInterface
+	options RouterOptions
+}
+
+// RouterOptions tune how the API treats requests.
+type RouterOptions struct {
+	// RequireIfMatch refuses PATCH, PUT, DELETE and merge requests that do
+	// not name the version they modify in an If-Match header.
+	RequireIfMatch bool
+	// CacheControl is the Cache-Control header of successful reads, unless
+	// CacheControlRoutes has one for their route; see CacheControlMiddleware.
+	CacheControl       string
+	CacheControlRoutes map[string]string
+	// WithoutPostgres answers full-text search and the Atom feeds, whose
+	// queries need PostgreSQL, with 501 Not Implemented, as on SQLite.
+	WithoutPostgres bool
 }
 
-func NewRouter(e *EventHandler, s *SportHandler, v *VenueHandler, t *TeamHandler) *Router {
-	return &Router{eventHandler: e, sportHandler: s, venueHandler: v, teamHandler: t}
+func NewRouter(e *EventHandler, s *SportHandler, v *VenueHandler, t *TeamHandler, f *FeedHandler,
+	sr *SeriesHandler, b *BroadcastHandler, sh *SearchHandler, a *AuditHandler,
+	lm services.LastModifiedServiceInterface, o RouterOptions) *Router {
+	return &Router{eventHandler: e, sportHandler: s, venueHandler: v, teamHandler: t, feedHandler: f,
+		seriesHandler: sr, broadcastHandler: b, searchHandler: sh, auditHandler: a, lastModifiedService: lm, options: o}
 }
 
 func(r *Router) InitServer() *gin.Engine{
 	router := gin.Default()
+	router.Use(ActorMiddleware)
+	router.Use(ReadYourWritesMiddleware)
+	router.Use(IfMatchMiddleware(r.options.RequireIfMatch))
+	router.Use(CacheControlMiddleware(r.options.CacheControl, r.options.CacheControlRoutes))
 
 	router.Static("/static", "./static")
 	router.GET("/", func(c *gin.Context) {
@@ -27,35 +58,95 @@ func(r *Router) InitServer() *gin.Engine{
 		teams := api.Group("teams")
 		{
 			teams.POST("", r.teamHandler.HandleCreateTeam)
+			teams.GET("/duplicates", r.teamHandler.HandleListDuplicates)
 			teams.GET("/:id", r.teamHandler.HandleGetTeamByID)
-			teams.GET("", r.teamHandler.HandleListTeams)
+			teams.GET("", ListLastModified(r.lastModifiedService, services.AuditEntityTeam), r.teamHandler.HandleListTeams)
 			teams.PATCH("/:id", r.teamHandler.HandleUpdateTeam)
 			teams.DELETE("/:id", r.teamHandler.HandleDeleteTeam)
+			teams.GET("/:id/conflicts", r.teamHandler.HandleListConflicts)
+			teams.GET("/:id/attendance", r.teamHandler.HandleGetAttendance)
+			teams.POST("/:id/merge", IfMatch(r.options.RequireIfMatch), r.teamHandler.HandleMergeTeam)
+			teams.POST("/:id/restore", r.teamHandler.HandleRestoreTeam)
 		}
 		venues := api.Group("venues")
 		{
 			venues.POST("", r.venueHandler.HandleCreateVenue)
+			venues.GET("/nearby", ListLastModified(r.lastModifiedService, services.AuditEntityVenue), r.venueHandler.HandleListNearbyVenues)
+			venues.GET("/duplicates", r.venueHandler.HandleListDuplicates)
 			venues.GET("/:id", r.venueHandler.HandleGetVenueByID)
-			venues.GET("", r.venueHandler.HandleListVenues)
+			venues.GET("", ListLastModified(r.lastModifiedService, services.AuditEntityVenue), r.venueHandler.HandleListVenues)
 			venues.PATCH("/:id", r.venueHandler.HandleUpdateVenue)
 			venues.DELETE("/:id", r.venueHandler.HandleDeleteVenue)
+			venues.GET("/:id/attendance", r.venueHandler.HandleGetAttendance)
+			venues.POST("/:id/merge", IfMatch(r.options.RequireIfMatch), r.venueHandler.HandleMergeVenue)
+			venues.POST("/:id/restore", r.venueHandler.HandleRestoreVenue)
 		}
 		sports := api.Group("sports")
 		{
 			sports.POST("", r.sportHandler.HandleCreateSport)
 			sports.GET("/:id", r.sportHandler.HandleGetSportByID)
-			sports.GET("", r.sportHandler.HandleListSports)
+			sports.GET("", ListLastModified(r.lastModifiedService, services.AuditEntitySport), r.sportHandler.HandleListSports)
 			sports.DELETE("/:id", r.sportHandler.HandleDeleteSport)
 			sports.PUT("/:id", r.sportHandler.HandleUpdateSport)
+			sports.POST("/:id/restore", r.sportHandler.HandleRestoreSport)
 		}
 		events := api.Group("/events")
 		{
 			events.POST("", r.eventHandler.HandleCreateEvent)
+			events.GET("/nearby", r.eventHandler.HandleListNearbyEvents)
 			events.GET("/:id", r.eventHandler.HandleGetEventByID)
-			events.GET("", r.eventHandler.HandleListEvents)
+			events.GET("", ListLastModified(r.lastModifiedService, services.AuditEntityEvent), r.eventHandler.HandleListEvents)
 			events.PATCH("/:id", r.eventHandler.HandleUpdateEvent)
 			events.DELETE("/:id", r.eventHandler.HandleDeleteEvent)
+			events.POST("/:id/restore", r.eventHandler.HandleRestoreEvent)
+			events.GET("/:id/reschedules", r.eventHandler.HandleListReschedules)
+			events.GET("/:id/history", r.auditHandler.HandleEventHistory)
+			events.GET("/:id/broadcasts", r.broadcastHandler.HandleListBroadcasts)
+			events.POST("/:id/broadcasts", r.broadcastHandler.HandleCreateBroadcast)
+			events.GET("/:id/broadcasts/:broadcastId", r.broadcastHandler.HandleGetBroadcast)
+			events.PATCH("/:id/broadcasts/:broadcastId", r.broadcastHandler.HandleUpdateBroadcast)
+			events.DELETE("/:id/broadcasts/:broadcastId", r.broadcastHandler.HandleDeleteBroadcast)
+		}
+		series := api.Group("series")
+		{
+			series.POST("", r.seriesHandler.HandleCreateSeries)
+			series.GET("/:id", r.seriesHandler.HandleGetSeriesByID)
+			series.GET("", ListLastModified(r.lastModifiedService, services.LastModifiedSeries), r.seriesHandler.HandleListSeries)
+			series.DELETE("/:id", r.seriesHandler.HandleDeleteSeries)
+			series.POST("/:id/restore", r.seriesHandler.HandleRestoreSeries)
+			series.POST("/:id/materialize", r.seriesHandler.HandleMaterializeSeries)
+			series.POST("/:id/exceptions", r.seriesHandler.HandleAddException)
+			series.PATCH("/:id/occurrences/:eventId", r.seriesHandler.HandleUpdateOccurrence)
+			series.DELETE("/:id/occurrences/:eventId", r.seriesHandler.HandleDeleteOccurrence)
+		}
+		search := r.searchHandler.HandleSearch
+		resultsFeed, changesFeed := r.feedHandler.HandleResultsFeed, r.feedHandler.HandleChangesFeed
+		if r.options.WithoutPostgres {
+			search = notImplemented("full-text search needs PostgreSQL")
+			resultsFeed = notImplemented("the Atom feeds need PostgreSQL")
+			changesFeed = resultsFeed
+		}
+		api.GET("/search", search)
+		api.GET("/audit", r.auditHandler.HandleListAudit)
+		feeds := api.Group("feeds")
+		{
+			feeds.GET("/results.atom", resultsFeed)
+			feeds.GET("/changes.atom", changesFeed)
 		}
 	}
 	return router
 }
+
+// notImplemented answers a route the database cannot serve.
+func notImplemented(reason string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": reason})
+	}
+}
+
+// ReadYourWritesMiddleware makes the reads of a request that wrote see its
+// writes, even when reads are otherwise served by read replicas.
+func ReadYourWritesMiddleware(c *gin.Context) {
+	c.Request = c.Request.WithContext(services.TrackWrites(c.Request.Context()))
+	c.Next()
+}
@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_WithoutPostgres(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		RouterOptions{WithoutPostgres: true}).InitServer()
+
+	for _, path := range []string{"/api/v1/search?q=derby", "/api/v1/feeds/results.atom", "/api/v1/feeds/changes.atom"} {
+		t.Run(path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+
+			assert.Equal(t, http.StatusNotImplemented, w.Code)
+			assert.Contains(t, w.Body.String(), "PostgreSQL")
+		})
+	}
+}
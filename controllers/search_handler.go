@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+type SearchHandler struct {
+	searchService services.SearchServiceInterface
+}
+
+func NewSearchHandler(s services.SearchServiceInterface) *SearchHandler {
+	return &SearchHandler{searchService: s}
+}
+
+// HandleSearch answers ?q= with the matching events, teams and venues,
+// grouped by type and ranked within each group. ?limit= caps every group.
+func (h *SearchHandler) HandleSearch(c *gin.Context) {
+	req := services.SearchRequest{Query: c.Query("q")}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a number"})
+			return
+		}
+		req.Limit = limit
+	}
+	results, err := h.searchService.Search(c.Request.Context(), req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toDTOSearchResults(req.Query, *results))
+}
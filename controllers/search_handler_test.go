@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// MockSearchService is a mock implementation of SearchServiceInterface
+type MockSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSearchService) Search(ctx context.Context, req services.SearchRequest) (*services.SearchResults, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.SearchResults), args.Error(1)
+}
+
+func TestSearchHandler_HandleSearch(t *testing.T) {
+	kickoff := time.Date(2025, 12, 12, 19, 30, 0, 0, time.UTC)
+	results := &services.SearchResults{
+		Events: []services.SearchHit{
+			{Type: services.SearchHitEvent, ID: 5, Title: "ZSC Lions vs Eisbären Berlin", EventDatetime: &kickoff, Rank: 0.1},
+		},
+		Teams: []services.SearchHit{
+			{Type: services.SearchHitTeam, ID: 6, Title: "Eisbären Berlin", Detail: stringPtr("Berlin"), Rank: 0.6},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		mockRequest    *services.SearchRequest
+		mockResults    *services.SearchResults
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "successful search",
+			queryParams:    "?q=Eisbaren&limit=5",
+			mockRequest:    &services.SearchRequest{Query: "Eisbaren", Limit: 5},
+			mockResults:    results,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid limit",
+			queryParams:    "?q=Eisbaren&limit=many",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing query",
+			queryParams:    "",
+			mockRequest:    &services.SearchRequest{},
+			mockError:      fmt.Errorf("validation error: q must contain at least one letter or digit"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			queryParams:    "?q=Vienna",
+			mockRequest:    &services.SearchRequest{Query: "Vienna"},
+			mockError:      fmt.Errorf("failed to search events: connection lost"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSearchService)
+			handler := NewSearchHandler(mockService)
+
+			router := setupRouter()
+			router.GET("/search", handler.HandleSearch)
+
+			if tt.mockRequest != nil {
+				mockService.On("Search", mock.Anything, *tt.mockRequest).Return(tt.mockResults, tt.mockError)
+			}
+
+			req := httptest.NewRequest("GET", "/search"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response searchResultsDTO
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "Eisbaren", response.Query)
+				assert.Len(t, response.Events, 1)
+				assert.Len(t, response.Teams, 1)
+				assert.NotNil(t, response.Venues)
+				assert.Equal(t, "team", response.Teams[0].Type)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+type SeriesHandler struct {
+	seriesService services.SeriesServiceInterface
+}
+
+func NewSeriesHandler(s services.SeriesServiceInterface) *SeriesHandler {
+	return &SeriesHandler{seriesService: s}
+}
+
+func (h *SeriesHandler) HandleCreateSeries(c *gin.Context) {
+	var req services.CreateSeriesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	newID, err := h.seriesService.CreateSeries(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": newID})
+}
+
+func (h *SeriesHandler) HandleGetSeriesByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID format"})
+		return
+	}
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	series, err := h.seriesService.GetSeriesByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	respondResource(c, series.Version, series.UpdatedAt, toDTOSeries(*series))
+}
+
+func (h *SeriesHandler) HandleListSeries(c *gin.Context) {
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	seriesList, err := h.seriesService.ListSeries(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	seriesDTOs := make([]seriesDTO, 0, len(seriesList))
+	for _, series := range seriesList {
+		seriesDTOs = append(seriesDTOs, toDTOSeries(series))
+	}
+	respondList(c, seriesDTOs)
+}
+
+func (h *SeriesHandler) HandleMaterializeSeries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID format"})
+		return
+	}
+	created, err := h.seriesService.MaterializeSeries(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"created": created})
+}
+
+func (h *SeriesHandler) HandleAddException(c *gin.Context) {
+	var req services.SeriesExceptionRequest
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID format"})
+		return
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = h.seriesService.AddException(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// HandleUpdateOccurrence patches one occurrence (?scope=this, the default)
+// or that occurrence and all following ones (?scope=following).
+func (h *SeriesHandler) HandleUpdateOccurrence(c *gin.Context) {
+	var req services.UpdateEventRequest
+
+	seriesID, eventID, ok := parseOccurrenceIDs(c)
+	if !ok {
+		return
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err := h.seriesService.UpdateOccurrence(c.Request.Context(), seriesID, eventID, c.Query("scope"), req)
+	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
+		if respondScheduleConflict(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *SeriesHandler) HandleDeleteOccurrence(c *gin.Context) {
+	seriesID, eventID, ok := parseOccurrenceIDs(c)
+	if !ok {
+		return
+	}
+	err := h.seriesService.DeleteOccurrence(c.Request.Context(), seriesID, eventID, c.Query("scope"))
+	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *SeriesHandler) HandleDeleteSeries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID format"})
+		return
+	}
+	err = h.seriesService.DeleteSeries(c.Request.Context(), id)
+	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// HandleRestoreSeries undeletes a soft-deleted series and returns it. Its
+// deleted occurrences are restored separately, as events.
+func (h *SeriesHandler) HandleRestoreSeries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID format"})
+		return
+	}
+	series, err := h.seriesService.RestoreSeries(c.Request.Context(), id)
+	if err != nil {
+		respondMergeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDTOSeries(*series))
+}
+
+func parseOccurrenceIDs(c *gin.Context) (int, int, bool) {
+	seriesID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID format"})
+		return 0, 0, false
+	}
+	eventID, err := strconv.Atoi(c.Param("eventId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID format"})
+		return 0, 0, false
+	}
+	return seriesID, eventID, true
+}
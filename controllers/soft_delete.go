@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// bindReadContext returns the request context, asking the reads to include
+// soft-deleted rows when the request has ?include_deleted=true.
+func bindReadContext(c *gin.Context) (context.Context, bool) {
+	ctx := c.Request.Context()
+	includeDeletedStr := c.Query("include_deleted")
+	if includeDeletedStr == "" {
+		return ctx, true
+	}
+	includeDeleted, err := strconv.ParseBool(includeDeletedStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "include_deleted must be true or false"})
+		return nil, false
+	}
+	if includeDeleted {
+		ctx = services.WithDeleted(ctx)
+	}
+	return ctx, true
+}
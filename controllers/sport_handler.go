@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -38,16 +40,24 @@ func (h *SportHandler) HandleGetSportByID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
 		return
 	}
-	sport, err := h.sportService.GetSportByID(c.Request.Context(), id)
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	sport, err := h.sportService.GetSportByID(ctx, id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, toDTOSport(*sport))
+	respondResource(c, sport.Version, sport.UpdatedAt, toDTOSport(*sport))
 }
 
 func (h *SportHandler) HandleListSports(c *gin.Context) {
-	sports, err := h.sportService.ListSports(c.Request.Context())
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	sports, err := h.sportService.ListSports(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -57,7 +67,7 @@ func (h *SportHandler) HandleListSports(c *gin.Context) {
 		sportsDTO = append(sportsDTO,toDTOSport(sport))
 	}
 
-	c.JSON(http.StatusOK, sportsDTO)
+	respondList(c, sportsDTO)
 }
 
 func (h *SportHandler) HandleUpdateSport(c *gin.Context) {
@@ -75,6 +85,13 @@ func (h *SportHandler) HandleUpdateSport(c *gin.Context) {
 	}
 	err = h.sportService.UpdateSport(c.Request.Context(), id, req)
 	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -91,9 +108,28 @@ func (h *SportHandler) HandleDeleteSport(c *gin.Context) {
 
 	err = h.sportService.DeleteSport(c.Request.Context(), id)
 	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusOK)
 }
+
+// HandleRestoreSport undeletes a soft-deleted sport and returns it.
+func (h *SportHandler) HandleRestoreSport(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+	sport, err := h.sportService.RestoreSport(c.Request.Context(), id)
+	if err != nil {
+		respondMergeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDTOSport(*sport))
+}
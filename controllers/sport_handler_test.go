@@ -3,12 +3,14 @@ package controllers
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -51,6 +53,14 @@ func (m *MockSportService) DeleteSport(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *MockSportService) RestoreSport(ctx context.Context, id int) (*services.Sport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.Sport), args.Error(1)
+}
+
 func TestSportHandler_HandleCreateSport(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -269,6 +279,13 @@ func TestSportHandler_HandleUpdateSport(t *testing.T) {
 			mockError:      fmt.Errorf("validation error"),
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:           "deleted or missing sport",
+			sportID:        "1",
+			requestBody:    services.SportRequest{Name: "Updated"},
+			mockError:      fmt.Errorf("database error: %w", sql.ErrNoRows),
+			expectedStatus: http.StatusNotFound,
+		},
 	}
 
 	for _, tt := range tests {
@@ -356,3 +373,109 @@ func TestSportHandler_HandleDeleteSport(t *testing.T) {
 	}
 }
 
+func TestSportHandler_HandleListSports_IncludeDeleted(t *testing.T) {
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		query          string
+		includeDeleted bool
+		expectedStatus int
+	}{
+		{name: "default", query: "", includeDeleted: false, expectedStatus: http.StatusOK},
+		{name: "include deleted", query: "?include_deleted=true", includeDeleted: true, expectedStatus: http.StatusOK},
+		{name: "explicitly excluded", query: "?include_deleted=false", includeDeleted: false, expectedStatus: http.StatusOK},
+		{name: "invalid flag", query: "?include_deleted=maybe", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSportService)
+			handler := NewSportHandler(mockService)
+
+			router := setupRouter()
+			router.GET("/sports", handler.HandleListSports)
+
+			req := httptest.NewRequest("GET", "/sports"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			if tt.expectedStatus == http.StatusOK {
+				mockService.On("ListSports", mock.MatchedBy(func(ctx context.Context) bool {
+					return services.IncludeDeleted(ctx) == tt.includeDeleted
+				})).Return([]services.Sport{{ID: 1, Name: "Football", DeletedAt: &deletedAt}}, nil)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response []sportDTO
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, deletedAt, *response[0].DeletedAt)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSportHandler_HandleRestoreSport(t *testing.T) {
+	tests := []struct {
+		name           string
+		sportID        string
+		mockSport      *services.Sport
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "successful restore",
+			sportID:        "1",
+			mockSport:      &services.Sport{ID: 1, Name: "Football"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid ID format",
+			sportID:        "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "sport not deleted",
+			sportID:        "1",
+			mockError:      fmt.Errorf("validation error: sport 1 is not deleted"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "sport not found",
+			sportID:        "999",
+			mockError:      fmt.Errorf("database error: %w", sql.ErrNoRows),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSportService)
+			handler := NewSportHandler(mockService)
+
+			router := setupRouter()
+			router.POST("/sports/:id/restore", handler.HandleRestoreSport)
+
+			req := httptest.NewRequest("POST", "/sports/"+tt.sportID+"/restore", nil)
+			w := httptest.NewRecorder()
+
+			if tt.name != "invalid ID format" {
+				id, _ := strconv.Atoi(tt.sportID)
+				mockService.On("RestoreSport", mock.Anything, id).Return(tt.mockSport, tt.mockError)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response sportDTO
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, 1, response.ID)
+				assert.Nil(t, response.DeletedAt)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
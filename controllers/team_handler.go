@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/vsennikov/sports-event-calendar/services"
@@ -25,6 +28,10 @@ func (h *TeamHandler) HandleCreateTeam(c *gin.Context) {
 	}
 	newID, err := h.teamService.CreateTeam(c.Request.Context(), req)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -38,17 +45,47 @@ func (h *TeamHandler) HandleGetTeamByID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
 		return
 	}
-	team, err := h.teamService.GetTeamByID(c.Request.Context(), id)
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	team, err := h.teamService.GetTeamByID(ctx, id)
 	if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 	}
-	c.JSON(http.StatusOK,toDTOTeam(*team))
+	respondResource(c, team.Version, team.UpdatedAt, toDTOTeam(*team))
 }
 
+// HandleListTeams lists all teams, or with ?name= the teams known by that
+// name, short name, code or alias, optionally within one ?sport_id=.
 func (h *TeamHandler) HandleListTeams(c *gin.Context) {
-	teams, err := h.teamService.ListTeams(c.Request.Context())
+	var teams []services.Team
+	var err error
+
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	if name, ok := c.GetQuery("name"); ok {
+		var sportID *int
+		if sportIDStr := c.Query("sport_id"); sportIDStr != "" {
+			id, convErr := strconv.Atoi(sportIDStr)
+			if convErr != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sport ID format"})
+				return
+			}
+			sportID = &id
+		}
+		teams, err = h.teamService.FindTeamsByName(ctx, name, sportID)
+	} else {
+		teams, err = h.teamService.ListTeams(ctx)
+	}
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -56,7 +93,7 @@ func (h *TeamHandler) HandleListTeams(c *gin.Context) {
 	for _, t := range teams {
 		teamDTOs = append(teamDTOs, toDTOTeam(t))
 	}
-	c.JSON(http.StatusOK, teamDTOs)
+	respondList(c, teamDTOs)
 }
 
 func (h *TeamHandler) HandleUpdateTeam(c *gin.Context) {
@@ -74,6 +111,13 @@ func (h *TeamHandler) HandleUpdateTeam(c *gin.Context) {
 	}
 	err = h.teamService.UpdateTeam(c.Request.Context(), id, req)
 	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -89,8 +133,141 @@ func (h *TeamHandler) HandleDeleteTeam(c *gin.Context) {
 	}
 	err = h.teamService.DeleteTeam(c.Request.Context(), id)
 	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusOK)
 }
+
+// HandleGetAttendance aggregates the recorded attendance of a team's events,
+// optionally limited by date_from/date_to.
+func (h *TeamHandler) HandleGetAttendance(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+	from, to, ok := bindDateRange(c)
+	if !ok {
+		return
+	}
+	stats, err := h.teamService.GetAttendanceStats(c.Request.Context(), id, from, to)
+	respondAttendanceStats(c, stats, err)
+}
+
+func (h *TeamHandler) HandleListConflicts(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+	conflicts, err := h.teamService.ListConflicts(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	conflictDTOs := make([]teamConflictDTO, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		conflictDTOs = append(conflictDTOs, toDTOTeamConflict(conflict))
+	}
+	c.JSON(http.StatusOK, conflictDTOs)
+}
+
+// HandleListDuplicates reports pairs of teams that are probably duplicates,
+// optionally with a ?min_similarity= between 0 and 1.
+func (h *TeamHandler) HandleListDuplicates(c *gin.Context) {
+	minSimilarity, ok := bindMinSimilarity(c)
+	if !ok {
+		return
+	}
+	candidates, err := h.teamService.ListDuplicateCandidates(c.Request.Context(), minSimilarity)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toDTOTeamDuplicates(candidates))
+}
+
+// HandleMergeTeam merges the team given as duplicate_id into the team of the
+// path and returns the surviving team.
+func (h *TeamHandler) HandleMergeTeam(c *gin.Context) {
+	var req services.MergeRequest
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	team, err := h.teamService.MergeTeams(c.Request.Context(), id, req.DuplicateID)
+	if err != nil {
+		respondMergeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDTOTeam(*team))
+}
+
+// HandleRestoreTeam undeletes a soft-deleted team and returns it.
+func (h *TeamHandler) HandleRestoreTeam(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+	team, err := h.teamService.RestoreTeam(c.Request.Context(), id)
+	if err != nil {
+		respondMergeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDTOTeam(*team))
+}
+
+// bindMinSimilarity reads the optional min_similarity query parameter of the
+// duplicate reports; 0 stands for the default.
+func bindMinSimilarity(c *gin.Context) (float64, bool) {
+	minSimilarityStr := c.Query("min_similarity")
+	if minSimilarityStr == "" {
+		return 0, true
+	}
+	minSimilarity, err := strconv.ParseFloat(minSimilarityStr, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_similarity must be a number"})
+		return 0, false
+	}
+	return minSimilarity, true
+}
+
+// respondMergeError writes the failure of a merge or of a restore.
+func respondMergeError(c *gin.Context, err error) {
+	if respondPreconditionFailed(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "validation error"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrVenueConflict), errors.Is(err, services.ErrTeamsPlayEachOther):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/vsennikov/sports-event-calendar/services"
@@ -25,6 +28,10 @@ func (h *VenueHandler) HandleCreateVenue(c *gin.Context) {
 	}
 	newID, err := h.venueService.CreateVenue(c.Request.Context(), req)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -39,18 +46,53 @@ func (h *VenueHandler) HandleGetVenueByID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
 		return
 	}
-	venue, err := h.venueService.GetVenueByID(c.Request.Context(), id)
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	venue, err := h.venueService.GetVenueByID(ctx, id)
 	if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, toDTOVenue(*venue))
+	respondResource(c, venue.Version, venue.UpdatedAt, toDTOVenue(*venue))
 }
 
 func (h *VenueHandler) HandleListVenues(c *gin.Context) {
-	venues, err := h.venueService.ListVenues(c.Request.Context())
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	venues, err := h.venueService.ListVenues(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	venueDTOs := make([]venueDTO, 0, len(venues))
+	for _, v := range venues {
+		venueDTOs = append(venueDTOs, toDTOVenue(v))
+	}
+	respondList(c, venueDTOs)
+}
+
+// HandleListNearbyVenues lists venues within radius_km of lat/lon, nearest
+// first.
+func (h *VenueHandler) HandleListNearbyVenues(c *gin.Context) {
+	point, radiusKm, ok := bindNearbyQuery(c)
+	if !ok {
+		return
+	}
+	ctx, ok := bindReadContext(c)
+	if !ok {
+		return
+	}
+	venues, err := h.venueService.ListVenuesNearby(ctx, point, radiusKm)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -58,7 +100,68 @@ func (h *VenueHandler) HandleListVenues(c *gin.Context) {
 	for _, v := range venues {
 		venueDTOs = append(venueDTOs, toDTOVenue(v))
 	}
-	c.JSON(http.StatusOK, venueDTOs)
+	respondList(c, venueDTOs)
+}
+
+// HandleGetAttendance aggregates the recorded attendance at a venue,
+// optionally limited by date_from/date_to.
+func (h *VenueHandler) HandleGetAttendance(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+	from, to, ok := bindDateRange(c)
+	if !ok {
+		return
+	}
+	stats, err := h.venueService.GetAttendanceStats(c.Request.Context(), id, from, to)
+	respondAttendanceStats(c, stats, err)
+}
+
+// respondAttendanceStats writes the result of an attendance aggregation.
+func respondAttendanceStats(c *gin.Context, stats *services.AttendanceStats, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case strings.HasPrefix(err.Error(), "validation error"):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, toDTOAttendanceStats(*stats))
+}
+
+// bindNearbyQuery reads the required lat/lon and optional radius_km query
+// parameters of the nearby endpoints.
+func bindNearbyQuery(c *gin.Context) (services.GeoPoint, float64, bool) {
+	var point services.GeoPoint
+	var radiusKm float64
+
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lat is required and must be a number"})
+		return point, 0, false
+	}
+	lon, err := strconv.ParseFloat(c.Query("lon"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lon is required and must be a number"})
+		return point, 0, false
+	}
+	if radiusStr := c.Query("radius_km"); radiusStr != "" {
+		radiusKm, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "radius_km must be a number"})
+			return point, 0, false
+		}
+	}
+	point.Latitude = lat
+	point.Longitude = lon
+	return point, radiusKm, true
 }
 
 func (h *VenueHandler) HandleUpdateVenue(c *gin.Context) {
@@ -76,6 +179,13 @@ func (h *VenueHandler) HandleUpdateVenue(c *gin.Context) {
 	}
 	err = h.venueService.UpdateVenue(c.Request.Context(), id, req)
 	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -91,8 +201,69 @@ func (h *VenueHandler) HandleDeleteVenue(c *gin.Context) {
 	}
 	err = h.venueService.DeleteVenue(c.Request.Context(), id)
 	if err != nil {
+		if respondPreconditionFailed(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusOK)
 }
+
+// HandleListDuplicates reports pairs of venues that are probably duplicates,
+// optionally with a ?min_similarity= between 0 and 1.
+func (h *VenueHandler) HandleListDuplicates(c *gin.Context) {
+	minSimilarity, ok := bindMinSimilarity(c)
+	if !ok {
+		return
+	}
+	candidates, err := h.venueService.ListDuplicateCandidates(c.Request.Context(), minSimilarity)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "validation error") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toDTOVenueDuplicates(candidates))
+}
+
+// HandleMergeVenue merges the venue given as duplicate_id into the venue of
+// the path and returns the surviving venue.
+func (h *VenueHandler) HandleMergeVenue(c *gin.Context) {
+	var req services.MergeRequest
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	venue, err := h.venueService.MergeVenues(c.Request.Context(), id, req.DuplicateID)
+	if err != nil {
+		respondMergeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDTOVenue(*venue))
+}
+
+// HandleRestoreVenue undeletes a soft-deleted venue and returns it.
+func (h *VenueHandler) HandleRestoreVenue(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+	venue, err := h.venueService.RestoreVenue(c.Request.Context(), id)
+	if err != nil {
+		respondMergeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDTOVenue(*venue))
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+const ifMatchHeader = "If-Match"
+
+// IfMatchMiddleware applies IfMatch to the PATCH, PUT and DELETE requests.
+func IfMatchMiddleware(require bool) gin.HandlerFunc {
+	ifMatch := IfMatch(require)
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPatch, http.MethodPut, http.MethodDelete:
+			ifMatch(c)
+		default:
+			c.Next()
+		}
+	}
+}
+
+// IfMatch makes a request carrying an If-Match header conditional on the
+// version it names; "*" matches any version. With require set, requests are
+// refused unless they carry the header, so that no client can overwrite a
+// change it has not seen. POST routes that modify a record, such as merges,
+// use it directly.
+func IfMatch(require bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := strings.TrimSpace(c.GetHeader(ifMatchHeader))
+		if header == "" {
+			if require {
+				c.AbortWithStatusJSON(http.StatusPreconditionRequired,
+					gin.H{"error": "If-Match header with the ETag of the resource is required"})
+				return
+			}
+			c.Next()
+			return
+		}
+		if header == "*" {
+			c.Next()
+			return
+		}
+		version, ok := parseETag(header)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest,
+				gin.H{"error": `If-Match must be "*" or a single ETag such as "3"`})
+			return
+		}
+		c.Request = c.Request.WithContext(services.WithExpectedVersion(c.Request.Context(), version))
+		c.Next()
+	}
+}
+
+// parseETag reads a version from an ETag written by versionETag.
+func parseETag(etag string) (int, bool) {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.Atoi(etag[1 : len(etag)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// versionETag is the ETag of a record at version.
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// respondPreconditionFailed writes a 412 with the current version of the
+// resource when err is a version mismatch, and reports whether it did.
+func respondPreconditionFailed(c *gin.Context, err error) bool {
+	var mismatch *services.VersionMismatchError
+	if !errors.As(err, &mismatch) {
+		return false
+	}
+	c.Header("ETag", versionETag(mismatch.CurrentVersion))
+	c.JSON(http.StatusPreconditionFailed, gin.H{
+		"error":           mismatch.Error(),
+		"current_version": mismatch.CurrentVersion,
+	})
+	return true
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestIfMatchMiddleware(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		header          string
+		require         bool
+		expectedStatus  int
+		expectedVersion int
+	}{
+		{name: "version", method: "PATCH", header: `"3"`, expectedStatus: http.StatusOK, expectedVersion: 3},
+		{name: "any version", method: "DELETE", header: "*", require: true, expectedStatus: http.StatusOK},
+		{name: "no header", method: "PUT", expectedStatus: http.StatusOK},
+		{name: "no header when required", method: "PATCH", require: true, expectedStatus: http.StatusPreconditionRequired},
+		{name: "reads need no header", method: "GET", require: true, expectedStatus: http.StatusOK},
+		{name: "unquoted version", method: "PATCH", header: "3", expectedStatus: http.StatusBadRequest},
+		{name: "weak ETag", method: "PATCH", header: `W/"3"`, expectedStatus: http.StatusBadRequest},
+		{name: "several ETags", method: "DELETE", header: `"3", "4"`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version := 0
+			router := setupRouter()
+			router.Use(IfMatchMiddleware(tt.require))
+			router.Handle(tt.method, "/things/1", func(c *gin.Context) {
+				version, _ = services.ExpectedVersion(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/things/1", nil)
+			if tt.header != "" {
+				req.Header.Set("If-Match", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
+
+func TestIfMatch_MergeRoutes(t *testing.T) {
+	version := 0
+	router := setupRouter()
+	router.Use(IfMatchMiddleware(true))
+	router.POST("/things", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.POST("/things/:id/merge", IfMatch(true), func(c *gin.Context) {
+		version, _ = services.ExpectedVersion(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/things", nil))
+	assert.Equal(t, http.StatusCreated, w.Code, "other POSTs need no header")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/things/1/merge", nil))
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+
+	req := httptest.NewRequest("POST", "/things/1/merge", nil)
+	req.Header.Set("If-Match", `"3"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 3, version)
+}
+
+func TestSportHandler_Versions(t *testing.T) {
+	newRouter := func(mockService *MockSportService) *gin.Engine {
+		handler := NewSportHandler(mockService)
+		router := setupRouter()
+		router.Use(IfMatchMiddleware(false))
+		router.GET("/sports/:id", handler.HandleGetSportByID)
+		router.PUT("/sports/:id", handler.HandleUpdateSport)
+		router.DELETE("/sports/:id", handler.HandleDeleteSport)
+		return router
+	}
+
+	t.Run("GET sets the ETag", func(t *testing.T) {
+		mockService := new(MockSportService)
+		mockService.On("GetSportByID", mock.Anything, 1).Return(&services.Sport{ID: 1, Name: "Rugby", Version: 4}, nil)
+
+		w := httptest.NewRecorder()
+		newRouter(mockService).ServeHTTP(w, httptest.NewRequest("GET", "/sports/1", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, float64(4), body["version"])
+	})
+
+	t.Run("PUT at a stale version", func(t *testing.T) {
+		mockService := new(MockSportService)
+		mismatch := &services.VersionMismatchError{Entity: "sport", ID: 1, CurrentVersion: 4}
+		mockService.On("UpdateSport", mock.Anything, 1, services.SportRequest{Name: "Rugby"}).
+			Return(fmt.Errorf("database error: %w", mismatch))
+
+		body, _ := json.Marshal(services.SportRequest{Name: "Rugby"})
+		req := httptest.NewRequest("PUT", "/sports/1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"3"`)
+		w := httptest.NewRecorder()
+		newRouter(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+		assert.JSONEq(t, `{"error": "sport 1 was modified: it is at version 4", "current_version": 4}`, w.Body.String())
+	})
+
+	t.Run("DELETE at the current version", func(t *testing.T) {
+		mockService := new(MockSportService)
+		mockService.On("DeleteSport", mock.MatchedBy(func(ctx context.Context) bool {
+			version, ok := services.ExpectedVersion(ctx)
+			return ok && version == 4
+		}), 1).Return(nil)
+
+		req := httptest.NewRequest("DELETE", "/sports/1", nil)
+		req.Header.Set("If-Match", `"4"`)
+		w := httptest.NewRecorder()
+		newRouter(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,283 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// auditBookkeepingColumns are the columns the database maintains on every
+// write, which would otherwise make each snapshot differ from the last.
+const auditBookkeepingColumns = ` - 'version' - 'created_at' - 'updated_at'`
+
+// auditSnapshotQueries select an entity row as a JSON object of its columns,
+// the generated search vectors and the bookkeeping columns left out, and a
+// team's aliases and a series' exception dates added.
+var auditSnapshotQueries = map[string]string{
+	services.AuditEntityEvent: `SELECT to_jsonb(e) - 'search_vector'` + auditBookkeepingColumns + ` FROM events e WHERE e.id = $1`,
+	services.AuditEntitySeries: `
+	SELECT (to_jsonb(es)` + auditBookkeepingColumns + `) || jsonb_build_object('exceptions', (
+		SELECT COALESCE(jsonb_agg(x.exception_date ORDER BY x.exception_date), '[]'::jsonb)
+		FROM event_series_exceptions x WHERE x._series_id = es.id))
+	FROM event_series es WHERE es.id = $1`,
+	services.AuditEntitySport: `SELECT to_jsonb(s)` + auditBookkeepingColumns + ` FROM sports s WHERE s.id = $1`,
+	services.AuditEntityTeam: `
+	SELECT (to_jsonb(t) - 'search_vector'` + auditBookkeepingColumns + `) || jsonb_build_object('aliases', (
+		SELECT COALESCE(jsonb_agg(a.alias ORDER BY a.alias), '[]'::jsonb)
+		FROM team_aliases a WHERE a._team_id = t.id))
+	FROM teams t WHERE t.id = $1`,
+	services.AuditEntityVenue: `SELECT to_jsonb(v) - 'search_vector'` + auditBookkeepingColumns + ` FROM venues v WHERE v.id = $1`,
+}
+
+// auditEntityTables name the table of each entity, for SQLite, which has no
+// to_jsonb: there, snapshots are assembled from the row's columns.
+var auditEntityTables = map[string]string{
+	services.AuditEntityEvent:  "events",
+	services.AuditEntitySeries: "event_series",
+	services.AuditEntitySport:  "sports",
+	services.AuditEntityTeam:   "teams",
+	services.AuditEntityVenue:  "venues",
+}
+
+type AuditRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) ListAuditEntries(ctx context.Context,
+	params services.ListAuditEntriesParams) ([]services.AuditEntry, error) {
+	query := `
+	SELECT id, entity_type, entity_id, action, actor, changed_at, before, after
+	FROM audit_log
+	WHERE ($1::text IS NULL OR entity_type = $1) AND ($2::int IS NULL OR entity_id = $2)
+	ORDER BY changed_at DESC, id DESC
+	LIMIT $3`
+	var limit *int
+	var dbModels []auditEntryDBModel
+
+	if params.Limit > 0 {
+		limit = &params.Limit
+	}
+	if err := r.db.SelectContext(ctx, &dbModels, query, params.EntityType, params.EntityID, limit); err != nil {
+		return nil, err
+	}
+	entries := make([]services.AuditEntry, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		entries = append(entries, toServiceAuditEntry(dbModel))
+	}
+	return entries, nil
+}
+
+// auditedWrite runs write in a transaction and records in the audit log, in
+// that same transaction, how it changed the entity row. A creation passes id
+// 0; write returns the ID of the row it wrote.
+func auditedWrite(ctx context.Context, db dbtx, entityType, action string, id int,
+	write func(tx *sqlx.Tx) (int, error)) (int, error) {
+	err := inTx(ctx, db, func(tx *sqlx.Tx) error {
+		before, err := snapshotEntity(ctx, tx, entityType, id)
+		if err != nil {
+			return err
+		}
+		id, err = write(tx)
+		if err != nil {
+			return err
+		}
+		after, err := snapshotEntity(ctx, tx, entityType, id)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, entityType, id, action, before, after)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// snapshotEntity returns the columns of an entity row, or nil when there is
+// no such row.
+func snapshotEntity(ctx context.Context, tx *sqlx.Tx, entityType string, id int) (map[string]interface{}, error) {
+	var raw []byte
+	var snapshot map[string]interface{}
+
+	if id == 0 {
+		return nil, nil
+	}
+	if tx.DriverName() == sqliteDriverName {
+		return snapshotSQLiteEntity(ctx, tx, entityType, id)
+	}
+	err := tx.QueryRowContext(ctx, auditSnapshotQueries[entityType], id).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// snapshotSQLiteEntity is snapshotEntity for SQLite. The columns go through
+// JSON as they do in PostgreSQL, so that they compare alike; booleans, which
+// SQLite stores as integers, are turned back into booleans first.
+func snapshotSQLiteEntity(ctx context.Context, tx *sqlx.Tx, entityType string, id int) (map[string]interface{}, error) {
+	columns := map[string]interface{}{}
+	var snapshot map[string]interface{}
+
+	rows, err := tx.QueryxContext(ctx, "SELECT * FROM "+auditEntityTables[entityType]+" WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	if err := rows.MapScan(columns); err != nil {
+		return nil, err
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	for _, columnType := range columnTypes {
+		if value, ok := columns[columnType.Name()].(int64); ok && columnType.DatabaseTypeName() == "BOOLEAN" {
+			columns[columnType.Name()] = value != 0
+		}
+	}
+	delete(columns, "version")
+	delete(columns, "created_at")
+	delete(columns, "updated_at")
+	if entityType == services.AuditEntityTeam {
+		aliases := []string{}
+		query := `SELECT alias FROM team_aliases WHERE _team_id = $1 ORDER BY alias ASC`
+		if err := tx.SelectContext(ctx, &aliases, query, id); err != nil {
+			return nil, err
+		}
+		columns["aliases"] = aliases
+	}
+	if entityType == services.AuditEntitySeries {
+		exceptions := []string{}
+		query := `
+		SELECT strftime('%Y-%m-%d', exception_date) FROM event_series_exceptions
+		WHERE _series_id = $1 ORDER BY exception_date ASC`
+		if err := tx.SelectContext(ctx, &exceptions, query, id); err != nil {
+			return nil, err
+		}
+		columns["exceptions"] = exceptions
+	}
+
+	raw, err := json.Marshal(columns)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// recordAudit appends the columns that changed between the two snapshots to
+// the audit log, attributed to the actor of ctx. Writes that changed nothing
+// are not recorded, except merges.
+func recordAudit(ctx context.Context, tx *sqlx.Tx, entityType string, id int, action string,
+	before, after map[string]interface{}) error {
+	query := `
+	INSERT INTO audit_log (entity_type, entity_id, action, actor, before, after)
+	VALUES ($1, $2, $3, $4, $5, $6)`
+
+	changedBefore, changedAfter := services.DiffSnapshots(before, after)
+	if len(changedBefore) == 0 && len(changedAfter) == 0 && action != services.AuditActionMerge {
+		return nil
+	}
+	beforeJSON, err := marshalSnapshot(changedBefore)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := marshalSnapshot(changedAfter)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query, entityType, id, action, services.ActorFromContext(ctx), beforeJSON, afterJSON)
+	return err
+}
+
+// marshalSnapshot encodes one side of a change for a JSONB column; a nil
+// snapshot becomes NULL.
+func marshalSnapshot(snapshot map[string]interface{}) (interface{}, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+// recordMergeAudit records a merge: the survivor's changes as a merge, the
+// duplicate, gone by now, as deleted and each event repointed from the
+// duplicate to the survivor as updated.
+func recordMergeAudit(ctx context.Context, tx *sqlx.Tx, entityType string, survivorID, duplicateID int,
+	survivorBefore, duplicateBefore map[string]interface{}, eventsBefore map[int]map[string]interface{}) error {
+	survivorAfter, err := snapshotEntity(ctx, tx, entityType, survivorID)
+	if err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, entityType, survivorID, services.AuditActionMerge, survivorBefore, survivorAfter); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, entityType, duplicateID, services.AuditActionDelete, duplicateBefore, nil); err != nil {
+		return err
+	}
+	return recordEventUpdates(ctx, tx, eventsBefore)
+}
+
+// recordEventUpdates records the change of each event since its snapshot in
+// eventsBefore as an update, in the order of the event IDs.
+func recordEventUpdates(ctx context.Context, tx *sqlx.Tx, eventsBefore map[int]map[string]interface{}) error {
+	for _, eventID := range slices.Sorted(maps.Keys(eventsBefore)) {
+		eventAfter, err := snapshotEntity(ctx, tx, services.AuditEntityEvent, eventID)
+		if err != nil {
+			return err
+		}
+		err = recordAudit(ctx, tx, services.AuditEntityEvent, eventID, services.AuditActionUpdate,
+			eventsBefore[eventID], eventAfter)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotEvents returns the snapshots of the events matching where, deleted
+// ones included, by event ID.
+func snapshotEvents(ctx context.Context, tx *sqlx.Tx, where string, args ...interface{}) (map[int]map[string]interface{}, error) {
+	var ids []int
+
+	if err := tx.SelectContext(ctx, &ids, "SELECT id FROM events WHERE "+where, args...); err != nil {
+		return nil, err
+	}
+	snapshots := make(map[int]map[string]interface{}, len(ids))
+	for _, id := range ids {
+		snapshot, err := snapshotEntity(ctx, tx, services.AuditEntityEvent, id)
+		if err != nil {
+			return nil, err
+		}
+		snapshots[id] = snapshot
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,104 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestAuditRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	repo := NewAuditRepository(db)
+	sportRepo := NewSportRepository(db)
+	teamRepo := NewTeamRepository(db)
+	ctx := services.WithActor(context.Background(), "alice")
+	sportType := services.AuditEntitySport
+	teamType := services.AuditEntityTeam
+
+	sportID, err := sportRepo.CreateSport(ctx, services.SportRequest{Name: "Audit Footbal"})
+	require.NoError(t, err)
+
+	t.Run("records a create with the whole row", func(t *testing.T) {
+		entries, err := repo.ListAuditEntries(ctx, services.ListAuditEntriesParams{EntityType: &sportType, EntityID: &sportID})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, services.AuditActionCreate, entries[0].Action)
+		assert.Equal(t, "alice", entries[0].Actor)
+		assert.Nil(t, entries[0].Before)
+
+		var after map[string]interface{}
+		require.NoError(t, json.Unmarshal(entries[0].After, &after))
+		assert.Equal(t, "Audit Footbal", after["name"])
+	})
+
+	t.Run("records only the changed columns of an update", func(t *testing.T) {
+		sport, err := sportRepo.GetSportById(ctx, sportID)
+		require.NoError(t, err)
+		sport.Name = "Audit Football"
+		require.NoError(t, sportRepo.UpdateSport(ctx, *sport))
+
+		entries, err := repo.ListAuditEntries(ctx, services.ListAuditEntriesParams{EntityType: &sportType, EntityID: &sportID, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, services.AuditActionUpdate, entries[0].Action)
+		assert.JSONEq(t, `{"name": "Audit Footbal"}`, string(entries[0].Before))
+		assert.JSONEq(t, `{"name": "Audit Football"}`, string(entries[0].After))
+	})
+
+	t.Run("skips writes that change nothing", func(t *testing.T) {
+		sport, err := sportRepo.GetSportById(ctx, sportID)
+		require.NoError(t, err)
+		require.NoError(t, sportRepo.UpdateSport(ctx, *sport))
+
+		entries, err := repo.ListAuditEntries(ctx, services.ListAuditEntriesParams{EntityType: &sportType, EntityID: &sportID})
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("records a merge and the deleted duplicate", func(t *testing.T) {
+		survivorID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{Name: "Audit United", City: "Audit", SportID: sportID})
+		require.NoError(t, err)
+		duplicateID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{Name: "Audit Utd", City: "Audit", SportID: sportID})
+		require.NoError(t, err)
+		survivor, err := teamRepo.GetTeamByID(ctx, survivorID)
+		require.NoError(t, err)
+		survivor.Aliases = []string{"Audit Utd"}
+
+		require.NoError(t, teamRepo.MergeTeams(ctx, *survivor, duplicateID))
+
+		entries, err := repo.ListAuditEntries(ctx, services.ListAuditEntriesParams{EntityType: &teamType, EntityID: &survivorID})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, services.AuditActionMerge, entries[0].Action)
+		assert.JSONEq(t, `{"aliases": ["Audit Utd"]}`, string(entries[0].After))
+
+		entries, err = repo.ListAuditEntries(ctx, services.ListAuditEntriesParams{EntityType: &teamType, EntityID: &duplicateID})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, services.AuditActionDelete, entries[0].Action)
+		assert.Nil(t, entries[0].After)
+	})
+
+	t.Run("rejects changes to recorded entries", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, "UPDATE audit_log SET actor = 'mallory'")
+		assert.Error(t, err)
+		_, err = db.ExecContext(ctx, "DELETE FROM audit_log")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,372 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// Reads of events return them joined with their sport, venue and teams, so
+// they depend on all four entities.
+var eventReadDependencies = cacheEntities
+
+// CachedEventRepository serves GetEventByID, ListEvents and CountEvents from
+// the cache. The other reads back validations and guards, which must see the
+// latest writes, and go straight to the repository.
+type CachedEventRepository struct {
+	services.EventRepositoryInterface
+	cache *RepositoryCache
+}
+
+func NewCachedEventRepository(r services.EventRepositoryInterface, cache *RepositoryCache) *CachedEventRepository {
+	return &CachedEventRepository{EventRepositoryInterface: r, cache: cache}
+}
+
+func (r *CachedEventRepository) GetEventByID(ctx context.Context, id int) (*services.Event, error) {
+	return cachedRead(ctx, r.cache, eventReadDependencies, cacheKey("GetEventByID", id),
+		func() (*services.Event, error) { return r.EventRepositoryInterface.GetEventByID(ctx, id) },
+		copyEventPtr)
+}
+
+func (r *CachedEventRepository) ListEvents(ctx context.Context, params services.ListEventsParams) ([]services.Event, error) {
+	return cachedRead(ctx, r.cache, eventReadDependencies, cacheKey("ListEvents", normalizeListEventsParams(params)),
+		func() ([]services.Event, error) { return r.EventRepositoryInterface.ListEvents(ctx, params) },
+		copySlice(copyEvent))
+}
+
+func (r *CachedEventRepository) CountEvents(ctx context.Context, params services.ListEventsParams) (int, error) {
+	// The count does not depend on the page.
+	params.Limit, params.Offset = 0, 0
+	return cachedRead(ctx, r.cache, eventReadDependencies, cacheKey("CountEvents", normalizeListEventsParams(params)),
+		func() (int, error) { return r.EventRepositoryInterface.CountEvents(ctx, params) },
+		identity[int])
+}
+
+func (r *CachedEventRepository) CreateEvent(ctx context.Context, params services.CreateEventParams) (int, error) {
+	defer r.cache.Invalidate(cacheEntityEvents)
+	return r.EventRepositoryInterface.CreateEvent(ctx, params)
+}
+
+func (r *CachedEventRepository) UpdateEvent(ctx context.Context, event services.Event) error {
+	defer r.cache.Invalidate(cacheEntityEvents)
+	return r.EventRepositoryInterface.UpdateEvent(ctx, event)
+}
+
+func (r *CachedEventRepository) DeleteEvent(ctx context.Context, id int) error {
+	defer r.cache.Invalidate(cacheEntityEvents)
+	return r.EventRepositoryInterface.DeleteEvent(ctx, id)
+}
+
+func (r *CachedEventRepository) RestoreEvent(ctx context.Context, id int) error {
+	defer r.cache.Invalidate(cacheEntityEvents)
+	return r.EventRepositoryInterface.RestoreEvent(ctx, id)
+}
+
+// normalizeListEventsParams puts the times in UTC, so that the same instants
+// given in different zones share a cache entry.
+func normalizeListEventsParams(params services.ListEventsParams) services.ListEventsParams {
+	for _, t := range []**time.Time{&params.DateFrom, &params.DateTo, &params.StartFrom} {
+		if *t != nil {
+			utc := (*t).UTC()
+			*t = &utc
+		}
+	}
+	return params
+}
+
+type CachedSportRepository struct {
+	services.SportRepositoryInterface
+	cache *RepositoryCache
+}
+
+func NewCachedSportRepository(r services.SportRepositoryInterface, cache *RepositoryCache) *CachedSportRepository {
+	return &CachedSportRepository{SportRepositoryInterface: r, cache: cache}
+}
+
+func (r *CachedSportRepository) GetSportById(ctx context.Context, id int) (*services.Sport, error) {
+	return cachedRead(ctx, r.cache, []string{cacheEntitySports}, cacheKey("GetSportById", id),
+		func() (*services.Sport, error) { return r.SportRepositoryInterface.GetSportById(ctx, id) },
+		copyPtr(cloneSport))
+}
+
+func (r *CachedSportRepository) ListSports(ctx context.Context) ([]services.Sport, error) {
+	return cachedRead(ctx, r.cache, []string{cacheEntitySports}, cacheKey("ListSports"),
+		func() ([]services.Sport, error) { return r.SportRepositoryInterface.ListSports(ctx) },
+		copySlice(cloneSport))
+}
+
+func (r *CachedSportRepository) CreateSport(ctx context.Context, params services.SportRequest) (int, error) {
+	defer r.cache.Invalidate(cacheEntitySports)
+	return r.SportRepositoryInterface.CreateSport(ctx, params)
+}
+
+func (r *CachedSportRepository) UpdateSport(ctx context.Context, sport services.Sport) error {
+	defer r.cache.Invalidate(cacheEntitySports)
+	return r.SportRepositoryInterface.UpdateSport(ctx, sport)
+}
+
+func (r *CachedSportRepository) DeleteSport(ctx context.Context, id int) error {
+	defer r.cache.Invalidate(cacheEntitySports)
+	return r.SportRepositoryInterface.DeleteSport(ctx, id)
+}
+
+func (r *CachedSportRepository) RestoreSport(ctx context.Context, id int) error {
+	defer r.cache.Invalidate(cacheEntitySports)
+	return r.SportRepositoryInterface.RestoreSport(ctx, id)
+}
+
+type CachedTeamRepository struct {
+	services.TeamRepositoryInterface
+	cache *RepositoryCache
+}
+
+func NewCachedTeamRepository(r services.TeamRepositoryInterface, cache *RepositoryCache) *CachedTeamRepository {
+	return &CachedTeamRepository{TeamRepositoryInterface: r, cache: cache}
+}
+
+func (r *CachedTeamRepository) GetTeamByID(ctx context.Context, id int) (*services.Team, error) {
+	return cachedRead(ctx, r.cache, []string{cacheEntityTeams}, cacheKey("GetTeamByID", id),
+		func() (*services.Team, error) { return r.TeamRepositoryInterface.GetTeamByID(ctx, id) },
+		copyPtr(copyTeam))
+}
+
+func (r *CachedTeamRepository) ListTeams(ctx context.Context) ([]services.Team, error) {
+	return cachedRead(ctx, r.cache, []string{cacheEntityTeams}, cacheKey("ListTeams"),
+		func() ([]services.Team, error) { return r.TeamRepositoryInterface.ListTeams(ctx) },
+		copySlice(copyTeam))
+}
+
+func (r *CachedTeamRepository) FindTeamsByName(ctx context.Context, name string, sportID *int) ([]services.Team, error) {
+	return cachedRead(ctx, r.cache, []string{cacheEntityTeams}, cacheKey("FindTeamsByName", name, sportID),
+		func() ([]services.Team, error) { return r.TeamRepositoryInterface.FindTeamsByName(ctx, name, sportID) },
+		copySlice(copyTeam))
+}
+
+func (r *CachedTeamRepository) CreateTeam(ctx context.Context, params services.TeamRequest) (int, error) {
+	defer r.cache.Invalidate(cacheEntityTeams)
+	return r.TeamRepositoryInterface.CreateTeam(ctx, params)
+}
+
+func (r *CachedTeamRepository) UpdateTeam(ctx context.Context, team services.Team) error {
+	defer r.cache.Invalidate(cacheEntityTeams)
+	return r.TeamRepositoryInterface.UpdateTeam(ctx, team)
+}
+
+func (r *CachedTeamRepository) DeleteTeam(ctx context.Context, id int) error {
+	defer r.cache.Invalidate(cacheEntityTeams)
+	return r.TeamRepositoryInterface.DeleteTeam(ctx, id)
+}
+
+func (r *CachedTeamRepository) RestoreTeam(ctx context.Context, id int) error {
+	defer r.cache.Invalidate(cacheEntityTeams)
+	return r.TeamRepositoryInterface.RestoreTeam(ctx, id)
+}
+
+// MergeTeams also re-points events to the survivor.
+func (r *CachedTeamRepository) MergeTeams(ctx context.Context, survivor services.Team, duplicateID int) error {
+	defer r.cache.Invalidate(cacheEntityTeams, cacheEntityEvents)
+	return r.TeamRepositoryInterface.MergeTeams(ctx, survivor, duplicateID)
+}
+
+type CachedVenueRepository struct {
+	services.VenueRepositoryInterface
+	cache *RepositoryCache
+}
+
+func NewCachedVenueRepository(r services.VenueRepositoryInterface, cache *RepositoryCache) *CachedVenueRepository {
+	return &CachedVenueRepository{VenueRepositoryInterface: r, cache: cache}
+}
+
+func (r *CachedVenueRepository) GetVenueById(ctx context.Context, id int) (*services.Venue, error) {
+	return cachedRead(ctx, r.cache, []string{cacheEntityVenues}, cacheKey("GetVenueById", id),
+		func() (*services.Venue, error) { return r.VenueRepositoryInterface.GetVenueById(ctx, id) },
+		copyPtr(copyVenue))
+}
+
+func (r *CachedVenueRepository) ListVenues(ctx context.Context) ([]services.Venue, error) {
+	return cachedRead(ctx, r.cache, []string{cacheEntityVenues}, cacheKey("ListVenues"),
+		func() ([]services.Venue, error) { return r.VenueRepositoryInterface.ListVenues(ctx) },
+		copySlice(copyVenue))
+}
+
+func (r *CachedVenueRepository) ListVenuesNearby(ctx context.Context, point services.GeoPoint, radiusKm float64) ([]services.Venue, error) {
+	return cachedRead(ctx, r.cache, []string{cacheEntityVenues}, cacheKey("ListVenuesNearby", point, radiusKm),
+		func() ([]services.Venue, error) {
+			return r.VenueRepositoryInterface.ListVenuesNearby(ctx, point, radiusKm)
+		},
+		copySlice(copyVenue))
+}
+
+func (r *CachedVenueRepository) CreateVenue(ctx context.Context, params services.VenueRequest) (int, error) {
+	defer r.cache.Invalidate(cacheEntityVenues)
+	return r.VenueRepositoryInterface.CreateVenue(ctx, params)
+}
+
+func (r *CachedVenueRepository) UpdateVenue(ctx context.Context, venue services.Venue) error {
+	defer r.cache.Invalidate(cacheEntityVenues)
+	return r.VenueRepositoryInterface.UpdateVenue(ctx, venue)
+}
+
+func (r *CachedVenueRepository) DeleteVenue(ctx context.Context, id int) error {
+	defer r.cache.Invalidate(cacheEntityVenues)
+	return r.VenueRepositoryInterface.DeleteVenue(ctx, id)
+}
+
+func (r *CachedVenueRepository) RestoreVenue(ctx context.Context, id int) error {
+	defer r.cache.Invalidate(cacheEntityVenues)
+	return r.VenueRepositoryInterface.RestoreVenue(ctx, id)
+}
+
+// MergeVenues also re-points events to the survivor.
+func (r *CachedVenueRepository) MergeVenues(ctx context.Context, survivor services.Venue, duplicateID int) error {
+	defer r.cache.Invalidate(cacheEntityVenues, cacheEntityEvents)
+	return r.VenueRepositoryInterface.MergeVenues(ctx, survivor, duplicateID)
+}
+
+// CachedTransactor invalidates the whole cache once a transaction ends. The
+// repositories inside it are not cached: a transaction reads what it is
+// about to write.
+type CachedTransactor struct {
+	services.Transactor
+	cache *RepositoryCache
+}
+
+func NewCachedTransactor(t services.Transactor, cache *RepositoryCache) *CachedTransactor {
+	return &CachedTransactor{Transactor: t, cache: cache}
+}
+
+func (t *CachedTransactor) WithinTx(ctx context.Context, fn func(repos services.Repositories) error) error {
+	defer t.cache.Invalidate(cacheEntities...)
+	return t.Transactor.WithinTx(ctx, fn)
+}
+
+// CachedBroadcastRepository invalidates events on writes, as events are
+// read with their broadcasts. Broadcasts themselves are not cached.
+type CachedBroadcastRepository struct {
+	services.BroadcastRepositoryInterface
+	cache *RepositoryCache
+}
+
+func NewCachedBroadcastRepository(r services.BroadcastRepositoryInterface, cache *RepositoryCache) *CachedBroadcastRepository {
+	return &CachedBroadcastRepository{BroadcastRepositoryInterface: r, cache: cache}
+}
+
+func (r *CachedBroadcastRepository) CreateBroadcast(ctx context.Context, broadcast services.Broadcast) (int, error) {
+	defer r.cache.Invalidate(cacheEntityEvents)
+	return r.BroadcastRepositoryInterface.CreateBroadcast(ctx, broadcast)
+}
+
+func (r *CachedBroadcastRepository) UpdateBroadcast(ctx context.Context, broadcast services.Broadcast) error {
+	defer r.cache.Invalidate(cacheEntityEvents)
+	return r.BroadcastRepositoryInterface.UpdateBroadcast(ctx, broadcast)
+}
+
+func (r *CachedBroadcastRepository) DeleteBroadcast(ctx context.Context, eventID, id int) error {
+	defer r.cache.Invalidate(cacheEntityEvents)
+	return r.BroadcastRepositoryInterface.DeleteBroadcast(ctx, eventID, id)
+}
+
+// CachedSeriesRepository invalidates events when a series hands its events
+// over to another. Series themselves are not cached.
+type CachedSeriesRepository struct {
+	services.SeriesRepositoryInterface
+	cache *RepositoryCache
+}
+
+func NewCachedSeriesRepository(r services.SeriesRepositoryInterface, cache *RepositoryCache) *CachedSeriesRepository {
+	return &CachedSeriesRepository{SeriesRepositoryInterface: r, cache: cache}
+}
+
+func (r *CachedSeriesRepository) MoveSeriesEvents(ctx context.Context, fromSeriesID, toSeriesID int, from time.Time) error {
+	defer r.cache.Invalidate(cacheEntityEvents)
+	return r.SeriesRepositoryInterface.MoveSeriesEvents(ctx, fromSeriesID, toSeriesID, from)
+}
+
+// CachedPurgeRepository invalidates the whole cache after a purge, which
+// only removes soft-deleted rows but changes what reads including them
+// return.
+type CachedPurgeRepository struct {
+	services.PurgeRepositoryInterface
+	cache *RepositoryCache
+}
+
+func NewCachedPurgeRepository(r services.PurgeRepositoryInterface, cache *RepositoryCache) *CachedPurgeRepository {
+	return &CachedPurgeRepository{PurgeRepositoryInterface: r, cache: cache}
+}
+
+func (r *CachedPurgeRepository) PurgeDeleted(ctx context.Context, before time.Time) (*services.PurgeResult, error) {
+	defer r.cache.Invalidate(cacheEntities...)
+	return r.PurgeRepositoryInterface.PurgeDeleted(ctx, before)
+}
+
+// The cache hands out copies of what it holds, so that callers changing
+// them, as services do before saving, leave the cached values alone.
+
+func identity[T any](v T) T {
+	return v
+}
+
+func copyPtr[T any](copyValue func(T) T) func(*T) *T {
+	return func(p *T) *T {
+		if p == nil {
+			return nil
+		}
+		v := copyValue(*p)
+		return &v
+	}
+}
+
+func copySlice[T any](copyValue func(T) T) func([]T) []T {
+	return func(values []T) []T {
+		if values == nil {
+			return nil
+		}
+		copied := make([]T, len(values))
+		for i, v := range values {
+			copied[i] = copyValue(v)
+		}
+		return copied
+	}
+}
+
+// copyTeam is cloneTeam keeping the aliases in the order they were read.
+func copyTeam(team services.Team) services.Team {
+	team.ShortName = clonePtr(team.ShortName)
+	team.Code = clonePtr(team.Code)
+	team.DeletedAt = clonePtr(team.DeletedAt)
+	if team.Aliases != nil {
+		team.Aliases = append([]string{}, team.Aliases...)
+	}
+	return team
+}
+
+func copyVenue(venue services.Venue) services.Venue {
+	venue = cloneVenue(venue)
+	venue.DistanceKm = clonePtr(venue.DistanceKm)
+	return venue
+}
+
+func copyBroadcast(broadcast services.Broadcast) services.Broadcast {
+	broadcast.Channel = clonePtr(broadcast.Channel)
+	broadcast.URL = clonePtr(broadcast.URL)
+	return broadcast
+}
+
+func copyEvent(event services.Event) services.Event {
+	event.Description = clonePtr(event.Description)
+	event.HomeScore = clonePtr(event.HomeScore)
+	event.AwayScore = clonePtr(event.AwayScore)
+	event.Attendance = clonePtr(event.Attendance)
+	event.SeriesID = clonePtr(event.SeriesID)
+	event.OriginalDatetime = clonePtr(event.OriginalDatetime)
+	event.Broadcasts = copySlice(copyBroadcast)(event.Broadcasts)
+	event.DeletedAt = clonePtr(event.DeletedAt)
+	event.Sport = cloneSport(event.Sport)
+	event.Venue = copyVenue(event.Venue)
+	event.HomeTeam = copyTeam(event.HomeTeam)
+	event.AwayTeam = copyTeam(event.AwayTeam)
+	return event
+}
+
+var copyEventPtr = copyPtr(copyEvent)
@@ -0,0 +1,119 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+const broadcastColumns = "id, _event_id, broadcaster, channel, country_code, url, start_datetime, version, created_at, updated_at"
+
+type BroadcastRepository struct {
+	db dbtx
+}
+
+func NewBroadcastRepository(db *sqlx.DB) *BroadcastRepository {
+	return &BroadcastRepository{db: db}
+}
+
+func (r *BroadcastRepository) CreateBroadcast(ctx context.Context, broadcast services.Broadcast) (int, error) {
+	var newID int
+	query := `
+	INSERT INTO event_broadcasts(_event_id, broadcaster, channel, country_code, url, start_datetime)
+	VALUES($1, $2, $3, $4, $5, $6)
+	RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		broadcast.EventID,
+		broadcast.Broadcaster,
+		broadcast.Channel,
+		broadcast.CountryCode,
+		broadcast.URL,
+		broadcast.StartDatetime,
+	).Scan(&newID)
+	if err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+// GetBroadcast returns sql.ErrNoRows unless broadcast id belongs to event
+// eventID.
+func (r *BroadcastRepository) GetBroadcast(ctx context.Context, eventID, id int) (*services.Broadcast, error) {
+	var dbModel broadcastDBModel
+	query := "SELECT " + broadcastColumns + " FROM event_broadcasts WHERE _event_id = $1 AND id = $2"
+
+	if err := r.db.GetContext(ctx, &dbModel, query, eventID, id); err != nil {
+		return nil, err
+	}
+	broadcast := toServiceBroadcast(dbModel)
+	return &broadcast, nil
+}
+
+func (r *BroadcastRepository) ListBroadcasts(ctx context.Context, eventID int, countryCode *string) ([]services.Broadcast, error) {
+	query := "SELECT " + broadcastColumns + ` FROM event_broadcasts
+	WHERE _event_id = $1 AND ($2::text IS NULL OR country_code = $2)
+	ORDER BY start_datetime ASC, country_code ASC, broadcaster ASC, id ASC`
+	var dbModels []broadcastDBModel
+
+	if err := r.db.SelectContext(ctx, &dbModels, query, eventID, countryCode); err != nil {
+		return nil, err
+	}
+	broadcasts := make([]services.Broadcast, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		broadcasts = append(broadcasts, toServiceBroadcast(dbModel))
+	}
+	return broadcasts, nil
+}
+
+func (r *BroadcastRepository) UpdateBroadcast(ctx context.Context, broadcast services.Broadcast) error {
+	query := `
+	UPDATE event_broadcasts SET
+	broadcaster = $1,
+	channel = $2,
+	country_code = $3,
+	url = $4,
+	start_datetime = $5
+	WHERE _event_id = $6 AND id = $7` + versionCondition("$8")
+
+	return execVersioned(ctx, r.db, "broadcast", "event_broadcasts", broadcast.ID, broadcast.Version, query,
+		broadcast.Broadcaster,
+		broadcast.Channel,
+		broadcast.CountryCode,
+		broadcast.URL,
+		broadcast.StartDatetime,
+		broadcast.EventID,
+		broadcast.ID,
+		broadcast.Version,
+	)
+}
+
+func (r *BroadcastRepository) DeleteBroadcast(ctx context.Context, eventID, id int) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM event_broadcasts WHERE _event_id = $1 AND id = $2", eventID, id)
+	return err
+}
+
+// listBroadcastsForEvents loads the broadcasts of several events at once,
+// grouped by event ID.
+func listBroadcastsForEvents(ctx context.Context, db dbtx, eventIDs []int) (map[int][]services.Broadcast, error) {
+	broadcasts := make(map[int][]services.Broadcast)
+	if len(eventIDs) == 0 {
+		return broadcasts, nil
+	}
+	query, args, err := sqlx.In("SELECT "+broadcastColumns+` FROM event_broadcasts
+	WHERE _event_id IN (?)
+	ORDER BY start_datetime ASC, country_code ASC, broadcaster ASC, id ASC`, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	var dbModels []broadcastDBModel
+
+	if err := db.SelectContext(ctx, &dbModels, db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	for _, dbModel := range dbModels {
+		broadcasts[dbModel.EventID] = append(broadcasts[dbModel.EventID], toServiceBroadcast(dbModel))
+	}
+	return broadcasts, nil
+}
@@ -0,0 +1,157 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestBroadcastRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	repo := NewBroadcastRepository(db)
+	eventRepo := NewEventRepository(db)
+	ctx := context.Background()
+
+	sportID, err := NewSportRepository(db).CreateSport(ctx, services.SportRequest{Name: "Test Football"})
+	require.NoError(t, err)
+
+	teamRepo := NewTeamRepository(db)
+	homeTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{
+		Name:    "Home Team",
+		City:    "Home City",
+		SportID: sportID,
+	})
+	require.NoError(t, err)
+	awayTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{
+		Name:    "Away Team",
+		City:    "Away City",
+		SportID: sportID,
+	})
+	require.NoError(t, err)
+
+	kickoff := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	createEvent := func(start time.Time) int {
+		id, err := eventRepo.CreateEvent(ctx, services.CreateEventParams{
+			EventDatetime: start,
+			EndDatetime:   start.Add(2 * time.Hour),
+			SportID:       sportID,
+			HomeTeamID:    homeTeamID,
+			AwayTeamID:    awayTeamID,
+		})
+		require.NoError(t, err)
+		return id
+	}
+	eventID := createEvent(kickoff)
+	otherEventID := createEvent(kickoff.Add(72 * time.Hour))
+
+	channel := "ORF 1"
+	url := "https://tvthek.orf.at"
+	var orfID int
+
+	t.Run("CreateBroadcast and GetBroadcast", func(t *testing.T) {
+		orfID, err = repo.CreateBroadcast(ctx, services.Broadcast{
+			EventID:       eventID,
+			Broadcaster:   "ORF",
+			Channel:       &channel,
+			CountryCode:   "AT",
+			URL:           &url,
+			StartDatetime: kickoff.Add(-15 * time.Minute),
+		})
+		require.NoError(t, err)
+
+		broadcast, err := repo.GetBroadcast(ctx, eventID, orfID)
+		require.NoError(t, err)
+		assert.Equal(t, "ORF", broadcast.Broadcaster)
+		assert.Equal(t, channel, *broadcast.Channel)
+		assert.Equal(t, url, *broadcast.URL)
+		assert.True(t, broadcast.StartDatetime.Equal(kickoff.Add(-15*time.Minute)))
+
+		_, err = repo.GetBroadcast(ctx, otherEventID, orfID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("ListBroadcasts", func(t *testing.T) {
+		_, err := repo.CreateBroadcast(ctx, services.Broadcast{
+			EventID:       eventID,
+			Broadcaster:   "TNT Sports",
+			CountryCode:   "GB",
+			StartDatetime: kickoff,
+		})
+		require.NoError(t, err)
+
+		broadcasts, err := repo.ListBroadcasts(ctx, eventID, nil)
+		require.NoError(t, err)
+		require.Len(t, broadcasts, 2)
+		assert.Equal(t, "ORF", broadcasts[0].Broadcaster)
+		assert.Nil(t, broadcasts[1].Channel)
+
+		country := "GB"
+		broadcasts, err = repo.ListBroadcasts(ctx, eventID, &country)
+		require.NoError(t, err)
+		require.Len(t, broadcasts, 1)
+		assert.Equal(t, "TNT Sports", broadcasts[0].Broadcaster)
+	})
+
+	t.Run("events embed broadcasts and filter by country", func(t *testing.T) {
+		event, err := eventRepo.GetEventByID(ctx, eventID)
+		require.NoError(t, err)
+		assert.Len(t, event.Broadcasts, 2)
+
+		country := "AT"
+		events, err := eventRepo.ListEvents(ctx, services.ListEventsParams{BroadcastCountry: &country, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, eventID, events[0].ID)
+		assert.Len(t, events[0].Broadcasts, 2)
+
+		count, err := eventRepo.CountEvents(ctx, services.ListEventsParams{BroadcastCountry: &country})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		events, err = eventRepo.ListEvents(ctx, services.ListEventsParams{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Empty(t, events[1].Broadcasts)
+	})
+
+	t.Run("UpdateBroadcast", func(t *testing.T) {
+		broadcast, err := repo.GetBroadcast(ctx, eventID, orfID)
+		require.NoError(t, err)
+		broadcast.Broadcaster = "ORF Sport+"
+		broadcast.Channel = nil
+		require.NoError(t, repo.UpdateBroadcast(ctx, *broadcast))
+
+		updated, err := repo.GetBroadcast(ctx, eventID, orfID)
+		require.NoError(t, err)
+		assert.Equal(t, "ORF Sport+", updated.Broadcaster)
+		assert.Nil(t, updated.Channel)
+	})
+
+	t.Run("DeleteBroadcast and cascade", func(t *testing.T) {
+		require.NoError(t, repo.DeleteBroadcast(ctx, eventID, orfID))
+		_, err := repo.GetBroadcast(ctx, eventID, orfID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		require.NoError(t, eventRepo.DeleteEvent(ctx, eventID))
+		broadcasts, err := repo.ListBroadcasts(ctx, eventID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, broadcasts)
+	})
+}
@@ -0,0 +1,133 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+const eventChangeColumns = `
+    c.id,
+    c._event_id,
+    c.change_type,
+    c.changed_at,
+    c.event_datetime,
+    c.home_score,
+    c.away_score,
+    c._sport_id,
+    c.sport_name,
+    c._home_team_id,
+    c.home_team_name,
+    c._away_team_id,
+    c.away_team_name
+`
+
+const baseEventChangeSelectQuery = "SELECT" + eventChangeColumns + "FROM event_changes c"
+
+type EventChangeRepository struct {
+	db dbtx
+}
+
+func NewEventChangeRepository(db *sqlx.DB) *EventChangeRepository {
+	return &EventChangeRepository{db: db}
+}
+
+func (r *EventChangeRepository) RecordEventChange(ctx context.Context, change services.EventChange) error {
+	query := `
+	INSERT INTO event_changes(
+		_event_id, change_type, event_datetime, home_score, away_score,
+		_sport_id, sport_name, _home_team_id, home_team_name, _away_team_id, away_team_name)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		change.EventID,
+		change.ChangeType,
+		change.EventDatetime,
+		change.HomeScore,
+		change.AwayScore,
+		change.Sport.ID,
+		change.Sport.Name,
+		change.HomeTeam.ID,
+		change.HomeTeam.Name,
+		change.AwayTeam.ID,
+		change.AwayTeam.Name,
+	)
+	return err
+}
+
+func (r *EventChangeRepository) ListEventChanges(ctx context.Context,
+	params services.ListEventChangesParams) ([]services.EventChange, error) {
+	whereQuery, args := buildEventChangeFilter(params)
+	args = append(args, params.Limit)
+	query := fmt.Sprintf(
+		"%s WHERE %s ORDER BY c.changed_at DESC, c.id DESC LIMIT $%d",
+		baseEventChangeSelectQuery,
+		strings.Join(whereQuery, " AND "),
+		len(args),
+	)
+	return r.selectEventChanges(ctx, query, args)
+}
+
+// ListLatestResults returns the most recent score of every event that has
+// been scored and not cancelled since, newest first.
+func (r *EventChangeRepository) ListLatestResults(ctx context.Context,
+	params services.ListEventChangesParams) ([]services.EventChange, error) {
+	whereQuery, args := buildEventChangeFilter(params)
+	whereQuery = append(whereQuery, fmt.Sprintf(`NOT EXISTS (
+		SELECT 1 FROM event_changes x
+		WHERE x._event_id = c._event_id AND x.change_type = '%s')`, services.EventChangeCancelled))
+	args = append(args, params.Limit)
+	query := fmt.Sprintf(
+		`SELECT * FROM (
+			SELECT DISTINCT ON (c._event_id) %s FROM event_changes c
+			WHERE %s
+			ORDER BY c._event_id, c.changed_at DESC, c.id DESC
+		) latest
+		ORDER BY changed_at DESC, id DESC
+		LIMIT $%d`,
+		eventChangeColumns,
+		strings.Join(whereQuery, " AND "),
+		len(args),
+	)
+	return r.selectEventChanges(ctx, query, args)
+}
+
+func (r *EventChangeRepository) selectEventChanges(ctx context.Context,
+	query string, args []interface{}) ([]services.EventChange, error) {
+	var dbModels []eventChangeDBModel
+
+	if err := r.db.SelectContext(ctx, &dbModels, query, args...); err != nil {
+		return nil, err
+	}
+	changes := make([]services.EventChange, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		changes = append(changes, toServiceEventChange(dbModel))
+	}
+	return changes, nil
+}
+
+func buildEventChangeFilter(params services.ListEventChangesParams) ([]string, []interface{}) {
+	var args []interface{}
+	i := 1
+	whereQuery := []string{"1=1"}
+
+	if len(params.ChangeTypes) > 0 {
+		args = append(args, params.ChangeTypes)
+		whereQuery = append(whereQuery, fmt.Sprintf("c.change_type = ANY($%d)", i))
+		i++
+	}
+	if params.SportID != nil {
+		args = append(args, *params.SportID)
+		whereQuery = append(whereQuery, fmt.Sprintf("c._sport_id = $%d", i))
+		i++
+	}
+	if params.TeamID != nil {
+		args = append(args, *params.TeamID)
+		whereQuery = append(whereQuery, fmt.Sprintf("(c._home_team_id = $%d OR c._away_team_id = $%d)", i, i))
+		i++
+	}
+	return whereQuery, args
+}
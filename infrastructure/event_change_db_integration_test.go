@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestEventChangeRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	repo := NewEventChangeRepository(db)
+	ctx := context.Background()
+
+	sport := services.Sport{ID: 1, Name: "Football"}
+	homeTeam := services.Team{ID: 1, Name: "Home Team"}
+	awayTeam := services.Team{ID: 2, Name: "Away Team"}
+	otherTeam := services.Team{ID: 3, Name: "Other Team"}
+	kickoff := time.Now().Add(24 * time.Hour)
+	homeScore, awayScore := 2, 1
+
+	record := func(eventID int, changeType string, home, away services.Team) {
+		err := repo.RecordEventChange(ctx, services.EventChange{
+			EventID:       eventID,
+			ChangeType:    changeType,
+			EventDatetime: kickoff,
+			HomeScore:     &homeScore,
+			AwayScore:     &awayScore,
+			Sport:         sport,
+			HomeTeam:      home,
+			AwayTeam:      away,
+		})
+		require.NoError(t, err)
+	}
+
+	record(1, services.EventChangeCreated, homeTeam, awayTeam)
+	record(1, services.EventChangeScored, homeTeam, awayTeam)
+	record(1, services.EventChangeScored, homeTeam, awayTeam)
+	record(2, services.EventChangeCreated, homeTeam, otherTeam)
+	record(2, services.EventChangeScored, homeTeam, otherTeam)
+	record(2, services.EventChangeCancelled, homeTeam, otherTeam)
+
+	t.Run("ListEventChanges", func(t *testing.T) {
+		changes, err := repo.ListEventChanges(ctx, services.ListEventChangesParams{
+			ChangeTypes: []string{services.EventChangeCreated, services.EventChangeCancelled},
+			Limit:       10,
+		})
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+		assert.Equal(t, services.EventChangeCancelled, changes[0].ChangeType)
+		assert.Equal(t, "Other Team", changes[0].AwayTeam.Name)
+	})
+
+	t.Run("ListEventChanges with team filter", func(t *testing.T) {
+		changes, err := repo.ListEventChanges(ctx, services.ListEventChangesParams{
+			TeamID: &otherTeam.ID,
+			Limit:  10,
+		})
+		require.NoError(t, err)
+		assert.Len(t, changes, 3)
+		for _, change := range changes {
+			assert.Equal(t, 2, change.EventID)
+		}
+	})
+
+	t.Run("ListLatestResults", func(t *testing.T) {
+		results, err := repo.ListLatestResults(ctx, services.ListEventChangesParams{
+			ChangeTypes: []string{services.EventChangeScored},
+			SportID:     &sport.ID,
+			Limit:       10,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 1, results[0].EventID)
+		assert.Equal(t, homeScore, *results[0].HomeScore)
+	})
+}
@@ -2,15 +2,18 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/vsennikov/sports-event-calendar/services"
 )
 
 type EventRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func NewEventRepository(db *sqlx.DB) *EventRepository {
@@ -21,49 +24,47 @@ func NewEventRepository(db *sqlx.DB) *EventRepository {
 
 func (r *EventRepository) GetEventByID(ctx context.Context, id int) (*services.Event, error) {
 	var dbModel eventDBModel
-	query := baseEventSelectQuery + " WHERE e.id = $1"
+	query := baseEventSelectQuery + " WHERE e.id = $1 AND " + notDeletedSQL(ctx, "e")
 
 	if err := r.db.GetContext(ctx, &dbModel, query, id); err != nil {
 		return nil, err
 	}
 	event := toServiceEvent(dbModel)
+	broadcasts, err := listBroadcastsForEvents(ctx, r.db, []int{id})
+	if err != nil {
+		return nil, err
+	}
+	event.Broadcasts = broadcasts[id]
 	return &event, nil
 }
 
 func (r *EventRepository) CreateEvent(ctx context.Context, params services.CreateEventParams) (int, error) {
 	var newID int
 	query := `
-	INSERT INTO events(event_datetime, description, _sport_id, _venue_id, _home_team_id, _away_team_id)
-	VALUES($1, $2, $3, $4, $5, $6)
+	INSERT INTO events(
+		event_datetime, end_datetime, description, _sport_id, _venue_id,
+		_home_team_id, _away_team_id, _series_id, allow_venue_overlap)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	RETURNING id`
 
-	err := r.db.QueryRowContext(
-		ctx, query,
-		params.EventDatetime, params.Description, params.SportID,
-		params.VenueID, params.HomeTeamID, params.AwayTeamID,
-	).Scan(&newID)
+	newID, err := auditedWrite(ctx, r.db, services.AuditEntityEvent, services.AuditActionCreate, 0, func(tx *sqlx.Tx) (int, error) {
+		err := tx.QueryRowContext(
+			ctx, query,
+			params.EventDatetime, params.EndDatetime, params.Description, params.SportID, params.VenueID,
+			params.HomeTeamID, params.AwayTeamID, params.SeriesID, params.AllowVenueOverlap,
+		).Scan(&newID)
+		return newID, err
+	})
 	if err != nil {
-		return 0, err
+		return 0, translateEventWriteError(err)
 	}
 	return newID, nil
 }
 
 func (r *EventRepository) CountEvents(ctx context.Context, params services.ListEventsParams) (int, error) {
-	var args []interface{}
 	var total int
-	i := 1
-	whereQuery := []string{"1=1"}
+	whereQuery, args := buildEventFilter(ctx, params)
 
-	if params.SportID != nil {
-		args = append(args, *params.SportID)
-		whereQuery = append(whereQuery, fmt.Sprintf("e._sport_id = $%d", i))
-		i++
-	}
-	if params.DateFrom != nil {
-		args = append(args, *params.DateFrom)
-		whereQuery = append(whereQuery, fmt.Sprintf("event_datetime >= $%d", i))
-		i++
-	}
 	query := fmt.Sprintf("SELECT COUNT(*) FROM events e WHERE %s", strings.Join(whereQuery, " AND "))
 	if err := r.db.GetContext(ctx, &total, query, args...); err != nil {
 		return 0, err
@@ -74,26 +75,14 @@ func (r *EventRepository) CountEvents(ctx context.Context, params services.ListE
 func (r *EventRepository) ListEvents(ctx context.Context,
 	params services.ListEventsParams) ([]services.Event, error) {
 	var dbModels []eventDBModel
-	var args []interface{}
-	i := 1
-	whereQuery := []string{"1=1"}
+	whereQuery, args := buildEventFilter(ctx, params)
+	i := len(args) + 1
 
-	if params.SportID != nil {
-		args = append(args, *params.SportID)
-		whereQuery = append(whereQuery, fmt.Sprintf("e._sport_id = $%d", i))
-		i++
-	}
-	if params.DateFrom != nil {
-		args = append(args, *params.DateFrom)
-		whereQuery = append(whereQuery, fmt.Sprintf("e.event_datetime >= $%d", i))
-		i++
-	}
 	args = append(args, params.Limit)
 	limitClause := fmt.Sprintf("LIMIT $%d", i)
 	i++
 	args = append(args, params.Offset)
 	offsetClause := fmt.Sprintf("OFFSET $%d", i)
-	i++
 	query := fmt.Sprintf(
 		"%s WHERE %s ORDER BY e.event_datetime ASC %s %s",
 		baseEventSelectQuery,
@@ -105,51 +94,185 @@ func (r *EventRepository) ListEvents(ctx context.Context,
 		return nil, err
 	}
 	events := make([]services.Event, 0, len(dbModels))
+	eventIDs := make([]int, 0, len(dbModels))
 	for _, dbModel := range dbModels {
 		events = append(events, toServiceEvent(dbModel))
+		eventIDs = append(eventIDs, dbModel.ID)
+	}
+	broadcasts, err := listBroadcastsForEvents(ctx, r.db, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		events[i].Broadcasts = broadcasts[events[i].ID]
 	}
 	return events, nil
 }
 
+// buildEventFilter translates the list filters into WHERE conditions on the
+// events table (aliased e) and their positional arguments.
+func buildEventFilter(ctx context.Context, params services.ListEventsParams) ([]string, []interface{}) {
+	var args []interface{}
+	i := 1
+	whereQuery := []string{notDeletedSQL(ctx, "e")}
+
+	if params.SportID != nil {
+		args = append(args, *params.SportID)
+		whereQuery = append(whereQuery, fmt.Sprintf("e._sport_id = $%d", i))
+		i++
+	}
+	if params.SeriesID != nil {
+		args = append(args, *params.SeriesID)
+		whereQuery = append(whereQuery, fmt.Sprintf("e._series_id = $%d", i))
+		i++
+	}
+	if params.DateFrom != nil {
+		args = append(args, *params.DateFrom)
+		whereQuery = append(whereQuery, fmt.Sprintf("e.end_datetime > $%d", i))
+		i++
+	}
+	if params.StartFrom != nil {
+		args = append(args, *params.StartFrom)
+		whereQuery = append(whereQuery, fmt.Sprintf("e.event_datetime >= $%d", i))
+		i++
+	}
+	if params.DateTo != nil {
+		args = append(args, *params.DateTo)
+		whereQuery = append(whereQuery, fmt.Sprintf("e.event_datetime < $%d", i))
+		i++
+	}
+	if params.Near != nil {
+		args = append(args, params.Near.Latitude, params.Near.Longitude, params.RadiusKm)
+		lat, lon, radius := fmt.Sprintf("$%d", i), fmt.Sprintf("$%d", i+1), fmt.Sprintf("$%d", i+2)
+		whereQuery = append(whereQuery, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM venues nv WHERE nv.id = e._venue_id AND %s AND %s <= %s)",
+			latitudeBandSQL("nv.latitude", lat, radius),
+			haversineKmSQL("nv.latitude", "nv.longitude", lat, lon),
+			float8Param(radius)))
+		i += 3
+	}
+	if params.BroadcastCountry != nil {
+		args = append(args, *params.BroadcastCountry)
+		whereQuery = append(whereQuery, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM event_broadcasts b WHERE b._event_id = e.id AND b.country_code = $%d)", i))
+		i++
+	}
+	return whereQuery, args
+}
+
+// UpdateEvent saves an event read at event.Version; an update by someone
+// else in between makes it fail with a VersionMismatchError.
 func (r *EventRepository) UpdateEvent(ctx context.Context, event services.Event) error {
 	query := `
 	UPDATE events SET
     event_datetime = $1,
-    description = $2,
-    home_score = $3,
-    away_score = $4,
-    _sport_id = $5,
-    _venue_id = $6,
-    _home_team_id = $7,
-    _away_team_id = $8
-	WHERE id = $9`
+    end_datetime = $2,
+    description = $3,
+    home_score = $4,
+    away_score = $5,
+    _sport_id = $6,
+    _venue_id = $7,
+    _home_team_id = $8,
+    _away_team_id = $9,
+    allow_venue_overlap = $10,
+    attendance = $11
+	WHERE id = $12` + versionCondition("$13")
 	var venueID *int
 
 	if event.Venue.ID != 0 {
 		venueID = &event.Venue.ID
 	}
-	_, err := r.db.ExecContext(ctx, query,
-		event.EventDatetime,
-		event.Description,
-		event.HomeScore,
-		event.AwayScore,
-		event.Sport.ID,
-		venueID,
-		event.HomeTeam.ID,
-		event.AwayTeam.ID,
-		event.ID,
-	)
-	return err
+	_, err := auditedWrite(ctx, r.db, services.AuditEntityEvent, services.AuditActionUpdate, event.ID, func(tx *sqlx.Tx) (int, error) {
+		err := execVersioned(ctx, tx, services.AuditEntityEvent, "events", event.ID, event.Version, query,
+			event.EventDatetime,
+			event.EndDatetime,
+			event.Description,
+			event.HomeScore,
+			event.AwayScore,
+			event.Sport.ID,
+			venueID,
+			event.HomeTeam.ID,
+			event.AwayTeam.ID,
+			event.AllowVenueOverlap,
+			event.Attendance,
+			event.ID,
+			event.Version,
+		)
+		return event.ID, err
+	})
+	return translateEventWriteError(err)
 }
 
+// DeleteEvent soft-deletes an event, freeing its venue slot; the purge job
+// removes it for good.
 func (r *EventRepository) DeleteEvent(ctx context.Context, id int) error {
-	query := "DELETE FROM events WHERE id = $1"
-	_, err := r.db.ExecContext(ctx, query, id)
+	query := "UPDATE events SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+	_, err := auditedWrite(ctx, r.db, services.AuditEntityEvent, services.AuditActionDelete, id, func(tx *sqlx.Tx) (int, error) {
+		_, err := tx.ExecContext(ctx, query, id)
+		return id, err
+	})
 	return err
 }
 
+// RestoreEvent undeletes an event. Taking back a venue slot booked since
+// fails with ErrVenueConflict.
+func (r *EventRepository) RestoreEvent(ctx context.Context, id int) error {
+	_, err := auditedWrite(ctx, r.db, services.AuditEntityEvent, services.AuditActionRestore, id, func(tx *sqlx.Tx) (int, error) {
+		return id, restoreRow(ctx, tx, "events", id)
+	})
+	return translateEventWriteError(err)
+}
+
+// ListVenueConflicts returns the events at the venue overlapping the
+// half-open interval start..end, except the excluded event.
+func (r *EventRepository) ListVenueConflicts(ctx context.Context, venueID int,
+	start, end time.Time, excludeEventID int) ([]services.Event, error) {
+	var dbModels []eventDBModel
+	query := baseEventSelectQuery + `
+	WHERE e._venue_id = $1 AND e.event_datetime < $3 AND e.end_datetime > $2 AND e.id <> $4
+	AND e.deleted_at IS NULL
+	ORDER BY e.event_datetime ASC`
+
+	if err := r.db.SelectContext(ctx, &dbModels, query, venueID, start, end, excludeEventID); err != nil {
+		return nil, err
+	}
+	events := make([]services.Event, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		events = append(events, toServiceEvent(dbModel))
+	}
+	return events, nil
+}
+
+// ListTeamEvents returns the events a team plays in, ordered by kickoff,
+// deleted ones only under WithDeleted. When given, from and to restrict them
+// to events overlapping from..to.
+func (r *EventRepository) ListTeamEvents(ctx context.Context, teamID int,
+	from, to *time.Time) ([]services.Event, error) {
+	var dbModels []eventDBModel
+	args := []interface{}{teamID}
+	query := baseEventSelectQuery + " WHERE (e._home_team_id = $1 OR e._away_team_id = $1) AND " + notDeletedSQL(ctx, "e")
+
+	if from != nil {
+		args = append(args, *from)
+		query += fmt.Sprintf(" AND e.end_datetime > $%d", len(args))
+	}
+	if to != nil {
+		args = append(args, *to)
+		query += fmt.Sprintf(" AND e.event_datetime < $%d", len(args))
+	}
+	query += " ORDER BY e.event_datetime ASC, e.id ASC"
+	if err := r.db.SelectContext(ctx, &dbModels, query, args...); err != nil {
+		return nil, err
+	}
+	events := make([]services.Event, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		events = append(events, toServiceEvent(dbModel))
+	}
+	return events, nil
+}
+
 func (r *EventRepository) CountEventsBySportID(ctx context.Context, sportID int) (int, error) {
-	query := "SELECT COUNT(*) FROM events WHERE _sport_id = $1"
+	query := "SELECT COUNT(*) FROM events WHERE _sport_id = $1 AND deleted_at IS NULL"
 	var total int
 
 	if err := r.db.GetContext(ctx, &total, query, sportID); err != nil {
@@ -159,7 +282,7 @@ func (r *EventRepository) CountEventsBySportID(ctx context.Context, sportID int)
 }
 
 func (r *EventRepository) CountEventsByVenueId(ctx context.Context, venueID int) (int, error) {
-	query := "SELECT COUNT(*) FROM events WHERE _venue_id = $1"
+	query := "SELECT COUNT(*) FROM events WHERE _venue_id = $1 AND deleted_at IS NULL"
 	var total int
 
 	if err := r.db.GetContext(ctx, &total, query, venueID); err != nil {
@@ -169,7 +292,7 @@ func (r *EventRepository) CountEventsByVenueId(ctx context.Context, venueID int)
 }
 
 func (r *EventRepository) CountEventsByTeamID(ctx context.Context, teamID int) (int, error) {
-	query := "SELECT COUNT(*) FROM events WHERE _home_team_id = $1 OR _away_team_id = $1"
+	query := "SELECT COUNT(*) FROM events WHERE (_home_team_id = $1 OR _away_team_id = $1) AND deleted_at IS NULL"
 	var total int
 
 	if err := r.db.GetContext(ctx, &total, query, teamID); err != nil {
@@ -177,3 +300,75 @@ func (r *EventRepository) CountEventsByTeamID(ctx context.Context, teamID int) (
 	}
 	return total, nil
 }
+
+// GetAttendanceStats aggregates the events with a recorded attendance
+// selected by params. The peak event is the earliest one with the highest
+// attendance.
+func (r *EventRepository) GetAttendanceStats(ctx context.Context,
+	params services.AttendanceStatsParams) (*services.AttendanceStats, error) {
+	var dbModel attendanceStatsDBModel
+	whereQuery, args := buildAttendanceFilter(params)
+	where := strings.Join(whereQuery, " AND ")
+
+	query := fmt.Sprintf(`
+	SELECT
+		COUNT(*) AS event_count,
+		COALESCE(SUM(e.attendance), 0) AS total_attendance,
+		COALESCE(AVG(e.attendance), 0)::float8 AS average_attendance,
+		COALESCE(MAX(e.attendance), 0) AS peak_attendance,
+		AVG(e.attendance::float8 / v.capacity) AS average_utilization
+	FROM events e
+	LEFT JOIN venues v ON v.id = e._venue_id
+	WHERE %s`, where)
+	if err := r.db.GetContext(ctx, &dbModel, query, args...); err != nil {
+		return nil, err
+	}
+	stats := toServiceAttendanceStats(dbModel)
+	if stats.EventCount == 0 {
+		return &stats, nil
+	}
+	var peakEventID int
+	peakQuery := fmt.Sprintf(
+		"SELECT e.id FROM events e WHERE %s ORDER BY e.attendance DESC, e.event_datetime ASC, e.id ASC LIMIT 1",
+		where)
+	if err := r.db.GetContext(ctx, &peakEventID, peakQuery, args...); err != nil {
+		return nil, err
+	}
+	stats.PeakEventID = &peakEventID
+	return &stats, nil
+}
+
+// buildAttendanceFilter selects the events with a recorded attendance that
+// match the venue, team and kickoff range of params.
+func buildAttendanceFilter(params services.AttendanceStatsParams) ([]string, []interface{}) {
+	var args []interface{}
+	whereQuery := []string{"e.attendance IS NOT NULL", "e.deleted_at IS NULL"}
+
+	if params.VenueID != nil {
+		args = append(args, *params.VenueID)
+		whereQuery = append(whereQuery, fmt.Sprintf("e._venue_id = $%d", len(args)))
+	}
+	if params.TeamID != nil {
+		args = append(args, *params.TeamID)
+		whereQuery = append(whereQuery, fmt.Sprintf("(e._home_team_id = $%d OR e._away_team_id = $%d)", len(args), len(args)))
+	}
+	if params.DateFrom != nil {
+		args = append(args, *params.DateFrom)
+		whereQuery = append(whereQuery, fmt.Sprintf("e.event_datetime >= $%d", len(args)))
+	}
+	if params.DateTo != nil {
+		args = append(args, *params.DateTo)
+		whereQuery = append(whereQuery, fmt.Sprintf("e.event_datetime < $%d", len(args)))
+	}
+	return whereQuery, args
+}
+
+// translateEventWriteError maps a violation of the venue exclusion constraint,
+// which only fires when two bookings race each other, to ErrVenueConflict.
+func translateEventWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23P01" {
+		return fmt.Errorf("%w: %s", services.ErrVenueConflict, pgErr.Message)
+	}
+	return err
+}
@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -29,7 +30,7 @@ func TestEventRepository_Integration(t *testing.T) {
 
 	// Setup test data
 	sportRepo := NewSportRepository(db)
-	sportID, err := sportRepo.CreateSport(ctx, "Test Football")
+	sportID, err := sportRepo.CreateSport(ctx, services.SportRequest{Name: "Test Football"})
 	require.NoError(t, err)
 
 	teamRepo := NewTeamRepository(db)
@@ -60,6 +61,7 @@ func TestEventRepository_Integration(t *testing.T) {
 		description := "Test event"
 		params := services.CreateEventParams{
 			EventDatetime: eventTime,
+			EndDatetime:   eventTime.Add(2 * time.Hour),
 			Description:   &description,
 			SportID:       sportID,
 			VenueID:       &venueID,
@@ -76,6 +78,7 @@ func TestEventRepository_Integration(t *testing.T) {
 		eventTime := time.Now().Add(24 * time.Hour)
 		params := services.CreateEventParams{
 			EventDatetime: eventTime,
+			EndDatetime:   eventTime.Add(2 * time.Hour),
 			SportID:       sportID,
 			HomeTeamID:    homeTeamID,
 			AwayTeamID:    awayTeamID,
@@ -98,6 +101,7 @@ func TestEventRepository_Integration(t *testing.T) {
 			eventTime := time.Now().Add(time.Duration(i+1) * 24 * time.Hour)
 			params := services.CreateEventParams{
 				EventDatetime: eventTime,
+				EndDatetime:   eventTime.Add(2 * time.Hour),
 				SportID:       sportID,
 				HomeTeamID:    homeTeamID,
 				AwayTeamID:    awayTeamID,
@@ -147,6 +151,7 @@ func TestEventRepository_Integration(t *testing.T) {
 		eventTime := time.Now().Add(24 * time.Hour)
 		params := services.CreateEventParams{
 			EventDatetime: eventTime,
+			EndDatetime:   eventTime.Add(2 * time.Hour),
 			SportID:       sportID,
 			HomeTeamID:    homeTeamID,
 			AwayTeamID:    awayTeamID,
@@ -179,6 +184,7 @@ func TestEventRepository_Integration(t *testing.T) {
 		eventTime := time.Now().Add(24 * time.Hour)
 		params := services.CreateEventParams{
 			EventDatetime: eventTime,
+			EndDatetime:   eventTime.Add(2 * time.Hour),
 			SportID:       sportID,
 			HomeTeamID:    homeTeamID,
 			AwayTeamID:    awayTeamID,
@@ -194,6 +200,39 @@ func TestEventRepository_Integration(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("soft delete frees the venue slot and RestoreEvent", func(t *testing.T) {
+		start := time.Now().AddDate(0, 0, 12).UTC().Truncate(time.Hour)
+		params := services.CreateEventParams{
+			EventDatetime: start,
+			EndDatetime:   start.Add(2 * time.Hour),
+			SportID:       sportID,
+			VenueID:       &venueID,
+			HomeTeamID:    homeTeamID,
+			AwayTeamID:    awayTeamID,
+		}
+		id, err := repo.CreateEvent(ctx, params)
+		require.NoError(t, err)
+		require.NoError(t, repo.DeleteEvent(ctx, id))
+
+		deleted, err := repo.GetEventByID(services.WithDeleted(ctx), id)
+		require.NoError(t, err)
+		require.NotNil(t, deleted.DeletedAt)
+		conflicts, err := repo.ListVenueConflicts(ctx, venueID, start, start.Add(2*time.Hour), 0)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+
+		replacementID, err := repo.CreateEvent(ctx, params)
+		require.NoError(t, err)
+		err = repo.RestoreEvent(ctx, id)
+		assert.True(t, errors.Is(err, services.ErrVenueConflict))
+
+		require.NoError(t, repo.DeleteEvent(ctx, replacementID))
+		require.NoError(t, repo.RestoreEvent(ctx, id))
+		restored, err := repo.GetEventByID(ctx, id)
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+	})
+
 	t.Run("CountEventsBySportID", func(t *testing.T) {
 		count, err := repo.CountEventsBySportID(ctx, sportID)
 		require.NoError(t, err)
@@ -211,5 +250,201 @@ func TestEventRepository_Integration(t *testing.T) {
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, count, 0)
 	})
+
+	t.Run("venue double booking", func(t *testing.T) {
+		start := time.Now().AddDate(0, 0, 10).UTC().Truncate(time.Hour)
+		params := services.CreateEventParams{
+			EventDatetime: start,
+			EndDatetime:   start.Add(2 * time.Hour),
+			SportID:       sportID,
+			VenueID:       &venueID,
+			HomeTeamID:    homeTeamID,
+			AwayTeamID:    awayTeamID,
+		}
+		id, err := repo.CreateEvent(ctx, params)
+		require.NoError(t, err)
+
+		conflicts, err := repo.ListVenueConflicts(ctx, venueID, start.Add(time.Hour), start.Add(3*time.Hour), 0)
+		require.NoError(t, err)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, id, conflicts[0].ID)
+		assert.True(t, conflicts[0].EndDatetime.Equal(start.Add(2*time.Hour)))
+
+		conflicts, err = repo.ListVenueConflicts(ctx, venueID, start.Add(2*time.Hour), start.Add(4*time.Hour), 0)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+
+		conflicts, err = repo.ListVenueConflicts(ctx, venueID, start, start.Add(time.Hour), id)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+
+		overlapping := params
+		overlapping.EventDatetime = start.Add(time.Hour)
+		overlapping.EndDatetime = start.Add(3 * time.Hour)
+		_, err = repo.CreateEvent(ctx, overlapping)
+		assert.True(t, errors.Is(err, services.ErrVenueConflict))
+
+		overlapping.AllowVenueOverlap = true
+		_, err = repo.CreateEvent(ctx, overlapping)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ListEvents with overlapping date range", func(t *testing.T) {
+		start := time.Now().AddDate(0, 0, 30).UTC().Truncate(24 * time.Hour).Add(23 * time.Hour)
+		id, err := repo.CreateEvent(ctx, services.CreateEventParams{
+			EventDatetime: start,
+			EndDatetime:   start.Add(2 * time.Hour),
+			SportID:       sportID,
+			HomeTeamID:    homeTeamID,
+			AwayTeamID:    awayTeamID,
+		})
+		require.NoError(t, err)
+
+		nextDay := start.Add(time.Hour)
+		dayAfter := nextDay.AddDate(0, 0, 1)
+		events, err := repo.ListEvents(ctx, services.ListEventsParams{
+			DateFrom: &nextDay,
+			DateTo:   &dayAfter,
+			Limit:    10,
+		})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, id, events[0].ID)
+
+		count, err := repo.CountEvents(ctx, services.ListEventsParams{StartFrom: &nextDay, DateTo: &dayAfter})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("ListEvents near a point", func(t *testing.T) {
+		lat, lon := 48.2188, 16.3906
+		nearVenueID, err := venueRepo.CreateVenue(ctx, services.VenueRequest{
+			Name:        "Ernst Happel Stadion",
+			City:        "Vienna",
+			CountryCode: "AT",
+			Latitude:    &lat,
+			Longitude:   &lon,
+		})
+		require.NoError(t, err)
+		start := time.Now().AddDate(0, 0, 40).UTC().Truncate(time.Hour)
+		id, err := repo.CreateEvent(ctx, services.CreateEventParams{
+			EventDatetime: start,
+			EndDatetime:   start.Add(2 * time.Hour),
+			SportID:       sportID,
+			HomeTeamID:    homeTeamID,
+			AwayTeamID:    awayTeamID,
+			VenueID:       &nearVenueID,
+		})
+		require.NoError(t, err)
+
+		params := services.ListEventsParams{
+			Near:     &services.GeoPoint{Latitude: 48.2082, Longitude: 16.3738},
+			RadiusKm: 10,
+			Limit:    10,
+		}
+		events, err := repo.ListEvents(ctx, params)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, id, events[0].ID)
+		require.NotNil(t, events[0].Venue.Latitude)
+		assert.Equal(t, lat, *events[0].Venue.Latitude)
+
+		params.Near = &services.GeoPoint{Latitude: 47.0707, Longitude: 15.4395}
+		count, err := repo.CountEvents(ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("GetAttendanceStats", func(t *testing.T) {
+		capacity := 1000
+		arenaID, err := venueRepo.CreateVenue(ctx, services.VenueRequest{
+			Name:        "Attendance Arena",
+			City:        "Test City",
+			CountryCode: "US",
+			Capacity:    &capacity,
+		})
+		require.NoError(t, err)
+		start := time.Date(2025, 3, 1, 18, 0, 0, 0, time.UTC)
+		var ids []int
+		for i, attendance := range []int{800, 600, 950} {
+			kickoff := start.AddDate(0, 0, 7*i)
+			id, err := repo.CreateEvent(ctx, services.CreateEventParams{
+				EventDatetime: kickoff,
+				EndDatetime:   kickoff.Add(2 * time.Hour),
+				SportID:       sportID,
+				VenueID:       &arenaID,
+				HomeTeamID:    homeTeamID,
+				AwayTeamID:    awayTeamID,
+			})
+			require.NoError(t, err)
+			event, err := repo.GetEventByID(ctx, id)
+			require.NoError(t, err)
+			event.Attendance = &attendance
+			require.NoError(t, repo.UpdateEvent(ctx, *event))
+			ids = append(ids, id)
+		}
+
+		event, err := repo.GetEventByID(ctx, ids[0])
+		require.NoError(t, err)
+		require.NotNil(t, event.Attendance)
+		assert.Equal(t, 800, *event.Attendance)
+		require.NotNil(t, event.Venue.Capacity)
+		assert.Equal(t, capacity, *event.Venue.Capacity)
+
+		stats, err := repo.GetAttendanceStats(ctx, services.AttendanceStatsParams{VenueID: &arenaID})
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.EventCount)
+		assert.Equal(t, 2350, stats.TotalAttendance)
+		assert.InDelta(t, 783.33, stats.AverageAttendance, 0.01)
+		assert.Equal(t, 950, stats.PeakAttendance)
+		require.NotNil(t, stats.PeakEventID)
+		assert.Equal(t, ids[2], *stats.PeakEventID)
+		require.NotNil(t, stats.AverageUtilization)
+		assert.InDelta(t, 0.7833, *stats.AverageUtilization, 0.0001)
+
+		to := start.AddDate(0, 0, 8)
+		stats, err = repo.GetAttendanceStats(ctx, services.AttendanceStatsParams{TeamID: &awayTeamID, DateFrom: &start, DateTo: &to})
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.EventCount)
+		assert.Equal(t, 800, stats.PeakAttendance)
+
+		empty := start.AddDate(-1, 0, 0)
+		stats, err = repo.GetAttendanceStats(ctx, services.AttendanceStatsParams{VenueID: &arenaID, DateTo: &empty})
+		require.NoError(t, err)
+		assert.Equal(t, 0, stats.EventCount)
+		assert.Nil(t, stats.PeakEventID)
+	})
+
+	t.Run("ListTeamEvents", func(t *testing.T) {
+		start := time.Now().AddDate(0, 0, 20).UTC().Truncate(time.Hour)
+		var ids []int
+		for day := 0; day < 3; day++ {
+			id, err := repo.CreateEvent(ctx, services.CreateEventParams{
+				EventDatetime: start.AddDate(0, 0, day),
+				EndDatetime:   start.AddDate(0, 0, day).Add(2 * time.Hour),
+				SportID:       sportID,
+				HomeTeamID:    awayTeamID,
+				AwayTeamID:    homeTeamID,
+			})
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		from := start.Add(time.Hour)
+		to := start.AddDate(0, 0, 1).Add(time.Hour)
+		events, err := repo.ListTeamEvents(ctx, homeTeamID, &from, &to)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, ids[0], events[0].ID)
+		assert.Equal(t, ids[1], events[1].ID)
+		assert.Equal(t, services.RestPolicyReject, events[0].Sport.RestConflictPolicy)
+
+		events, err = repo.ListTeamEvents(ctx, awayTeamID, nil, nil)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, len(events), 3)
+		for i := 1; i < len(events); i++ {
+			assert.False(t, events[i].EventDatetime.Before(events[i-1].EventDatetime))
+		}
+	})
 }
 
@@ -0,0 +1,51 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+type EventRescheduleRepository struct {
+	db dbtx
+}
+
+func NewEventRescheduleRepository(db *sqlx.DB) *EventRescheduleRepository {
+	return &EventRescheduleRepository{db: db}
+}
+
+func (r *EventRescheduleRepository) RecordReschedule(ctx context.Context, reschedule services.EventReschedule) error {
+	query := `
+	INSERT INTO event_reschedules(
+		_event_id, previous_datetime, new_datetime, _previous_venue_id, _new_venue_id, reason)
+	VALUES($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		reschedule.EventID,
+		reschedule.PreviousDatetime,
+		reschedule.NewDatetime,
+		reschedule.PreviousVenueID,
+		reschedule.NewVenueID,
+		reschedule.Reason,
+	)
+	return err
+}
+
+func (r *EventRescheduleRepository) ListReschedules(ctx context.Context, eventID int) ([]services.EventReschedule, error) {
+	query := `
+	SELECT id, _event_id, previous_datetime, new_datetime, _previous_venue_id, _new_venue_id, reason, changed_at
+	FROM event_reschedules
+	WHERE _event_id = $1
+	ORDER BY changed_at ASC, id ASC`
+	var dbModels []eventRescheduleDBModel
+
+	if err := r.db.SelectContext(ctx, &dbModels, query, eventID); err != nil {
+		return nil, err
+	}
+	reschedules := make([]services.EventReschedule, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		reschedules = append(reschedules, toServiceEventReschedule(dbModel))
+	}
+	return reschedules, nil
+}
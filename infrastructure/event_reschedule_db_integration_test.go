@@ -0,0 +1,129 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestEventRescheduleRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	repo := NewEventRescheduleRepository(db)
+	eventRepo := NewEventRepository(db)
+	ctx := context.Background()
+
+	sportID, err := NewSportRepository(db).CreateSport(ctx, services.SportRequest{Name: "Test Football"})
+	require.NoError(t, err)
+
+	teamRepo := NewTeamRepository(db)
+	homeTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{
+		Name:    "Home Team",
+		City:    "Home City",
+		SportID: sportID,
+	})
+	require.NoError(t, err)
+	awayTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{
+		Name:    "Away Team",
+		City:    "Away City",
+		SportID: sportID,
+	})
+	require.NoError(t, err)
+
+	venueID, err := NewVenueRepository(db).CreateVenue(ctx, services.VenueRequest{
+		Name:        "Test Venue",
+		City:        "Test City",
+		CountryCode: "AT",
+	})
+	require.NoError(t, err)
+
+	original := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	eventID, err := eventRepo.CreateEvent(ctx, services.CreateEventParams{
+		EventDatetime: original,
+		EndDatetime:   original.Add(2 * time.Hour),
+		SportID:       sportID,
+		HomeTeamID:    homeTeamID,
+		AwayTeamID:    awayTeamID,
+	})
+	require.NoError(t, err)
+
+	t.Run("event without reschedules", func(t *testing.T) {
+		event, err := eventRepo.GetEventByID(ctx, eventID)
+		require.NoError(t, err)
+		assert.Nil(t, event.OriginalDatetime)
+
+		reschedules, err := repo.ListReschedules(ctx, eventID)
+		require.NoError(t, err)
+		assert.Empty(t, reschedules)
+	})
+
+	t.Run("RecordReschedule and ListReschedules", func(t *testing.T) {
+		reason := "Waterlogged pitch"
+		moved := original.Add(48 * time.Hour)
+
+		// A venue-only change does not count as a moved kickoff.
+		err := repo.RecordReschedule(ctx, services.EventReschedule{
+			EventID:          eventID,
+			PreviousDatetime: original,
+			NewDatetime:      original,
+			NewVenueID:       &venueID,
+		})
+		require.NoError(t, err)
+		event, err := eventRepo.GetEventByID(ctx, eventID)
+		require.NoError(t, err)
+		assert.Nil(t, event.OriginalDatetime)
+
+		err = repo.RecordReschedule(ctx, services.EventReschedule{
+			EventID:          eventID,
+			PreviousDatetime: original,
+			NewDatetime:      moved,
+			PreviousVenueID:  &venueID,
+			NewVenueID:       &venueID,
+			Reason:           &reason,
+		})
+		require.NoError(t, err)
+		err = repo.RecordReschedule(ctx, services.EventReschedule{
+			EventID:          eventID,
+			PreviousDatetime: moved,
+			NewDatetime:      moved.Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		reschedules, err := repo.ListReschedules(ctx, eventID)
+		require.NoError(t, err)
+		require.Len(t, reschedules, 3)
+		assert.Nil(t, reschedules[0].PreviousVenueID)
+		assert.Equal(t, venueID, *reschedules[0].NewVenueID)
+		assert.Equal(t, reason, *reschedules[1].Reason)
+		assert.True(t, reschedules[1].NewDatetime.Equal(moved))
+		assert.Nil(t, reschedules[2].Reason)
+
+		event, err = eventRepo.GetEventByID(ctx, eventID)
+		require.NoError(t, err)
+		require.NotNil(t, event.OriginalDatetime)
+		assert.True(t, event.OriginalDatetime.Equal(original))
+	})
+
+	t.Run("history is removed with the event", func(t *testing.T) {
+		require.NoError(t, eventRepo.DeleteEvent(ctx, eventID))
+
+		reschedules, err := repo.ListReschedules(ctx, eventID)
+		require.NoError(t, err)
+		assert.Empty(t, reschedules)
+	})
+}
@@ -4,21 +4,45 @@ const baseEventSelectQuery = `
 SELECT
     e.id,
     e.event_datetime,
+    e.end_datetime,
     e.description,
     e.home_score,
     e.away_score,
+    e.attendance,
+    e._series_id AS series_id,
+    e.allow_venue_overlap,
+    e.deleted_at,
+    e.version,
+    e.created_at,
+    e.updated_at,
+    (SELECT r.previous_datetime FROM event_reschedules r
+        WHERE r._event_id = e.id AND r.previous_datetime <> r.new_datetime
+        ORDER BY r.changed_at ASC, r.id ASC LIMIT 1) AS original_datetime,
     s.id AS "sport.id",
     s.name AS "sport.name",
+    s.default_duration_minutes AS "sport.default_duration_minutes",
+    s.min_rest_minutes AS "sport.min_rest_minutes",
+    s.rest_conflict_policy AS "sport.rest_conflict_policy",
     v.id AS "venue.id",
     v.name AS "venue.name",
     v.city AS "venue.city",
     v.country_code AS "venue.country_code",
+    v.time_zone AS "venue.time_zone",
+    v.address AS "venue.address",
+    v.postal_code AS "venue.postal_code",
+    v.latitude AS "venue.latitude",
+    v.longitude AS "venue.longitude",
+    v.capacity AS "venue.capacity",
     ht.id AS "ht.id",
     ht.name AS "ht.name",
     ht.city AS "ht.city",
+    ht.short_name AS "ht.short_name",
+    ht.code AS "ht.code",
     at.id AS "at.id",
     at.name AS "at.name",
-    at.city AS "at.city"
+    at.city AS "at.city",
+    at.short_name AS "at.short_name",
+    at.code AS "at.code"
 FROM events e
 JOIN sports s ON e._sport_id = s.id
 LEFT JOIN venues v ON e._venue_id = v.id
@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// kmPerDegreeLatitude is the length of one degree of latitude, used to
+// narrow nearby searches to a latitude band the coordinates index can serve.
+const kmPerDegreeLatitude = 111.2
+
+// haversineKmSQL returns an SQL expression for the great-circle distance in
+// kilometres between the coordinate columns and the point bound to latParam
+// and lonParam.
+func haversineKmSQL(latColumn, lonColumn, latParam, lonParam string) string {
+	latParam, lonParam = float8Param(latParam), float8Param(lonParam)
+	return fmt.Sprintf(
+		"(%g * 2 * ASIN(LEAST(1, SQRT("+
+			"POWER(SIN(RADIANS(%s - %s) / 2), 2) + "+
+			"COS(RADIANS(%s)) * COS(RADIANS(%s)) * POWER(SIN(RADIANS(%s - %s) / 2), 2)))))",
+		services.EarthRadiusKm,
+		latColumn, latParam,
+		latParam, latColumn, lonColumn, lonParam,
+	)
+}
+
+// latitudeBandSQL returns a condition keeping only rows whose latitude is
+// within radiusParam kilometres of latParam.
+func latitudeBandSQL(latColumn, latParam, radiusParam string) string {
+	latParam, radiusParam = float8Param(latParam), float8Param(radiusParam)
+	return fmt.Sprintf("%s BETWEEN %s - %s / %g AND %s + %s / %g",
+		latColumn, latParam, radiusParam, kmPerDegreeLatitude,
+		latParam, radiusParam, kmPerDegreeLatitude)
+}
+
+// float8Param casts a placeholder so that PostgreSQL does not infer numeric
+// from the surrounding arithmetic.
+func float8Param(param string) string {
+	return param + "::float8"
+}
@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// lastModifiedQueries select the newest change to the records of each entity
+// type, deleted records included: a soft delete touches updated_at, and the
+// rows purged later were out of every list already. A series also changes
+// with its exception dates, which are only ever added.
+var lastModifiedQueries = map[string]string{
+	services.AuditEntityEvent: `SELECT MAX(updated_at) FROM events`,
+	services.AuditEntitySport: `SELECT MAX(updated_at) FROM sports`,
+	services.AuditEntityTeam:  `SELECT MAX(updated_at) FROM teams`,
+	services.AuditEntityVenue: `SELECT MAX(updated_at) FROM venues`,
+	services.LastModifiedSeries: `
+	SELECT MAX(changed_at) FROM (
+		SELECT MAX(updated_at) AS changed_at FROM event_series
+		UNION ALL
+		SELECT MAX(created_at) FROM event_series_exceptions
+	) AS changes`,
+}
+
+type LastModifiedRepository struct {
+	db dbtx
+}
+
+func NewLastModifiedRepository(db *sqlx.DB) *LastModifiedRepository {
+	return &LastModifiedRepository{db: db}
+}
+
+func (r *LastModifiedRepository) LastModified(ctx context.Context, entityType string) (time.Time, error) {
+	var lastModified sql.NullTime
+
+	query, ok := lastModifiedQueries[entityType]
+	if !ok {
+		return time.Time{}, fmt.Errorf("unknown entity type %q", entityType)
+	}
+	if err := r.db.GetContext(ctx, &lastModified, query); err != nil {
+		return time.Time{}, err
+	}
+	return lastModified.Time, nil
+}
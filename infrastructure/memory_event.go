@@ -0,0 +1,408 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// MemoryEventRepository is the in-memory EventRepository. Its events have no
+// broadcasts, so a BroadcastCountry filter selects none of them.
+type MemoryEventRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryEventRepository(store *MemoryStore) *MemoryEventRepository {
+	return &MemoryEventRepository{store: store}
+}
+
+func (r *MemoryEventRepository) GetEventByID(ctx context.Context, id int) (*services.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row, ok := r.store.events[id]
+	if !ok || !visible(ctx, row.event.DeletedAt) {
+		return nil, sql.ErrNoRows
+	}
+	event := r.store.joinEvent(row)
+	return &event, nil
+}
+
+func (r *MemoryEventRepository) CreateEvent(ctx context.Context, params services.CreateEventParams) (int, error) {
+	row := memoryEvent{
+		event: services.Event{
+			EventDatetime:     memoryTime(params.EventDatetime),
+			EndDatetime:       memoryTime(params.EndDatetime),
+			Description:       clonePtr(params.Description),
+			SeriesID:          clonePtr(params.SeriesID),
+			AllowVenueOverlap: params.AllowVenueOverlap,
+			Version:           1,
+		},
+		sportID:    params.SportID,
+		venueID:    clonePtr(params.VenueID),
+		homeTeamID: params.HomeTeamID,
+		awayTeamID: params.AwayTeamID,
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.checkEvent(row); err != nil {
+		return 0, translateEventWriteError(err)
+	}
+	row.event.ID = r.store.nextID("events")
+	row.event.CreatedAt = memoryNow()
+	row.event.UpdatedAt = row.event.CreatedAt
+	r.store.events[row.event.ID] = row
+	return row.event.ID, nil
+}
+
+func (r *MemoryEventRepository) CountEvents(ctx context.Context, params services.ListEventsParams) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return len(r.store.filterEvents(ctx, params)), nil
+}
+
+// ListEvents returns a page of the events matching params, ordered by
+// kickoff; a Limit of 0 selects none, as LIMIT 0 does.
+func (r *MemoryEventRepository) ListEvents(ctx context.Context,
+	params services.ListEventsParams) ([]services.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rows := r.store.filterEvents(ctx, params)
+	start := min(max(params.Offset, 0), len(rows))
+	end := min(start+max(params.Limit, 0), len(rows))
+	return r.store.joinEvents(rows[start:end]), nil
+}
+
+// filterEvents returns the events matching params as buildEventFilter
+// selects them, ordered by kickoff.
+func (s *MemoryStore) filterEvents(ctx context.Context, params services.ListEventsParams) []memoryEvent {
+	return sortedValues(s.events, func(row memoryEvent) bool {
+		event := row.event
+		switch {
+		case !visible(ctx, event.DeletedAt):
+			return false
+		case params.SportID != nil && row.sportID != *params.SportID:
+			return false
+		case params.SeriesID != nil && !equalPtr(event.SeriesID, params.SeriesID):
+			return false
+		case params.DateFrom != nil && !event.EndDatetime.After(*params.DateFrom):
+			return false
+		case params.StartFrom != nil && event.EventDatetime.Before(*params.StartFrom):
+			return false
+		case params.DateTo != nil && !event.EventDatetime.Before(*params.DateTo):
+			return false
+		case params.BroadcastCountry != nil:
+			return false
+		}
+		if params.Near != nil {
+			if row.venueID == nil {
+				return false
+			}
+			venue, ok := s.venues[*row.venueID]
+			return ok && withinRadius(venue, *params.Near, params.RadiusKm)
+		}
+		return true
+	}, byKickoff)
+}
+
+// UpdateEvent saves an event read at event.Version; an update by someone
+// else in between makes it fail with a VersionMismatchError.
+func (r *MemoryEventRepository) UpdateEvent(ctx context.Context, event services.Event) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.events[event.ID]
+	if apply, err := applyUpdate(services.AuditEntityEvent, event.ID, ok, row.event.Version, event.Version); !apply {
+		return err
+	}
+	row.event.EventDatetime = memoryTime(event.EventDatetime)
+	row.event.EndDatetime = memoryTime(event.EndDatetime)
+	row.event.Description = clonePtr(event.Description)
+	row.event.HomeScore = clonePtr(event.HomeScore)
+	row.event.AwayScore = clonePtr(event.AwayScore)
+	row.event.AllowVenueOverlap = event.AllowVenueOverlap
+	row.event.Attendance = clonePtr(event.Attendance)
+	row.sportID = event.Sport.ID
+	row.venueID = nil
+	if event.Venue.ID != 0 {
+		venueID := event.Venue.ID
+		row.venueID = &venueID
+	}
+	row.homeTeamID = event.HomeTeam.ID
+	row.awayTeamID = event.AwayTeam.ID
+	if err := r.store.checkEvent(row); err != nil {
+		return translateEventWriteError(err)
+	}
+	row.event = touchEvent(row.event)
+	r.store.events[event.ID] = row
+	return nil
+}
+
+func (r *MemoryEventRepository) DeleteEvent(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if row, ok := r.store.events[id]; ok && row.event.DeletedAt == nil {
+		deletedAt := memoryNow()
+		row.event.DeletedAt = &deletedAt
+		row.event = touchEvent(row.event)
+		r.store.events[id] = row
+	}
+	return nil
+}
+
+// RestoreEvent undeletes an event. Taking back a venue slot booked since
+// fails with ErrVenueConflict.
+func (r *MemoryEventRepository) RestoreEvent(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.events[id]
+	if !ok || row.event.DeletedAt == nil {
+		return sql.ErrNoRows
+	}
+	row.event.DeletedAt = nil
+	if err := checkVenueBooking(r.store.events, row); err != nil {
+		return translateEventWriteError(err)
+	}
+	row.event = touchEvent(row.event)
+	r.store.events[id] = row
+	return nil
+}
+
+// ListVenueConflicts returns the events at the venue overlapping the
+// half-open interval start..end, except the excluded event.
+func (r *MemoryEventRepository) ListVenueConflicts(ctx context.Context, venueID int,
+	start, end time.Time, excludeEventID int) ([]services.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rows := sortedValues(r.store.events, func(row memoryEvent) bool {
+		return row.event.DeletedAt == nil && row.event.ID != excludeEventID &&
+			row.venueID != nil && *row.venueID == venueID &&
+			row.event.EventDatetime.Before(end) && row.event.EndDatetime.After(start)
+	}, byKickoff)
+	return r.store.joinEvents(rows), nil
+}
+
+// ListTeamEvents returns the events a team plays in, ordered by kickoff,
+// deleted ones only under WithDeleted. When given, from and to restrict them
+// to events overlapping from..to.
+func (r *MemoryEventRepository) ListTeamEvents(ctx context.Context, teamID int,
+	from, to *time.Time) ([]services.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rows := sortedValues(r.store.events, func(row memoryEvent) bool {
+		return visible(ctx, row.event.DeletedAt) && (row.homeTeamID == teamID || row.awayTeamID == teamID) &&
+			(from == nil || row.event.EndDatetime.After(*from)) &&
+			(to == nil || row.event.EventDatetime.Before(*to))
+	}, byKickoff)
+	return r.store.joinEvents(rows), nil
+}
+
+func (r *MemoryEventRepository) CountEventsBySportID(ctx context.Context, sportID int) (int, error) {
+	return r.countEvents(func(row memoryEvent) bool { return row.sportID == sportID }), nil
+}
+
+func (r *MemoryEventRepository) CountEventsByVenueId(ctx context.Context, venueID int) (int, error) {
+	return r.countEvents(func(row memoryEvent) bool { return row.venueID != nil && *row.venueID == venueID }), nil
+}
+
+func (r *MemoryEventRepository) CountEventsByTeamID(ctx context.Context, teamID int) (int, error) {
+	return r.countEvents(func(row memoryEvent) bool { return row.homeTeamID == teamID || row.awayTeamID == teamID }), nil
+}
+
+// countEvents counts the events that are not deleted and match.
+func (r *MemoryEventRepository) countEvents(match func(memoryEvent) bool) int {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, row := range r.store.events {
+		if row.event.DeletedAt == nil && match(row) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetAttendanceStats aggregates the events with a recorded attendance
+// selected by params. The peak event is the earliest one with the highest
+// attendance.
+func (r *MemoryEventRepository) GetAttendanceStats(ctx context.Context,
+	params services.AttendanceStatsParams) (*services.AttendanceStats, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	stats := &services.AttendanceStats{}
+	var peak *memoryEvent
+	utilization, utilized := 0.0, 0
+	for _, row := range r.store.events {
+		event := row.event
+		switch {
+		case event.Attendance == nil || event.DeletedAt != nil:
+			continue
+		case params.VenueID != nil && !equalPtr(row.venueID, params.VenueID):
+			continue
+		case params.TeamID != nil && row.homeTeamID != *params.TeamID && row.awayTeamID != *params.TeamID:
+			continue
+		case params.DateFrom != nil && event.EventDatetime.Before(*params.DateFrom):
+			continue
+		case params.DateTo != nil && !event.EventDatetime.Before(*params.DateTo):
+			continue
+		}
+		stats.EventCount++
+		stats.TotalAttendance += *event.Attendance
+		if peak == nil || *event.Attendance > *peak.event.Attendance ||
+			(*event.Attendance == *peak.event.Attendance && byKickoff(row, *peak)) {
+			peak = &row
+		}
+		if row.venueID != nil {
+			if venue := r.store.venues[*row.venueID]; venue.Capacity != nil {
+				utilization += float64(*event.Attendance) / float64(*venue.Capacity)
+				utilized++
+			}
+		}
+	}
+	if stats.EventCount == 0 {
+		return stats, nil
+	}
+	stats.AverageAttendance = float64(stats.TotalAttendance) / float64(stats.EventCount)
+	stats.PeakAttendance = *peak.event.Attendance
+	stats.PeakEventID = &peak.event.ID
+	if utilized > 0 {
+		average := utilization / float64(utilized)
+		stats.AverageUtilization = &average
+	}
+	return stats, nil
+}
+
+// checkEvent enforces the constraints of the events table on a row about to
+// be written, except the series foreign key.
+func (s *MemoryStore) checkEvent(row memoryEvent) error {
+	switch {
+	case row.homeTeamID == row.awayTeamID:
+		return checkViolation("events", "check_teams_not_equal")
+	case !row.event.EndDatetime.After(row.event.EventDatetime):
+		return checkViolation("events", "check_end_after_start")
+	case row.event.Attendance != nil && *row.event.Attendance < 0:
+		return checkViolation("events", "check_attendance_not_negative")
+	}
+	if err := checkVenueBooking(s.events, row); err != nil {
+		return err
+	}
+	if _, ok := s.sports[row.sportID]; !ok {
+		return foreignKeyViolation("events", "fk_sport")
+	}
+	if row.venueID != nil {
+		if _, ok := s.venues[*row.venueID]; !ok {
+			return foreignKeyViolation("events", "fk_venue")
+		}
+	}
+	if _, ok := s.teams[row.homeTeamID]; !ok {
+		return foreignKeyViolation("events", "fk_home_team")
+	}
+	if _, ok := s.teams[row.awayTeamID]; !ok {
+		return foreignKeyViolation("events", "fk_away_team")
+	}
+	return nil
+}
+
+// checkVenueBooking enforces the no_venue_double_booking exclusion
+// constraint for row among events.
+func checkVenueBooking(events map[int]memoryEvent, row memoryEvent) error {
+	if !booksVenue(row) {
+		return nil
+	}
+	for _, other := range events {
+		if other.event.ID != row.event.ID && booksVenue(other) && *other.venueID == *row.venueID &&
+			other.event.EventDatetime.Before(row.event.EndDatetime) &&
+			row.event.EventDatetime.Before(other.event.EndDatetime) {
+			return exclusionViolation("events", "no_venue_double_booking")
+		}
+	}
+	return nil
+}
+
+// booksVenue reports whether an event holds its venue exclusively.
+func booksVenue(row memoryEvent) bool {
+	return row.venueID != nil && !row.event.AllowVenueOverlap && row.event.DeletedAt == nil
+}
+
+// joinEvent fills in the sport, venue and teams of an event as
+// baseEventSelectQuery joins them.
+func (s *MemoryStore) joinEvent(row memoryEvent) services.Event {
+	event := row.event
+	event.Description = clonePtr(event.Description)
+	event.HomeScore = clonePtr(event.HomeScore)
+	event.AwayScore = clonePtr(event.AwayScore)
+	event.Attendance = clonePtr(event.Attendance)
+	event.SeriesID = clonePtr(event.SeriesID)
+	event.DeletedAt = clonePtr(event.DeletedAt)
+
+	sport := s.sports[row.sportID]
+	event.Sport = services.Sport{
+		ID:                     sport.ID,
+		Name:                   sport.Name,
+		DefaultDurationMinutes: sport.DefaultDurationMinutes,
+		MinRestMinutes:         sport.MinRestMinutes,
+		RestConflictPolicy:     sport.RestConflictPolicy,
+	}
+	if row.venueID != nil {
+		venue := cloneVenue(s.venues[*row.venueID])
+		event.Venue = services.Venue{
+			ID:          venue.ID,
+			Name:        venue.Name,
+			City:        venue.City,
+			CountryCode: venue.CountryCode,
+			TimeZone:    venue.TimeZone,
+			Address:     venue.Address,
+			PostalCode:  venue.PostalCode,
+			Latitude:    venue.Latitude,
+			Longitude:   venue.Longitude,
+			Capacity:    venue.Capacity,
+		}
+	}
+	event.HomeTeam = eventTeam(s.teams[row.homeTeamID])
+	event.AwayTeam = eventTeam(s.teams[row.awayTeamID])
+	return event
+}
+
+func (s *MemoryStore) joinEvents(rows []memoryEvent) []services.Event {
+	events := make([]services.Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, s.joinEvent(row))
+	}
+	return events
+}
+
+// eventTeam is the part of a team an event carries.
+func eventTeam(team services.Team) services.Team {
+	return services.Team{
+		ID:        team.ID,
+		Name:      team.Name,
+		City:      team.City,
+		ShortName: clonePtr(team.ShortName),
+		Code:      clonePtr(team.Code),
+	}
+}
+
+// byKickoff orders events by kickoff, then id.
+func byKickoff(a, b memoryEvent) bool {
+	if !a.event.EventDatetime.Equal(b.event.EventDatetime) {
+		return a.event.EventDatetime.Before(b.event.EventDatetime)
+	}
+	return a.event.ID < b.event.ID
+}
+
+func touchEvent(event services.Event) services.Event {
+	event.Version++
+	event.UpdatedAt = memoryNow()
+	return event
+}
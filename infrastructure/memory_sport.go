@@ -0,0 +1,153 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// MemorySportRepository is the in-memory SportRepository.
+type MemorySportRepository struct {
+	store *MemoryStore
+}
+
+func NewMemorySportRepository(store *MemoryStore) *MemorySportRepository {
+	return &MemorySportRepository{store: store}
+}
+
+func (r *MemorySportRepository) CreateSport(ctx context.Context, params services.SportRequest) (int, error) {
+	sport := services.Sport{
+		Name:                   params.Name,
+		DefaultDurationMinutes: services.DefaultEventDurationMinutes,
+		RestConflictPolicy:     services.RestPolicyReject,
+		Version:                1,
+	}
+	if params.DefaultDurationMinutes != nil {
+		sport.DefaultDurationMinutes = *params.DefaultDurationMinutes
+	}
+	if params.MinRestMinutes != nil {
+		sport.MinRestMinutes = *params.MinRestMinutes
+	}
+	if params.RestConflictPolicy != nil {
+		sport.RestConflictPolicy = *params.RestConflictPolicy
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.checkSport(sport); err != nil {
+		return 0, err
+	}
+	sport.ID = r.store.nextID("sports")
+	sport.CreatedAt = memoryNow()
+	sport.UpdatedAt = sport.CreatedAt
+	r.store.sports[sport.ID] = sport
+	return sport.ID, nil
+}
+
+func (r *MemorySportRepository) GetSportById(ctx context.Context, id int) (*services.Sport, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	sport, ok := r.store.sports[id]
+	if !ok || !visible(ctx, sport.DeletedAt) {
+		return nil, sql.ErrNoRows
+	}
+	sport = cloneSport(sport)
+	return &sport, nil
+}
+
+func (r *MemorySportRepository) ListSports(ctx context.Context) ([]services.Sport, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rows := sortedValues(r.store.sports,
+		func(sport services.Sport) bool { return visible(ctx, sport.DeletedAt) },
+		func(a, b services.Sport) bool { return lessName(a.Name, b.Name) })
+	sports := make([]services.Sport, 0, len(rows))
+	for _, sport := range rows {
+		sports = append(sports, cloneSport(sport))
+	}
+	return sports, nil
+}
+
+// UpdateSport saves a sport, conditionally on a non-zero sport.Version as
+// SportRepository.UpdateSport does. A missing or soft-deleted sport is
+// sql.ErrNoRows.
+func (r *MemorySportRepository) UpdateSport(ctx context.Context, sport services.Sport) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.sports[sport.ID]
+	if !ok || current.DeletedAt != nil {
+		return sql.ErrNoRows
+	}
+	if apply, err := applyUpdate(services.AuditEntitySport, sport.ID, ok, current.Version, sport.Version); !apply {
+		return err
+	}
+	current.Name = sport.Name
+	current.DefaultDurationMinutes = sport.DefaultDurationMinutes
+	current.MinRestMinutes = sport.MinRestMinutes
+	current.RestConflictPolicy = sport.RestConflictPolicy
+	if err := r.store.checkSport(current); err != nil {
+		return err
+	}
+	r.store.sports[sport.ID] = touchSport(current)
+	return nil
+}
+
+func (r *MemorySportRepository) DeleteSport(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if sport, ok := r.store.sports[id]; ok && sport.DeletedAt == nil {
+		deletedAt := memoryNow()
+		sport.DeletedAt = &deletedAt
+		r.store.sports[id] = touchSport(sport)
+	}
+	return nil
+}
+
+func (r *MemorySportRepository) RestoreSport(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	sport, ok := r.store.sports[id]
+	if !ok || sport.DeletedAt == nil {
+		return sql.ErrNoRows
+	}
+	sport.DeletedAt = nil
+	r.store.sports[id] = touchSport(sport)
+	return nil
+}
+
+// checkSport enforces the constraints of the sports table on a row about to
+// be written.
+func (s *MemoryStore) checkSport(sport services.Sport) error {
+	switch {
+	case sport.DefaultDurationMinutes <= 0:
+		return checkViolation("sports", "check_default_duration_positive")
+	case sport.MinRestMinutes < 0:
+		return checkViolation("sports", "check_min_rest_not_negative")
+	case sport.RestConflictPolicy != services.RestPolicyReject && sport.RestConflictPolicy != services.RestPolicyWarn:
+		return checkViolation("sports", "check_rest_conflict_policy")
+	}
+	for _, other := range s.sports {
+		if other.ID != sport.ID && other.Name == sport.Name {
+			return uniqueViolation("sports", "sports_name_key")
+		}
+	}
+	return nil
+}
+
+// touchSport does what the update triggers of the sports table do.
+func touchSport(sport services.Sport) services.Sport {
+	sport.Version++
+	sport.UpdatedAt = memoryNow()
+	return sport
+}
+
+func cloneSport(sport services.Sport) services.Sport {
+	sport.DeletedAt = clonePtr(sport.DeletedAt)
+	return sport
+}
@@ -0,0 +1,185 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vsennikov/sports-event-calendar/services"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MemoryStore holds the rows of the in-memory repositories, which behave like
+// the PostgreSQL ones: the same filters, ordering and paging, the same
+// versions and soft deletes, and constraint violations reported as the
+// *pgconn.PgError the database would return. Repositories sharing a store see
+// each other's rows, so foreign keys are checked across them.
+//
+// The store keeps no series, broadcasts, reschedules, change history or audit
+// log: series ids of events are not checked, events are never broadcast and
+// never carry an original kickoff. Names are ordered ignoring case and
+// accents, which approximates the database collation.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	sports map[int]services.Sport
+	venues map[int]services.Venue
+	teams  map[int]services.Team
+	events map[int]memoryEvent
+	lastID map[string]int
+}
+
+// memoryEvent is an event row; its sport, venue and teams are only
+// referenced by id and joined in on reads.
+type memoryEvent struct {
+	event      services.Event
+	sportID    int
+	venueID    *int
+	homeTeamID int
+	awayTeamID int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sports: map[int]services.Sport{},
+		venues: map[int]services.Venue{},
+		teams:  map[int]services.Team{},
+		events: map[int]memoryEvent{},
+		lastID: map[string]int{},
+	}
+}
+
+// nextID hands out the ids of a table like its serial column.
+func (s *MemoryStore) nextID(table string) int {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+// memoryNow is the time written to timestamp columns, at the precision
+// PostgreSQL keeps.
+func memoryNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// memoryTime stores t at the precision PostgreSQL keeps.
+func memoryTime(t time.Time) time.Time {
+	return t.Truncate(time.Microsecond)
+}
+
+// visible reports whether a row deleted at deletedAt is read under ctx.
+func visible(ctx context.Context, deletedAt *time.Time) bool {
+	return deletedAt == nil || services.IncludeDeleted(ctx)
+}
+
+// applyUpdate reports whether an update of the row id, found when ok, takes
+// effect, failing as execVersioned does when a non-zero version does not
+// match the row's current one.
+func applyUpdate(entity string, id int, ok bool, current, version int) (bool, error) {
+	switch {
+	case !ok && version == 0:
+		return false, nil
+	case !ok:
+		return false, sql.ErrNoRows
+	case version != 0 && version != current:
+		return false, &services.VersionMismatchError{Entity: entity, ID: id, CurrentVersion: current}
+	}
+	return true, nil
+}
+
+func uniqueViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           "23505",
+		Message:        fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+func foreignKeyViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           "23503",
+		Message:        fmt.Sprintf("insert or update on table %q violates foreign key constraint %q", table, constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+func checkViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           "23514",
+		Message:        fmt.Sprintf("new row for relation %q violates check constraint %q", table, constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+func exclusionViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           "23P01",
+		Message:        fmt.Sprintf("conflicting key value violates exclusion constraint %q", constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+// foldName folds a name as lower(search_unaccent(name)) does in the database.
+func foldName(name string) string {
+	return strings.ToLower(unaccent(name))
+}
+
+// unaccent strips the accents off s as search_unaccent does in the database.
+func unaccent(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case r == 'ß':
+			b.WriteString("ss")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// lessName orders names ignoring case and accents, falling back to their
+// bytes.
+func lessName(a, b string) bool {
+	if fa, fb := foldName(a), foldName(b); fa != fb {
+		return fa < fb
+	}
+	return a < b
+}
+
+// sortedValues returns the rows kept by keep, ordered by less.
+func sortedValues[T any](rows map[int]T, keep func(T) bool, less func(a, b T) bool) []T {
+	values := make([]T, 0, len(rows))
+	for _, row := range rows {
+		if keep(row) {
+			values = append(values, row)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return less(values[i], values[j]) })
+	return values
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	return a != nil && b != nil && *a == *b
+}
@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/infrastructure/repotest"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestMemoryRepositories_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		store := NewMemoryStore()
+		return repotest.Repositories{
+			Sports: NewMemorySportRepository(store),
+			Teams:  NewMemoryTeamRepository(store),
+			Venues: NewMemoryVenueRepository(store),
+			Events: NewMemoryEventRepository(store),
+		}
+	})
+}
+
+func TestMemoryStore_ConcurrentWrites(t *testing.T) {
+	sports := NewMemorySportRepository(NewMemoryStore())
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	ids := make([]int, 50)
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := sports.CreateSport(ctx, services.SportRequest{Name: fmt.Sprintf("Sport %02d", i)})
+			assert.NoError(t, err)
+			ids[i] = id
+			_, err = sports.ListSports(ctx)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	listed, err := sports.ListSports(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed, len(ids))
+	sort.Ints(ids)
+	for i, id := range ids {
+		assert.Equal(t, i+1, id, "ids are handed out once each")
+	}
+}
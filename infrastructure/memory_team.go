@@ -0,0 +1,265 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// MemoryTeamRepository is the in-memory TeamRepository.
+type MemoryTeamRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryTeamRepository(store *MemoryStore) *MemoryTeamRepository {
+	return &MemoryTeamRepository{store: store}
+}
+
+func (r *MemoryTeamRepository) CreateTeam(ctx context.Context, params services.TeamRequest) (int, error) {
+	team := services.Team{
+		Name:      params.Name,
+		City:      params.City,
+		SportID:   params.SportID,
+		ShortName: clonePtr(params.ShortName),
+		Code:      clonePtr(params.Code),
+		Aliases:   append([]string{}, params.Aliases...),
+		Version:   1,
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.checkTeam(r.store.teams, team); err != nil {
+		return 0, err
+	}
+	team.ID = r.store.nextID("teams")
+	team.CreatedAt = memoryNow()
+	team.UpdatedAt = team.CreatedAt
+	r.store.teams[team.ID] = team
+	return team.ID, nil
+}
+
+func (r *MemoryTeamRepository) GetTeamByID(ctx context.Context, id int) (*services.Team, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	team, ok := r.store.teams[id]
+	if !ok || !visible(ctx, team.DeletedAt) {
+		return nil, sql.ErrNoRows
+	}
+	team = cloneTeam(team)
+	return &team, nil
+}
+
+func (r *MemoryTeamRepository) ListTeams(ctx context.Context) ([]services.Team, error) {
+	return r.selectTeams(func(team services.Team) bool { return visible(ctx, team.DeletedAt) }), nil
+}
+
+func (r *MemoryTeamRepository) CountTeamsBySportID(ctx context.Context, sportID int) (int, error) {
+	teams := r.selectTeams(func(team services.Team) bool { return team.DeletedAt == nil && team.SportID == sportID })
+	return len(teams), nil
+}
+
+// FindTeamsByName returns the teams whose name, short name, code or one of
+// whose aliases equals name, ignoring case and accents (case only for codes).
+func (r *MemoryTeamRepository) FindTeamsByName(ctx context.Context, name string, sportID *int) ([]services.Team, error) {
+	folded := foldName(name)
+	matches := func(team services.Team) bool {
+		if !visible(ctx, team.DeletedAt) || (sportID != nil && team.SportID != *sportID) {
+			return false
+		}
+		if foldName(team.Name) == folded ||
+			(team.ShortName != nil && foldName(*team.ShortName) == folded) ||
+			(team.Code != nil && strings.ToLower(*team.Code) == strings.ToLower(name)) {
+			return true
+		}
+		for _, alias := range team.Aliases {
+			if foldName(alias) == folded {
+				return true
+			}
+		}
+		return false
+	}
+	return r.selectTeams(matches), nil
+}
+
+// UpdateTeam saves a team and replaces its aliases, unless the team has
+// moved past a non-zero team.Version.
+func (r *MemoryTeamRepository) UpdateTeam(ctx context.Context, team services.Team) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.teams[team.ID]
+	apply, err := applyUpdate(services.AuditEntityTeam, team.ID, ok, current.Version, team.Version)
+	if err != nil {
+		return err
+	}
+	if !apply {
+		if len(team.Aliases) > 0 {
+			return foreignKeyViolation("team_aliases", "fk_team")
+		}
+		return nil
+	}
+	current.Name = team.Name
+	current.City = team.City
+	current.ShortName = clonePtr(team.ShortName)
+	current.Code = clonePtr(team.Code)
+	current.Aliases = append([]string{}, team.Aliases...)
+	if err := r.store.checkTeam(r.store.teams, current); err != nil {
+		return err
+	}
+	r.store.teams[team.ID] = touchTeam(current)
+	return nil
+}
+
+func (r *MemoryTeamRepository) DeleteTeam(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if team, ok := r.store.teams[id]; ok && team.DeletedAt == nil {
+		deletedAt := memoryNow()
+		team.DeletedAt = &deletedAt
+		r.store.teams[id] = touchTeam(team)
+	}
+	return nil
+}
+
+func (r *MemoryTeamRepository) RestoreTeam(ctx context.Context, id int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	team, ok := r.store.teams[id]
+	if !ok || team.DeletedAt == nil {
+		return sql.ErrNoRows
+	}
+	team.DeletedAt = nil
+	r.store.teams[id] = touchTeam(team)
+	return nil
+}
+
+// MergeTeams moves the events of the duplicate team to the survivor, deletes
+// the duplicate with its aliases and saves the survivor, all or nothing. As
+// in TeamRepository.MergeTeams the survivor may take over the names of the
+// duplicate, and teams that meet in an event fail with a TeamClashError.
+func (r *MemoryTeamRepository) MergeTeams(ctx context.Context, survivor services.Team, duplicateID int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	clash := &services.TeamClashError{SurvivorID: survivor.ID, DuplicateID: duplicateID}
+	events := maps.Clone(r.store.events)
+	for id, row := range events {
+		if row.homeTeamID != duplicateID && row.awayTeamID != duplicateID {
+			continue
+		}
+		if row.homeTeamID == duplicateID {
+			row.homeTeamID = survivor.ID
+		}
+		if row.awayTeamID == duplicateID {
+			row.awayTeamID = survivor.ID
+		}
+		if row.homeTeamID == row.awayTeamID {
+			clash.EventIDs = append(clash.EventIDs, id)
+		}
+		row.event = touchEvent(row.event)
+		events[id] = row
+	}
+	if len(clash.EventIDs) > 0 {
+		slices.Sort(clash.EventIDs)
+		return clash
+	}
+	teams := maps.Clone(r.store.teams)
+	delete(teams, duplicateID)
+	if current, ok := teams[survivor.ID]; ok {
+		current.Name = survivor.Name
+		current.City = survivor.City
+		current.ShortName = clonePtr(survivor.ShortName)
+		current.Code = clonePtr(survivor.Code)
+		current.Aliases = append([]string{}, survivor.Aliases...)
+		if err := r.store.checkTeam(teams, current); err != nil {
+			return err
+		}
+		teams[survivor.ID] = touchTeam(current)
+	} else if len(survivor.Aliases) > 0 {
+		return foreignKeyViolation("team_aliases", "fk_team")
+	}
+	r.store.events, r.store.teams = events, teams
+	return nil
+}
+
+// selectTeams returns the teams kept by keep ordered by name, then id.
+func (r *MemoryTeamRepository) selectTeams(keep func(services.Team) bool) []services.Team {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rows := sortedValues(r.store.teams, keep, func(a, b services.Team) bool {
+		if a.Name != b.Name {
+			return lessName(a.Name, b.Name)
+		}
+		return a.ID < b.ID
+	})
+	teams := make([]services.Team, 0, len(rows))
+	for _, team := range rows {
+		teams = append(teams, cloneTeam(team))
+	}
+	return teams
+}
+
+// checkTeam enforces the constraints of the teams and team_aliases tables on
+// a team about to be written among teams.
+func (s *MemoryStore) checkTeam(teams map[int]services.Team, team services.Team) error {
+	if _, ok := s.sports[team.SportID]; !ok {
+		return foreignKeyViolation("teams", "fk_sport")
+	}
+	aliases := map[string]bool{}
+	for i, alias := range team.Aliases {
+		for _, other := range team.Aliases[:i] {
+			if other == alias {
+				return uniqueViolation("team_aliases", "pk_team_aliases")
+			}
+		}
+		aliases[foldName(alias)] = true
+	}
+	if len(aliases) < len(team.Aliases) {
+		return uniqueViolation("team_aliases", "uq_team_alias_sport")
+	}
+	for _, other := range teams {
+		if other.ID == team.ID || other.SportID != team.SportID {
+			continue
+		}
+		switch {
+		case other.Name == team.Name:
+			return uniqueViolation("teams", "uq_team_sport")
+		case equalPtr(other.ShortName, team.ShortName):
+			return uniqueViolation("teams", "uq_team_short_name_sport")
+		case equalPtr(other.Code, team.Code):
+			return uniqueViolation("teams", "uq_team_code_sport")
+		}
+		for _, alias := range other.Aliases {
+			if aliases[foldName(alias)] {
+				return uniqueViolation("team_aliases", "uq_team_alias_sport")
+			}
+		}
+	}
+	return nil
+}
+
+func touchTeam(team services.Team) services.Team {
+	team.Version++
+	team.UpdatedAt = memoryNow()
+	return team
+}
+
+// cloneTeam copies a team row, with its aliases in the order
+// TeamRepository lists them.
+func cloneTeam(team services.Team) services.Team {
+	team.ShortName = clonePtr(team.ShortName)
+	team.Code = clonePtr(team.Code)
+	team.DeletedAt = clonePtr(team.DeletedAt)
+	team.Aliases = append([]string{}, team.Aliases...)
+	sort.Slice(team.Aliases, func(i, j int) bool { return lessName(team.Aliases[i], team.Aliases[j]) })
+	return team
+}
@@ -0,0 +1,241 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"maps"
+	"math"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// MemoryVenueRepository is the in-memory VenueRepository.
+type MemoryVenueRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryVenueRepository(store *MemoryStore) *MemoryVenueRepository {
+	return &MemoryVenueRepository{store: store}
+}
+
+func (v *MemoryVenueRepository) CreateVenue(ctx context.Context, params services.VenueRequest) (int, error) {
+	venue := services.Venue{
+		Name:        params.Name,
+		City:        params.City,
+		CountryCode: params.CountryCode,
+		TimeZone:    params.TimeZone,
+		Address:     clonePtr(params.Address),
+		PostalCode:  clonePtr(params.PostalCode),
+		Latitude:    clonePtr(params.Latitude),
+		Longitude:   clonePtr(params.Longitude),
+		Capacity:    clonePtr(params.Capacity),
+		Version:     1,
+	}
+	if err := checkVenue(venue); err != nil {
+		return 0, err
+	}
+
+	v.store.mu.Lock()
+	defer v.store.mu.Unlock()
+	venue.ID = v.store.nextID("venues")
+	venue.CreatedAt = memoryNow()
+	venue.UpdatedAt = venue.CreatedAt
+	v.store.venues[venue.ID] = venue
+	return venue.ID, nil
+}
+
+func (v *MemoryVenueRepository) GetVenueById(ctx context.Context, id int) (*services.Venue, error) {
+	v.store.mu.RLock()
+	defer v.store.mu.RUnlock()
+
+	venue, ok := v.store.venues[id]
+	if !ok || !visible(ctx, venue.DeletedAt) {
+		return nil, sql.ErrNoRows
+	}
+	venue = cloneVenue(venue)
+	return &venue, nil
+}
+
+func (v *MemoryVenueRepository) ListVenues(ctx context.Context) ([]services.Venue, error) {
+	v.store.mu.RLock()
+	defer v.store.mu.RUnlock()
+
+	rows := sortedValues(v.store.venues,
+		func(venue services.Venue) bool { return visible(ctx, venue.DeletedAt) },
+		func(a, b services.Venue) bool { return lessName(a.Name, b.Name) })
+	venues := make([]services.Venue, 0, len(rows))
+	for _, venue := range rows {
+		venues = append(venues, cloneVenue(venue))
+	}
+	return venues, nil
+}
+
+func (v *MemoryVenueRepository) UpdateVenue(ctx context.Context, venue services.Venue) error {
+	v.store.mu.Lock()
+	defer v.store.mu.Unlock()
+
+	current, ok := v.store.venues[venue.ID]
+	if apply, err := applyUpdate(services.AuditEntityVenue, venue.ID, ok, current.Version, venue.Version); !apply {
+		return err
+	}
+	current.Name = venue.Name
+	current.City = venue.City
+	current.CountryCode = venue.CountryCode
+	current.TimeZone = venue.TimeZone
+	current.Address = clonePtr(venue.Address)
+	current.PostalCode = clonePtr(venue.PostalCode)
+	current.Latitude = clonePtr(venue.Latitude)
+	current.Longitude = clonePtr(venue.Longitude)
+	current.Capacity = clonePtr(venue.Capacity)
+	if err := checkVenue(current); err != nil {
+		return err
+	}
+	v.store.venues[venue.ID] = touchVenue(current)
+	return nil
+}
+
+func (v *MemoryVenueRepository) DeleteVenue(ctx context.Context, id int) error {
+	v.store.mu.Lock()
+	defer v.store.mu.Unlock()
+
+	if venue, ok := v.store.venues[id]; ok && venue.DeletedAt == nil {
+		deletedAt := memoryNow()
+		venue.DeletedAt = &deletedAt
+		v.store.venues[id] = touchVenue(venue)
+	}
+	return nil
+}
+
+func (v *MemoryVenueRepository) RestoreVenue(ctx context.Context, id int) error {
+	v.store.mu.Lock()
+	defer v.store.mu.Unlock()
+
+	venue, ok := v.store.venues[id]
+	if !ok || venue.DeletedAt == nil {
+		return sql.ErrNoRows
+	}
+	venue.DeletedAt = nil
+	v.store.venues[id] = touchVenue(venue)
+	return nil
+}
+
+// MergeVenues moves the events of the duplicate venue to the survivor,
+// deletes the duplicate and saves the survivor, all or nothing. An event
+// moved onto a booking of the survivor fails the merge with ErrVenueConflict.
+func (v *MemoryVenueRepository) MergeVenues(ctx context.Context, survivor services.Venue, duplicateID int) error {
+	v.store.mu.Lock()
+	defer v.store.mu.Unlock()
+
+	events := maps.Clone(v.store.events)
+	var moved []int
+	for id, row := range events {
+		if row.venueID != nil && *row.venueID == duplicateID {
+			row.venueID = &survivor.ID
+			row.event = touchEvent(row.event)
+			events[id] = row
+			moved = append(moved, id)
+		}
+	}
+	for _, id := range moved {
+		if err := checkVenueBooking(events, events[id]); err != nil {
+			return translateEventWriteError(err)
+		}
+	}
+	venues := maps.Clone(v.store.venues)
+	delete(venues, duplicateID)
+	if current, ok := venues[survivor.ID]; ok {
+		current.Address = clonePtr(survivor.Address)
+		current.PostalCode = clonePtr(survivor.PostalCode)
+		current.Latitude = clonePtr(survivor.Latitude)
+		current.Longitude = clonePtr(survivor.Longitude)
+		current.Capacity = clonePtr(survivor.Capacity)
+		if err := checkVenue(current); err != nil {
+			return err
+		}
+		venues[survivor.ID] = touchVenue(current)
+	}
+	v.store.events, v.store.venues = events, venues
+	return nil
+}
+
+// ListVenuesNearby returns the venues within radiusKm of point with their
+// distance, nearest first, as VenueRepository.ListVenuesNearby does.
+func (v *MemoryVenueRepository) ListVenuesNearby(ctx context.Context, point services.GeoPoint,
+	radiusKm float64) ([]services.Venue, error) {
+	v.store.mu.RLock()
+	defer v.store.mu.RUnlock()
+
+	rows := sortedValues(v.store.venues,
+		func(venue services.Venue) bool {
+			return visible(ctx, venue.DeletedAt) && withinRadius(venue, point, radiusKm)
+		},
+		func(a, b services.Venue) bool {
+			da, db := venueDistanceKm(a, point), venueDistanceKm(b, point)
+			if da != db {
+				return da < db
+			}
+			return lessName(a.Name, b.Name)
+		})
+	venues := make([]services.Venue, 0, len(rows))
+	for _, venue := range rows {
+		venue = cloneVenue(venue)
+		distance := venueDistanceKm(venue, point)
+		venue.DistanceKm = &distance
+		venues = append(venues, venue)
+	}
+	return venues, nil
+}
+
+// withinRadius reports whether venue has coordinates within radiusKm of
+// point, applying the same latitude band as latitudeBandSQL first.
+func withinRadius(venue services.Venue, point services.GeoPoint, radiusKm float64) bool {
+	if venue.Latitude == nil || venue.Longitude == nil {
+		return false
+	}
+	if math.Abs(*venue.Latitude-point.Latitude) > radiusKm/kmPerDegreeLatitude {
+		return false
+	}
+	return venueDistanceKm(venue, point) <= radiusKm
+}
+
+// venueDistanceKm is the distance haversineKmSQL computes for a venue with
+// coordinates.
+func venueDistanceKm(venue services.Venue, point services.GeoPoint) float64 {
+	lat1 := *venue.Latitude * math.Pi / 180
+	lat2 := point.Latitude * math.Pi / 180
+	dLat := (*venue.Latitude - point.Latitude) * math.Pi / 180
+	dLon := (*venue.Longitude - point.Longitude) * math.Pi / 180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat2)*math.Cos(lat1)*math.Pow(math.Sin(dLon/2), 2)
+	return services.EarthRadiusKm * 2 * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// checkVenue enforces the check constraints of the venues table.
+func checkVenue(venue services.Venue) error {
+	switch {
+	case venue.Capacity != nil && *venue.Capacity <= 0:
+		return checkViolation("venues", "check_capacity_positive")
+	case venue.Latitude != nil && (*venue.Latitude < -90 || *venue.Latitude > 90):
+		return checkViolation("venues", "check_latitude_range")
+	case venue.Longitude != nil && (*venue.Longitude < -180 || *venue.Longitude > 180):
+		return checkViolation("venues", "check_longitude_range")
+	case (venue.Latitude == nil) != (venue.Longitude == nil):
+		return checkViolation("venues", "check_coordinates_pair")
+	}
+	return nil
+}
+
+func touchVenue(venue services.Venue) services.Venue {
+	venue.Version++
+	venue.UpdatedAt = memoryNow()
+	return venue
+}
+
+func cloneVenue(venue services.Venue) services.Venue {
+	venue.Address = clonePtr(venue.Address)
+	venue.PostalCode = clonePtr(venue.PostalCode)
+	venue.Latitude = clonePtr(venue.Latitude)
+	venue.Longitude = clonePtr(venue.Longitude)
+	venue.Capacity = clonePtr(venue.Capacity)
+	venue.DeletedAt = clonePtr(venue.DeletedAt)
+	return venue
+}
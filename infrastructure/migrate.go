@@ -0,0 +1,291 @@
+package infrastructure
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql migrations_sqlite/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey names the advisory lock held while migrating, so that
+// instances starting at the same time do not apply a migration twice.
+const migrationLockKey = 7_320_114_502
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one schema change, read from a pair of
+// <version>_<name>.up.sql and <version>_<name>.down.sql files.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+type migrationStep struct {
+	migration Migration
+	up        bool
+}
+
+// Migrator applies the migrations compiled into the binary and records them
+// in the schema_migrations table.
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+// NewMigrator reads the migrations for the database behind db: SQLite has
+// its own, in migrations_sqlite.
+func NewMigrator(db *sqlx.DB) (*Migrator, error) {
+	dir := "migrations"
+	if db != nil && db.DriverName() == sqliteDriverName {
+		dir = "migrations_sqlite"
+	}
+	migrations, err := loadMigrations(migrationFiles, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration and returns how many it applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	return m.run(ctx, func(applied map[int]time.Time) ([]migrationStep, error) {
+		var steps []migrationStep
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; !ok {
+				steps = append(steps, migrationStep{migration: migration, up: true})
+			}
+		}
+		return steps, nil
+	})
+}
+
+// Down reverts the last steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, fmt.Errorf("steps must be positive")
+	}
+	return m.run(ctx, func(applied map[int]time.Time) ([]migrationStep, error) {
+		versions := make([]int, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		slices.Sort(versions)
+		target := 0
+		if steps < len(versions) {
+			target = versions[len(versions)-steps-1]
+		}
+		return m.plan(applied, target, false)
+	})
+}
+
+// Goto migrates up or down until exactly the migrations up to version are
+// applied. Version 0 reverts them all.
+func (m *Migrator) Goto(ctx context.Context, version int) (int, error) {
+	if version != 0 && !slices.ContainsFunc(m.migrations, func(migration Migration) bool {
+		return migration.Version == version
+	}) {
+		return 0, fmt.Errorf("unknown migration version %d", version)
+	}
+	return m.run(ctx, func(applied map[int]time.Time) ([]migrationStep, error) {
+		return m.plan(applied, version, true)
+	})
+}
+
+// Status lists the known migrations with the time each was applied, nil for
+// pending ones.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	_, err := m.run(ctx, func(applied map[int]time.Time) ([]migrationStep, error) {
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// plan reverts the applied migrations above target, newest first, and, when
+// forward is set, applies the pending ones up to target, oldest first.
+func (m *Migrator) plan(applied map[int]time.Time, target int, forward bool) ([]migrationStep, error) {
+	var steps []migrationStep
+	for version := range applied {
+		if version > target && !slices.ContainsFunc(m.migrations, func(migration Migration) bool {
+			return migration.Version == version
+		}) {
+			return nil, fmt.Errorf("cannot revert migration %d: it is unknown to this build", version)
+		}
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; ok && migration.Version > target {
+			steps = append(steps, migrationStep{migration: migration, up: false})
+		}
+	}
+	if !forward {
+		return steps, nil
+	}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok && migration.Version <= target {
+			steps = append(steps, migrationStep{migration: migration, up: true})
+		}
+	}
+	return steps, nil
+}
+
+// run takes the migration lock on one connection, plans the steps from the
+// applied versions and runs each in its own transaction. SQLite has no
+// advisory locks; there, an instance migrating alongside another fails on
+// the first step the other already applied, and that step is rolled back.
+func (m *Migrator) run(ctx context.Context,
+	plan func(applied map[int]time.Time) ([]migrationStep, error)) (int, error) {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if m.db.DriverName() != sqliteDriverName {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			return 0, fmt.Errorf("failed to take migration lock: %w", err)
+		}
+		defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+	}
+
+	query := `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return 0, err
+	}
+	var rows []struct {
+		Version   int       `db:"version"`
+		AppliedAt time.Time `db:"applied_at"`
+	}
+	if err := sqlx.SelectContext(ctx, conn, &rows, "SELECT version, applied_at FROM schema_migrations"); err != nil {
+		return 0, err
+	}
+	applied := make(map[int]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	steps, err := plan(applied)
+	if err != nil {
+		return 0, err
+	}
+	for i, step := range steps {
+		if err := applyMigrationStep(ctx, conn, step); err != nil {
+			return i, err
+		}
+	}
+	return len(steps), nil
+}
+
+func applyMigrationStep(ctx context.Context, conn *sqlx.Conn, step migrationStep) error {
+	migration := step.migration
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if step.up {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return fmt.Errorf("failed to apply migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		query := `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`
+		if _, err := tx.ExecContext(ctx, query, migration.Version, migration.Name); err != nil {
+			return err
+		}
+	} else {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return fmt.Errorf("failed to revert migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		query := `DELETE FROM schema_migrations WHERE version = $1`
+		if _, err := tx.ExecContext(ctx, query, migration.Version); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if step.up {
+		log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+	} else {
+		log.Printf("Reverted migration %04d_%s", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+// loadMigrations reads the migration files in dir, sorted by version. Every
+// version needs both an up and a down file.
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	return migrations, nil
+}
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	ctx := context.Background()
+	latest := migrator.migrations[len(migrator.migrations)-1].Version
+
+	t.Run("up is a no-op when up to date", func(t *testing.T) {
+		count, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		for _, status := range statuses {
+			assert.NotNil(t, status.AppliedAt, "migration %d", status.Version)
+		}
+	})
+
+	t.Run("down and up again", func(t *testing.T) {
+		count, err := migrator.Down(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, statuses[len(statuses)-1].AppliedAt)
+
+		count, err = migrator.Goto(ctx, latest)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("concurrent runners apply each migration once", func(t *testing.T) {
+		_, err := migrator.Goto(ctx, 0)
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		counts := make([]int, 3)
+		errs := make([]error, 3)
+		for i := range counts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				counts[i], errs[i] = migrator.Up(ctx)
+			}()
+		}
+		wg.Wait()
+
+		total := 0
+		for i := range counts {
+			require.NoError(t, errs[i])
+			total += counts[i]
+		}
+		assert.Equal(t, len(migrator.migrations), total)
+	})
+}
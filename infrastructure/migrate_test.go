@@ -0,0 +1,117 @@
+package infrastructure
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("embedded migrations", func(t *testing.T) {
+		migrator, err := NewMigrator(nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, migrator.migrations)
+		assert.Equal(t, 1, migrator.migrations[0].Version)
+		assert.Equal(t, "initial_schema", migrator.migrations[0].Name)
+	})
+
+	t.Run("sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"m/0010_add_index.up.sql":   {Data: []byte("CREATE INDEX")},
+			"m/0010_add_index.down.sql": {Data: []byte("DROP INDEX")},
+			"m/0002_add_table.up.sql":   {Data: []byte("CREATE TABLE")},
+			"m/0002_add_table.down.sql": {Data: []byte("DROP TABLE")},
+		}
+
+		migrations, err := loadMigrations(fsys, "m")
+		require.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 2, Name: "add_table", Up: "CREATE TABLE", Down: "DROP TABLE"},
+			{Version: 10, Name: "add_index", Up: "CREATE INDEX", Down: "DROP INDEX"},
+		}, migrations)
+	})
+
+	tests := []struct {
+		name  string
+		files []string
+	}{
+		{name: "missing down file", files: []string{"0001_a.up.sql"}},
+		{name: "invalid file name", files: []string{"0001_a.sql"}},
+		{name: "version zero", files: []string{"0000_a.up.sql", "0000_a.down.sql"}},
+		{name: "two names for a version", files: []string{"0001_a.up.sql", "0001_b.down.sql"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for _, file := range tt.files {
+				fsys["m/"+file] = &fstest.MapFile{Data: []byte("SELECT 1")}
+			}
+
+			_, err := loadMigrations(fsys, "m")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMigrator_Plan(t *testing.T) {
+	migrator := &Migrator{migrations: []Migration{
+		{Version: 1, Name: "one"},
+		{Version: 2, Name: "two"},
+		{Version: 3, Name: "three"},
+	}}
+	now := time.Now()
+
+	type step struct {
+		version int
+		up      bool
+	}
+	versions := func(steps []migrationStep) []step {
+		result := []step{}
+		for _, s := range steps {
+			result = append(result, step{s.migration.Version, s.up})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name          string
+		applied       []int
+		target        int
+		forward       bool
+		expected      []step
+		expectedError bool
+	}{
+		{name: "forward to a version", applied: []int{1}, target: 2, forward: true,
+			expected: []step{{2, true}}},
+		{name: "back to a version", applied: []int{1, 2, 3}, target: 1, forward: true,
+			expected: []step{{3, false}, {2, false}}},
+		{name: "back to nothing", applied: []int{1, 2}, target: 0,
+			expected: []step{{2, false}, {1, false}}},
+		{name: "fills a gap below the target", applied: []int{1, 3}, target: 3, forward: true,
+			expected: []step{{2, true}}},
+		{name: "gap left alone when reverting", applied: []int{1, 3}, target: 1,
+			expected: []step{{3, false}}},
+		{name: "unknown version above target", applied: []int{1, 2, 3, 4}, target: 2, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied := map[int]time.Time{}
+			for _, version := range tt.applied {
+				applied[version] = now
+			}
+
+			steps, err := migrator.plan(applied, tt.target, tt.forward)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, versions(steps))
+		})
+	}
+}
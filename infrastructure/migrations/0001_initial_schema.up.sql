@@ -0,0 +1,44 @@
+-- Baseline schema: the tables of the former init.sql, without its seed data.
+-- Every later change is a migration of its own.
+
+CREATE TABLE IF NOT EXISTS sports (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS venues (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    country_code CHAR(2) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    _sport_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
+
+    CONSTRAINT uq_team_sport UNIQUE (name, _sport_id)
+);
+
+CREATE TABLE IF NOT EXISTS events (
+    id SERIAL PRIMARY KEY,
+    event_datetime TIMESTAMPTZ NOT NULL,
+    description TEXT,
+    home_score INTEGER,
+    away_score INTEGER,
+    _sport_id INTEGER NOT NULL,
+    _venue_id INTEGER,
+    _home_team_id INTEGER NOT NULL,
+    _away_team_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
+    CONSTRAINT fk_venue FOREIGN KEY(_venue_id) REFERENCES venues(id),
+    CONSTRAINT fk_home_team FOREIGN KEY(_home_team_id) REFERENCES teams(id),
+    CONSTRAINT fk_away_team FOREIGN KEY(_away_team_id) REFERENCES teams(id),
+
+    CONSTRAINT check_teams_not_equal CHECK (_home_team_id <> _away_team_id)
+);
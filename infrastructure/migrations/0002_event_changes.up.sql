@@ -0,0 +1,22 @@
+-- History of created, rescheduled, cancelled and scored events for the Atom
+-- feeds. Names are copied so that entries survive renames and deletes.
+
+CREATE TABLE event_changes (
+    id SERIAL PRIMARY KEY,
+    _event_id INTEGER NOT NULL,
+    change_type VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    event_datetime TIMESTAMPTZ NOT NULL,
+    home_score INTEGER,
+    away_score INTEGER,
+    _sport_id INTEGER NOT NULL,
+    sport_name VARCHAR(100) NOT NULL,
+    _home_team_id INTEGER NOT NULL,
+    home_team_name VARCHAR(100) NOT NULL,
+    _away_team_id INTEGER NOT NULL,
+    away_team_name VARCHAR(100) NOT NULL,
+
+    CONSTRAINT check_change_type CHECK (change_type IN ('created', 'rescheduled', 'cancelled', 'scored'))
+);
+
+CREATE INDEX idx_event_changes_changed_at ON event_changes (changed_at DESC);
@@ -0,0 +1,2 @@
+-- Existing venues are taken to be in UTC until they are given their zone.
+ALTER TABLE venues ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,3 @@
+ALTER TABLE events DROP COLUMN IF EXISTS _series_id;
+DROP TABLE IF EXISTS event_series_exceptions;
+DROP TABLE IF EXISTS event_series;
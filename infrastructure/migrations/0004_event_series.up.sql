@@ -0,0 +1,31 @@
+CREATE TABLE event_series (
+    id SERIAL PRIMARY KEY,
+    rrule TEXT NOT NULL,
+    start_datetime TIMESTAMPTZ NOT NULL,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    materialized_until TIMESTAMPTZ,
+    description TEXT,
+    _sport_id INTEGER NOT NULL,
+    _venue_id INTEGER,
+    _home_team_id INTEGER NOT NULL,
+    _away_team_id INTEGER NOT NULL,
+
+    CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
+    CONSTRAINT fk_venue FOREIGN KEY(_venue_id) REFERENCES venues(id),
+    CONSTRAINT fk_home_team FOREIGN KEY(_home_team_id) REFERENCES teams(id),
+    CONSTRAINT fk_away_team FOREIGN KEY(_away_team_id) REFERENCES teams(id),
+
+    CONSTRAINT check_teams_not_equal CHECK (_home_team_id <> _away_team_id)
+);
+
+CREATE TABLE event_series_exceptions (
+    _series_id INTEGER NOT NULL,
+    exception_date DATE NOT NULL,
+
+    CONSTRAINT fk_series FOREIGN KEY(_series_id) REFERENCES event_series(id) ON DELETE CASCADE,
+    CONSTRAINT pk_event_series_exceptions PRIMARY KEY (_series_id, exception_date)
+);
+
+ALTER TABLE events
+    ADD COLUMN _series_id INTEGER,
+    ADD CONSTRAINT fk_series FOREIGN KEY(_series_id) REFERENCES event_series(id) ON DELETE SET NULL;
@@ -0,0 +1,16 @@
+CREATE TABLE event_reschedules (
+    id SERIAL PRIMARY KEY,
+    _event_id INTEGER NOT NULL,
+    previous_datetime TIMESTAMPTZ NOT NULL,
+    new_datetime TIMESTAMPTZ NOT NULL,
+    _previous_venue_id INTEGER,
+    _new_venue_id INTEGER,
+    reason TEXT,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_event FOREIGN KEY(_event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_previous_venue FOREIGN KEY(_previous_venue_id) REFERENCES venues(id) ON DELETE SET NULL,
+    CONSTRAINT fk_new_venue FOREIGN KEY(_new_venue_id) REFERENCES venues(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_event_reschedules_event ON event_reschedules (_event_id, changed_at);
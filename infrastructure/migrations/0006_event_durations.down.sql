@@ -0,0 +1,6 @@
+-- The btree_gist extension is left installed; other schemas may rely on it.
+ALTER TABLE events
+    DROP CONSTRAINT IF EXISTS no_venue_double_booking,
+    DROP COLUMN IF EXISTS allow_venue_overlap,
+    DROP COLUMN IF EXISTS end_datetime;
+ALTER TABLE sports DROP COLUMN IF EXISTS default_duration_minutes;
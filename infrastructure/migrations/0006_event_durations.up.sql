@@ -0,0 +1,38 @@
+-- Events get an end, by default their sport's duration, and may no longer
+-- overlap at a venue unless they opt out.
+
+-- Needed for the integer equality part of the venue exclusion constraint
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE sports
+    ADD COLUMN default_duration_minutes INTEGER NOT NULL DEFAULT 120,
+    ADD CONSTRAINT check_default_duration_positive CHECK (default_duration_minutes > 0);
+
+ALTER TABLE events
+    ADD COLUMN end_datetime TIMESTAMPTZ,
+    ADD COLUMN allow_venue_overlap BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE events e
+SET end_datetime = e.event_datetime + s.default_duration_minutes * INTERVAL '1 minute'
+FROM sports s
+WHERE s.id = e._sport_id;
+
+-- Bookings that already overlap stay allowed rather than failing the
+-- migration; new ones are checked.
+UPDATE events e
+SET allow_venue_overlap = TRUE
+WHERE EXISTS (
+    SELECT 1 FROM events o
+    WHERE o.id <> e.id AND o._venue_id = e._venue_id
+    AND tstzrange(o.event_datetime, o.end_datetime) && tstzrange(e.event_datetime, e.end_datetime)
+);
+
+ALTER TABLE events
+    ALTER COLUMN end_datetime SET NOT NULL,
+    ADD CONSTRAINT check_end_after_start CHECK (end_datetime > event_datetime),
+    -- Bookings at one venue must not overlap unless the event opts out,
+    -- e.g. for venues with several pitches
+    ADD CONSTRAINT no_venue_double_booking EXCLUDE USING gist (
+        _venue_id WITH =,
+        tstzrange(event_datetime, end_datetime) WITH &&
+    ) WHERE (_venue_id IS NOT NULL AND NOT allow_venue_overlap);
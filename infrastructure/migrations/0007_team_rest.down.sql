@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_events_away_team;
+DROP INDEX IF EXISTS idx_events_home_team;
+ALTER TABLE sports
+    DROP COLUMN IF EXISTS rest_conflict_policy,
+    DROP COLUMN IF EXISTS min_rest_minutes;
@@ -0,0 +1,8 @@
+ALTER TABLE sports
+    ADD COLUMN min_rest_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN rest_conflict_policy VARCHAR(10) NOT NULL DEFAULT 'reject',
+    ADD CONSTRAINT check_min_rest_not_negative CHECK (min_rest_minutes >= 0),
+    ADD CONSTRAINT check_rest_conflict_policy CHECK (rest_conflict_policy IN ('reject', 'warn'));
+
+CREATE INDEX idx_events_home_team ON events (_home_team_id, event_datetime);
+CREATE INDEX idx_events_away_team ON events (_away_team_id, event_datetime);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_venues_coordinates;
+ALTER TABLE venues
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS postal_code,
+    DROP COLUMN IF EXISTS address;
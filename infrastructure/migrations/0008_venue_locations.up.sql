@@ -0,0 +1,10 @@
+ALTER TABLE venues
+    ADD COLUMN address VARCHAR(255),
+    ADD COLUMN postal_code VARCHAR(20),
+    ADD COLUMN latitude DOUBLE PRECISION,
+    ADD COLUMN longitude DOUBLE PRECISION,
+    ADD CONSTRAINT check_latitude_range CHECK (latitude BETWEEN -90 AND 90),
+    ADD CONSTRAINT check_longitude_range CHECK (longitude BETWEEN -180 AND 180),
+    ADD CONSTRAINT check_coordinates_pair CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+CREATE INDEX idx_venues_coordinates ON venues (latitude, longitude);
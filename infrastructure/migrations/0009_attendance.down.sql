@@ -0,0 +1,2 @@
+ALTER TABLE events DROP COLUMN IF EXISTS attendance;
+ALTER TABLE venues DROP COLUMN IF EXISTS capacity;
@@ -0,0 +1,7 @@
+ALTER TABLE venues
+    ADD COLUMN capacity INTEGER,
+    ADD CONSTRAINT check_capacity_positive CHECK (capacity > 0);
+
+ALTER TABLE events
+    ADD COLUMN attendance INTEGER,
+    ADD CONSTRAINT check_attendance_not_negative CHECK (attendance >= 0);
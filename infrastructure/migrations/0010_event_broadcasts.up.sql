@@ -0,0 +1,14 @@
+CREATE TABLE event_broadcasts (
+    id SERIAL PRIMARY KEY,
+    _event_id INTEGER NOT NULL,
+    broadcaster VARCHAR(100) NOT NULL,
+    channel VARCHAR(100),
+    country_code CHAR(2) NOT NULL,
+    url VARCHAR(2048),
+    start_datetime TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT fk_event FOREIGN KEY(_event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_event_broadcasts_event ON event_broadcasts (_event_id, start_datetime);
+CREATE INDEX idx_event_broadcasts_country ON event_broadcasts (country_code, _event_id);
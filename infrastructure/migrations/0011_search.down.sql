@@ -0,0 +1,5 @@
+-- The unaccent extension is left installed; other schemas may rely on it.
+ALTER TABLE events DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE teams DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE venues DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS search_unaccent(text);
@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE; search vectors need an IMMUTABLE variant.
+CREATE OR REPLACE FUNCTION search_unaccent(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+ALTER TABLE venues ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', search_unaccent(name)), 'A') ||
+    setweight(to_tsvector('simple', search_unaccent(city)), 'B')
+) STORED;
+CREATE INDEX idx_venues_search ON venues USING GIN (search_vector);
+
+ALTER TABLE teams ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', search_unaccent(name)), 'A') ||
+    setweight(to_tsvector('simple', search_unaccent(city)), 'B')
+) STORED;
+CREATE INDEX idx_teams_search ON teams USING GIN (search_vector);
+
+ALTER TABLE events ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple', search_unaccent(coalesce(description, '')))
+) STORED;
+CREATE INDEX idx_events_search ON events USING GIN (search_vector);
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS team_aliases;
+
+ALTER TABLE teams DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE teams ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', search_unaccent(name)), 'A') ||
+    setweight(to_tsvector('simple', search_unaccent(city)), 'B')
+) STORED;
+CREATE INDEX idx_teams_search ON teams USING GIN (search_vector);
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS code,
+    DROP COLUMN IF EXISTS short_name;
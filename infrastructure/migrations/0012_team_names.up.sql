@@ -0,0 +1,28 @@
+-- Teams get a short name, a three-letter code and aliases, all unique within
+-- their sport and all searchable.
+
+ALTER TABLE teams
+    ADD COLUMN short_name VARCHAR(50),
+    ADD COLUMN code CHAR(3),
+    ADD CONSTRAINT uq_team_short_name_sport UNIQUE (short_name, _sport_id),
+    ADD CONSTRAINT uq_team_code_sport UNIQUE (code, _sport_id);
+
+-- A generated column cannot change its expression; it is built anew.
+ALTER TABLE teams DROP COLUMN search_vector;
+ALTER TABLE teams ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', search_unaccent(name || ' ' || coalesce(short_name, '') || ' ' || coalesce(code, ''))), 'A') ||
+    setweight(to_tsvector('simple', search_unaccent(city)), 'B')
+) STORED;
+CREATE INDEX idx_teams_search ON teams USING GIN (search_vector);
+
+CREATE TABLE team_aliases (
+    _team_id INTEGER NOT NULL,
+    _sport_id INTEGER NOT NULL,
+    alias VARCHAR(100) NOT NULL,
+
+    CONSTRAINT fk_team FOREIGN KEY(_team_id) REFERENCES teams(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
+    CONSTRAINT pk_team_aliases PRIMARY KEY (_team_id, alias)
+);
+
+CREATE UNIQUE INDEX uq_team_alias_sport ON team_aliases (_sport_id, lower(search_unaccent(alias)));
@@ -0,0 +1,13 @@
+-- Rows that are only marked deleted come back as live rows.
+ALTER TABLE events
+    DROP CONSTRAINT IF EXISTS no_venue_double_booking,
+    ADD CONSTRAINT no_venue_double_booking EXCLUDE USING gist (
+        _venue_id WITH =,
+        tstzrange(event_datetime, end_datetime) WITH &&
+    ) WHERE (_venue_id IS NOT NULL AND NOT allow_venue_overlap);
+
+ALTER TABLE events DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE event_series DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE teams DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE venues DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE sports DROP COLUMN IF EXISTS deleted_at;
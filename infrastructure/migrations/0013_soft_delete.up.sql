@@ -0,0 +1,15 @@
+-- Deleting only marks rows; the purge job removes them for good.
+
+ALTER TABLE sports ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE venues ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE teams ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE event_series ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE events ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Deleted events free their venue slot
+ALTER TABLE events
+    DROP CONSTRAINT no_venue_double_booking,
+    ADD CONSTRAINT no_venue_double_booking EXCLUDE USING gist (
+        _venue_id WITH =,
+        tstzrange(event_datetime, end_datetime) WITH &&
+    ) WHERE (_venue_id IS NOT NULL AND NOT allow_venue_overlap AND deleted_at IS NULL);
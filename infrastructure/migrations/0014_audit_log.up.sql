@@ -0,0 +1,24 @@
+-- Append-only: before/after hold the changed columns of the entity row.
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    before JSONB,
+    after JSONB,
+
+    CONSTRAINT check_entity_type CHECK (entity_type IN ('event', 'sport', 'team', 'venue')),
+    CONSTRAINT check_action CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge'))
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log (entity_type, entity_id, changed_at DESC);
+CREATE INDEX idx_audit_log_changed_at ON audit_log (changed_at DESC);
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$ BEGIN RAISE EXCEPTION 'audit_log is append-only'; END $$;
+
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS event_broadcasts_bump_version ON event_broadcasts;
+DROP TRIGGER IF EXISTS events_bump_version ON events;
+DROP TRIGGER IF EXISTS event_series_bump_version ON event_series;
+DROP TRIGGER IF EXISTS teams_bump_version ON teams;
+DROP TRIGGER IF EXISTS venues_bump_version ON venues;
+DROP TRIGGER IF EXISTS sports_bump_version ON sports;
+
+ALTER TABLE event_broadcasts DROP COLUMN IF EXISTS version;
+ALTER TABLE events DROP COLUMN IF EXISTS version;
+ALTER TABLE event_series DROP COLUMN IF EXISTS version;
+ALTER TABLE teams DROP COLUMN IF EXISTS version;
+ALTER TABLE venues DROP COLUMN IF EXISTS version;
+ALTER TABLE sports DROP COLUMN IF EXISTS version;
+
+DROP FUNCTION IF EXISTS bump_version();
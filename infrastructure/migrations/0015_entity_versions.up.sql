@@ -0,0 +1,29 @@
+-- Optimistic concurrency: every entity row carries a version, starting at 1
+-- and bumped by a trigger on each update, so that no write can forget to.
+
+CREATE FUNCTION bump_version() RETURNS trigger AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE sports ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE venues ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE teams ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE event_series ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE events ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE event_broadcasts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+CREATE TRIGGER sports_bump_version BEFORE UPDATE ON sports
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+CREATE TRIGGER venues_bump_version BEFORE UPDATE ON venues
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+CREATE TRIGGER teams_bump_version BEFORE UPDATE ON teams
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+CREATE TRIGGER event_series_bump_version BEFORE UPDATE ON event_series
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+CREATE TRIGGER events_bump_version BEFORE UPDATE ON events
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+CREATE TRIGGER event_broadcasts_bump_version BEFORE UPDATE ON event_broadcasts
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
@@ -0,0 +1,19 @@
+DROP TRIGGER IF EXISTS event_broadcasts_touch_updated_at ON event_broadcasts;
+DROP TRIGGER IF EXISTS events_touch_updated_at ON events;
+DROP TRIGGER IF EXISTS event_series_exceptions_touch_updated_at ON event_series_exceptions;
+DROP TRIGGER IF EXISTS event_series_touch_updated_at ON event_series;
+DROP TRIGGER IF EXISTS team_aliases_touch_updated_at ON team_aliases;
+DROP TRIGGER IF EXISTS teams_touch_updated_at ON teams;
+DROP TRIGGER IF EXISTS venues_touch_updated_at ON venues;
+DROP TRIGGER IF EXISTS sports_touch_updated_at ON sports;
+
+ALTER TABLE event_broadcasts DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS created_at;
+ALTER TABLE events DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS created_at;
+ALTER TABLE event_series_exceptions DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS created_at;
+ALTER TABLE event_series DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS created_at;
+ALTER TABLE team_aliases DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS created_at;
+ALTER TABLE teams DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS created_at;
+ALTER TABLE venues DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS created_at;
+ALTER TABLE sports DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS created_at;
+
+DROP FUNCTION IF EXISTS touch_updated_at();
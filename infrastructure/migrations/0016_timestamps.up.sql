@@ -0,0 +1,52 @@
+-- Every table but the append-only logs, which have their changed_at, records
+-- when each row was created and last updated; a trigger keeps updated_at
+-- current. Existing rows count as created now.
+
+CREATE FUNCTION touch_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at := NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE sports
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE venues
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE teams
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE team_aliases
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE event_series
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE event_series_exceptions
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE events
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE event_broadcasts
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE TRIGGER sports_touch_updated_at BEFORE UPDATE ON sports
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+CREATE TRIGGER venues_touch_updated_at BEFORE UPDATE ON venues
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+CREATE TRIGGER teams_touch_updated_at BEFORE UPDATE ON teams
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+CREATE TRIGGER team_aliases_touch_updated_at BEFORE UPDATE ON team_aliases
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+CREATE TRIGGER event_series_touch_updated_at BEFORE UPDATE ON event_series
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+CREATE TRIGGER event_series_exceptions_touch_updated_at BEFORE UPDATE ON event_series_exceptions
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+CREATE TRIGGER events_touch_updated_at BEFORE UPDATE ON events
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+CREATE TRIGGER event_broadcasts_touch_updated_at BEFORE UPDATE ON event_broadcasts
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
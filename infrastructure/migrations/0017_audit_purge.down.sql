@@ -0,0 +1,6 @@
+-- NOT VALID keeps the entries already recorded: the log is append-only.
+ALTER TABLE audit_log
+    DROP CONSTRAINT check_entity_type,
+    DROP CONSTRAINT check_action,
+    ADD CONSTRAINT check_entity_type CHECK (entity_type IN ('event', 'sport', 'team', 'venue')) NOT VALID,
+    ADD CONSTRAINT check_action CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge')) NOT VALID;
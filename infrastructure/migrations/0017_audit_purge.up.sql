@@ -0,0 +1,6 @@
+-- The purge job records each row it hard-deletes, series included.
+ALTER TABLE audit_log
+    DROP CONSTRAINT check_entity_type,
+    DROP CONSTRAINT check_action,
+    ADD CONSTRAINT check_entity_type CHECK (entity_type IN ('event', 'series', 'sport', 'team', 'venue')),
+    ADD CONSTRAINT check_action CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge', 'purge'));
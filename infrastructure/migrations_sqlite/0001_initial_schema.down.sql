@@ -0,0 +1,12 @@
+-- Dropping a table drops its indexes and triggers with it.
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS event_changes;
+DROP TABLE IF EXISTS event_broadcasts;
+DROP TABLE IF EXISTS event_reschedules;
+DROP TABLE IF EXISTS events;
+DROP TABLE IF EXISTS event_series_exceptions;
+DROP TABLE IF EXISTS event_series;
+DROP TABLE IF EXISTS team_aliases;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS venues;
+DROP TABLE IF EXISTS sports;
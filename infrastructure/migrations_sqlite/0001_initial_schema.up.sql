@@ -0,0 +1,311 @@
+-- The schema of the PostgreSQL migrations up to 0016_timestamps, for SQLite.
+-- Timestamps are stored as fixed-width UTC text, which orders
+-- chronologically. Triggers stand in for what SQLite lacks: the venue
+-- exclusion constraint, and BEFORE UPDATE triggers that can assign to NEW.
+-- The search_unaccent function and calendar_name collation are registered
+-- by the application on every connection.
+
+CREATE TABLE sports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) COLLATE calendar_name UNIQUE NOT NULL,
+    default_duration_minutes INTEGER NOT NULL DEFAULT 120,
+    min_rest_minutes INTEGER NOT NULL DEFAULT 0,
+    rest_conflict_policy VARCHAR(10) NOT NULL DEFAULT 'reject',
+    deleted_at TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+
+    CONSTRAINT check_default_duration_positive CHECK (default_duration_minutes > 0),
+    CONSTRAINT check_min_rest_not_negative CHECK (min_rest_minutes >= 0),
+    CONSTRAINT check_rest_conflict_policy CHECK (rest_conflict_policy IN ('reject', 'warn'))
+);
+
+CREATE TABLE venues (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) COLLATE calendar_name NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    country_code CHAR(2) NOT NULL,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    address VARCHAR(255),
+    postal_code VARCHAR(20),
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    capacity INTEGER,
+    deleted_at TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+
+    CONSTRAINT check_capacity_positive CHECK (capacity > 0),
+    CONSTRAINT check_latitude_range CHECK (latitude BETWEEN -90 AND 90),
+    CONSTRAINT check_longitude_range CHECK (longitude BETWEEN -180 AND 180),
+    CONSTRAINT check_coordinates_pair CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+CREATE INDEX idx_venues_coordinates ON venues (latitude, longitude);
+
+CREATE TABLE teams (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) COLLATE calendar_name NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    _sport_id INTEGER NOT NULL,
+    short_name VARCHAR(50),
+    code CHAR(3),
+    deleted_at TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+
+    CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
+
+    CONSTRAINT uq_team_sport UNIQUE (name, _sport_id),
+    CONSTRAINT uq_team_short_name_sport UNIQUE (short_name, _sport_id),
+    CONSTRAINT uq_team_code_sport UNIQUE (code, _sport_id)
+);
+
+CREATE TABLE team_aliases (
+    _team_id INTEGER NOT NULL,
+    _sport_id INTEGER NOT NULL,
+    alias VARCHAR(100) COLLATE calendar_name NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+
+    CONSTRAINT fk_team FOREIGN KEY(_team_id) REFERENCES teams(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
+    CONSTRAINT pk_team_aliases PRIMARY KEY (_team_id, alias)
+);
+
+CREATE UNIQUE INDEX uq_team_alias_sport ON team_aliases (_sport_id, lower(search_unaccent(alias)));
+
+CREATE TABLE event_series (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rrule TEXT NOT NULL,
+    start_datetime TIMESTAMP NOT NULL,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    materialized_until TIMESTAMP,
+    description TEXT,
+    _sport_id INTEGER NOT NULL,
+    _venue_id INTEGER,
+    _home_team_id INTEGER NOT NULL,
+    _away_team_id INTEGER NOT NULL,
+    deleted_at TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+
+    CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
+    CONSTRAINT fk_venue FOREIGN KEY(_venue_id) REFERENCES venues(id),
+    CONSTRAINT fk_home_team FOREIGN KEY(_home_team_id) REFERENCES teams(id),
+    CONSTRAINT fk_away_team FOREIGN KEY(_away_team_id) REFERENCES teams(id),
+
+    CONSTRAINT check_teams_not_equal CHECK (_home_team_id <> _away_team_id)
+);
+
+CREATE TABLE event_series_exceptions (
+    _series_id INTEGER NOT NULL,
+    exception_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+
+    CONSTRAINT fk_series FOREIGN KEY(_series_id) REFERENCES event_series(id) ON DELETE CASCADE,
+    CONSTRAINT pk_event_series_exceptions PRIMARY KEY (_series_id, exception_date)
+);
+
+CREATE TABLE events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_datetime TIMESTAMP NOT NULL,
+    end_datetime TIMESTAMP NOT NULL,
+    description TEXT,
+    home_score INTEGER,
+    away_score INTEGER,
+    _sport_id INTEGER NOT NULL,
+    _venue_id INTEGER,
+    _home_team_id INTEGER NOT NULL,
+    _away_team_id INTEGER NOT NULL,
+    _series_id INTEGER,
+    allow_venue_overlap BOOLEAN NOT NULL DEFAULT FALSE,
+    attendance INTEGER,
+    deleted_at TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+
+    CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
+    CONSTRAINT fk_venue FOREIGN KEY(_venue_id) REFERENCES venues(id),
+    CONSTRAINT fk_home_team FOREIGN KEY(_home_team_id) REFERENCES teams(id),
+    CONSTRAINT fk_away_team FOREIGN KEY(_away_team_id) REFERENCES teams(id),
+    CONSTRAINT fk_series FOREIGN KEY(_series_id) REFERENCES event_series(id) ON DELETE SET NULL,
+
+    CONSTRAINT check_teams_not_equal CHECK (_home_team_id <> _away_team_id),
+    CONSTRAINT check_end_after_start CHECK (end_datetime > event_datetime),
+    CONSTRAINT check_attendance_not_negative CHECK (attendance >= 0)
+);
+
+CREATE INDEX idx_events_venue ON events (_venue_id, event_datetime);
+CREATE INDEX idx_events_home_team ON events (_home_team_id, event_datetime);
+CREATE INDEX idx_events_away_team ON events (_away_team_id, event_datetime);
+
+-- no_venue_double_booking: bookings at one venue must not overlap unless the
+-- event opts out; deleted events free their slot. The triggers run AFTER the
+-- write so that, as in PostgreSQL, check constraints are reported first.
+CREATE TRIGGER events_no_venue_double_booking_insert AFTER INSERT ON events
+    FOR EACH ROW WHEN NEW._venue_id IS NOT NULL AND NOT NEW.allow_venue_overlap AND NEW.deleted_at IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'conflicting key value violates exclusion constraint "no_venue_double_booking"')
+    FROM events e
+    WHERE e._venue_id = NEW._venue_id AND e.id <> NEW.id
+        AND NOT e.allow_venue_overlap AND e.deleted_at IS NULL
+        AND e.event_datetime < NEW.end_datetime AND e.end_datetime > NEW.event_datetime;
+END;
+
+CREATE TRIGGER events_no_venue_double_booking_update
+    AFTER UPDATE OF event_datetime, end_datetime, _venue_id, allow_venue_overlap, deleted_at ON events
+    FOR EACH ROW WHEN NEW._venue_id IS NOT NULL AND NOT NEW.allow_venue_overlap AND NEW.deleted_at IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'conflicting key value violates exclusion constraint "no_venue_double_booking"')
+    FROM events e
+    WHERE e._venue_id = NEW._venue_id AND e.id <> NEW.id
+        AND NOT e.allow_venue_overlap AND e.deleted_at IS NULL
+        AND e.event_datetime < NEW.end_datetime AND e.end_datetime > NEW.event_datetime;
+END;
+
+CREATE TABLE event_reschedules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    _event_id INTEGER NOT NULL,
+    previous_datetime TIMESTAMP NOT NULL,
+    new_datetime TIMESTAMP NOT NULL,
+    _previous_venue_id INTEGER,
+    _new_venue_id INTEGER,
+    reason TEXT,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+
+    CONSTRAINT fk_event FOREIGN KEY(_event_id) REFERENCES events(id) ON DELETE CASCADE,
+    CONSTRAINT fk_previous_venue FOREIGN KEY(_previous_venue_id) REFERENCES venues(id) ON DELETE SET NULL,
+    CONSTRAINT fk_new_venue FOREIGN KEY(_new_venue_id) REFERENCES venues(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_event_reschedules_event ON event_reschedules (_event_id, changed_at);
+
+CREATE TABLE event_broadcasts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    _event_id INTEGER NOT NULL,
+    broadcaster VARCHAR(100) NOT NULL,
+    channel VARCHAR(100),
+    country_code CHAR(2) NOT NULL,
+    url VARCHAR(2048),
+    start_datetime TIMESTAMP NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+
+    CONSTRAINT fk_event FOREIGN KEY(_event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_event_broadcasts_event ON event_broadcasts (_event_id, start_datetime);
+CREATE INDEX idx_event_broadcasts_country ON event_broadcasts (country_code, _event_id);
+
+CREATE TABLE event_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    _event_id INTEGER NOT NULL,
+    change_type VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    event_datetime TIMESTAMP NOT NULL,
+    home_score INTEGER,
+    away_score INTEGER,
+    _sport_id INTEGER NOT NULL,
+    sport_name VARCHAR(100) NOT NULL,
+    _home_team_id INTEGER NOT NULL,
+    home_team_name VARCHAR(100) NOT NULL,
+    _away_team_id INTEGER NOT NULL,
+    away_team_name VARCHAR(100) NOT NULL,
+
+    CONSTRAINT check_change_type CHECK (change_type IN ('created', 'rescheduled', 'cancelled', 'scored'))
+);
+
+CREATE INDEX idx_event_changes_changed_at ON event_changes (changed_at DESC);
+
+-- Append-only: before/after hold the changed columns of the entity row, as
+-- JSON text.
+CREATE TABLE audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    before TEXT,
+    after TEXT,
+
+    CONSTRAINT check_entity_type CHECK (entity_type IN ('event', 'sport', 'team', 'venue')),
+    CONSTRAINT check_action CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge'))
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log (entity_type, entity_id, changed_at DESC);
+CREATE INDEX idx_audit_log_changed_at ON audit_log (changed_at DESC);
+
+CREATE TRIGGER audit_log_append_only_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER audit_log_append_only_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+-- Every update bumps the row's version and touches its updated_at. The
+-- triggers' own updates do not fire them again: recursive triggers are off.
+CREATE TRIGGER sports_bump_version AFTER UPDATE ON sports FOR EACH ROW
+BEGIN
+    UPDATE sports SET version = OLD.version + 1,
+        updated_at = strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')
+    WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER venues_bump_version AFTER UPDATE ON venues FOR EACH ROW
+BEGIN
+    UPDATE venues SET version = OLD.version + 1,
+        updated_at = strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')
+    WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER teams_bump_version AFTER UPDATE ON teams FOR EACH ROW
+BEGIN
+    UPDATE teams SET version = OLD.version + 1,
+        updated_at = strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')
+    WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER event_series_bump_version AFTER UPDATE ON event_series FOR EACH ROW
+BEGIN
+    UPDATE event_series SET version = OLD.version + 1,
+        updated_at = strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')
+    WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER events_bump_version AFTER UPDATE ON events FOR EACH ROW
+BEGIN
+    UPDATE events SET version = OLD.version + 1,
+        updated_at = strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')
+    WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER event_broadcasts_bump_version AFTER UPDATE ON event_broadcasts FOR EACH ROW
+BEGIN
+    UPDATE event_broadcasts SET version = OLD.version + 1,
+        updated_at = strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')
+    WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER team_aliases_touch_updated_at AFTER UPDATE ON team_aliases FOR EACH ROW
+BEGIN
+    UPDATE team_aliases SET updated_at = strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')
+    WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER event_series_exceptions_touch_updated_at AFTER UPDATE ON event_series_exceptions FOR EACH ROW
+BEGIN
+    UPDATE event_series_exceptions SET updated_at = strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')
+    WHERE rowid = NEW.rowid;
+END;
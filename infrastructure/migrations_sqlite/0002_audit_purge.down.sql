@@ -0,0 +1,34 @@
+-- The entries of purges and series cannot be kept under the old constraints.
+CREATE TABLE audit_log_rebuilt (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    before TEXT,
+    after TEXT,
+
+    CONSTRAINT check_entity_type CHECK (entity_type IN ('event', 'sport', 'team', 'venue')),
+    CONSTRAINT check_action CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge'))
+);
+
+INSERT INTO audit_log_rebuilt (id, entity_type, entity_id, action, actor, changed_at, before, after)
+SELECT id, entity_type, entity_id, action, actor, changed_at, before, after FROM audit_log
+WHERE entity_type <> 'series' AND action <> 'purge';
+
+DROP TABLE audit_log;
+ALTER TABLE audit_log_rebuilt RENAME TO audit_log;
+
+CREATE INDEX idx_audit_log_entity ON audit_log (entity_type, entity_id, changed_at DESC);
+CREATE INDEX idx_audit_log_changed_at ON audit_log (changed_at DESC);
+
+CREATE TRIGGER audit_log_append_only_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER audit_log_append_only_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
@@ -0,0 +1,35 @@
+-- As 0017_audit_purge: the purge job records each row it hard-deletes,
+-- series included. SQLite cannot alter CHECK constraints, so the table is
+-- rebuilt; dropping it does not fire its append-only triggers.
+CREATE TABLE audit_log_rebuilt (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now')),
+    before TEXT,
+    after TEXT,
+
+    CONSTRAINT check_entity_type CHECK (entity_type IN ('event', 'series', 'sport', 'team', 'venue')),
+    CONSTRAINT check_action CHECK (action IN ('create', 'update', 'delete', 'restore', 'merge', 'purge'))
+);
+
+INSERT INTO audit_log_rebuilt (id, entity_type, entity_id, action, actor, changed_at, before, after)
+SELECT id, entity_type, entity_id, action, actor, changed_at, before, after FROM audit_log;
+
+DROP TABLE audit_log;
+ALTER TABLE audit_log_rebuilt RENAME TO audit_log;
+
+CREATE INDEX idx_audit_log_entity ON audit_log (entity_type, entity_id, changed_at DESC);
+CREATE INDEX idx_audit_log_changed_at ON audit_log (changed_at DESC);
+
+CREATE TRIGGER audit_log_append_only_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER audit_log_append_only_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
@@ -10,21 +10,45 @@ import (
 	"github.com/vsennikov/sports-event-calendar/config"
 )
 
+// NewConnection connects to the database cfg.DBDriver names: "postgres", or
+// "sqlite" for a database in the file cfg.DBName.
 func NewConnection(cfg config.Config) (*sqlx.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+	switch cfg.DBDriver {
+	case "", "postgres":
+	case "sqlite":
+		return newSQLiteConnection(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported db driver %q", cfg.DBDriver)
+	}
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s connect_timeout=%d",
 		cfg.DBHost,
 		cfg.DBPort,
 		cfg.DBUser,
 		cfg.DBPassword,
 		cfg.DBName,
+		cfg.DBConnectTimeoutSeconds,
 	)
+	if cfg.DBSSLMode != "" {
+		// Without it pgx falls back to its default, prefer.
+		dsn += " sslmode=" + cfg.DBSSLMode
+	}
+	if cfg.DBStatementTimeoutSeconds > 0 {
+		// pgx sends parameters it does not know itself to the server.
+		dsn += fmt.Sprintf(" statement_timeout=%d", cfg.DBStatementTimeoutSeconds*1000)
+	}
 	db, err := sqlx.Connect("pgx", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to db: %w", err)
 	}
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	configurePool(db, cfg)
 	log.Println("connected to db")
 	return db, nil
-}
\ No newline at end of file
+}
+
+// configurePool sizes the connection pool of a database as cfg says.
+func configurePool(db *sqlx.DB, cfg config.Config) {
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.DBConnMaxLifetimeSeconds) * time.Second)
+	db.SetConnMaxIdleTime(time.Duration(cfg.DBConnMaxIdleTimeSeconds) * time.Second)
+}
@@ -0,0 +1,105 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+type PurgeRepository struct {
+	db *sqlx.DB
+}
+
+func NewPurgeRepository(db *sqlx.DB) *PurgeRepository {
+	return &PurgeRepository{db: db}
+}
+
+// PurgeDeleted hard-deletes, in one transaction, the rows soft-deleted before
+// the cutoff. Events and series go first so that the teams, venues and sports
+// they referenced can follow; a deleted row still referenced by a live one is
+// kept until that reference is gone. Each purged row is recorded in the audit
+// log, as is each event that loses its purged series.
+func (r *PurgeRepository) PurgeDeleted(ctx context.Context, before time.Time) (*services.PurgeResult, error) {
+	var result services.PurgeResult
+	steps := []struct {
+		entityType string
+		count      *int
+		table      string
+		alias      string
+		where      string
+	}{
+		{services.AuditEntityEvent, &result.Events, "events", "e", "e.deleted_at < $1"},
+		{services.AuditEntitySeries, &result.Series, "event_series", "es", "es.deleted_at < $1"},
+		{services.AuditEntityTeam, &result.Teams, "teams", "t", `t.deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM events e WHERE t.id IN (e._home_team_id, e._away_team_id))
+		AND NOT EXISTS (SELECT 1 FROM event_series es WHERE t.id IN (es._home_team_id, es._away_team_id))`},
+		{services.AuditEntityVenue, &result.Venues, "venues", "v", `v.deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM events e WHERE e._venue_id = v.id)
+		AND NOT EXISTS (SELECT 1 FROM event_series es WHERE es._venue_id = v.id)`},
+		{services.AuditEntitySport, &result.Sports, "sports", "s", `s.deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM teams t WHERE t._sport_id = s.id)
+		AND NOT EXISTS (SELECT 1 FROM events e WHERE e._sport_id = s.id)
+		AND NOT EXISTS (SELECT 1 FROM event_series es WHERE es._sport_id = s.id)
+		AND NOT EXISTS (SELECT 1 FROM team_aliases ta WHERE ta._sport_id = s.id)`},
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	for _, step := range steps {
+		var ids []int
+		from := step.table + " AS " + step.alias + " WHERE " + step.where
+		query := "SELECT " + step.alias + ".id FROM " + from + " ORDER BY " + step.alias + ".id"
+		if err := tx.SelectContext(ctx, &ids, query, before); err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			purged, err := purgeRow(ctx, tx, step.entityType, id,
+				"DELETE FROM "+from+" AND "+step.alias+".id = $2", before)
+			if err != nil {
+				return nil, err
+			}
+			if purged {
+				*step.count++
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// purgeRow runs the delete of one row, which may find the row no longer
+// purgeable, and records the row as purged when it is gone. A purged series
+// leaves its events behind without it, so their change is recorded as well.
+func purgeRow(ctx context.Context, tx *sqlx.Tx, entityType string, id int,
+	query string, before time.Time) (bool, error) {
+	snapshot, err := snapshotEntity(ctx, tx, entityType, id)
+	if err != nil {
+		return false, err
+	}
+	var eventsBefore map[int]map[string]interface{}
+	if entityType == services.AuditEntitySeries {
+		eventsBefore, err = snapshotEvents(ctx, tx, "_series_id = $1", id)
+		if err != nil {
+			return false, err
+		}
+	}
+	res, err := tx.ExecContext(ctx, query, before, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+	if err := recordAudit(ctx, tx, entityType, id, services.AuditActionPurge, snapshot, nil); err != nil {
+		return false, err
+	}
+	return true, recordEventUpdates(ctx, tx, eventsBefore)
+}
@@ -0,0 +1,94 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestPurgeRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	repo := NewPurgeRepository(db)
+	sportRepo := NewSportRepository(db)
+	teamRepo := NewTeamRepository(db)
+	eventRepo := NewEventRepository(db)
+	ctx := context.Background()
+	withDeleted := services.WithDeleted(ctx)
+
+	sportID, err := sportRepo.CreateSport(ctx, services.SportRequest{Name: "Purge Football"})
+	require.NoError(t, err)
+	homeTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{Name: "Purge Home", City: "Home", SportID: sportID})
+	require.NoError(t, err)
+	awayTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{Name: "Purge Away", City: "Away", SportID: sportID})
+	require.NoError(t, err)
+	start := time.Now().Add(24 * time.Hour)
+	eventID, err := eventRepo.CreateEvent(ctx, services.CreateEventParams{
+		EventDatetime: start,
+		EndDatetime:   start.Add(2 * time.Hour),
+		SportID:       sportID,
+		HomeTeamID:    homeTeamID,
+		AwayTeamID:    awayTeamID,
+	})
+	require.NoError(t, err)
+
+	t.Run("keeps rows deleted after the cutoff", func(t *testing.T) {
+		require.NoError(t, eventRepo.DeleteEvent(ctx, eventID))
+
+		result, err := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, services.PurgeResult{}, *result)
+		_, err = eventRepo.GetEventByID(withDeleted, eventID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("keeps deleted rows still referenced", func(t *testing.T) {
+		require.NoError(t, teamRepo.DeleteTeam(ctx, awayTeamID))
+		require.NoError(t, eventRepo.RestoreEvent(ctx, eventID))
+
+		result, err := repo.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Teams)
+		_, err = teamRepo.GetTeamByID(withDeleted, awayTeamID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("purges expired rows", func(t *testing.T) {
+		require.NoError(t, eventRepo.DeleteEvent(ctx, eventID))
+
+		result, err := repo.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Events)
+		assert.Equal(t, 1, result.Teams)
+		_, err = eventRepo.GetEventByID(withDeleted, eventID)
+		assert.Error(t, err)
+		_, err = teamRepo.GetTeamByID(withDeleted, awayTeamID)
+		assert.Error(t, err)
+		_, err = teamRepo.GetTeamByID(ctx, homeTeamID)
+		assert.NoError(t, err)
+
+		entityType := services.AuditEntityEvent
+		entries, err := NewAuditRepository(db).ListAuditEntries(ctx, services.ListAuditEntriesParams{
+			EntityType: &entityType, EntityID: &eventID, Limit: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, services.AuditActionPurge, entries[0].Action)
+		assert.Nil(t, entries[0].After)
+	})
+}
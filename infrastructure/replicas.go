@@ -0,0 +1,240 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/config"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// replicaPingTimeout bounds a health check of a replica.
+const replicaPingTimeout = 2 * time.Second
+
+// ReplicaSet is the primary database with its read replicas. The Routed*
+// repositories send their reads to the replicas through it, and their writes
+// to the primary.
+//
+// Reads go round-robin to the replicas that passed their last health check,
+// and to the primary when none did or when the context already made a write
+// (see services.TrackWrites). A read failing for a lost connection marks its
+// replica down and is retried on the primary.
+type ReplicaSet struct {
+	primary  *sqlx.DB
+	replicas []*replica
+	reader   *replicaReader
+	next     atomic.Uint64
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type replica struct {
+	name    string
+	db      *sqlx.DB
+	healthy atomic.Bool
+}
+
+// NewReplicaSet checks the health of replicas once and returns them as the
+// replicas of primary.
+func NewReplicaSet(primary *sqlx.DB, replicas []*sqlx.DB) *ReplicaSet {
+	s := &ReplicaSet{primary: primary, stop: make(chan struct{})}
+	for i, db := range replicas {
+		// Replicas are named by position: their DSNs hold passwords.
+		s.replicas = append(s.replicas, &replica{name: fmt.Sprintf("replica %d", i+1), db: db})
+	}
+	s.reader = &replicaReader{set: s}
+	s.checkHealth()
+	return s
+}
+
+// ConnectReplicas opens the replicas listed in cfg.DBReplicaDSNs, separated
+// by commas, and starts checking their health every
+// cfg.DBReplicaHealthCheckSeconds. It returns nil when there are none.
+// Replicas that cannot be reached yet are not an error; they are used once
+// they pass a health check.
+func ConnectReplicas(cfg config.Config, primary *sqlx.DB) (*ReplicaSet, error) {
+	var dsns []string
+	for _, dsn := range strings.Split(cfg.DBReplicaDSNs, ",") {
+		if dsn = strings.TrimSpace(dsn); dsn != "" {
+			dsns = append(dsns, dsn)
+		}
+	}
+	if len(dsns) == 0 {
+		return nil, nil
+	}
+	if primary.DriverName() != "pgx" {
+		return nil, fmt.Errorf("read replicas need the postgres db driver")
+	}
+	var dbs []*sqlx.DB
+	for i, dsn := range dsns {
+		db, err := sqlx.Open("pgx", dsn)
+		if err != nil {
+			for _, db := range dbs {
+				db.Close()
+			}
+			return nil, fmt.Errorf("failed to open replica %d: %w", i+1, err)
+		}
+		configurePool(db, cfg)
+		dbs = append(dbs, db)
+	}
+	s := NewReplicaSet(primary, dbs)
+	s.StartHealthChecks(time.Duration(cfg.DBReplicaHealthCheckSeconds) * time.Second)
+	return s, nil
+}
+
+// StartHealthChecks checks the health of the replicas every interval in the
+// background, until Close. An interval of 0 or less disables the checks, so
+// that replicas only go down on failed reads and never come back.
+func (s *ReplicaSet) StartHealthChecks(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.checkHealth()
+			}
+		}
+	}()
+}
+
+// Close stops the health checks and closes the replicas. The primary is left
+// open.
+func (s *ReplicaSet) Close() error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	var errs []error
+	for _, r := range s.replicas {
+		errs = append(errs, r.db.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (s *ReplicaSet) checkHealth() {
+	for _, r := range s.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), replicaPingTimeout)
+		err := r.db.PingContext(ctx)
+		cancel()
+		if err != nil {
+			s.markDown(r, err)
+		} else if !r.healthy.Swap(true) {
+			log.Printf("%s is up", r.name)
+		}
+	}
+}
+
+func (s *ReplicaSet) markDown(r *replica, err error) {
+	if r.healthy.Swap(false) {
+		log.Printf("%s is down, reading from the primary instead: %v", r.name, err)
+	}
+}
+
+// pick returns the database to read from under ctx, with its replica; the
+// replica is nil for the primary.
+func (s *ReplicaSet) pick(ctx context.Context) (*sqlx.DB, *replica) {
+	if services.ReadsFromPrimary(ctx) {
+		return s.primary, nil
+	}
+	start := s.next.Add(1)
+	for i := range uint64(len(s.replicas)) {
+		r := s.replicas[(start+i)%uint64(len(s.replicas))]
+		if r.healthy.Load() {
+			return r.db, r
+		}
+	}
+	return s.primary, nil
+}
+
+// isConnectionError reports whether err is a read failing for want of a
+// working connection to the database, rather than for what it asked.
+func isConnectionError(err error) bool {
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.As(err, &connectErr) || errors.As(err, &netErr)
+}
+
+// replicaReader is the dbtx of the repositories reading from a ReplicaSet.
+// Rows read with QueryRowContext and QueryRowxContext report their errors
+// only when scanned, too late to retry them on the primary.
+type replicaReader struct {
+	set *ReplicaSet
+}
+
+func (r *replicaReader) read(ctx context.Context, run func(db *sqlx.DB) error) error {
+	db, replica := r.set.pick(ctx)
+	err := run(db)
+	if replica != nil && err != nil && ctx.Err() == nil && isConnectionError(err) {
+		r.set.markDown(replica, err)
+		return run(r.set.primary)
+	}
+	return err
+}
+
+func (r *replicaReader) DriverName() string {
+	return r.set.primary.DriverName()
+}
+
+func (r *replicaReader) Rebind(query string) string {
+	return r.set.primary.Rebind(query)
+}
+
+func (r *replicaReader) BindNamed(query string, arg interface{}) (string, []interface{}, error) {
+	return r.set.primary.BindNamed(query, arg)
+}
+
+func (r *replicaReader) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := r.read(ctx, func(db *sqlx.DB) (err error) {
+		rows, err = db.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (r *replicaReader) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	var rows *sqlx.Rows
+	err := r.read(ctx, func(db *sqlx.DB) (err error) {
+		rows, err = db.QueryxContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (r *replicaReader) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	db, _ := r.set.pick(ctx)
+	return db.QueryRowxContext(ctx, query, args...)
+}
+
+func (r *replicaReader) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	db, _ := r.set.pick(ctx)
+	return db.QueryRowContext(ctx, query, args...)
+}
+
+func (r *replicaReader) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return r.read(ctx, func(db *sqlx.DB) error { return db.GetContext(ctx, dest, query, args...) })
+}
+
+func (r *replicaReader) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return r.read(ctx, func(db *sqlx.DB) error { return db.SelectContext(ctx, dest, query, args...) })
+}
+
+// ExecContext goes to the primary; replicas cannot be written to.
+func (r *replicaReader) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.set.primary.ExecContext(ctx, query, args...)
+}
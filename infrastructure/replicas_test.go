@@ -0,0 +1,129 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/config"
+	"github.com/vsennikov/sports-event-calendar/infrastructure/repotest"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// The tests stand in two SQLite databases for a primary and a replica that
+// has not caught up, so that where a read went shows in what it returns.
+
+// With the primary as its own replica, routing must not change what the
+// repositories do.
+func TestRoutedRepositories_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db := newSQLiteTestDB(t)
+		replicas := NewReplicaSet(db, []*sqlx.DB{db})
+		return repotest.Repositories{
+			Sports: NewRoutedSportRepository(replicas),
+			Teams:  NewRoutedTeamRepository(replicas),
+			Venues: NewRoutedVenueRepository(replicas),
+			Events: NewRoutedEventRepository(replicas),
+		}
+	})
+}
+
+func TestReplicaSet_RoutesReadsToReplicas(t *testing.T) {
+	ctx := context.Background()
+	primary, replica := newSQLiteTestDB(t), newSQLiteTestDB(t)
+	replicas := NewReplicaSet(primary, []*sqlx.DB{replica})
+	sports := NewRoutedSportRepository(replicas)
+
+	id, err := sports.CreateSport(ctx, services.SportRequest{Name: "Football"})
+	require.NoError(t, err)
+	_, err = sports.GetSportById(ctx, id)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "reads go to the replica")
+	listed, err := NewSportRepository(primary).ListSports(ctx)
+	require.NoError(t, err)
+	assert.Len(t, listed, 1, "writes go to the primary")
+}
+
+func TestReplicaSet_ReadYourWrites(t *testing.T) {
+	ctx := services.TrackWrites(context.Background())
+	replicas := NewReplicaSet(newSQLiteTestDB(t), []*sqlx.DB{newSQLiteTestDB(t)})
+	sports := NewRoutedSportRepository(replicas)
+
+	listed, err := sports.ListSports(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+	assert.False(t, services.ReadsFromPrimary(ctx))
+
+	id, err := sports.CreateSport(ctx, services.SportRequest{Name: "Football"})
+	require.NoError(t, err)
+	assert.True(t, services.ReadsFromPrimary(ctx))
+	sport, err := sports.GetSportById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Football", sport.Name)
+
+	txCtx := services.TrackWrites(context.Background())
+	err = NewRoutedTransactor(replicas).WithinTx(txCtx, func(repos services.Repositories) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, services.ReadsFromPrimary(txCtx), "units of work count as writes")
+}
+
+func TestReplicaSet_FallsBackToPrimary(t *testing.T) {
+	ctx := context.Background()
+	primary, replica := newSQLiteTestDB(t), newSQLiteTestDB(t)
+	replicas := NewReplicaSet(primary, []*sqlx.DB{replica})
+	_, err := NewSportRepository(primary).CreateSport(ctx, services.SportRequest{Name: "Football"})
+	require.NoError(t, err)
+
+	var readFrom []*sqlx.DB
+	err = replicas.reader.read(ctx, func(db *sqlx.DB) error {
+		readFrom = append(readFrom, db)
+		if db == replica {
+			return &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*sqlx.DB{replica, primary}, readFrom, "a read losing its connection is retried on the primary")
+	assert.False(t, replicas.replicas[0].healthy.Load())
+
+	listed, err := NewRoutedSportRepository(replicas).ListSports(ctx)
+	require.NoError(t, err)
+	assert.Len(t, listed, 1, "reads go to the primary while the replica is down")
+	replicas.checkHealth()
+	assert.True(t, replicas.replicas[0].healthy.Load(), "the replica is back once it passes a health check")
+
+	err = replicas.reader.read(ctx, func(db *sqlx.DB) error { return sql.ErrNoRows })
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.True(t, replicas.replicas[0].healthy.Load(), "other errors leave the replica up")
+}
+
+func TestReplicaSet_SkipsUnhealthyReplicas(t *testing.T) {
+	ctx := context.Background()
+	primary := newSQLiteTestDB(t)
+	down, up := newSQLiteTestDB(t), newSQLiteTestDB(t)
+	require.NoError(t, down.Close())
+	replicas := NewReplicaSet(primary, []*sqlx.DB{down, up})
+	assert.False(t, replicas.replicas[0].healthy.Load())
+	assert.True(t, replicas.replicas[1].healthy.Load())
+
+	for range 4 {
+		db, replica := replicas.pick(ctx)
+		assert.Same(t, up, db)
+		assert.Same(t, replicas.replicas[1], replica)
+	}
+}
+
+func TestConnectReplicas(t *testing.T) {
+	primary := newSQLiteTestDB(t)
+
+	replicas, err := ConnectReplicas(config.Config{DBReplicaDSNs: " , "}, primary)
+	require.NoError(t, err)
+	assert.Nil(t, replicas)
+
+	_, err = ConnectReplicas(config.Config{DBReplicaDSNs: "host=replica dbname=calendar"}, primary)
+	assert.ErrorContains(t, err, "read replicas need the postgres db driver")
+}
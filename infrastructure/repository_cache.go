@@ -0,0 +1,206 @@
+package infrastructure
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// The entities cached reads depend on; a write to one invalidates every read
+// that depends on it.
+const (
+	cacheEntityEvents = "events"
+	cacheEntitySports = "sports"
+	cacheEntityTeams  = "teams"
+	cacheEntityVenues = "venues"
+)
+
+// cacheEntities are all of them. An event read depends on all of them, as
+// events are read joined with their sport, venue and teams.
+var cacheEntities = []string{cacheEntityEvents, cacheEntitySports, cacheEntityTeams, cacheEntityVenues}
+
+// RepositoryCache is an in-process LRU cache of repository reads, shared by
+// the Cached*Repository decorators. Entries expire after a TTL and are
+// dropped as soon as this process writes to an entity they depend on; writes
+// made by other processes, such as other instances or the seed command, only
+// show once the entries expire.
+type RepositoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+	entries  map[string]*list.Element
+	// lru holds the entries, the most recently used at the front.
+	lru *list.List
+	// generations count the writes to each entity, so that a read started
+	// before a write does not cache what it read.
+	generations map[string]uint64
+	stats       CacheStats
+}
+
+// CacheStats count how the cache served reads since it was created.
+type CacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Evictions     uint64
+	Invalidations uint64
+	Entries       int
+}
+
+// HitRatio is the share of reads served from the cache.
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+type cacheEntry struct {
+	key       string
+	value     interface{}
+	dependsOn []string
+	expiresAt time.Time
+}
+
+// NewRepositoryCache returns a cache of at most capacity entries, each kept
+// for ttl.
+func NewRepositoryCache(capacity int, ttl time.Duration) *RepositoryCache {
+	return &RepositoryCache{
+		capacity:    max(capacity, 1),
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     map[string]*list.Element{},
+		lru:         list.New(),
+		generations: map[string]uint64{},
+	}
+}
+
+// Stats returns the statistics so far.
+func (c *RepositoryCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// Invalidate drops the entries depending on any of entities.
+func (c *RepositoryCache) Invalidate(entities ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, entity := range entities {
+		c.generations[entity]++
+	}
+	for element := c.lru.Front(); element != nil; {
+		next := element.Next()
+		entry := element.Value.(*cacheEntry)
+		if slices.ContainsFunc(entry.dependsOn, func(entity string) bool { return slices.Contains(entities, entity) }) {
+			c.remove(element)
+			c.stats.Invalidations++
+		}
+		element = next
+	}
+}
+
+func (c *RepositoryCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if ok && c.now().Before(element.Value.(*cacheEntry).expiresAt) {
+		c.lru.MoveToFront(element)
+		c.stats.Hits++
+		return element.Value.(*cacheEntry).value, true
+	}
+	if ok {
+		c.remove(element)
+	}
+	c.stats.Misses++
+	return nil, false
+}
+
+// generation sums the write counts of entities, which grows with every write
+// to any of them.
+func (c *RepositoryCache) generation(entities []string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var generation uint64
+	for _, entity := range entities {
+		generation += c.generations[entity]
+	}
+	return generation
+}
+
+// put caches value, read when entities were at generation, unless one of
+// them was written since.
+func (c *RepositoryCache) put(key string, value interface{}, entities []string, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var current uint64
+	for _, entity := range entities {
+		current += c.generations[entity]
+	}
+	if current != generation {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{
+		key: key, value: value, dependsOn: entities, expiresAt: c.now().Add(c.ttl),
+	})
+	for c.lru.Len() > c.capacity {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *RepositoryCache) remove(element *list.Element) {
+	c.lru.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
+}
+
+// cachedRead returns the value cached under key, or reads and caches it.
+// Callers get a copy, so that changing it leaves the cached value alone.
+// Failed reads are not cached. Reads including soft-deleted rows are cached
+// apart from the others. Reads that have to see the writes made before them
+// under ctx skip the cache, which may hold what a lagging replica returned.
+func cachedRead[T any](ctx context.Context, c *RepositoryCache, entities []string, key string,
+	read func() (T, error), copyValue func(T) T) (T, error) {
+	if services.ReadsFromPrimary(ctx) {
+		return read()
+	}
+	if services.IncludeDeleted(ctx) {
+		key += "|deleted"
+	}
+	if value, ok := c.get(key); ok {
+		return copyValue(value.(T)), nil
+	}
+	generation := c.generation(entities)
+	value, err := read()
+	if err != nil {
+		return value, err
+	}
+	c.put(key, value, entities, generation)
+	return copyValue(value), nil
+}
+
+// cacheKey normalizes the parameters of a read into a cache key: equal
+// parameters give equal keys, wherever their pointers point.
+func cacheKey(read string, params ...interface{}) string {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		panic(fmt.Sprintf("cannot build cache key for %s: %v", read, err))
+	}
+	return read + string(raw)
+}
@@ -0,0 +1,151 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/infrastructure/repotest"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// The cached repositories have to behave as the ones they wrap, whatever
+// order the suite reads and writes in.
+func TestCachedRepositories_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		store := NewMemoryStore()
+		cache := NewRepositoryCache(100, time.Minute)
+		return repotest.Repositories{
+			Sports: NewCachedSportRepository(NewMemorySportRepository(store), cache),
+			Teams:  NewCachedTeamRepository(NewMemoryTeamRepository(store), cache),
+			Venues: NewCachedVenueRepository(NewMemoryVenueRepository(store), cache),
+			Events: NewCachedEventRepository(NewMemoryEventRepository(store), cache),
+		}
+	})
+}
+
+// countingSports counts the reads reaching the repository.
+type countingSports struct {
+	services.SportRepositoryInterface
+	lists int
+}
+
+func (r *countingSports) ListSports(ctx context.Context) ([]services.Sport, error) {
+	r.lists++
+	return r.SportRepositoryInterface.ListSports(ctx)
+}
+
+func newCountingSports(t *testing.T, cache *RepositoryCache) (*countingSports, *CachedSportRepository) {
+	t.Helper()
+
+	counting := &countingSports{SportRepositoryInterface: NewMemorySportRepository(NewMemoryStore())}
+	_, err := counting.CreateSport(context.Background(), services.SportRequest{Name: "Football"})
+	require.NoError(t, err)
+	return counting, NewCachedSportRepository(counting, cache)
+}
+
+func TestRepositoryCache_HitsAndInvalidation(t *testing.T) {
+	ctx := context.Background()
+	cache := NewRepositoryCache(10, time.Minute)
+	counting, sports := newCountingSports(t, cache)
+
+	for range 3 {
+		listed, err := sports.ListSports(ctx)
+		require.NoError(t, err)
+		assert.Len(t, listed, 1)
+	}
+	assert.Equal(t, 1, counting.lists)
+
+	_, err := sports.ListSports(services.WithDeleted(ctx))
+	require.NoError(t, err)
+	assert.Equal(t, 2, counting.lists, "reads including deleted rows are cached apart")
+
+	_, err = sports.CreateSport(ctx, services.SportRequest{Name: "Hockey"})
+	require.NoError(t, err)
+	listed, err := sports.ListSports(ctx)
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+	assert.Equal(t, 3, counting.lists)
+
+	stats := cache.Stats()
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 3, Invalidations: 2, Entries: 1}, stats)
+	assert.InDelta(t, 0.4, stats.HitRatio(), 1e-9)
+}
+
+func TestRepositoryCache_ExpiryAndEviction(t *testing.T) {
+	now := time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC)
+	cache := NewRepositoryCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+	read := func(key string) {
+		_, err := cachedRead(context.Background(), cache, []string{cacheEntitySports}, key,
+			func() (string, error) { return key, nil }, identity[string])
+		require.NoError(t, err)
+	}
+
+	read("a")
+	read("b")
+	read("a")
+	read("c")
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 3, Evictions: 1, Entries: 2}, cache.Stats())
+	read("a")
+	read("b")
+	assert.Equal(t, uint64(2), cache.Stats().Hits, "b was the least recently used")
+
+	now = now.Add(time.Minute)
+	read("a")
+	assert.Equal(t, uint64(5), cache.Stats().Misses, "a expired")
+}
+
+func TestRepositoryCache_CopiesAndErrors(t *testing.T) {
+	ctx := context.Background()
+	cache := NewRepositoryCache(10, time.Minute)
+	_, sports := newCountingSports(t, cache)
+
+	sport, err := sports.GetSportById(ctx, 1)
+	require.NoError(t, err)
+	sport.Name = "Changed"
+	sport, err = sports.GetSportById(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Football", sport.Name)
+
+	failing := errors.New("connection refused")
+	for range 2 {
+		_, err = cachedRead(ctx, cache, []string{cacheEntitySports}, "failing",
+			func() (int, error) { return 0, failing }, identity[int])
+		assert.ErrorIs(t, err, failing)
+	}
+	assert.Equal(t, uint64(3), cache.Stats().Misses, "failed reads are not cached")
+}
+
+func TestRepositoryCache_WriteDuringRead(t *testing.T) {
+	ctx := context.Background()
+	cache := NewRepositoryCache(10, time.Minute)
+	read := func(value string) (string, error) {
+		return cachedRead(ctx, cache, eventReadDependencies, "event", func() (string, error) {
+			if value == "before" {
+				// A venue written while the event was read.
+				cache.Invalidate(cacheEntityVenues)
+			}
+			return value, nil
+		}, identity[string])
+	}
+
+	_, err := read("before")
+	require.NoError(t, err)
+	value, err := read("after")
+	require.NoError(t, err)
+	assert.Equal(t, "after", value, "what was read before the write is not cached")
+}
+
+func TestNormalizeListEventsParams(t *testing.T) {
+	utc := time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC)
+	berlin := utc.In(time.FixedZone("CEST", 2*60*60))
+
+	assert.Equal(t,
+		cacheKey("ListEvents", normalizeListEventsParams(services.ListEventsParams{DateFrom: &utc, Limit: 10})),
+		cacheKey("ListEvents", normalizeListEventsParams(services.ListEventsParams{DateFrom: &berlin, Limit: 10})))
+	assert.Equal(t, "CEST", berlin.Location().String(), "the params passed in are left alone")
+}
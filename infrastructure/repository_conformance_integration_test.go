@@ -0,0 +1,32 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/vsennikov/sports-event-calendar/infrastructure/repotest"
+)
+
+func TestRepositoryConformance_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		CleanupTestDB(t, db)
+		return repotest.Repositories{
+			Sports: NewSportRepository(db),
+			Teams:  NewTeamRepository(db),
+			Venues: NewVenueRepository(db),
+			Events: NewEventRepository(db),
+		}
+	})
+}
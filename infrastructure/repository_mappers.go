@@ -2,41 +2,68 @@ package infrastructure
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/vsennikov/sports-event-calendar/services"
 )
 
 func toServiceEvent(db eventDBModel) services.Event {
 	var venue services.Venue
+	var originalDatetime *time.Time
 	if db.VenueID.Valid {
 		venue = services.Venue{
 			ID:          int(db.VenueID.Int64),
 			Name:        db.VenueName.String,
 			City:        db.VenueCity.String,
 			CountryCode: db.VenueCountryCode.String,
+			TimeZone:    db.VenueTimeZone.String,
+			Address:     nullStringToStringPtr(db.VenueAddress),
+			PostalCode:  nullStringToStringPtr(db.VenuePostalCode),
+			Latitude:    nullFloat64ToFloat64Ptr(db.VenueLatitude),
+			Longitude:   nullFloat64ToFloat64Ptr(db.VenueLongitude),
+			Capacity:    nullInt64ToIntPtr(db.VenueCapacity),
 		}
 	}
+	if db.OriginalDatetime.Valid {
+		originalDatetime = &db.OriginalDatetime.Time
+	}
 
 	return services.Event{
 		ID:            db.ID,
 		EventDatetime: db.EventDatetime,
+		EndDatetime:   db.EndDatetime,
 		Description:   nullStringToStringPtr(db.Description),
 		HomeScore:     nullInt64ToIntPtr(db.HomeScore),
 		AwayScore:     nullInt64ToIntPtr(db.AwayScore),
+		Attendance:    nullInt64ToIntPtr(db.Attendance),
+		SeriesID:      nullInt64ToIntPtr(db.SeriesID),
+		OriginalDatetime: originalDatetime,
+		AllowVenueOverlap: db.AllowVenueOverlap,
+		DeletedAt: nullTimeToTimePtr(db.DeletedAt),
+		Version: db.Version,
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
 		Sport: services.Sport{
-			ID:   db.SportID,
-			Name: db.SportName,
+			ID:                     db.SportID,
+			Name:                   db.SportName,
+			DefaultDurationMinutes: db.SportDefaultDurationMinutes,
+			MinRestMinutes:         db.SportMinRestMinutes,
+			RestConflictPolicy:     db.SportRestConflictPolicy,
 		},
 		Venue: venue,
 		HomeTeam: services.Team{
 			ID:   db.HomeTeamID,
 			Name: db.HomeTeamName,
 			City: db.HomeTeamCity,
+			ShortName: nullStringToStringPtr(db.HomeTeamShortName),
+			Code: nullStringToStringPtr(db.HomeTeamCode),
 		},
 		AwayTeam: services.Team{
 			ID:   db.AwayTeamID,
 			Name: db.AwayTeamName,
 			City: db.AwayTeamCity,
+			ShortName: nullStringToStringPtr(db.AwayTeamShortName),
+			Code: nullStringToStringPtr(db.AwayTeamCode),
 		},
 	}
 }
@@ -56,10 +83,33 @@ func nullInt64ToIntPtr(i sql.NullInt64) *int {
 	return nil
 }
 
+func nullTimeToTimePtr(t sql.NullTime) *time.Time {
+	if t.Valid {
+		val := t.Time
+		return &val
+	}
+	return nil
+}
+
+func nullFloat64ToFloat64Ptr(f sql.NullFloat64) *float64 {
+	if f.Valid {
+		val := f.Float64
+		return &val
+	}
+	return nil
+}
+
 func toServiceSport(db sportDBModel) services.Sport {
 	return services.Sport{
 		ID: db.ID,
 		Name: db.Name,
+		DefaultDurationMinutes: db.DefaultDurationMinutes,
+		MinRestMinutes: db.MinRestMinutes,
+		RestConflictPolicy: db.RestConflictPolicy,
+		DeletedAt: nullTimeToTimePtr(db.DeletedAt),
+		Version: db.Version,
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
 	}
 }
 
@@ -69,13 +119,146 @@ func toServiceVenue(db venueDBModel) services.Venue {
 		Name: db.Name,
 		City: db.City,
 		CountryCode: db.CountryCode,
+		TimeZone: db.TimeZone,
+		Address: nullStringToStringPtr(db.Address),
+		PostalCode: nullStringToStringPtr(db.PostalCode),
+		Latitude: nullFloat64ToFloat64Ptr(db.Latitude),
+		Longitude: nullFloat64ToFloat64Ptr(db.Longitude),
+		Capacity: nullInt64ToIntPtr(db.Capacity),
+		DistanceKm: nullFloat64ToFloat64Ptr(db.DistanceKm),
+		DeletedAt: nullTimeToTimePtr(db.DeletedAt),
+		Version: db.Version,
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
 	}
 }
 
-func toServiceTeam(db teamDBModel) services.Team {
+func toServiceTeam(db teamDBModel, aliases []string) services.Team {
 	return services.Team{
 		ID: db.ID,
 		Name: db.Name,
 		City: db.City,
+		SportID: db.SportID,
+		ShortName: nullStringToStringPtr(db.ShortName),
+		Code: nullStringToStringPtr(db.Code),
+		Aliases: aliases,
+		DeletedAt: nullTimeToTimePtr(db.DeletedAt),
+		Version: db.Version,
+		CreatedAt: db.CreatedAt,
+		UpdatedAt: db.UpdatedAt,
+	}
+}
+
+func toServiceEventChange(db eventChangeDBModel) services.EventChange {
+	return services.EventChange{
+		ID:            db.ID,
+		EventID:       db.EventID,
+		ChangeType:    db.ChangeType,
+		ChangedAt:     db.ChangedAt,
+		EventDatetime: db.EventDatetime,
+		HomeScore:     nullInt64ToIntPtr(db.HomeScore),
+		AwayScore:     nullInt64ToIntPtr(db.AwayScore),
+		Sport: services.Sport{
+			ID:   db.SportID,
+			Name: db.SportName,
+		},
+		HomeTeam: services.Team{
+			ID:   db.HomeTeamID,
+			Name: db.HomeTeamName,
+		},
+		AwayTeam: services.Team{
+			ID:   db.AwayTeamID,
+			Name: db.AwayTeamName,
+		},
+	}
+}
+
+func toServiceEventReschedule(db eventRescheduleDBModel) services.EventReschedule {
+	return services.EventReschedule{
+		ID:               db.ID,
+		EventID:          db.EventID,
+		PreviousDatetime: db.PreviousDatetime,
+		NewDatetime:      db.NewDatetime,
+		PreviousVenueID:  nullInt64ToIntPtr(db.PreviousVenueID),
+		NewVenueID:       nullInt64ToIntPtr(db.NewVenueID),
+		Reason:           nullStringToStringPtr(db.Reason),
+		ChangedAt:        db.ChangedAt,
+	}
+}
+
+func toServiceSeries(db seriesDBModel, exceptionDates []time.Time) services.EventSeries {
+	var materializedUntil *time.Time
+	if db.MaterializedUntil.Valid {
+		materializedUntil = &db.MaterializedUntil.Time
+	}
+	return services.EventSeries{
+		ID:                db.ID,
+		RRule:             db.RRule,
+		StartDatetime:     db.StartDatetime,
+		TimeZone:          db.TimeZone,
+		MaterializedUntil: materializedUntil,
+		Description:       nullStringToStringPtr(db.Description),
+		SportID:           db.SportID,
+		VenueID:           nullInt64ToIntPtr(db.VenueID),
+		HomeTeamID:        db.HomeTeamID,
+		AwayTeamID:        db.AwayTeamID,
+		ExceptionDates:    exceptionDates,
+		DeletedAt:         nullTimeToTimePtr(db.DeletedAt),
+		Version:           db.Version,
+		CreatedAt:         db.CreatedAt,
+		UpdatedAt:         db.UpdatedAt,
+	}
+}
+
+func toServiceAttendanceStats(db attendanceStatsDBModel) services.AttendanceStats {
+	return services.AttendanceStats{
+		EventCount:         db.EventCount,
+		TotalAttendance:    db.TotalAttendance,
+		AverageAttendance:  db.AverageAttendance,
+		PeakAttendance:     db.PeakAttendance,
+		AverageUtilization: nullFloat64ToFloat64Ptr(db.AverageUtilization),
+	}
+}
+
+func toServiceBroadcast(db broadcastDBModel) services.Broadcast {
+	return services.Broadcast{
+		ID:            db.ID,
+		EventID:       db.EventID,
+		Broadcaster:   db.Broadcaster,
+		Channel:       nullStringToStringPtr(db.Channel),
+		CountryCode:   db.CountryCode,
+		URL:           nullStringToStringPtr(db.URL),
+		StartDatetime: db.StartDatetime,
+		Version:       db.Version,
+		CreatedAt:     db.CreatedAt,
+		UpdatedAt:     db.UpdatedAt,
+	}
+}
+
+func toServiceSearchHit(hitType string, db searchHitDBModel) services.SearchHit {
+	var eventDatetime *time.Time
+	if db.EventDatetime.Valid {
+		eventDatetime = &db.EventDatetime.Time
+	}
+	return services.SearchHit{
+		Type:          hitType,
+		ID:            db.ID,
+		Title:         db.Title,
+		Detail:        nullStringToStringPtr(db.Detail),
+		EventDatetime: eventDatetime,
+		Rank:          db.Rank,
+	}
+}
+
+func toServiceAuditEntry(db auditEntryDBModel) services.AuditEntry {
+	return services.AuditEntry{
+		ID:         db.ID,
+		EntityType: db.EntityType,
+		EntityID:   db.EntityID,
+		Action:     db.Action,
+		Actor:      db.Actor,
+		ChangedAt:  db.ChangedAt,
+		Before:     db.Before,
+		After:      db.After,
 	}
 }
@@ -9,30 +9,59 @@ import (
 type eventDBModel struct {
 	ID            int            `db:"id"`
 	EventDatetime time.Time      `db:"event_datetime"`
+	EndDatetime   time.Time      `db:"end_datetime"`
 	Description   sql.NullString `db:"description"`
 	HomeScore     sql.NullInt64  `db:"home_score"`
 	AwayScore     sql.NullInt64  `db:"away_score"`
+	Attendance    sql.NullInt64  `db:"attendance"`
+	SeriesID      sql.NullInt64  `db:"series_id"`
+	AllowVenueOverlap bool       `db:"allow_venue_overlap"`
+	OriginalDatetime sql.NullTime `db:"original_datetime"`
+	DeletedAt     sql.NullTime   `db:"deleted_at"`
+	Version       int            `db:"version"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
 
 	SportID	int    `db:"sport.id"`
 	SportName string `db:"sport.name"`
+	SportDefaultDurationMinutes int `db:"sport.default_duration_minutes"`
+	SportMinRestMinutes int `db:"sport.min_rest_minutes"`
+	SportRestConflictPolicy string `db:"sport.rest_conflict_policy"`
 
 	VenueID          sql.NullInt64  `db:"venue.id"`
 	VenueName        sql.NullString `db:"venue.name"`
 	VenueCity        sql.NullString `db:"venue.city"`
 	VenueCountryCode sql.NullString `db:"venue.country_code"`
+	VenueTimeZone    sql.NullString `db:"venue.time_zone"`
+	VenueAddress     sql.NullString  `db:"venue.address"`
+	VenuePostalCode  sql.NullString  `db:"venue.postal_code"`
+	VenueLatitude    sql.NullFloat64 `db:"venue.latitude"`
+	VenueLongitude   sql.NullFloat64 `db:"venue.longitude"`
+	VenueCapacity    sql.NullInt64   `db:"venue.capacity"`
 
 	HomeTeamID   int    `db:"ht.id"`
 	HomeTeamName string `db:"ht.name"`
 	HomeTeamCity string `db:"ht.city"`
+	HomeTeamShortName sql.NullString `db:"ht.short_name"`
+	HomeTeamCode      sql.NullString `db:"ht.code"`
 
 	AwayTeamID   int    `db:"at.id"`
 	AwayTeamName string `db:"at.name"`
 	AwayTeamCity string `db:"at.city"`
+	AwayTeamShortName sql.NullString `db:"at.short_name"`
+	AwayTeamCode      sql.NullString `db:"at.code"`
 }
 
 type sportDBModel struct {
 	ID	 int	`db:"id"`
 	Name string `db:"name"`
+	DefaultDurationMinutes int `db:"default_duration_minutes"`
+	MinRestMinutes int `db:"min_rest_minutes"`
+	RestConflictPolicy string `db:"rest_conflict_policy"`
+	DeletedAt sql.NullTime `db:"deleted_at"`
+	Version int `db:"version"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
 }
 
 type venueDBModel struct {
@@ -40,11 +69,115 @@ type venueDBModel struct {
 	Name 		string `db:"name"`
 	City 		string `db:"city"`
 	CountryCode string `db:"country_code"`
+	TimeZone 	string `db:"time_zone"`
+	Address 	sql.NullString `db:"address"`
+	PostalCode 	sql.NullString `db:"postal_code"`
+	Latitude 	sql.NullFloat64 `db:"latitude"`
+	Longitude 	sql.NullFloat64 `db:"longitude"`
+	Capacity 	sql.NullInt64 `db:"capacity"`
+	DistanceKm 	sql.NullFloat64 `db:"distance_km"`
+	DeletedAt 	sql.NullTime `db:"deleted_at"`
+	Version 	int `db:"version"`
+	CreatedAt 	time.Time `db:"created_at"`
+	UpdatedAt 	time.Time `db:"updated_at"`
 }
 
 type teamDBModel struct {
-	ID      int    `db:"id"`
-	Name    string `db:"name"`
-	City    string `db:"city"`
-	SportID int    `db:"_sport_id"`
+	ID        int            `db:"id"`
+	Name      string         `db:"name"`
+	City      string         `db:"city"`
+	SportID   int            `db:"_sport_id"`
+	ShortName sql.NullString `db:"short_name"`
+	Code      sql.NullString `db:"code"`
+	DeletedAt sql.NullTime   `db:"deleted_at"`
+	Version   int            `db:"version"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+type eventChangeDBModel struct {
+	ID            int           `db:"id"`
+	EventID       int           `db:"_event_id"`
+	ChangeType    string        `db:"change_type"`
+	ChangedAt     time.Time     `db:"changed_at"`
+	EventDatetime time.Time     `db:"event_datetime"`
+	HomeScore     sql.NullInt64 `db:"home_score"`
+	AwayScore     sql.NullInt64 `db:"away_score"`
+
+	SportID   int    `db:"_sport_id"`
+	SportName string `db:"sport_name"`
+
+	HomeTeamID   int    `db:"_home_team_id"`
+	HomeTeamName string `db:"home_team_name"`
+
+	AwayTeamID   int    `db:"_away_team_id"`
+	AwayTeamName string `db:"away_team_name"`
+}
+
+type eventRescheduleDBModel struct {
+	ID               int            `db:"id"`
+	EventID          int            `db:"_event_id"`
+	PreviousDatetime time.Time      `db:"previous_datetime"`
+	NewDatetime      time.Time      `db:"new_datetime"`
+	PreviousVenueID  sql.NullInt64  `db:"_previous_venue_id"`
+	NewVenueID       sql.NullInt64  `db:"_new_venue_id"`
+	Reason           sql.NullString `db:"reason"`
+	ChangedAt        time.Time      `db:"changed_at"`
+}
+
+type seriesDBModel struct {
+	ID                int            `db:"id"`
+	RRule             string         `db:"rrule"`
+	StartDatetime     time.Time      `db:"start_datetime"`
+	TimeZone          string         `db:"time_zone"`
+	MaterializedUntil sql.NullTime   `db:"materialized_until"`
+	Description       sql.NullString `db:"description"`
+	SportID           int            `db:"_sport_id"`
+	VenueID           sql.NullInt64  `db:"_venue_id"`
+	HomeTeamID        int            `db:"_home_team_id"`
+	AwayTeamID        int            `db:"_away_team_id"`
+	DeletedAt         sql.NullTime   `db:"deleted_at"`
+	Version           int            `db:"version"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+}
+
+type attendanceStatsDBModel struct {
+	EventCount         int             `db:"event_count"`
+	TotalAttendance    int             `db:"total_attendance"`
+	AverageAttendance  float64         `db:"average_attendance"`
+	PeakAttendance     int             `db:"peak_attendance"`
+	AverageUtilization sql.NullFloat64 `db:"average_utilization"`
+}
+
+type broadcastDBModel struct {
+	ID            int            `db:"id"`
+	EventID       int            `db:"_event_id"`
+	Broadcaster   string         `db:"broadcaster"`
+	Channel       sql.NullString `db:"channel"`
+	CountryCode   string         `db:"country_code"`
+	URL           sql.NullString `db:"url"`
+	StartDatetime time.Time      `db:"start_datetime"`
+	Version       int            `db:"version"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+type searchHitDBModel struct {
+	ID            int            `db:"id"`
+	Title         string         `db:"title"`
+	Detail        sql.NullString `db:"detail"`
+	EventDatetime sql.NullTime   `db:"event_datetime"`
+	Rank          float64        `db:"rank"`
+}
+
+type auditEntryDBModel struct {
+	ID         int       `db:"id"`
+	EntityType string    `db:"entity_type"`
+	EntityID   int       `db:"entity_id"`
+	Action     string    `db:"action"`
+	Actor      string    `db:"actor"`
+	ChangedAt  time.Time `db:"changed_at"`
+	Before     []byte    `db:"before"`
+	After      []byte    `db:"after"`
 }
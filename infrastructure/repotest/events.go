@@ -0,0 +1,294 @@
+package repotest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func testEvents(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	ctx := context.Background()
+	kickoff := time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC)
+
+	t.Run("CreateEvent and GetEventByID", func(t *testing.T) {
+		repos := newRepos(t)
+		f := newFixture(t, repos)
+		params := f.event(kickoff)
+		params.Description = stringPtr("Derby")
+		id, err := repos.Events.CreateEvent(ctx, params)
+		require.NoError(t, err)
+
+		event, err := repos.Events.GetEventByID(ctx, id)
+		require.NoError(t, err)
+		assert.True(t, event.EventDatetime.Equal(kickoff))
+		assert.True(t, event.EndDatetime.Equal(kickoff.Add(2*time.Hour)))
+		assert.Equal(t, "Derby", *event.Description)
+		assert.Equal(t, "Football", event.Sport.Name)
+		assert.Equal(t, services.DefaultEventDurationMinutes, event.Sport.DefaultDurationMinutes)
+		assert.Equal(t, "Arena", event.Venue.Name)
+		assert.Equal(t, 50000, *event.Venue.Capacity)
+		assert.Equal(t, "Alpha", event.HomeTeam.Name)
+		assert.Equal(t, "Bra", *event.AwayTeam.Code)
+		assert.Zero(t, event.HomeTeam.SportID, "events carry only part of their teams")
+		assert.Nil(t, event.Broadcasts)
+		assert.Nil(t, event.OriginalDatetime)
+		assert.Equal(t, 1, event.Version)
+
+		_, err = repos.Events.GetEventByID(ctx, 404)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("CreateEvent enforces constraints", func(t *testing.T) {
+		repos := newRepos(t)
+		f := newFixture(t, repos)
+
+		for name, change := range map[string]func(*services.CreateEventParams){
+			"unknown sport":     func(p *services.CreateEventParams) { p.SportID = 404 },
+			"unknown venue":     func(p *services.CreateEventParams) { p.VenueID = intPtr(404) },
+			"unknown home team": func(p *services.CreateEventParams) { p.HomeTeamID = 404 },
+			"unknown away team": func(p *services.CreateEventParams) { p.AwayTeamID = 404 },
+		} {
+			t.Run(name, func(t *testing.T) {
+				params := f.event(kickoff)
+				change(&params)
+				_, err := repos.Events.CreateEvent(ctx, params)
+				requireConstraintError(t, err, "23503")
+			})
+		}
+
+		params := f.event(kickoff)
+		params.AwayTeamID = params.HomeTeamID
+		_, err := repos.Events.CreateEvent(ctx, params)
+		requireConstraintError(t, err, "23514")
+		params = f.event(kickoff)
+		params.EndDatetime = kickoff
+		_, err = repos.Events.CreateEvent(ctx, params)
+		requireConstraintError(t, err, "23514")
+	})
+
+	t.Run("venue bookings do not overlap", func(t *testing.T) {
+		repos := newRepos(t)
+		f := newFixture(t, repos)
+		firstID, err := repos.Events.CreateEvent(ctx, f.event(kickoff))
+		require.NoError(t, err)
+
+		_, err = repos.Events.CreateEvent(ctx, f.event(kickoff.Add(time.Hour)))
+		assert.ErrorIs(t, err, services.ErrVenueConflict)
+		_, err = repos.Events.CreateEvent(ctx, f.event(kickoff.Add(2*time.Hour)))
+		assert.NoError(t, err, "bookings are half-open")
+		shared := f.event(kickoff.Add(time.Hour))
+		shared.AllowVenueOverlap = true
+		_, err = repos.Events.CreateEvent(ctx, shared)
+		assert.NoError(t, err)
+		elsewhere := f.event(kickoff)
+		elsewhere.VenueID = nil
+		_, err = repos.Events.CreateEvent(ctx, elsewhere)
+		assert.NoError(t, err)
+
+		require.NoError(t, repos.Events.DeleteEvent(ctx, firstID))
+		_, err = repos.Events.CreateEvent(ctx, f.event(kickoff.Add(-time.Hour)))
+		require.NoError(t, err, "deleted events free their slot")
+		assert.ErrorIs(t, repos.Events.RestoreEvent(ctx, firstID), services.ErrVenueConflict)
+		_, err = repos.Events.GetEventByID(ctx, firstID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("ListEvents and CountEvents", func(t *testing.T) {
+		repos := newRepos(t)
+		f := newFixture(t, repos)
+		otherSportID, err := repos.Sports.CreateSport(ctx, services.SportRequest{Name: "Hockey"})
+		require.NoError(t, err)
+		farVenueID, err := repos.Venues.CreateVenue(ctx, services.VenueRequest{
+			Name: "Far", City: "Hamburg", CountryCode: "DE", TimeZone: "Europe/Berlin",
+			Latitude: float64Ptr(53.5511), Longitude: float64Ptr(9.9937),
+		})
+		require.NoError(t, err)
+
+		var ids []int
+		for day := 4; day >= 0; day-- {
+			params := f.event(kickoff.AddDate(0, 0, day))
+			switch day {
+			case 1:
+				params.SportID = otherSportID
+			case 3:
+				params.VenueID = &farVenueID
+			}
+			id, err := repos.Events.CreateEvent(ctx, params)
+			require.NoError(t, err)
+			ids = append([]int{id}, ids...)
+		}
+		deletedID, err := repos.Events.CreateEvent(ctx, f.event(kickoff.AddDate(0, 0, 5)))
+		require.NoError(t, err)
+		require.NoError(t, repos.Events.DeleteEvent(ctx, deletedID))
+
+		list := func(ctx context.Context, params services.ListEventsParams) []int {
+			t.Helper()
+			if params.Limit == 0 {
+				params.Limit = 100
+			}
+			events, err := repos.Events.ListEvents(ctx, params)
+			require.NoError(t, err)
+			eventIDs := make([]int, 0, len(events))
+			for _, event := range events {
+				eventIDs = append(eventIDs, event.ID)
+			}
+			if params.Offset == 0 && params.Limit == 100 {
+				count, err := repos.Events.CountEvents(ctx, params)
+				require.NoError(t, err)
+				assert.Equal(t, len(events), count)
+			}
+			return eventIDs
+		}
+
+		assert.Equal(t, ids, list(ctx, services.ListEventsParams{}))
+		assert.Equal(t, ids[1:3], list(ctx, services.ListEventsParams{Limit: 2, Offset: 1}))
+		assert.Empty(t, list(ctx, services.ListEventsParams{Offset: 10}))
+		assert.Equal(t, []int{ids[1]}, list(ctx, services.ListEventsParams{SportID: &otherSportID}))
+		assert.Equal(t, ids[1:3], list(ctx, services.ListEventsParams{
+			DateFrom: timePtr(kickoff.AddDate(0, 0, 1)), DateTo: timePtr(kickoff.AddDate(0, 0, 2).Add(time.Hour)),
+		}))
+		assert.Equal(t, ids[0:2], list(ctx, services.ListEventsParams{
+			DateFrom: timePtr(kickoff.Add(time.Hour)), DateTo: timePtr(kickoff.AddDate(0, 0, 2)),
+		}), "DateFrom selects the events still running")
+		assert.Equal(t, ids[1:2], list(ctx, services.ListEventsParams{
+			StartFrom: timePtr(kickoff.Add(time.Hour)), DateTo: timePtr(kickoff.AddDate(0, 0, 2)),
+		}))
+		assert.Equal(t, []int{ids[0], ids[1], ids[2], ids[4]}, list(ctx, services.ListEventsParams{
+			Near: &services.GeoPoint{Latitude: 52.52, Longitude: 13.405}, RadiusKm: 50,
+		}))
+		assert.Empty(t, list(ctx, services.ListEventsParams{BroadcastCountry: stringPtr("GB")}))
+		assert.Equal(t, append(append([]int{}, ids...), deletedID), list(services.WithDeleted(ctx), services.ListEventsParams{}))
+	})
+
+	t.Run("UpdateEvent", func(t *testing.T) {
+		repos := newRepos(t)
+		f := newFixture(t, repos)
+		id, err := repos.Events.CreateEvent(ctx, f.event(kickoff))
+		require.NoError(t, err)
+		_, err = repos.Events.CreateEvent(ctx, f.event(kickoff.AddDate(0, 0, 1)))
+		require.NoError(t, err)
+		event, err := repos.Events.GetEventByID(ctx, id)
+		require.NoError(t, err)
+
+		event.HomeScore, event.AwayScore, event.Attendance = intPtr(2), intPtr(1), intPtr(40000)
+		event.AwayTeam.ID = f.teamIDs[2]
+		require.NoError(t, repos.Events.UpdateEvent(ctx, *event))
+		updated, err := repos.Events.GetEventByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, 2, *updated.HomeScore)
+		assert.Equal(t, "Charlie", updated.AwayTeam.Name)
+		assert.Equal(t, 2, updated.Version)
+
+		var mismatch *services.VersionMismatchError
+		require.ErrorAs(t, repos.Events.UpdateEvent(ctx, *event), &mismatch)
+		assert.Equal(t, 2, mismatch.CurrentVersion)
+
+		updated.EventDatetime = kickoff.AddDate(0, 0, 1).Add(time.Hour)
+		updated.EndDatetime = updated.EventDatetime.Add(2 * time.Hour)
+		assert.ErrorIs(t, repos.Events.UpdateEvent(ctx, *updated), services.ErrVenueConflict)
+		updated.Venue = services.Venue{}
+		require.NoError(t, repos.Events.UpdateEvent(ctx, *updated))
+		moved, err := repos.Events.GetEventByID(ctx, id)
+		require.NoError(t, err)
+		assert.Zero(t, moved.Venue.ID)
+
+		moved.Attendance = intPtr(-1)
+		requireConstraintError(t, repos.Events.UpdateEvent(ctx, *moved), "23514")
+		assert.ErrorIs(t, repos.Events.UpdateEvent(ctx, services.Event{ID: 404, Version: 1}), sql.ErrNoRows)
+	})
+
+	t.Run("counts, conflicts and team schedules", func(t *testing.T) {
+		repos := newRepos(t)
+		f := newFixture(t, repos)
+		firstID, err := repos.Events.CreateEvent(ctx, f.event(kickoff))
+		require.NoError(t, err)
+		params := f.event(kickoff.AddDate(0, 0, 1))
+		params.HomeTeamID = f.teamIDs[2]
+		secondID, err := repos.Events.CreateEvent(ctx, params)
+		require.NoError(t, err)
+		deletedID, err := repos.Events.CreateEvent(ctx, f.event(kickoff.AddDate(0, 0, 2)))
+		require.NoError(t, err)
+		require.NoError(t, repos.Events.DeleteEvent(ctx, deletedID))
+
+		count, err := repos.Events.CountEventsBySportID(ctx, f.sportID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		count, err = repos.Events.CountEventsByVenueId(ctx, f.venueID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		count, err = repos.Events.CountEventsByTeamID(ctx, f.teamIDs[0])
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		conflicts, err := repos.Events.ListVenueConflicts(ctx, f.venueID, kickoff.Add(time.Hour), kickoff.AddDate(0, 0, 3), 0)
+		require.NoError(t, err)
+		require.Len(t, conflicts, 2)
+		assert.Equal(t, []int{firstID, secondID}, []int{conflicts[0].ID, conflicts[1].ID})
+		conflicts, err = repos.Events.ListVenueConflicts(ctx, f.venueID, kickoff.Add(time.Hour), kickoff.AddDate(0, 0, 3), firstID)
+		require.NoError(t, err)
+		assert.Len(t, conflicts, 1)
+
+		events, err := repos.Events.ListTeamEvents(ctx, f.teamIDs[1], nil, nil)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, firstID, events[0].ID)
+		events, err = repos.Events.ListTeamEvents(ctx, f.teamIDs[1], timePtr(kickoff.Add(2*time.Hour)), nil)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, secondID, events[0].ID)
+		events, err = repos.Events.ListTeamEvents(ctx, f.teamIDs[1], nil, timePtr(kickoff))
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("GetAttendanceStats", func(t *testing.T) {
+		repos := newRepos(t)
+		f := newFixture(t, repos)
+		record := func(start time.Time, venueID *int, attendance int) int {
+			params := f.event(start)
+			params.VenueID = venueID
+			id, err := repos.Events.CreateEvent(ctx, params)
+			require.NoError(t, err)
+			event, err := repos.Events.GetEventByID(ctx, id)
+			require.NoError(t, err)
+			event.Attendance = &attendance
+			require.NoError(t, repos.Events.UpdateEvent(ctx, *event))
+			return id
+		}
+		record(kickoff, &f.venueID, 30000)
+		peakID := record(kickoff.AddDate(0, 0, 1), &f.venueID, 45000)
+		record(kickoff.AddDate(0, 0, 2), &f.venueID, 45000)
+		record(kickoff.AddDate(0, 0, 3), nil, 1000)
+		_, err := repos.Events.CreateEvent(ctx, f.event(kickoff.AddDate(0, 0, 4)))
+		require.NoError(t, err)
+
+		stats, err := repos.Events.GetAttendanceStats(ctx, services.AttendanceStatsParams{})
+		require.NoError(t, err)
+		assert.Equal(t, 4, stats.EventCount)
+		assert.Equal(t, 121000, stats.TotalAttendance)
+		assert.InDelta(t, 30250, stats.AverageAttendance, 0.001)
+		assert.Equal(t, 45000, stats.PeakAttendance)
+		require.NotNil(t, stats.PeakEventID)
+		assert.Equal(t, peakID, *stats.PeakEventID)
+		require.NotNil(t, stats.AverageUtilization)
+		assert.InDelta(t, 0.8, *stats.AverageUtilization, 0.001)
+
+		stats, err = repos.Events.GetAttendanceStats(ctx, services.AttendanceStatsParams{
+			VenueID: &f.venueID, DateFrom: timePtr(kickoff.AddDate(0, 0, 1)), DateTo: timePtr(kickoff.AddDate(0, 0, 2)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.EventCount)
+		assert.Equal(t, peakID, *stats.PeakEventID)
+
+		stats, err = repos.Events.GetAttendanceStats(ctx, services.AttendanceStatsParams{TeamID: &f.teamIDs[2]})
+		require.NoError(t, err)
+		assert.Equal(t, 0, stats.EventCount)
+		assert.Nil(t, stats.PeakEventID)
+		assert.Nil(t, stats.AverageUtilization)
+	})
+}
@@ -0,0 +1,93 @@
+// Package repotest is a conformance suite for implementations of the sport,
+// team, venue and event repositories. Every implementation is held to the
+// behaviour of the PostgreSQL one: what reads return and in which order,
+// versions and soft deletes, and which constraint violations writes report.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// Repositories are the implementations under test, all over one store.
+type Repositories struct {
+	Sports services.SportRepositoryInterface
+	Teams  services.TeamRepositoryInterface
+	Venues services.VenueRepositoryInterface
+	Events services.EventRepositoryInterface
+}
+
+// Run runs the suite. newRepos is called by every test for repositories over
+// an empty store.
+func Run(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	t.Run("Sports", func(t *testing.T) { testSports(t, newRepos) })
+	t.Run("Venues", func(t *testing.T) { testVenues(t, newRepos) })
+	t.Run("Teams", func(t *testing.T) { testTeams(t, newRepos) })
+	t.Run("Events", func(t *testing.T) { testEvents(t, newRepos) })
+}
+
+// requireConstraintError asserts that err is the violation of a constraint
+// reported with code, e.g. 23505 for a unique constraint.
+func requireConstraintError(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(err, &pgErr), "want a constraint violation, got %v", err)
+	require.Equal(t, code, pgErr.Code, pgErr.Message)
+}
+
+// fixture is a sport with a venue and three teams, for the tests that need
+// something to schedule.
+type fixture struct {
+	sportID int
+	venueID int
+	teamIDs [3]int
+}
+
+func newFixture(t *testing.T, repos Repositories) fixture {
+	t.Helper()
+	ctx := context.Background()
+
+	var f fixture
+	var err error
+	f.sportID, err = repos.Sports.CreateSport(ctx, services.SportRequest{Name: "Football"})
+	require.NoError(t, err)
+	f.venueID, err = repos.Venues.CreateVenue(ctx, services.VenueRequest{
+		Name: "Arena", City: "Berlin", CountryCode: "DE", TimeZone: "Europe/Berlin",
+		Latitude: float64Ptr(52.5145), Longitude: float64Ptr(13.2395), Capacity: intPtr(50000),
+	})
+	require.NoError(t, err)
+	for i, name := range []string{"Alpha", "Bravo", "Charlie"} {
+		f.teamIDs[i], err = repos.Teams.CreateTeam(ctx, services.TeamRequest{
+			Name: name, City: "Berlin", SportID: f.sportID, Code: stringPtr(name[:3]),
+		})
+		require.NoError(t, err)
+	}
+	return f
+}
+
+// event returns the parameters of a two hour event of the fixture starting
+// at start, between its first two teams at its venue.
+func (f fixture) event(start time.Time) services.CreateEventParams {
+	return services.CreateEventParams{
+		EventDatetime: start,
+		EndDatetime:   start.Add(2 * time.Hour),
+		SportID:       f.sportID,
+		VenueID:       intPtr(f.venueID),
+		HomeTeamID:    f.teamIDs[0],
+		AwayTeamID:    f.teamIDs[1],
+	}
+}
+
+func intPtr(v int) *int             { return &v }
+func float64Ptr(v float64) *float64 { return &v }
+func stringPtr(v string) *string    { return &v }
+func timePtr(v time.Time) *time.Time {
+	return &v
+}
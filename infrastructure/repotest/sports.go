@@ -0,0 +1,154 @@
+package repotest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func testSports(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	ctx := context.Background()
+
+	t.Run("CreateSport fills in defaults", func(t *testing.T) {
+		repo := newRepos(t).Sports
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+		require.NoError(t, err)
+
+		sport, err := repo.GetSportById(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Rugby", sport.Name)
+		assert.Equal(t, services.DefaultEventDurationMinutes, sport.DefaultDurationMinutes)
+		assert.Equal(t, 0, sport.MinRestMinutes)
+		assert.Equal(t, services.RestPolicyReject, sport.RestConflictPolicy)
+		assert.Equal(t, 1, sport.Version)
+		assert.False(t, sport.CreatedAt.IsZero())
+		assert.True(t, sport.CreatedAt.Equal(sport.UpdatedAt))
+		assert.Nil(t, sport.DeletedAt)
+	})
+
+	t.Run("CreateSport enforces constraints", func(t *testing.T) {
+		repo := newRepos(t).Sports
+		_, err := repo.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+		require.NoError(t, err)
+
+		_, err = repo.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+		requireConstraintError(t, err, "23505")
+		_, err = repo.CreateSport(ctx, services.SportRequest{Name: "Polo", DefaultDurationMinutes: intPtr(0)})
+		requireConstraintError(t, err, "23514")
+		_, err = repo.CreateSport(ctx, services.SportRequest{Name: "Polo", MinRestMinutes: intPtr(-1)})
+		requireConstraintError(t, err, "23514")
+		_, err = repo.CreateSport(ctx, services.SportRequest{Name: "Polo", RestConflictPolicy: stringPtr("ignore")})
+		requireConstraintError(t, err, "23514")
+	})
+
+	t.Run("GetSportById of a missing sport", func(t *testing.T) {
+		_, err := newRepos(t).Sports.GetSportById(ctx, 404)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("ListSports orders by name", func(t *testing.T) {
+		repo := newRepos(t).Sports
+		for _, name := range []string{"Cricket", "Athletics", "Badminton"} {
+			_, err := repo.CreateSport(ctx, services.SportRequest{Name: name})
+			require.NoError(t, err)
+		}
+
+		sports, err := repo.ListSports(ctx)
+		require.NoError(t, err)
+		require.Len(t, sports, 3)
+		assert.Equal(t, []string{"Athletics", "Badminton", "Cricket"},
+			[]string{sports[0].Name, sports[1].Name, sports[2].Name})
+	})
+
+	t.Run("UpdateSport", func(t *testing.T) {
+		repo := newRepos(t).Sports
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+		require.NoError(t, err)
+		_, err = repo.CreateSport(ctx, services.SportRequest{Name: "Hockey"})
+		require.NoError(t, err)
+		sport, err := repo.GetSportById(ctx, id)
+		require.NoError(t, err)
+
+		sport.Name = "Rugby Union"
+		sport.MinRestMinutes = 60
+		require.NoError(t, repo.UpdateSport(ctx, *sport))
+		updated, err := repo.GetSportById(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Rugby Union", updated.Name)
+		assert.Equal(t, 60, updated.MinRestMinutes)
+		assert.Equal(t, 2, updated.Version)
+		assert.False(t, updated.UpdatedAt.Before(sport.UpdatedAt))
+
+		var mismatch *services.VersionMismatchError
+		err = repo.UpdateSport(ctx, *sport)
+		require.ErrorAs(t, err, &mismatch)
+		assert.Equal(t, 2, mismatch.CurrentVersion)
+
+		updated.Name = "Hockey"
+		requireConstraintError(t, repo.UpdateSport(ctx, *updated), "23505")
+
+		err = repo.UpdateSport(ctx, services.Sport{ID: 404, Name: "Lacrosse",
+			DefaultDurationMinutes: 60, RestConflictPolicy: services.RestPolicyReject})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		err = repo.UpdateSport(ctx, services.Sport{ID: 404, Name: "Lacrosse", Version: 1,
+			DefaultDurationMinutes: 60, RestConflictPolicy: services.RestPolicyReject})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("UpdateSport of a deleted sport", func(t *testing.T) {
+		repo := newRepos(t).Sports
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+		require.NoError(t, err)
+		require.NoError(t, repo.DeleteSport(ctx, id))
+		deleted, err := repo.GetSportById(services.WithDeleted(ctx), id)
+		require.NoError(t, err)
+
+		renamed := *deleted
+		renamed.Name = "Rugby Union"
+		assert.ErrorIs(t, repo.UpdateSport(ctx, renamed), sql.ErrNoRows)
+		renamed.Version = 0
+		assert.ErrorIs(t, repo.UpdateSport(ctx, renamed), sql.ErrNoRows)
+
+		unchanged, err := repo.GetSportById(services.WithDeleted(ctx), id)
+		require.NoError(t, err)
+		assert.Equal(t, "Rugby", unchanged.Name)
+		assert.Equal(t, deleted.Version, unchanged.Version)
+	})
+
+	t.Run("DeleteSport and RestoreSport", func(t *testing.T) {
+		repo := newRepos(t).Sports
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.DeleteSport(ctx, id))
+		_, err = repo.GetSportById(ctx, id)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		sports, err := repo.ListSports(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, sports)
+
+		deleted, err := repo.GetSportById(services.WithDeleted(ctx), id)
+		require.NoError(t, err)
+		assert.NotNil(t, deleted.DeletedAt)
+		sports, err = repo.ListSports(services.WithDeleted(ctx))
+		require.NoError(t, err)
+		assert.Len(t, sports, 1)
+
+		assert.NoError(t, repo.DeleteSport(ctx, id))
+		assert.NoError(t, repo.DeleteSport(ctx, 404))
+		_, err = repo.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+		requireConstraintError(t, err, "23505")
+
+		require.NoError(t, repo.RestoreSport(ctx, id))
+		restored, err := repo.GetSportById(ctx, id)
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+		assert.Greater(t, restored.Version, deleted.Version)
+		assert.ErrorIs(t, repo.RestoreSport(ctx, id), sql.ErrNoRows)
+		assert.ErrorIs(t, repo.RestoreSport(ctx, 404), sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,212 @@
+package repotest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func testTeams(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	ctx := context.Background()
+
+	newSports := func(t *testing.T, repos Repositories) (int, int) {
+		football, err := repos.Sports.CreateSport(ctx, services.SportRequest{Name: "Football"})
+		require.NoError(t, err)
+		handball, err := repos.Sports.CreateSport(ctx, services.SportRequest{Name: "Handball"})
+		require.NoError(t, err)
+		return football, handball
+	}
+
+	t.Run("CreateTeam and GetTeamByID", func(t *testing.T) {
+		repos := newRepos(t)
+		football, _ := newSports(t, repos)
+		id, err := repos.Teams.CreateTeam(ctx, services.TeamRequest{
+			Name: "FC Bayern München", City: "Munich", SportID: football,
+			ShortName: stringPtr("Bayern"), Code: stringPtr("FCB"), Aliases: []string{"FC Bayern", "Bayern Munich"},
+		})
+		require.NoError(t, err)
+
+		team, err := repos.Teams.GetTeamByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "FC Bayern München", team.Name)
+		assert.Equal(t, football, team.SportID)
+		assert.Equal(t, "FCB", *team.Code)
+		assert.Equal(t, []string{"Bayern Munich", "FC Bayern"}, team.Aliases)
+		assert.Equal(t, 1, team.Version)
+
+		_, err = repos.Teams.GetTeamByID(ctx, 404)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("CreateTeam enforces constraints", func(t *testing.T) {
+		repos := newRepos(t)
+		football, handball := newSports(t, repos)
+		_, err := repos.Teams.CreateTeam(ctx, services.TeamRequest{
+			Name: "Rovers", City: "Leeds", SportID: football, Code: stringPtr("ROV"), Aliases: []string{"Café Rovers"},
+		})
+		require.NoError(t, err)
+
+		_, err = repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: "Rovers", City: "Leeds", SportID: 404})
+		requireConstraintError(t, err, "23503")
+		_, err = repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: "Rovers", City: "York", SportID: football})
+		requireConstraintError(t, err, "23505")
+		_, err = repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: "United", City: "York", SportID: football, Code: stringPtr("ROV")})
+		requireConstraintError(t, err, "23505")
+		_, err = repos.Teams.CreateTeam(ctx, services.TeamRequest{
+			Name: "United", City: "York", SportID: football, Aliases: []string{"CAFE ROVERS"},
+		})
+		requireConstraintError(t, err, "23505")
+
+		_, err = repos.Teams.CreateTeam(ctx, services.TeamRequest{
+			Name: "Rovers", City: "Leeds", SportID: handball, Code: stringPtr("ROV"), Aliases: []string{"Café Rovers"},
+		})
+		assert.NoError(t, err, "names are unique per sport")
+		_, err = repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: "United", City: "York", SportID: football})
+		assert.NoError(t, err, "teams without a code or short name do not clash")
+	})
+
+	t.Run("ListTeams and FindTeamsByName", func(t *testing.T) {
+		repos := newRepos(t)
+		football, handball := newSports(t, repos)
+		for _, params := range []services.TeamRequest{
+			{Name: "Wanderers", City: "Bolton", SportID: football, Code: stringPtr("WAN"), Aliases: []string{"Atlético"}},
+			{Name: "Athletic", City: "Bilbao", SportID: football, ShortName: stringPtr("Atlético")},
+			{Name: "Atletico", City: "Madrid", SportID: handball},
+			{Name: "Deleted", City: "Nowhere", SportID: football, Aliases: []string{"Atletico Old"}},
+		} {
+			id, err := repos.Teams.CreateTeam(ctx, params)
+			require.NoError(t, err)
+			if params.Name == "Deleted" {
+				require.NoError(t, repos.Teams.DeleteTeam(ctx, id))
+			}
+		}
+
+		teams, err := repos.Teams.ListTeams(ctx)
+		require.NoError(t, err)
+		require.Len(t, teams, 3)
+		assert.Equal(t, []string{"Athletic", "Atletico", "Wanderers"}, []string{teams[0].Name, teams[1].Name, teams[2].Name})
+		assert.NotNil(t, teams[0].Aliases)
+
+		found, err := repos.Teams.FindTeamsByName(ctx, "ATLETICO", nil)
+		require.NoError(t, err)
+		require.Len(t, found, 3)
+		assert.Equal(t, []string{"Athletic", "Atletico", "Wanderers"}, []string{found[0].Name, found[1].Name, found[2].Name})
+
+		found, err = repos.Teams.FindTeamsByName(ctx, "atlético", &football)
+		require.NoError(t, err)
+		assert.Len(t, found, 2)
+		found, err = repos.Teams.FindTeamsByName(ctx, "wan", nil)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "Wanderers", found[0].Name)
+		found, err = repos.Teams.FindTeamsByName(ctx, "Atletico Old", nil)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("UpdateTeam", func(t *testing.T) {
+		repos := newRepos(t)
+		football, _ := newSports(t, repos)
+		id, err := repos.Teams.CreateTeam(ctx, services.TeamRequest{
+			Name: "Rovers", City: "Leeds", SportID: football, Aliases: []string{"The Rovers"},
+		})
+		require.NoError(t, err)
+		_, err = repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: "United", City: "York", SportID: football})
+		require.NoError(t, err)
+		team, err := repos.Teams.GetTeamByID(ctx, id)
+		require.NoError(t, err)
+
+		team.City, team.Aliases = "Bradford", []string{"Bradford Rovers"}
+		require.NoError(t, repos.Teams.UpdateTeam(ctx, *team))
+		updated, err := repos.Teams.GetTeamByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Bradford", updated.City)
+		assert.Equal(t, []string{"Bradford Rovers"}, updated.Aliases)
+		assert.Equal(t, 2, updated.Version)
+
+		var mismatch *services.VersionMismatchError
+		require.ErrorAs(t, repos.Teams.UpdateTeam(ctx, *team), &mismatch)
+		assert.Equal(t, 2, mismatch.CurrentVersion)
+		updated.Name = "United"
+		requireConstraintError(t, repos.Teams.UpdateTeam(ctx, *updated), "23505")
+	})
+
+	t.Run("CountTeamsBySportID", func(t *testing.T) {
+		repos := newRepos(t)
+		football, handball := newSports(t, repos)
+		for _, name := range []string{"Rovers", "United", "Wanderers"} {
+			_, err := repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: name, City: "Leeds", SportID: football})
+			require.NoError(t, err)
+		}
+		deletedID, err := repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: "Athletic", City: "Leeds", SportID: football})
+		require.NoError(t, err)
+		require.NoError(t, repos.Teams.DeleteTeam(ctx, deletedID))
+
+		count, err := repos.Teams.CountTeamsBySportID(ctx, football)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		count, err = repos.Teams.CountTeamsBySportID(ctx, handball)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("DeleteTeam and RestoreTeam", func(t *testing.T) {
+		repos := newRepos(t)
+		football, _ := newSports(t, repos)
+		id, err := repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: "Rovers", City: "Leeds", SportID: football})
+		require.NoError(t, err)
+
+		require.NoError(t, repos.Teams.DeleteTeam(ctx, id))
+		_, err = repos.Teams.GetTeamByID(ctx, id)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		_, err = repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: "Rovers", City: "Leeds", SportID: football})
+		requireConstraintError(t, err, "23505")
+
+		require.NoError(t, repos.Teams.RestoreTeam(ctx, id))
+		_, err = repos.Teams.GetTeamByID(ctx, id)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, repos.Teams.RestoreTeam(ctx, id), sql.ErrNoRows)
+	})
+
+	t.Run("MergeTeams", func(t *testing.T) {
+		repos := newRepos(t)
+		f := newFixture(t, repos)
+		duplicateID, err := repos.Teams.CreateTeam(ctx, services.TeamRequest{
+			Name: "Alpha FC", City: "Berlin", SportID: f.sportID, Aliases: []string{"The Alphas"},
+		})
+		require.NoError(t, err)
+		params := f.event(time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC))
+		params.AwayTeamID = duplicateID
+		eventID, err := repos.Events.CreateEvent(ctx, params)
+		require.NoError(t, err)
+
+		survivor, err := repos.Teams.GetTeamByID(ctx, f.teamIDs[1])
+		require.NoError(t, err)
+		survivor.Name, survivor.Aliases = "Alpha FC", []string{"Bravo", "The Alphas"}
+		require.NoError(t, repos.Teams.MergeTeams(ctx, *survivor, duplicateID))
+
+		merged, err := repos.Teams.GetTeamByID(ctx, f.teamIDs[1])
+		require.NoError(t, err)
+		assert.Equal(t, "Alpha FC", merged.Name)
+		assert.Equal(t, []string{"Bravo", "The Alphas"}, merged.Aliases)
+		_, err = repos.Teams.GetTeamByID(services.WithDeleted(ctx), duplicateID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		event, err := repos.Events.GetEventByID(ctx, eventID)
+		require.NoError(t, err)
+		assert.Equal(t, f.teamIDs[1], event.AwayTeam.ID)
+		assert.Equal(t, "Alpha FC", event.AwayTeam.Name)
+
+		require.NoError(t, repos.Events.DeleteEvent(ctx, eventID))
+		err = repos.Teams.MergeTeams(ctx, *merged, f.teamIDs[0])
+		var clash *services.TeamClashError
+		require.ErrorAs(t, err, &clash)
+		assert.Equal(t, []int{eventID}, clash.EventIDs, "deleted events clash too")
+		_, err = repos.Teams.GetTeamByID(ctx, f.teamIDs[0])
+		assert.NoError(t, err, "a failed merge changes nothing")
+	})
+}
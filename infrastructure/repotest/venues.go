@@ -0,0 +1,155 @@
+package repotest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func testVenues(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	ctx := context.Background()
+
+	t.Run("CreateVenue and GetVenueById", func(t *testing.T) {
+		repo := newRepos(t).Venues
+		id, err := repo.CreateVenue(ctx, services.VenueRequest{
+			Name: "Arena", City: "Berlin", CountryCode: "DE", TimeZone: "Europe/Berlin",
+			Address: stringPtr("Olympischer Platz 3"), Latitude: float64Ptr(52.5145),
+			Longitude: float64Ptr(13.2395), Capacity: intPtr(74475),
+		})
+		require.NoError(t, err)
+
+		venue, err := repo.GetVenueById(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Arena", venue.Name)
+		assert.Equal(t, "Olympischer Platz 3", *venue.Address)
+		assert.Nil(t, venue.PostalCode)
+		assert.Equal(t, 74475, *venue.Capacity)
+		assert.Nil(t, venue.DistanceKm)
+		assert.Equal(t, 1, venue.Version)
+
+		_, err = repo.GetVenueById(ctx, 404)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("CreateVenue enforces constraints", func(t *testing.T) {
+		repo := newRepos(t).Venues
+		invalid := []services.VenueRequest{
+			{Capacity: intPtr(0)},
+			{Latitude: float64Ptr(91), Longitude: float64Ptr(0)},
+			{Latitude: float64Ptr(0), Longitude: float64Ptr(-181)},
+			{Latitude: float64Ptr(52.5)},
+		}
+		for _, params := range invalid {
+			params.Name, params.City, params.CountryCode, params.TimeZone = "Arena", "Berlin", "DE", "UTC"
+			_, err := repo.CreateVenue(ctx, params)
+			requireConstraintError(t, err, "23514")
+		}
+	})
+
+	t.Run("ListVenues, UpdateVenue and soft deletes", func(t *testing.T) {
+		repo := newRepos(t).Venues
+		var ids []int
+		for _, name := range []string{"Stadium", "Arena", "Ground"} {
+			id, err := repo.CreateVenue(ctx, services.VenueRequest{Name: name, City: "Leeds", CountryCode: "GB", TimeZone: "UTC"})
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		venues, err := repo.ListVenues(ctx)
+		require.NoError(t, err)
+		require.Len(t, venues, 3)
+		assert.Equal(t, []string{"Arena", "Ground", "Stadium"}, []string{venues[0].Name, venues[1].Name, venues[2].Name})
+
+		venue := venues[0]
+		venue.Capacity = intPtr(20000)
+		require.NoError(t, repo.UpdateVenue(ctx, venue))
+		var mismatch *services.VersionMismatchError
+		require.ErrorAs(t, repo.UpdateVenue(ctx, venue), &mismatch)
+		assert.Equal(t, 2, mismatch.CurrentVersion)
+		venue.Version, venue.Capacity = 0, intPtr(-5)
+		requireConstraintError(t, repo.UpdateVenue(ctx, venue), "23514")
+
+		require.NoError(t, repo.DeleteVenue(ctx, ids[0]))
+		venues, err = repo.ListVenues(ctx)
+		require.NoError(t, err)
+		assert.Len(t, venues, 2)
+		require.NoError(t, repo.RestoreVenue(ctx, ids[0]))
+		assert.ErrorIs(t, repo.RestoreVenue(ctx, ids[0]), sql.ErrNoRows)
+	})
+
+	t.Run("ListVenuesNearby", func(t *testing.T) {
+		repo := newRepos(t).Venues
+		create := func(name string, lat, lon *float64) int {
+			id, err := repo.CreateVenue(ctx, services.VenueRequest{
+				Name: name, City: "Berlin", CountryCode: "DE", TimeZone: "Europe/Berlin", Latitude: lat, Longitude: lon,
+			})
+			require.NoError(t, err)
+			return id
+		}
+		create("Potsdam", float64Ptr(52.3906), float64Ptr(13.0645))
+		create("Mitte", float64Ptr(52.5200), float64Ptr(13.4050))
+		create("Hamburg", float64Ptr(53.5511), float64Ptr(9.9937))
+		create("Nowhere", nil, nil)
+		deleted := create("Closed", float64Ptr(52.5201), float64Ptr(13.4051))
+		require.NoError(t, repo.DeleteVenue(ctx, deleted))
+
+		venues, err := repo.ListVenuesNearby(ctx, services.GeoPoint{Latitude: 52.52, Longitude: 13.405}, 50)
+		require.NoError(t, err)
+		require.Len(t, venues, 2)
+		assert.Equal(t, "Mitte", venues[0].Name)
+		assert.Equal(t, "Potsdam", venues[1].Name)
+		require.NotNil(t, venues[1].DistanceKm)
+		assert.InDelta(t, 0, *venues[0].DistanceKm, 0.01)
+		assert.InDelta(t, 27.2, *venues[1].DistanceKm, 0.5)
+
+		venues, err = repo.ListVenuesNearby(services.WithDeleted(ctx), services.GeoPoint{Latitude: 52.52, Longitude: 13.405}, 50)
+		require.NoError(t, err)
+		assert.Len(t, venues, 3)
+	})
+
+	t.Run("MergeVenues", func(t *testing.T) {
+		repos := newRepos(t)
+		f := newFixture(t, repos)
+		duplicateID, err := repos.Venues.CreateVenue(ctx, services.VenueRequest{
+			Name: "Arena (old)", City: "Berlin", CountryCode: "DE", TimeZone: "Europe/Berlin",
+		})
+		require.NoError(t, err)
+		start := time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC)
+		params := f.event(start)
+		params.VenueID = &duplicateID
+		movedID, err := repos.Events.CreateEvent(ctx, params)
+		require.NoError(t, err)
+
+		survivor, err := repos.Venues.GetVenueById(ctx, f.venueID)
+		require.NoError(t, err)
+		survivor.PostalCode = stringPtr("14053")
+		require.NoError(t, repos.Venues.MergeVenues(ctx, *survivor, duplicateID))
+
+		moved, err := repos.Events.GetEventByID(ctx, movedID)
+		require.NoError(t, err)
+		assert.Equal(t, f.venueID, moved.Venue.ID)
+		assert.Equal(t, 2, moved.Version)
+		_, err = repos.Venues.GetVenueById(services.WithDeleted(ctx), duplicateID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		merged, err := repos.Venues.GetVenueById(ctx, f.venueID)
+		require.NoError(t, err)
+		assert.Equal(t, "14053", *merged.PostalCode)
+
+		otherID, err := repos.Venues.CreateVenue(ctx, services.VenueRequest{
+			Name: "Annex", City: "Berlin", CountryCode: "DE", TimeZone: "Europe/Berlin",
+		})
+		require.NoError(t, err)
+		params.VenueID = &otherID
+		params.EventDatetime, params.EndDatetime = start.Add(time.Hour), start.Add(3*time.Hour)
+		_, err = repos.Events.CreateEvent(ctx, params)
+		require.NoError(t, err)
+		err = repos.Venues.MergeVenues(ctx, *merged, otherID)
+		assert.ErrorIs(t, err, services.ErrVenueConflict)
+		_, err = repos.Venues.GetVenueById(ctx, otherID)
+		assert.NoError(t, err, "a failed merge changes nothing")
+	})
+}
@@ -0,0 +1,379 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// The Routed* repositories read with the Get*, List* and Count* methods from
+// the replicas of a ReplicaSet, and make everything else, writes and the
+// other reads, on the primary. Writes are recorded on the context, so that
+// the reads after them under it see them; see services.TrackWrites.
+
+type RoutedEventRepository struct {
+	*EventRepository
+	replica *EventRepository
+}
+
+func NewRoutedEventRepository(replicas *ReplicaSet) *RoutedEventRepository {
+	return &RoutedEventRepository{
+		EventRepository: &EventRepository{db: replicas.primary},
+		replica:         &EventRepository{db: replicas.reader},
+	}
+}
+
+func (r *RoutedEventRepository) GetEventByID(ctx context.Context, id int) (*services.Event, error) {
+	return r.replica.GetEventByID(ctx, id)
+}
+
+func (r *RoutedEventRepository) CountEvents(ctx context.Context, params services.ListEventsParams) (int, error) {
+	return r.replica.CountEvents(ctx, params)
+}
+
+func (r *RoutedEventRepository) ListEvents(ctx context.Context, params services.ListEventsParams) ([]services.Event, error) {
+	return r.replica.ListEvents(ctx, params)
+}
+
+func (r *RoutedEventRepository) CountEventsBySportID(ctx context.Context, sportID int) (int, error) {
+	return r.replica.CountEventsBySportID(ctx, sportID)
+}
+
+func (r *RoutedEventRepository) CountEventsByVenueId(ctx context.Context, venueID int) (int, error) {
+	return r.replica.CountEventsByVenueId(ctx, venueID)
+}
+
+func (r *RoutedEventRepository) CountEventsByTeamID(ctx context.Context, teamID int) (int, error) {
+	return r.replica.CountEventsByTeamID(ctx, teamID)
+}
+
+func (r *RoutedEventRepository) ListVenueConflicts(ctx context.Context, venueID int, start, end time.Time,
+	excludeEventID int) ([]services.Event, error) {
+	return r.replica.ListVenueConflicts(ctx, venueID, start, end, excludeEventID)
+}
+
+func (r *RoutedEventRepository) ListTeamEvents(ctx context.Context, teamID int, from, to *time.Time) ([]services.Event, error) {
+	return r.replica.ListTeamEvents(ctx, teamID, from, to)
+}
+
+func (r *RoutedEventRepository) GetAttendanceStats(ctx context.Context,
+	params services.AttendanceStatsParams) (*services.AttendanceStats, error) {
+	return r.replica.GetAttendanceStats(ctx, params)
+}
+
+func (r *RoutedEventRepository) CreateEvent(ctx context.Context, params services.CreateEventParams) (int, error) {
+	defer services.RecordWrite(ctx)
+	return r.EventRepository.CreateEvent(ctx, params)
+}
+
+func (r *RoutedEventRepository) UpdateEvent(ctx context.Context, event services.Event) error {
+	defer services.RecordWrite(ctx)
+	return r.EventRepository.UpdateEvent(ctx, event)
+}
+
+func (r *RoutedEventRepository) DeleteEvent(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.EventRepository.DeleteEvent(ctx, id)
+}
+
+func (r *RoutedEventRepository) RestoreEvent(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.EventRepository.RestoreEvent(ctx, id)
+}
+
+type RoutedSportRepository struct {
+	*SportRepository
+	replica *SportRepository
+}
+
+func NewRoutedSportRepository(replicas *ReplicaSet) *RoutedSportRepository {
+	return &RoutedSportRepository{
+		SportRepository: &SportRepository{db: replicas.primary},
+		replica:         &SportRepository{db: replicas.reader},
+	}
+}
+
+func (r *RoutedSportRepository) GetSportById(ctx context.Context, id int) (*services.Sport, error) {
+	return r.replica.GetSportById(ctx, id)
+}
+
+func (r *RoutedSportRepository) ListSports(ctx context.Context) ([]services.Sport, error) {
+	return r.replica.ListSports(ctx)
+}
+
+func (r *RoutedSportRepository) CreateSport(ctx context.Context, params services.SportRequest) (int, error) {
+	defer services.RecordWrite(ctx)
+	return r.SportRepository.CreateSport(ctx, params)
+}
+
+func (r *RoutedSportRepository) UpdateSport(ctx context.Context, sport services.Sport) error {
+	defer services.RecordWrite(ctx)
+	return r.SportRepository.UpdateSport(ctx, sport)
+}
+
+func (r *RoutedSportRepository) DeleteSport(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.SportRepository.DeleteSport(ctx, id)
+}
+
+func (r *RoutedSportRepository) RestoreSport(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.SportRepository.RestoreSport(ctx, id)
+}
+
+type RoutedTeamRepository struct {
+	*TeamRepository
+	replica *TeamRepository
+}
+
+func NewRoutedTeamRepository(replicas *ReplicaSet) *RoutedTeamRepository {
+	return &RoutedTeamRepository{
+		TeamRepository: &TeamRepository{db: replicas.primary},
+		replica:        &TeamRepository{db: replicas.reader},
+	}
+}
+
+func (r *RoutedTeamRepository) GetTeamByID(ctx context.Context, id int) (*services.Team, error) {
+	return r.replica.GetTeamByID(ctx, id)
+}
+
+func (r *RoutedTeamRepository) ListTeams(ctx context.Context) ([]services.Team, error) {
+	return r.replica.ListTeams(ctx)
+}
+
+func (r *RoutedTeamRepository) CreateTeam(ctx context.Context, params services.TeamRequest) (int, error) {
+	defer services.RecordWrite(ctx)
+	return r.TeamRepository.CreateTeam(ctx, params)
+}
+
+func (r *RoutedTeamRepository) UpdateTeam(ctx context.Context, team services.Team) error {
+	defer services.RecordWrite(ctx)
+	return r.TeamRepository.UpdateTeam(ctx, team)
+}
+
+func (r *RoutedTeamRepository) DeleteTeam(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.TeamRepository.DeleteTeam(ctx, id)
+}
+
+func (r *RoutedTeamRepository) RestoreTeam(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.TeamRepository.RestoreTeam(ctx, id)
+}
+
+func (r *RoutedTeamRepository) MergeTeams(ctx context.Context, survivor services.Team, duplicateID int) error {
+	defer services.RecordWrite(ctx)
+	return r.TeamRepository.MergeTeams(ctx, survivor, duplicateID)
+}
+
+type RoutedVenueRepository struct {
+	*VenueRepository
+	replica *VenueRepository
+}
+
+func NewRoutedVenueRepository(replicas *ReplicaSet) *RoutedVenueRepository {
+	return &RoutedVenueRepository{
+		VenueRepository: &VenueRepository{db: replicas.primary},
+		replica:         &VenueRepository{db: replicas.reader},
+	}
+}
+
+func (r *RoutedVenueRepository) GetVenueById(ctx context.Context, id int) (*services.Venue, error) {
+	return r.replica.GetVenueById(ctx, id)
+}
+
+func (r *RoutedVenueRepository) ListVenues(ctx context.Context) ([]services.Venue, error) {
+	return r.replica.ListVenues(ctx)
+}
+
+func (r *RoutedVenueRepository) ListVenuesNearby(ctx context.Context, point services.GeoPoint, radiusKm float64) ([]services.Venue, error) {
+	return r.replica.ListVenuesNearby(ctx, point, radiusKm)
+}
+
+func (r *RoutedVenueRepository) CreateVenue(ctx context.Context, params services.VenueRequest) (int, error) {
+	defer services.RecordWrite(ctx)
+	return r.VenueRepository.CreateVenue(ctx, params)
+}
+
+func (r *RoutedVenueRepository) UpdateVenue(ctx context.Context, venue services.Venue) error {
+	defer services.RecordWrite(ctx)
+	return r.VenueRepository.UpdateVenue(ctx, venue)
+}
+
+func (r *RoutedVenueRepository) DeleteVenue(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.VenueRepository.DeleteVenue(ctx, id)
+}
+
+func (r *RoutedVenueRepository) RestoreVenue(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.VenueRepository.RestoreVenue(ctx, id)
+}
+
+func (r *RoutedVenueRepository) MergeVenues(ctx context.Context, survivor services.Venue, duplicateID int) error {
+	defer services.RecordWrite(ctx)
+	return r.VenueRepository.MergeVenues(ctx, survivor, duplicateID)
+}
+
+type RoutedBroadcastRepository struct {
+	*BroadcastRepository
+	replica *BroadcastRepository
+}
+
+func NewRoutedBroadcastRepository(replicas *ReplicaSet) *RoutedBroadcastRepository {
+	return &RoutedBroadcastRepository{
+		BroadcastRepository: &BroadcastRepository{db: replicas.primary},
+		replica:             &BroadcastRepository{db: replicas.reader},
+	}
+}
+
+func (r *RoutedBroadcastRepository) GetBroadcast(ctx context.Context, eventID, id int) (*services.Broadcast, error) {
+	return r.replica.GetBroadcast(ctx, eventID, id)
+}
+
+func (r *RoutedBroadcastRepository) ListBroadcasts(ctx context.Context, eventID int, countryCode *string) ([]services.Broadcast, error) {
+	return r.replica.ListBroadcasts(ctx, eventID, countryCode)
+}
+
+func (r *RoutedBroadcastRepository) CreateBroadcast(ctx context.Context, broadcast services.Broadcast) (int, error) {
+	defer services.RecordWrite(ctx)
+	return r.BroadcastRepository.CreateBroadcast(ctx, broadcast)
+}
+
+func (r *RoutedBroadcastRepository) UpdateBroadcast(ctx context.Context, broadcast services.Broadcast) error {
+	defer services.RecordWrite(ctx)
+	return r.BroadcastRepository.UpdateBroadcast(ctx, broadcast)
+}
+
+func (r *RoutedBroadcastRepository) DeleteBroadcast(ctx context.Context, eventID, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.BroadcastRepository.DeleteBroadcast(ctx, eventID, id)
+}
+
+type RoutedEventChangeRepository struct {
+	*EventChangeRepository
+	replica *EventChangeRepository
+}
+
+func NewRoutedEventChangeRepository(replicas *ReplicaSet) *RoutedEventChangeRepository {
+	return &RoutedEventChangeRepository{
+		EventChangeRepository: &EventChangeRepository{db: replicas.primary},
+		replica:               &EventChangeRepository{db: replicas.reader},
+	}
+}
+
+func (r *RoutedEventChangeRepository) ListEventChanges(ctx context.Context,
+	params services.ListEventChangesParams) ([]services.EventChange, error) {
+	return r.replica.ListEventChanges(ctx, params)
+}
+
+func (r *RoutedEventChangeRepository) ListLatestResults(ctx context.Context,
+	params services.ListEventChangesParams) ([]services.EventChange, error) {
+	return r.replica.ListLatestResults(ctx, params)
+}
+
+func (r *RoutedEventChangeRepository) RecordEventChange(ctx context.Context, change services.EventChange) error {
+	defer services.RecordWrite(ctx)
+	return r.EventChangeRepository.RecordEventChange(ctx, change)
+}
+
+type RoutedEventRescheduleRepository struct {
+	*EventRescheduleRepository
+	replica *EventRescheduleRepository
+}
+
+func NewRoutedEventRescheduleRepository(replicas *ReplicaSet) *RoutedEventRescheduleRepository {
+	return &RoutedEventRescheduleRepository{
+		EventRescheduleRepository: &EventRescheduleRepository{db: replicas.primary},
+		replica:                   &EventRescheduleRepository{db: replicas.reader},
+	}
+}
+
+func (r *RoutedEventRescheduleRepository) ListReschedules(ctx context.Context, eventID int) ([]services.EventReschedule, error) {
+	return r.replica.ListReschedules(ctx, eventID)
+}
+
+func (r *RoutedEventRescheduleRepository) RecordReschedule(ctx context.Context, reschedule services.EventReschedule) error {
+	defer services.RecordWrite(ctx)
+	return r.EventRescheduleRepository.RecordReschedule(ctx, reschedule)
+}
+
+// RoutedSeriesRepository keeps series on the primary, reads included, and
+// only records its writes: MoveSeriesEvents writes events.
+type RoutedSeriesRepository struct {
+	services.SeriesRepositoryInterface
+}
+
+func NewRoutedSeriesRepository(replicas *ReplicaSet) *RoutedSeriesRepository {
+	return &RoutedSeriesRepository{SeriesRepositoryInterface: &SeriesRepository{db: replicas.primary}}
+}
+
+func (r *RoutedSeriesRepository) CreateSeries(ctx context.Context, series services.EventSeries) (int, error) {
+	defer services.RecordWrite(ctx)
+	return r.SeriesRepositoryInterface.CreateSeries(ctx, series)
+}
+
+func (r *RoutedSeriesRepository) UpdateSeries(ctx context.Context, series services.EventSeries) error {
+	defer services.RecordWrite(ctx)
+	return r.SeriesRepositoryInterface.UpdateSeries(ctx, series)
+}
+
+func (r *RoutedSeriesRepository) DeleteSeries(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.SeriesRepositoryInterface.DeleteSeries(ctx, id)
+}
+
+func (r *RoutedSeriesRepository) RestoreSeries(ctx context.Context, id int) error {
+	defer services.RecordWrite(ctx)
+	return r.SeriesRepositoryInterface.RestoreSeries(ctx, id)
+}
+
+func (r *RoutedSeriesRepository) AddException(ctx context.Context, seriesID int, date time.Time) error {
+	defer services.RecordWrite(ctx)
+	return r.SeriesRepositoryInterface.AddException(ctx, seriesID, date)
+}
+
+func (r *RoutedSeriesRepository) MoveSeriesEvents(ctx context.Context, fromSeriesID, toSeriesID int, from time.Time) error {
+	defer services.RecordWrite(ctx)
+	return r.SeriesRepositoryInterface.MoveSeriesEvents(ctx, fromSeriesID, toSeriesID, from)
+}
+
+// RoutedLastModifiedRepository dates lists where they are read: series on
+// the primary, the other records on the replicas.
+type RoutedLastModifiedRepository struct {
+	*LastModifiedRepository
+	replica *LastModifiedRepository
+}
+
+func NewRoutedLastModifiedRepository(replicas *ReplicaSet) *RoutedLastModifiedRepository {
+	return &RoutedLastModifiedRepository{
+		LastModifiedRepository: &LastModifiedRepository{db: replicas.primary},
+		replica:                &LastModifiedRepository{db: replicas.reader},
+	}
+}
+
+func (r *RoutedLastModifiedRepository) LastModified(ctx context.Context, entityType string) (time.Time, error) {
+	if entityType == services.LastModifiedSeries {
+		return r.LastModifiedRepository.LastModified(ctx, entityType)
+	}
+	return r.replica.LastModified(ctx, entityType)
+}
+
+// RoutedTransactor runs units of work on the primary and records them as
+// writes once committed.
+type RoutedTransactor struct {
+	*Transactor
+}
+
+func NewRoutedTransactor(replicas *ReplicaSet) *RoutedTransactor {
+	return &RoutedTransactor{Transactor: NewTransactor(replicas.primary)}
+}
+
+func (t *RoutedTransactor) WithinTx(ctx context.Context, fn func(repos services.Repositories) error) error {
+	if err := t.Transactor.WithinTx(ctx, fn); err != nil {
+		return err
+	}
+	services.RecordWrite(ctx)
+	return nil
+}
@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// searchQuerySQL parses the tsquery built by the search service, folding
+// accents the same way the search_vector columns do.
+const searchQuerySQL = "to_tsquery('simple', search_unaccent($1))"
+
+type SearchRepository struct {
+	db *sqlx.DB
+}
+
+func NewSearchRepository(db *sqlx.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+func (r *SearchRepository) SearchEvents(ctx context.Context, query string, limit int) ([]services.SearchHit, error) {
+	sqlQuery := `
+	SELECT e.id, ht.name || ' vs ' || at.name AS title, e.description AS detail, e.event_datetime,
+		ts_rank(e.search_vector, q) AS rank
+	FROM events e
+	JOIN teams ht ON e._home_team_id = ht.id
+	JOIN teams at ON e._away_team_id = at.id
+	CROSS JOIN ` + searchQuerySQL + ` q
+	WHERE e.search_vector @@ q AND e.deleted_at IS NULL
+	ORDER BY rank DESC, e.event_datetime ASC, e.id ASC
+	LIMIT $2`
+	return r.search(ctx, services.SearchHitEvent, sqlQuery, query, limit)
+}
+
+func (r *SearchRepository) SearchTeams(ctx context.Context, query string, limit int) ([]services.SearchHit, error) {
+	sqlQuery := `
+	SELECT t.id, t.name AS title, t.city AS detail, ts_rank(t.search_vector, q) AS rank
+	FROM teams t
+	CROSS JOIN ` + searchQuerySQL + ` q
+	WHERE t.search_vector @@ q AND t.deleted_at IS NULL
+	ORDER BY rank DESC, t.name ASC, t.id ASC
+	LIMIT $2`
+	return r.search(ctx, services.SearchHitTeam, sqlQuery, query, limit)
+}
+
+func (r *SearchRepository) SearchVenues(ctx context.Context, query string, limit int) ([]services.SearchHit, error) {
+	sqlQuery := `
+	SELECT v.id, v.name AS title, v.city AS detail, ts_rank(v.search_vector, q) AS rank
+	FROM venues v
+	CROSS JOIN ` + searchQuerySQL + ` q
+	WHERE v.search_vector @@ q AND v.deleted_at IS NULL
+	ORDER BY rank DESC, v.name ASC, v.id ASC
+	LIMIT $2`
+	return r.search(ctx, services.SearchHitVenue, sqlQuery, query, limit)
+}
+
+func (r *SearchRepository) search(ctx context.Context, hitType, sqlQuery, query string,
+	limit int) ([]services.SearchHit, error) {
+	var dbModels []searchHitDBModel
+
+	if err := r.db.SelectContext(ctx, &dbModels, sqlQuery, query, limit); err != nil {
+		return nil, err
+	}
+	hits := make([]services.SearchHit, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		hits = append(hits, toServiceSearchHit(hitType, dbModel))
+	}
+	return hits, nil
+}
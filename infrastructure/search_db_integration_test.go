@@ -0,0 +1,118 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestSearchRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	repo := NewSearchRepository(db)
+	ctx := context.Background()
+
+	sportID, err := NewSportRepository(db).CreateSport(ctx, services.SportRequest{Name: "Test Ice Hockey"})
+	require.NoError(t, err)
+
+	teamRepo := NewTeamRepository(db)
+	eisbarenID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{
+		Name:    "Eisbären Berlin",
+		City:    "Berlin",
+		SportID: sportID,
+	})
+	require.NoError(t, err)
+	capitalsID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{
+		Name:    "Vienna Capitals",
+		City:    "Vienna",
+		SportID: sportID,
+	})
+	require.NoError(t, err)
+
+	venueID, err := NewVenueRepository(db).CreateVenue(ctx, services.VenueRequest{
+		Name:        "Steffl Arena",
+		City:        "Vienna",
+		CountryCode: "AT",
+	})
+	require.NoError(t, err)
+
+	description := "Ice Hockey League, Wintercup semifinal"
+	kickoff := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	eventID, err := NewEventRepository(db).CreateEvent(ctx, services.CreateEventParams{
+		EventDatetime: kickoff,
+		EndDatetime:   kickoff.Add(150 * time.Minute),
+		Description:   &description,
+		SportID:       sportID,
+		VenueID:       &venueID,
+		HomeTeamID:    capitalsID,
+		AwayTeamID:    eisbarenID,
+	})
+	require.NoError(t, err)
+
+	t.Run("SearchTeams matches unaccented prefixes", func(t *testing.T) {
+		hits, err := repo.SearchTeams(ctx, "eisbaren:*", 10)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, eisbarenID, hits[0].ID)
+		assert.Equal(t, services.SearchHitTeam, hits[0].Type)
+		assert.Equal(t, "Eisbären Berlin", hits[0].Title)
+
+		hits, err = repo.SearchTeams(ctx, "eisb:* & ber:*", 10)
+		require.NoError(t, err)
+		assert.Len(t, hits, 1)
+	})
+
+	t.Run("SearchVenues ranks name above city", func(t *testing.T) {
+		_, err := NewVenueRepository(db).CreateVenue(ctx, services.VenueRequest{
+			Name:        "Vienna Stadium",
+			City:        "Linz",
+			CountryCode: "AT",
+		})
+		require.NoError(t, err)
+
+		hits, err := repo.SearchVenues(ctx, "vienna:*", 10)
+		require.NoError(t, err)
+		require.Len(t, hits, 2)
+		assert.Equal(t, "Vienna Stadium", hits[0].Title)
+		assert.Equal(t, "Steffl Arena", hits[1].Title)
+		assert.Greater(t, hits[0].Rank, hits[1].Rank)
+	})
+
+	t.Run("SearchEvents matches descriptions", func(t *testing.T) {
+		hits, err := repo.SearchEvents(ctx, "winter:*", 10)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, eventID, hits[0].ID)
+		assert.Equal(t, "Vienna Capitals vs Eisbären Berlin", hits[0].Title)
+		require.NotNil(t, hits[0].EventDatetime)
+		assert.True(t, hits[0].EventDatetime.Equal(kickoff))
+
+		hits, err = repo.SearchEvents(ctx, "summer:*", 10)
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("search vectors follow updates", func(t *testing.T) {
+		require.NoError(t, teamRepo.UpdateTeam(ctx, services.Team{ID: capitalsID, Name: "spusu Vienna Capitals", City: "Wien"}))
+
+		hits, err := repo.SearchTeams(ctx, "wien:*", 10)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, capitalsID, hits[0].ID)
+	})
+}
@@ -0,0 +1,410 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed fixtures/*.yaml
+var fixtureFiles embed.FS
+
+// maxSeedBatchRows caps the rows of one multi-row INSERT; PostgreSQL also
+// allows at most 65535 parameters per statement.
+const (
+	maxSeedBatchRows   = 1000
+	maxStatementParams = 65535
+)
+
+// SeedDataset is a set of rows to seed. Teams and events refer to sports,
+// teams and venues by name, so names must be unique within the set.
+type SeedDataset struct {
+	Sports []SeedSport `yaml:"sports" json:"sports"`
+	Venues []SeedVenue `yaml:"venues" json:"venues"`
+	Teams  []SeedTeam  `yaml:"teams" json:"teams"`
+	Events []SeedEvent `yaml:"events" json:"events"`
+}
+
+type SeedSport struct {
+	Name                   string `yaml:"name" json:"name"`
+	DefaultDurationMinutes int    `yaml:"default_duration_minutes" json:"default_duration_minutes"`
+}
+
+type SeedVenue struct {
+	Name        string   `yaml:"name" json:"name"`
+	City        string   `yaml:"city" json:"city"`
+	CountryCode string   `yaml:"country_code" json:"country_code"`
+	TimeZone    string   `yaml:"time_zone" json:"time_zone"`
+	Latitude    *float64 `yaml:"latitude" json:"latitude"`
+	Longitude   *float64 `yaml:"longitude" json:"longitude"`
+	Capacity    *int     `yaml:"capacity" json:"capacity"`
+}
+
+type SeedTeam struct {
+	Name      string   `yaml:"name" json:"name"`
+	City      string   `yaml:"city" json:"city"`
+	Sport     string   `yaml:"sport" json:"sport"`
+	ShortName *string  `yaml:"short_name" json:"short_name"`
+	Code      *string  `yaml:"code" json:"code"`
+	Aliases   []string `yaml:"aliases" json:"aliases"`
+}
+
+// SeedEvent is an event of the set. Without an end_datetime it lasts the
+// default duration of its sport.
+type SeedEvent struct {
+	Sport         string          `yaml:"sport" json:"sport"`
+	HomeTeam      string          `yaml:"home_team" json:"home_team"`
+	AwayTeam      string          `yaml:"away_team" json:"away_team"`
+	Venue         *string         `yaml:"venue" json:"venue"`
+	EventDatetime time.Time       `yaml:"event_datetime" json:"event_datetime"`
+	EndDatetime   *time.Time      `yaml:"end_datetime" json:"end_datetime"`
+	HomeScore     *int            `yaml:"home_score" json:"home_score"`
+	AwayScore     *int            `yaml:"away_score" json:"away_score"`
+	Attendance    *int            `yaml:"attendance" json:"attendance"`
+	Description   *string         `yaml:"description" json:"description"`
+	Broadcasts    []SeedBroadcast `yaml:"broadcasts" json:"broadcasts"`
+}
+
+type SeedBroadcast struct {
+	Broadcaster   string    `yaml:"broadcaster" json:"broadcaster"`
+	Channel       *string   `yaml:"channel" json:"channel"`
+	CountryCode   string    `yaml:"country_code" json:"country_code"`
+	URL           *string   `yaml:"url" json:"url"`
+	StartDatetime time.Time `yaml:"start_datetime" json:"start_datetime"`
+}
+
+type SeedResult struct {
+	Sports     int
+	Venues     int
+	Teams      int
+	Events     int
+	Broadcasts int
+}
+
+// LoadFixtureSet reads a fixture set: a .yaml, .yml or .json file, or the
+// name of a set compiled into the binary such as "demo".
+func LoadFixtureSet(name string) (*SeedDataset, error) {
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == "" {
+		data, err := fs.ReadFile(fixtureFiles, path.Join("fixtures", name+".yaml"))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("unknown fixture set %q, available: %s", name, strings.Join(FixtureSetNames(), ", "))
+		}
+		if err != nil {
+			return nil, err
+		}
+		return parseFixtureSet(data, ".yaml")
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return parseFixtureSet(data, ext)
+}
+
+// FixtureSetNames lists the fixture sets compiled into the binary.
+func FixtureSetNames() []string {
+	entries, _ := fs.ReadDir(fixtureFiles, "fixtures")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	return names
+}
+
+func parseFixtureSet(data []byte, ext string) (*SeedDataset, error) {
+	var dataset SeedDataset
+	switch ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&dataset); err != nil {
+			return nil, fmt.Errorf("invalid fixture set: %w", err)
+		}
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&dataset); err != nil {
+			return nil, fmt.Errorf("invalid fixture set: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("fixture sets must be .yaml, .yml or .json files, not %s", ext)
+	}
+	return &dataset, nil
+}
+
+// Validate checks that the names are unique and every reference resolves.
+func (d *SeedDataset) Validate() error {
+	sports := make(map[string]bool, len(d.Sports))
+	for _, sport := range d.Sports {
+		if sport.Name == "" || sports[sport.Name] {
+			return fmt.Errorf("sport names must be set and unique: %q", sport.Name)
+		}
+		sports[sport.Name] = true
+	}
+	venues := make(map[string]bool, len(d.Venues))
+	for _, venue := range d.Venues {
+		if venue.Name == "" || venues[venue.Name] {
+			return fmt.Errorf("venue names must be set and unique: %q", venue.Name)
+		}
+		venues[venue.Name] = true
+	}
+	teams := make(map[string]bool, len(d.Teams))
+	for _, team := range d.Teams {
+		if !sports[team.Sport] {
+			return fmt.Errorf("team %q plays unknown sport %q", team.Name, team.Sport)
+		}
+		key := seedTeamKey(team.Sport, team.Name)
+		if team.Name == "" || teams[key] {
+			return fmt.Errorf("team names must be set and unique per sport: %q", team.Name)
+		}
+		teams[key] = true
+	}
+	for i, event := range d.Events {
+		if !teams[seedTeamKey(event.Sport, event.HomeTeam)] || !teams[seedTeamKey(event.Sport, event.AwayTeam)] {
+			return fmt.Errorf("event %d: teams %q and %q must be %s teams of the set",
+				i+1, event.HomeTeam, event.AwayTeam, event.Sport)
+		}
+		if event.HomeTeam == event.AwayTeam {
+			return fmt.Errorf("event %d: %q cannot play itself", i+1, event.HomeTeam)
+		}
+		if event.Venue != nil && !venues[*event.Venue] {
+			return fmt.Errorf("event %d: unknown venue %q", i+1, *event.Venue)
+		}
+		if event.EventDatetime.IsZero() {
+			return fmt.Errorf("event %d: event_datetime is required", i+1)
+		}
+	}
+	return nil
+}
+
+func seedTeamKey(sport, name string) string {
+	return sport + "\x00" + name
+}
+
+type Seeder struct {
+	db *sqlx.DB
+}
+
+func NewSeeder(db *sqlx.DB) *Seeder {
+	return &Seeder{db: db}
+}
+
+// Seed inserts the dataset in one transaction, in multi-row batches, with a
+// "created" and, for results, a "scored" feed entry per event. Sports that
+// already exist are reused; any other clash with existing rows rolls the
+// whole set back. Seeded rows bypass the audit log.
+func (s *Seeder) Seed(ctx context.Context, dataset *SeedDataset) (*SeedResult, error) {
+	if err := dataset.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	sportIDs := make(map[string]int, len(dataset.Sports))
+	sportDurations := make(map[string]int, len(dataset.Sports))
+	query := `
+	INSERT INTO sports (name, default_duration_minutes) VALUES ($1, $2)
+	ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+	RETURNING id, default_duration_minutes`
+	for _, sport := range dataset.Sports {
+		duration := sport.DefaultDurationMinutes
+		if duration == 0 {
+			duration = 120
+		}
+		var id int
+		if err := tx.QueryRowContext(ctx, query, sport.Name, duration).Scan(&id, &duration); err != nil {
+			return nil, fmt.Errorf("failed to seed sport %q: %w", sport.Name, err)
+		}
+		sportIDs[sport.Name] = id
+		sportDurations[sport.Name] = duration
+	}
+
+	venueIDs, err := insertBatches(ctx, tx,
+		"INSERT INTO venues (name, city, country_code, time_zone, latitude, longitude, capacity)", 7,
+		len(dataset.Venues), true, func(i int) []interface{} {
+			venue := dataset.Venues[i]
+			timeZone := venue.TimeZone
+			if timeZone == "" {
+				timeZone = "UTC"
+			}
+			return []interface{}{venue.Name, venue.City, venue.CountryCode, timeZone,
+				venue.Latitude, venue.Longitude, venue.Capacity}
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed venues: %w", err)
+	}
+	venueIDByName := make(map[string]int, len(dataset.Venues))
+	for i, venue := range dataset.Venues {
+		venueIDByName[venue.Name] = venueIDs[i]
+	}
+
+	teamIDs, err := insertBatches(ctx, tx,
+		"INSERT INTO teams (name, city, _sport_id, short_name, code)", 5,
+		len(dataset.Teams), true, func(i int) []interface{} {
+			team := dataset.Teams[i]
+			return []interface{}{team.Name, team.City, sportIDs[team.Sport], team.ShortName, team.Code}
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed teams: %w", err)
+	}
+	teamIDByKey := make(map[string]int, len(dataset.Teams))
+	type seedAlias struct {
+		teamID, sportID int
+		alias           string
+	}
+	var aliases []seedAlias
+	for i, team := range dataset.Teams {
+		teamIDByKey[seedTeamKey(team.Sport, team.Name)] = teamIDs[i]
+		for _, alias := range team.Aliases {
+			aliases = append(aliases, seedAlias{teamIDs[i], sportIDs[team.Sport], alias})
+		}
+	}
+	_, err = insertBatches(ctx, tx, "INSERT INTO team_aliases (_team_id, _sport_id, alias)", 3,
+		len(aliases), false, func(i int) []interface{} {
+			return []interface{}{aliases[i].teamID, aliases[i].sportID, aliases[i].alias}
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed team aliases: %w", err)
+	}
+
+	eventIDs, err := insertBatches(ctx, tx, `
+	INSERT INTO events (
+		event_datetime, end_datetime, description, home_score, away_score,
+		_sport_id, _venue_id, _home_team_id, _away_team_id, attendance)`, 10,
+		len(dataset.Events), true, func(i int) []interface{} {
+			event := dataset.Events[i]
+			end := event.EventDatetime.Add(time.Duration(sportDurations[event.Sport]) * time.Minute)
+			if event.EndDatetime != nil {
+				end = *event.EndDatetime
+			}
+			var venueID *int
+			if event.Venue != nil {
+				id := venueIDByName[*event.Venue]
+				venueID = &id
+			}
+			return []interface{}{event.EventDatetime, end, event.Description, event.HomeScore, event.AwayScore,
+				sportIDs[event.Sport], venueID, teamIDByKey[seedTeamKey(event.Sport, event.HomeTeam)],
+				teamIDByKey[seedTeamKey(event.Sport, event.AwayTeam)], event.Attendance}
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed events: %w", err)
+	}
+
+	type seedBroadcast struct {
+		eventID int
+		SeedBroadcast
+	}
+	type seedChange struct {
+		eventIndex int
+		changeType string
+	}
+	var broadcasts []seedBroadcast
+	var changes []seedChange
+	for i, event := range dataset.Events {
+		for _, broadcast := range event.Broadcasts {
+			broadcasts = append(broadcasts, seedBroadcast{eventIDs[i], broadcast})
+		}
+		changes = append(changes, seedChange{i, services.EventChangeCreated})
+		if event.HomeScore != nil {
+			changes = append(changes, seedChange{i, services.EventChangeScored})
+		}
+	}
+	_, err = insertBatches(ctx, tx,
+		"INSERT INTO event_broadcasts (_event_id, broadcaster, channel, country_code, url, start_datetime)", 6,
+		len(broadcasts), false, func(i int) []interface{} {
+			b := broadcasts[i]
+			return []interface{}{b.eventID, b.Broadcaster, b.Channel, b.CountryCode, b.URL, b.StartDatetime}
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed broadcasts: %w", err)
+	}
+	_, err = insertBatches(ctx, tx, `
+	INSERT INTO event_changes (
+		_event_id, change_type, event_datetime, home_score, away_score,
+		_sport_id, sport_name, _home_team_id, home_team_name, _away_team_id, away_team_name)`, 11,
+		len(changes), false, func(i int) []interface{} {
+			change := changes[i]
+			event := dataset.Events[change.eventIndex]
+			return []interface{}{eventIDs[change.eventIndex], change.changeType, event.EventDatetime,
+				event.HomeScore, event.AwayScore, sportIDs[event.Sport], event.Sport,
+				teamIDByKey[seedTeamKey(event.Sport, event.HomeTeam)], event.HomeTeam,
+				teamIDByKey[seedTeamKey(event.Sport, event.AwayTeam)], event.AwayTeam}
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed event changes: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &SeedResult{
+		Sports:     len(dataset.Sports),
+		Venues:     len(dataset.Venues),
+		Teams:      len(dataset.Teams),
+		Events:     len(dataset.Events),
+		Broadcasts: len(broadcasts),
+	}, nil
+}
+
+// insertBatches inserts n rows, built by row, with multi-row INSERT
+// statements. With returning set it returns the new IDs in row order, which
+// PostgreSQL keeps for a single INSERT ... VALUES.
+func insertBatches(ctx context.Context, tx *sqlx.Tx, insert string, columns, n int, returning bool,
+	row func(i int) []interface{}) ([]int, error) {
+	batchRows := min(maxSeedBatchRows, maxStatementParams/columns)
+	var ids []int
+	if returning {
+		ids = make([]int, 0, n)
+	}
+	for start := 0; start < n; start += batchRows {
+		end := min(start+batchRows, n)
+		var query strings.Builder
+		args := make([]interface{}, 0, (end-start)*columns)
+		query.WriteString(insert)
+		query.WriteString(" VALUES ")
+		for i := start; i < end; i++ {
+			if i > start {
+				query.WriteString(", ")
+			}
+			query.WriteString("(")
+			for c := 0; c < columns; c++ {
+				if c > 0 {
+					query.WriteString(", ")
+				}
+				fmt.Fprintf(&query, "$%d", len(args)+c+1)
+			}
+			query.WriteString(")")
+			args = append(args, row(i)...)
+		}
+		if !returning {
+			if _, err := tx.ExecContext(ctx, query.String(), args...); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		query.WriteString(" RETURNING id")
+		var batchIDs []int
+		if err := tx.SelectContext(ctx, &batchIDs, query.String(), args...); err != nil {
+			return nil, err
+		}
+		ids = append(ids, batchIDs...)
+	}
+	return ids, nil
+}
@@ -0,0 +1,315 @@
+package infrastructure
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GenerateParams sizes a generated dataset. The same params always generate
+// the same dataset.
+type GenerateParams struct {
+	Sports int
+	Teams  int
+	Venues int
+	Events int
+	Seed   uint64
+	// Anchor is the middle of the generated schedule; events ending before
+	// it are played and have a result.
+	Anchor time.Time
+}
+
+type sportTemplate struct {
+	name     string
+	duration int
+	score    func(r *rand.Rand) (home, away int)
+}
+
+var sportTemplates = []sportTemplate{
+	{"Football", 120, poissonScore(1.5, 1.1)},
+	{"Ice Hockey", 150, poissonScore(3.2, 2.7)},
+	{"Basketball", 120, normalScore(84, 80, 11)},
+	{"Handball", 90, normalScore(29, 27, 4)},
+	{"Volleyball", 120, volleyballScore},
+	{"Baseball", 180, poissonScore(4.6, 4.3)},
+	{"Rugby Union", 100, normalScore(24, 20, 9)},
+	{"Field Hockey", 90, poissonScore(2.1, 1.7)},
+	{"American Football", 210, normalScore(24, 21, 9)},
+	{"Water Polo", 60, normalScore(11, 10, 3)},
+}
+
+type seedCity struct {
+	name        string
+	countryCode string
+	timeZone    string
+	latitude    float64
+	longitude   float64
+}
+
+var seedCities = []seedCity{
+	{"Vienna", "AT", "Europe/Vienna", 48.2082, 16.3738},
+	{"Salzburg", "AT", "Europe/Vienna", 47.8095, 13.0550},
+	{"Graz", "AT", "Europe/Vienna", 47.0707, 15.4395},
+	{"Berlin", "DE", "Europe/Berlin", 52.5200, 13.4050},
+	{"Munich", "DE", "Europe/Berlin", 48.1351, 11.5820},
+	{"Hamburg", "DE", "Europe/Berlin", 53.5511, 9.9937},
+	{"Cologne", "DE", "Europe/Berlin", 50.9375, 6.9603},
+	{"Zurich", "CH", "Europe/Zurich", 47.3769, 8.5417},
+	{"Geneva", "CH", "Europe/Zurich", 46.2044, 6.1432},
+	{"Paris", "FR", "Europe/Paris", 48.8566, 2.3522},
+	{"Lyon", "FR", "Europe/Paris", 45.7640, 4.8357},
+	{"Marseille", "FR", "Europe/Paris", 43.2965, 5.3698},
+	{"London", "GB", "Europe/London", 51.5074, -0.1278},
+	{"Manchester", "GB", "Europe/London", 53.4808, -2.2426},
+	{"Liverpool", "GB", "Europe/London", 53.4084, -2.9916},
+	{"Glasgow", "GB", "Europe/London", 55.8642, -4.2518},
+	{"Dublin", "IE", "Europe/Dublin", 53.3498, -6.2603},
+	{"Madrid", "ES", "Europe/Madrid", 40.4168, -3.7038},
+	{"Barcelona", "ES", "Europe/Madrid", 41.3874, 2.1686},
+	{"Lisbon", "PT", "Europe/Lisbon", 38.7223, -9.1393},
+	{"Milan", "IT", "Europe/Rome", 45.4642, 9.1900},
+	{"Rome", "IT", "Europe/Rome", 41.9028, 12.4964},
+	{"Amsterdam", "NL", "Europe/Amsterdam", 52.3676, 4.9041},
+	{"Brussels", "BE", "Europe/Brussels", 50.8503, 4.3517},
+	{"Prague", "CZ", "Europe/Prague", 50.0755, 14.4378},
+	{"Warsaw", "PL", "Europe/Warsaw", 52.2297, 21.0122},
+	{"Budapest", "HU", "Europe/Budapest", 47.4979, 19.0402},
+	{"Stockholm", "SE", "Europe/Stockholm", 59.3293, 18.0686},
+	{"Helsinki", "FI", "Europe/Helsinki", 60.1699, 24.9384},
+	{"Oslo", "NO", "Europe/Oslo", 59.9139, 10.7522},
+	{"Copenhagen", "DK", "Europe/Copenhagen", 55.6761, 12.5683},
+	{"New York", "US", "America/New_York", 40.7128, -74.0060},
+	{"Chicago", "US", "America/Chicago", 41.8781, -87.6298},
+	{"Toronto", "CA", "America/Toronto", 43.6532, -79.3832},
+}
+
+var teamNicknames = []string{
+	"Lions", "Eagles", "Rovers", "United", "Wanderers", "Falcons", "Tigers", "Bears",
+	"Sharks", "Wolves", "Kings", "Rangers", "Royals", "Stars", "Titans", "Hawks",
+	"Dragons", "Pirates", "Giants", "Comets", "Knights", "Panthers", "Bulls", "Storm",
+}
+
+var venueKinds = []struct {
+	name        string
+	minCapacity int
+	maxCapacity int
+}{
+	{"Arena", 5000, 20000},
+	{"Stadium", 15000, 80000},
+	{"Park", 8000, 40000},
+	{"Dome", 10000, 45000},
+	{"Sports Centre", 2000, 8000},
+	{"Ground", 5000, 30000},
+}
+
+// GenerateDataset generates sports, teams, venues and a schedule of events
+// from params.Seed. Teams play the teams of their sport at their home venue,
+// never themselves. Teams play and venues host at most once a day, so the
+// schedule spans about two days per event and venue, centred on the anchor,
+// and grows when there are few teams for the events.
+func GenerateDataset(params GenerateParams) (*SeedDataset, error) {
+	if params.Sports < 1 || params.Sports > len(sportTemplates) {
+		return nil, fmt.Errorf("sports must be between 1 and %d", len(sportTemplates))
+	}
+	if params.Teams < 2*params.Sports {
+		return nil, fmt.Errorf("teams must be at least %d, two per sport", 2*params.Sports)
+	}
+	if params.Venues < 1 {
+		return nil, fmt.Errorf("venues must be at least 1")
+	}
+	if params.Events < 0 {
+		return nil, fmt.Errorf("events must not be negative")
+	}
+	r := rand.New(rand.NewPCG(params.Seed, 0x5eed))
+	dataset := &SeedDataset{
+		Sports: make([]SeedSport, 0, params.Sports),
+		Venues: make([]SeedVenue, 0, params.Venues),
+		Teams:  make([]SeedTeam, 0, params.Teams),
+		Events: make([]SeedEvent, 0, params.Events),
+	}
+
+	for _, template := range sportTemplates[:params.Sports] {
+		dataset.Sports = append(dataset.Sports, SeedSport{Name: template.name, DefaultDurationMinutes: template.duration})
+	}
+
+	venueNames := map[string]int{}
+	venuesByCity := map[string][]int{}
+	for i := 0; i < params.Venues; i++ {
+		city := seedCities[i%len(seedCities)]
+		kind := venueKinds[r.IntN(len(venueKinds))]
+		name := uniqueName(venueNames, city.name+" "+kind.name)
+		latitude := city.latitude + (r.Float64()-0.5)*0.1
+		longitude := city.longitude + (r.Float64()-0.5)*0.1
+		capacity := kind.minCapacity + r.IntN(kind.maxCapacity-kind.minCapacity+1)
+		dataset.Venues = append(dataset.Venues, SeedVenue{
+			Name:        name,
+			City:        city.name,
+			CountryCode: city.countryCode,
+			TimeZone:    city.timeZone,
+			Latitude:    &latitude,
+			Longitude:   &longitude,
+			Capacity:    &capacity,
+		})
+		venuesByCity[city.name] = append(venuesByCity[city.name], i)
+	}
+
+	teamsBySport := make([][]int, params.Sports)
+	homeVenues := make([]int, params.Teams)
+	venueTeams := make([]int, params.Venues)
+	teamNames := make([]map[string]int, params.Sports)
+	teamCodes := make([]map[string]bool, params.Sports)
+	for s := range teamNames {
+		teamNames[s] = map[string]int{}
+		teamCodes[s] = map[string]bool{}
+	}
+	for i := 0; i < params.Teams; i++ {
+		s := i % params.Sports
+		city := seedCities[r.IntN(len(seedCities))]
+		nickname := teamNicknames[r.IntN(len(teamNicknames))]
+		team := SeedTeam{
+			Name:  uniqueName(teamNames[s], city.name+" "+nickname),
+			City:  city.name,
+			Sport: dataset.Sports[s].Name,
+			Code:  teamCode(r, teamCodes[s], city.name, nickname),
+		}
+		dataset.Teams = append(dataset.Teams, team)
+		teamsBySport[s] = append(teamsBySport[s], i)
+		homeVenues[i] = i % params.Venues
+		if venues := venuesByCity[city.name]; len(venues) > 0 {
+			homeVenues[i] = slices.MinFunc(venues, func(a, b int) int { return venueTeams[a] - venueTeams[b] })
+		}
+		venueTeams[homeVenues[i]]++
+	}
+
+	if params.Events == 0 {
+		return dataset, nil
+	}
+	spanDays := max(28, int(math.Ceil(2*float64(params.Events)/float64(params.Venues))))
+	anchorDay := params.Anchor.UTC().Truncate(24 * time.Hour)
+	firstDay := anchorDay.AddDate(0, 0, -spanDays/2)
+	venueDays := make([]dayCalendar, params.Venues)
+	teamDays := make([]dayCalendar, params.Teams)
+	matchdays := make([]int, params.Sports)
+	for i := 0; i < params.Events; i++ {
+		s := r.IntN(params.Sports)
+		teams := teamsBySport[s]
+		home := teams[r.IntN(len(teams))]
+		away := teams[r.IntN(len(teams)-1)]
+		if away == home {
+			away = teams[len(teams)-1]
+		}
+		venue := homeVenues[home]
+		day := i * spanDays / params.Events
+		for venueDays[venue].booked(day) || teamDays[home].booked(day) || teamDays[away].booked(day) {
+			day++
+		}
+		venueDays[venue].book(day)
+		teamDays[home].book(day)
+		teamDays[away].book(day)
+		kickoff := firstDay.AddDate(0, 0, day).Add(time.Duration(12*60+15*r.IntN(37)) * time.Minute)
+		template := sportTemplates[s]
+		matchdays[s]++
+		description := fmt.Sprintf("%s league, matchday %d", template.name, (matchdays[s]-1)/max(1, len(teams)/2)+1)
+		venueName := dataset.Venues[venue].Name
+
+		event := SeedEvent{
+			Sport:         template.name,
+			HomeTeam:      dataset.Teams[home].Name,
+			AwayTeam:      dataset.Teams[away].Name,
+			Venue:         &venueName,
+			EventDatetime: kickoff,
+			Description:   &description,
+		}
+		if kickoff.Add(time.Duration(template.duration) * time.Minute).Before(params.Anchor) {
+			homeScore, awayScore := template.score(r)
+			capacity := *dataset.Venues[venue].Capacity
+			attendance := capacity/2 + r.IntN(capacity/2+1)
+			event.HomeScore, event.AwayScore, event.Attendance = &homeScore, &awayScore, &attendance
+		}
+		dataset.Events = append(dataset.Events, event)
+	}
+	return dataset, nil
+}
+
+// dayCalendar marks the days, counted from the first day of the schedule, on
+// which a team plays or a venue hosts an event.
+type dayCalendar []uint64
+
+func (c dayCalendar) booked(day int) bool {
+	return day/64 < len(c) && c[day/64]&(1<<(day%64)) != 0
+}
+
+func (c *dayCalendar) book(day int) {
+	for day/64 >= len(*c) {
+		*c = append(*c, 0)
+	}
+	(*c)[day/64] |= 1 << (day % 64)
+}
+
+// uniqueName returns name, or name with a number once it is taken.
+func uniqueName(taken map[string]int, name string) string {
+	taken[name]++
+	if n := taken[name]; n > 1 {
+		return fmt.Sprintf("%s %d", name, n)
+	}
+	return name
+}
+
+// teamCode abbreviates the city, falling back to the city's initial with the
+// nickname's and then to random letters; nil once the sport runs out.
+func teamCode(r *rand.Rand, taken map[string]bool, city, nickname string) *string {
+	letters := func(s string) string {
+		return strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	}
+	candidates := []string{letters(city)[:3], letters(city)[:1] + letters(nickname)[:2]}
+	for attempt := 0; attempt < 50; attempt++ {
+		candidates = append(candidates, string([]byte{
+			letters(city)[0], byte('A' + r.IntN(26)), byte('A' + r.IntN(26)),
+		}))
+	}
+	for _, code := range candidates {
+		if !taken[code] {
+			taken[code] = true
+			return &code
+		}
+	}
+	return nil
+}
+
+func poissonScore(homeMean, awayMean float64) func(r *rand.Rand) (int, int) {
+	return func(r *rand.Rand) (int, int) {
+		return poisson(r, homeMean), poisson(r, awayMean)
+	}
+}
+
+func normalScore(homeMean, awayMean, stdDev float64) func(r *rand.Rand) (int, int) {
+	return func(r *rand.Rand) (int, int) {
+		home := max(0, int(math.Round(homeMean+r.NormFloat64()*stdDev)))
+		away := max(0, int(math.Round(awayMean+r.NormFloat64()*stdDev)))
+		return home, away
+	}
+}
+
+// volleyballScore counts sets: the winner takes three, the loser up to two.
+func volleyballScore(r *rand.Rand) (int, int) {
+	loser := r.IntN(3)
+	if r.IntN(100) < 55 {
+		return 3, loser
+	}
+	return loser, 3
+}
+
+// poisson draws from a Poisson distribution with Knuth's algorithm, fine for
+// the small means of goal counts.
+func poisson(r *rand.Rand, mean float64) int {
+	limit := math.Exp(-mean)
+	k, p := 0, r.Float64()
+	for p > limit {
+		k++
+		p *= r.Float64()
+	}
+	return k
+}
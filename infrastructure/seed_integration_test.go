@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeeder_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	seeder := NewSeeder(db)
+	ctx := context.Background()
+	count := func(table string) int {
+		var n int
+		require.NoError(t, db.GetContext(ctx, &n, "SELECT COUNT(*) FROM "+table))
+		return n
+	}
+
+	t.Run("demo fixture set", func(t *testing.T) {
+		dataset, err := LoadFixtureSet("demo")
+		require.NoError(t, err)
+
+		result, err := seeder.Seed(ctx, dataset)
+		require.NoError(t, err)
+		assert.Equal(t, len(dataset.Events), result.Events)
+		assert.Equal(t, 3, result.Broadcasts)
+		assert.Equal(t, len(dataset.Events), count("events"))
+
+		var eventID int
+		require.NoError(t, db.GetContext(ctx, &eventID, "SELECT MIN(id) FROM events"))
+		event, err := NewEventRepository(db).GetEventByID(ctx, eventID)
+		require.NoError(t, err)
+		assert.Equal(t, dataset.Events[0].HomeTeam, event.HomeTeam.Name)
+		assert.Equal(t, dataset.Events[0].AwayTeam, event.AwayTeam.Name)
+		broadcasts, err := NewBroadcastRepository(db).ListBroadcasts(ctx, eventID, nil)
+		require.NoError(t, err)
+		assert.Len(t, broadcasts, 3)
+	})
+
+	t.Run("clash rolls the whole set back", func(t *testing.T) {
+		events := count("events")
+		dataset, err := LoadFixtureSet("demo")
+		require.NoError(t, err)
+
+		_, err = seeder.Seed(ctx, dataset)
+		assert.Error(t, err)
+		assert.Equal(t, events, count("events"))
+	})
+
+	t.Run("generated set reuses existing sports", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, "TRUNCATE events, teams, venues CASCADE")
+		require.NoError(t, err)
+		sports := count("sports")
+		dataset, err := GenerateDataset(GenerateParams{
+			Sports: 2, Teams: 10, Venues: 4, Events: 1500, Seed: 7,
+			Anchor: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+
+		result, err := seeder.Seed(ctx, dataset)
+		require.NoError(t, err)
+		assert.Equal(t, 1500, result.Events)
+		assert.Equal(t, sports, count("sports"))
+		assert.Equal(t, 1500, count("events"))
+	})
+}
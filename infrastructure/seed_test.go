@@ -0,0 +1,159 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFixtureSet(t *testing.T) {
+	t.Run("embedded demo set", func(t *testing.T) {
+		dataset, err := LoadFixtureSet("demo")
+		require.NoError(t, err)
+		require.NoError(t, dataset.Validate())
+		assert.Len(t, dataset.Sports, 2)
+		assert.Len(t, dataset.Teams, 6)
+		assert.Equal(t, []string{"FC Red Bull Salzburg", "RB Salzburg"}, dataset.Teams[0].Aliases)
+		assert.Equal(t, time.Date(2025, 10, 1, 19, 0, 0, 0, time.UTC), dataset.Events[0].EventDatetime.UTC())
+		assert.Len(t, dataset.Events[0].Broadcasts, 3)
+	})
+
+	t.Run("json file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "cup.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{
+			"sports": [{"name": "Handball"}],
+			"teams": [
+				{"name": "THW Kiel", "city": "Kiel", "sport": "Handball"},
+				{"name": "SG Flensburg", "city": "Flensburg", "sport": "Handball"}
+			],
+			"events": [{"sport": "Handball", "home_team": "THW Kiel", "away_team": "SG Flensburg",
+				"event_datetime": "2025-11-02T16:00:00Z"}]
+		}`), 0o600))
+
+		dataset, err := LoadFixtureSet(file)
+		require.NoError(t, err)
+		require.NoError(t, dataset.Validate())
+		assert.Equal(t, "SG Flensburg", dataset.Events[0].AwayTeam)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "typo.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("sports:\n  - nmae: Handball\n"), 0o600))
+
+		_, err := LoadFixtureSet(file)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown set", func(t *testing.T) {
+		_, err := LoadFixtureSet("nope")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "demo")
+	})
+}
+
+func TestSeedDataset_Validate(t *testing.T) {
+	venue := "Nowhere Arena"
+	valid := func() *SeedDataset {
+		return &SeedDataset{
+			Sports: []SeedSport{{Name: "Football"}},
+			Teams: []SeedTeam{
+				{Name: "A", Sport: "Football"},
+				{Name: "B", Sport: "Football"},
+			},
+			Events: []SeedEvent{{Sport: "Football", HomeTeam: "A", AwayTeam: "B", EventDatetime: time.Now()}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(d *SeedDataset)
+	}{
+		{name: "duplicate sport", modify: func(d *SeedDataset) { d.Sports = append(d.Sports, SeedSport{Name: "Football"}) }},
+		{name: "team of unknown sport", modify: func(d *SeedDataset) { d.Teams[1].Sport = "Cricket" }},
+		{name: "duplicate team", modify: func(d *SeedDataset) { d.Teams[1].Name = "A" }},
+		{name: "self match", modify: func(d *SeedDataset) { d.Events[0].AwayTeam = "A" }},
+		{name: "unknown team", modify: func(d *SeedDataset) { d.Events[0].AwayTeam = "C" }},
+		{name: "unknown venue", modify: func(d *SeedDataset) { d.Events[0].Venue = &venue }},
+		{name: "missing kickoff", modify: func(d *SeedDataset) { d.Events[0].EventDatetime = time.Time{} }},
+	}
+
+	require.NoError(t, valid().Validate())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset := valid()
+			tt.modify(dataset)
+			assert.Error(t, dataset.Validate())
+		})
+	}
+}
+
+func TestGenerateDataset(t *testing.T) {
+	anchor := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	params := GenerateParams{Sports: 3, Teams: 30, Venues: 8, Events: 2000, Seed: 42, Anchor: anchor}
+
+	dataset, err := GenerateDataset(params)
+	require.NoError(t, err)
+	require.NoError(t, dataset.Validate())
+
+	t.Run("deterministic", func(t *testing.T) {
+		again, err := GenerateDataset(params)
+		require.NoError(t, err)
+		assert.Equal(t, dataset, again)
+
+		params.Seed = 43
+		other, err := GenerateDataset(params)
+		require.NoError(t, err)
+		assert.NotEqual(t, dataset.Events, other.Events)
+	})
+
+	t.Run("sizes", func(t *testing.T) {
+		assert.Len(t, dataset.Sports, 3)
+		assert.Len(t, dataset.Teams, 30)
+		assert.Len(t, dataset.Venues, 8)
+		assert.Len(t, dataset.Events, 2000)
+	})
+
+	t.Run("plausible schedule", func(t *testing.T) {
+		venueDays := map[string]bool{}
+		teamDays := map[string]bool{}
+		past := 0
+		for _, event := range dataset.Events {
+			day := event.EventDatetime.Format(time.DateOnly)
+			assert.NotEqual(t, event.HomeTeam, event.AwayTeam)
+			assert.False(t, venueDays[*event.Venue+day], "%s hosts twice on %s", *event.Venue, day)
+			venueDays[*event.Venue+day] = true
+			for _, team := range []string{event.HomeTeam, event.AwayTeam} {
+				assert.False(t, teamDays[event.Sport+team+day], "%s plays twice on %s", team, day)
+				teamDays[event.Sport+team+day] = true
+			}
+
+			if event.EventDatetime.Before(anchor.Add(-4 * time.Hour)) {
+				past++
+				require.NotNil(t, event.HomeScore)
+				require.NotNil(t, event.AwayScore)
+				assert.GreaterOrEqual(t, *event.HomeScore, 0)
+				assert.GreaterOrEqual(t, *event.AwayScore, 0)
+			} else if event.EventDatetime.After(anchor) {
+				assert.Nil(t, event.HomeScore)
+			}
+		}
+		assert.InDelta(t, len(dataset.Events)/2, past, float64(len(dataset.Events))/10)
+	})
+
+	t.Run("invalid sizes", func(t *testing.T) {
+		for _, invalid := range []GenerateParams{
+			{Sports: 0, Teams: 2, Venues: 1},
+			{Sports: 11, Teams: 22, Venues: 1},
+			{Sports: 2, Teams: 3, Venues: 1},
+			{Sports: 1, Teams: 2, Venues: 0},
+			{Sports: 1, Teams: 2, Venues: 1, Events: -1},
+		} {
+			_, err := GenerateDataset(invalid)
+			assert.Error(t, err, "%+v", invalid)
+		}
+	})
+}
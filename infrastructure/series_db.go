@@ -0,0 +1,169 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+const baseSeriesSelectQuery = `
+SELECT
+    id, rrule, start_datetime, time_zone, materialized_until, description,
+    _sport_id, _venue_id, _home_team_id, _away_team_id, deleted_at, version,
+    created_at, updated_at
+FROM event_series
+`
+
+type SeriesRepository struct {
+	db dbtx
+}
+
+func NewSeriesRepository(db *sqlx.DB) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+func (r *SeriesRepository) CreateSeries(ctx context.Context, series services.EventSeries) (int, error) {
+	query := `
+	INSERT INTO event_series(
+		rrule, start_datetime, time_zone, materialized_until, description,
+		_sport_id, _venue_id, _home_team_id, _away_team_id)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING id`
+	var newID int
+
+	err := inTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowContext(ctx, query,
+			series.RRule,
+			series.StartDatetime,
+			series.TimeZone,
+			series.MaterializedUntil,
+			series.Description,
+			series.SportID,
+			series.VenueID,
+			series.HomeTeamID,
+			series.AwayTeamID,
+		).Scan(&newID)
+		if err != nil {
+			return err
+		}
+		for _, date := range series.ExceptionDates {
+			if err := addSeriesException(ctx, tx, newID, date); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+func (r *SeriesRepository) GetSeriesByID(ctx context.Context, id int) (*services.EventSeries, error) {
+	var dbModel seriesDBModel
+	query := baseSeriesSelectQuery + " WHERE id = $1 AND " + notDeletedSQL(ctx, "event_series")
+
+	if err := r.db.GetContext(ctx, &dbModel, query, id); err != nil {
+		return nil, err
+	}
+	exceptionDates, err := r.listExceptionDates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	series := toServiceSeries(dbModel, exceptionDates)
+	return &series, nil
+}
+
+func (r *SeriesRepository) ListSeries(ctx context.Context) ([]services.EventSeries, error) {
+	var dbModels []seriesDBModel
+	query := baseSeriesSelectQuery + " WHERE " + notDeletedSQL(ctx, "event_series") + " ORDER BY start_datetime ASC, id ASC"
+
+	if err := r.db.SelectContext(ctx, &dbModels, query); err != nil {
+		return nil, err
+	}
+	seriesList := make([]services.EventSeries, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		exceptionDates, err := r.listExceptionDates(ctx, dbModel.ID)
+		if err != nil {
+			return nil, err
+		}
+		seriesList = append(seriesList, toServiceSeries(dbModel, exceptionDates))
+	}
+	return seriesList, nil
+}
+
+func (r *SeriesRepository) UpdateSeries(ctx context.Context, series services.EventSeries) error {
+	query := `
+	UPDATE event_series SET
+    rrule = $1,
+    start_datetime = $2,
+    time_zone = $3,
+    materialized_until = $4,
+    description = $5,
+    _sport_id = $6,
+    _venue_id = $7,
+    _home_team_id = $8,
+    _away_team_id = $9
+	WHERE id = $10`
+
+	_, err := r.db.ExecContext(ctx, query,
+		series.RRule,
+		series.StartDatetime,
+		series.TimeZone,
+		series.MaterializedUntil,
+		series.Description,
+		series.SportID,
+		series.VenueID,
+		series.HomeTeamID,
+		series.AwayTeamID,
+		series.ID,
+	)
+	return err
+}
+
+// DeleteSeries soft-deletes a series; the purge job removes it for good.
+func (r *SeriesRepository) DeleteSeries(ctx context.Context, id int) error {
+	query := "UPDATE event_series SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *SeriesRepository) RestoreSeries(ctx context.Context, id int) error {
+	return restoreRow(ctx, r.db, "event_series", id)
+}
+
+func (r *SeriesRepository) AddException(ctx context.Context, seriesID int, date time.Time) error {
+	return addSeriesException(ctx, r.db, seriesID, date)
+}
+
+// MoveSeriesEvents re-points the events of one series starting at from to
+// another series, used when a series is split for "following" edits.
+func (r *SeriesRepository) MoveSeriesEvents(ctx context.Context, fromSeriesID, toSeriesID int, from time.Time) error {
+	query := "UPDATE events SET _series_id = $1 WHERE _series_id = $2 AND event_datetime >= $3"
+
+	_, err := r.db.ExecContext(ctx, query, toSeriesID, fromSeriesID, from)
+	return err
+}
+
+func (r *SeriesRepository) listExceptionDates(ctx context.Context, seriesID int) ([]time.Time, error) {
+	query := "SELECT exception_date FROM event_series_exceptions WHERE _series_id = $1 ORDER BY exception_date ASC"
+	var dates []time.Time
+
+	if err := r.db.SelectContext(ctx, &dates, query, seriesID); err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
+
+func addSeriesException(ctx context.Context, db sqlx.ExecerContext, seriesID int, date time.Time) error {
+	query := `
+	INSERT INTO event_series_exceptions(_series_id, exception_date)
+	VALUES($1, $2)
+	ON CONFLICT DO NOTHING`
+
+	_, err := db.ExecContext(ctx, query, seriesID, date.Format("2006-01-02"))
+	return err
+}
@@ -0,0 +1,135 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestSeriesRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	repo := NewSeriesRepository(db)
+	eventRepo := NewEventRepository(db)
+	ctx := context.Background()
+
+	sportID, err := NewSportRepository(db).CreateSport(ctx, services.SportRequest{Name: "Test Football"})
+	require.NoError(t, err)
+
+	teamRepo := NewTeamRepository(db)
+	homeTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{
+		Name:    "Home Team",
+		City:    "Home City",
+		SportID: sportID,
+	})
+	require.NoError(t, err)
+	awayTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{
+		Name:    "Away Team",
+		City:    "Away City",
+		SportID: sportID,
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2026, 3, 3, 18, 0, 0, 0, time.UTC)
+	seriesID, err := repo.CreateSeries(ctx, services.EventSeries{
+		RRule:          "FREQ=WEEKLY;COUNT=4",
+		StartDatetime:  start,
+		TimeZone:       "Europe/Vienna",
+		SportID:        sportID,
+		HomeTeamID:     homeTeamID,
+		AwayTeamID:     awayTeamID,
+		ExceptionDates: []time.Time{time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+	assert.Greater(t, seriesID, 0)
+
+	t.Run("GetSeriesByID", func(t *testing.T) {
+		series, err := repo.GetSeriesByID(ctx, seriesID)
+		require.NoError(t, err)
+		assert.Equal(t, "FREQ=WEEKLY;COUNT=4", series.RRule)
+		assert.True(t, series.StartDatetime.Equal(start))
+		assert.Nil(t, series.MaterializedUntil)
+		require.Len(t, series.ExceptionDates, 1)
+		assert.Equal(t, "2026-03-10", series.ExceptionDates[0].Format("2006-01-02"))
+	})
+
+	t.Run("AddException is idempotent", func(t *testing.T) {
+		date := time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repo.AddException(ctx, seriesID, date))
+		require.NoError(t, repo.AddException(ctx, seriesID, date))
+
+		series, err := repo.GetSeriesByID(ctx, seriesID)
+		require.NoError(t, err)
+		assert.Len(t, series.ExceptionDates, 2)
+	})
+
+	t.Run("UpdateSeries", func(t *testing.T) {
+		series, err := repo.GetSeriesByID(ctx, seriesID)
+		require.NoError(t, err)
+		materializedUntil := start.AddDate(0, 2, 0)
+		series.MaterializedUntil = &materializedUntil
+		series.RRule = "FREQ=WEEKLY;COUNT=6"
+
+		require.NoError(t, repo.UpdateSeries(ctx, *series))
+
+		updated, err := repo.GetSeriesByID(ctx, seriesID)
+		require.NoError(t, err)
+		assert.Equal(t, "FREQ=WEEKLY;COUNT=6", updated.RRule)
+		require.NotNil(t, updated.MaterializedUntil)
+		assert.True(t, updated.MaterializedUntil.Equal(materializedUntil))
+	})
+
+	t.Run("MoveSeriesEvents and DeleteSeries", func(t *testing.T) {
+		for week := 0; week < 3; week++ {
+			_, err := eventRepo.CreateEvent(ctx, services.CreateEventParams{
+				EventDatetime: start.AddDate(0, 0, 7*week),
+				EndDatetime:   start.AddDate(0, 0, 7*week).Add(2 * time.Hour),
+				SportID:       sportID,
+				HomeTeamID:    homeTeamID,
+				AwayTeamID:    awayTeamID,
+				SeriesID:      &seriesID,
+			})
+			require.NoError(t, err)
+		}
+		tailID, err := repo.CreateSeries(ctx, services.EventSeries{
+			RRule:         "FREQ=WEEKLY;COUNT=2",
+			StartDatetime: start.AddDate(0, 0, 7),
+			TimeZone:      "UTC",
+			SportID:       sportID,
+			HomeTeamID:    homeTeamID,
+			AwayTeamID:    awayTeamID,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.MoveSeriesEvents(ctx, seriesID, tailID, start.AddDate(0, 0, 7)))
+
+		count, err := eventRepo.CountEvents(ctx, services.ListEventsParams{SeriesID: &tailID})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		require.NoError(t, repo.DeleteSeries(ctx, tailID))
+		count, err = eventRepo.CountEvents(ctx, services.ListEventsParams{SeriesID: &tailID})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		seriesList, err := repo.ListSeries(ctx)
+		require.NoError(t, err)
+		require.Len(t, seriesList, 1)
+		assert.Equal(t, seriesID, seriesList[0].ID)
+	})
+}
@@ -0,0 +1,26 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// notDeletedSQL is the condition hiding the soft-deleted rows of a table
+// (given by name or alias) unless ctx asks to include them.
+func notDeletedSQL(ctx context.Context, table string) string {
+	if services.IncludeDeleted(ctx) {
+		return "TRUE"
+	}
+	return table + ".deleted_at IS NULL"
+}
+
+// restoreRow clears the deleted_at of a soft-deleted row, failing with
+// sql.ErrNoRows when the table has no such deleted row.
+func restoreRow(ctx context.Context, db sqlx.QueryerContext, table string, id int) error {
+	query := "UPDATE " + table + " SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL RETURNING id"
+	var restoredID int
+
+	return db.QueryRowxContext(ctx, query, id).Scan(&restoredID)
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type SportRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func NewSportRepository(db *sqlx.DB) *SportRepository {
@@ -17,18 +17,35 @@ func NewSportRepository(db *sqlx.DB) *SportRepository {
 	}
 }
 
-func (r *SportRepository) CreateSport(ctx context.Context, name string) (int, error) {
-	query := "INSERT INTO sports (name) VALUES ($1) RETURNING id"
+func (r *SportRepository) CreateSport(ctx context.Context, params services.SportRequest) (int, error) {
+	query := `
+	INSERT INTO sports (name, default_duration_minutes, min_rest_minutes, rest_conflict_policy)
+	VALUES ($1, $2, $3, $4) RETURNING id`
 	var newID int
+	durationMinutes := services.DefaultEventDurationMinutes
+	minRestMinutes := 0
+	restConflictPolicy := services.RestPolicyReject
 
-	if err := r.db.QueryRowContext(ctx, query, name).Scan(&newID); err != nil {
-		return 0, err
+	if params.DefaultDurationMinutes != nil {
+		durationMinutes = *params.DefaultDurationMinutes
 	}
-	return newID, nil
+	if params.MinRestMinutes != nil {
+		minRestMinutes = *params.MinRestMinutes
+	}
+	if params.RestConflictPolicy != nil {
+		restConflictPolicy = *params.RestConflictPolicy
+	}
+	return auditedWrite(ctx, r.db, services.AuditEntitySport, services.AuditActionCreate, 0, func(tx *sqlx.Tx) (int, error) {
+		err := tx.QueryRowContext(ctx, query,
+			params.Name, durationMinutes, minRestMinutes, restConflictPolicy).Scan(&newID)
+		return newID, err
+	})
 }
 
 func (r *SportRepository) GetSportById(ctx context.Context, id int) (*services.Sport, error) {
-	query := "SELECT id, name FROM sports WHERE id = $1"
+	query := `
+	SELECT id, name, default_duration_minutes, min_rest_minutes, rest_conflict_policy, deleted_at, version, created_at, updated_at
+	FROM sports WHERE id = $1 AND ` + notDeletedSQL(ctx, "sports")
 	var dbModel sportDBModel
 
 	if err := r.db.GetContext(ctx, &dbModel, query, id); err != nil {
@@ -39,7 +56,9 @@ func (r *SportRepository) GetSportById(ctx context.Context, id int) (*services.S
 }
 
 func (r *SportRepository) ListSports(ctx context.Context) ([]services.Sport, error) {
-	query := "SELECT id, name FROM sports ORDER BY name ASC"
+	query := `
+	SELECT id, name, default_duration_minutes, min_rest_minutes, rest_conflict_policy, deleted_at, version, created_at, updated_at
+	FROM sports WHERE ` + notDeletedSQL(ctx, "sports") + ` ORDER BY name ASC`
 	var dbModel []sportDBModel
 	if err := r.db.SelectContext(ctx, &dbModel, query); err != nil {
 		return nil, err
@@ -51,16 +70,43 @@ func (r *SportRepository) ListSports(ctx context.Context) ([]services.Sport, err
 	return sports, nil
 }
 
-func (r *SportRepository) UpdateSport(ctx context.Context, id int, name string) error {
-	query := "UPDATE sports SET name = $1 WHERE id = $2"
+// UpdateSport saves a sport. A non-zero sport.Version makes the update
+// conditional: a sport modified since fails with a VersionMismatchError.
+// A missing or soft-deleted sport is sql.ErrNoRows; restore it first.
+func (r *SportRepository) UpdateSport(ctx context.Context, sport services.Sport) error {
+	query := `
+	UPDATE sports SET
+		name = $1,
+		default_duration_minutes = $2,
+		min_rest_minutes = $3,
+		rest_conflict_policy = $4
+	WHERE id = $5 AND deleted_at IS NULL` + versionCondition("$6")
 
-	_, err := r.db.ExecContext(ctx, query, name, id)
+	_, err := auditedWrite(ctx, r.db, services.AuditEntitySport, services.AuditActionUpdate, sport.ID, func(tx *sqlx.Tx) (int, error) {
+		var liveID int
+		if err := tx.GetContext(ctx, &liveID, "SELECT id FROM sports WHERE id = $1 AND deleted_at IS NULL", sport.ID); err != nil {
+			return 0, err
+		}
+		return sport.ID, execVersioned(ctx, tx, services.AuditEntitySport, "sports", sport.ID, sport.Version, query,
+			sport.Name, sport.DefaultDurationMinutes, sport.MinRestMinutes, sport.RestConflictPolicy, sport.ID, sport.Version)
+	})
 	return err
 }
 
+// DeleteSport soft-deletes a sport; the purge job removes it for good.
 func (r *SportRepository) DeleteSport(ctx context.Context, id int) error {
-	query := "DELETE FROM sports WHERE id = $1"
+	query := "UPDATE sports SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	_, err := auditedWrite(ctx, r.db, services.AuditEntitySport, services.AuditActionDelete, id, func(tx *sqlx.Tx) (int, error) {
+		_, err := tx.ExecContext(ctx, query, id)
+		return id, err
+	})
+	return err
+}
 
-	_, err := r.db.ExecContext(ctx, query, id)
+func (r *SportRepository) RestoreSport(ctx context.Context, id int) error {
+	_, err := auditedWrite(ctx, r.db, services.AuditEntitySport, services.AuditActionRestore, id, func(tx *sqlx.Tx) (int, error) {
+		return id, restoreRow(ctx, tx, "sports", id)
+	})
 	return err
 }
@@ -2,10 +2,12 @@ package infrastructure
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
 )
 
 func TestSportRepository_Integration(t *testing.T) {
@@ -26,23 +28,26 @@ func TestSportRepository_Integration(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("CreateSport", func(t *testing.T) {
-		id, err := repo.CreateSport(ctx, "Basketball")
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Basketball"})
 		require.NoError(t, err)
 		assert.Greater(t, id, 0)
 	})
 
 	t.Run("GetSportById", func(t *testing.T) {
-		id, err := repo.CreateSport(ctx, "Tennis")
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Tennis"})
 		require.NoError(t, err)
 
 		sport, err := repo.GetSportById(ctx, id)
 		require.NoError(t, err)
 		assert.Equal(t, id, sport.ID)
 		assert.Equal(t, "Tennis", sport.Name)
+		assert.Equal(t, services.DefaultEventDurationMinutes, sport.DefaultDurationMinutes)
+		assert.Equal(t, 0, sport.MinRestMinutes)
+		assert.Equal(t, services.RestPolicyReject, sport.RestConflictPolicy)
 	})
 
 	t.Run("ListSports", func(t *testing.T) {
-		_, err := repo.CreateSport(ctx, "Soccer")
+		_, err := repo.CreateSport(ctx, services.SportRequest{Name: "Soccer"})
 		require.NoError(t, err)
 
 		sports, err := repo.ListSports(ctx)
@@ -51,19 +56,56 @@ func TestSportRepository_Integration(t *testing.T) {
 	})
 
 	t.Run("UpdateSport", func(t *testing.T) {
-		id, err := repo.CreateSport(ctx, "Baseball")
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Baseball"})
 		require.NoError(t, err)
 
-		err = repo.UpdateSport(ctx, id, "Updated Baseball")
+		err = repo.UpdateSport(ctx, services.Sport{
+			ID:                     id,
+			Name:                   "Updated Baseball",
+			DefaultDurationMinutes: 90,
+			MinRestMinutes:         720,
+			RestConflictPolicy:     services.RestPolicyWarn,
+		})
 		require.NoError(t, err)
 
 		sport, err := repo.GetSportById(ctx, id)
 		require.NoError(t, err)
 		assert.Equal(t, "Updated Baseball", sport.Name)
+		assert.Equal(t, 90, sport.DefaultDurationMinutes)
+		assert.Equal(t, 720, sport.MinRestMinutes)
+		assert.Equal(t, services.RestPolicyWarn, sport.RestConflictPolicy)
+	})
+
+	t.Run("UpdateSport at a version", func(t *testing.T) {
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Cricket"})
+		require.NoError(t, err)
+		sport, err := repo.GetSportById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, 1, sport.Version)
+
+		sport.Name = "Test Cricket"
+		require.NoError(t, repo.UpdateSport(ctx, *sport))
+		updated, err := repo.GetSportById(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated.Version)
+		assert.True(t, updated.CreatedAt.Equal(sport.CreatedAt))
+		assert.True(t, updated.UpdatedAt.After(sport.UpdatedAt))
+
+		sport.Name = "T20 Cricket"
+		err = repo.UpdateSport(ctx, *sport)
+		var mismatch *services.VersionMismatchError
+		require.ErrorAs(t, err, &mismatch)
+		assert.Equal(t, 2, mismatch.CurrentVersion)
+		unchanged, err := repo.GetSportById(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Test Cricket", unchanged.Name)
+
+		err = repo.UpdateSport(ctx, services.Sport{ID: id + 1000, Name: "Ghost", Version: 1})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
 	})
 
 	t.Run("DeleteSport", func(t *testing.T) {
-		id, err := repo.CreateSport(ctx, "Volleyball")
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Volleyball"})
 		require.NoError(t, err)
 
 		err = repo.DeleteSport(ctx, id)
@@ -72,5 +114,28 @@ func TestSportRepository_Integration(t *testing.T) {
 		_, err = repo.GetSportById(ctx, id)
 		assert.Error(t, err)
 	})
+
+	t.Run("soft delete and RestoreSport", func(t *testing.T) {
+		id, err := repo.CreateSport(ctx, services.SportRequest{Name: "Handball"})
+		require.NoError(t, err)
+		require.NoError(t, repo.DeleteSport(ctx, id))
+
+		sport, err := repo.GetSportById(services.WithDeleted(ctx), id)
+		require.NoError(t, err)
+		require.NotNil(t, sport.DeletedAt)
+		sports, err := repo.ListSports(ctx)
+		require.NoError(t, err)
+		for _, s := range sports {
+			assert.NotEqual(t, id, s.ID)
+		}
+
+		require.NoError(t, repo.RestoreSport(ctx, id))
+		sport, err = repo.GetSportById(ctx, id)
+		require.NoError(t, err)
+		assert.Nil(t, sport.DeletedAt)
+
+		err = repo.RestoreSport(ctx, id)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
 }
 
@@ -0,0 +1,312 @@
+package infrastructure
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/config"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// sqliteDriverName is the database/sql driver for SQLite databases: the
+// pure-Go modernc.org/sqlite driver behind a layer that translates the
+// PostgreSQL dialect the repositories are written in.
+const sqliteDriverName = "sqlite-pg"
+
+// sqliteTimeLayout is how timestamps are stored in SQLite. It is fixed-width
+// and always in UTC, so that comparing and ordering them as text is
+// chronological.
+const sqliteTimeLayout = "2006-01-02 15:04:05.000000-07:00"
+
+// sqliteNowSQL is NOW() in sqliteTimeLayout. SQLite keeps "now" fixed for the
+// duration of a statement, not of a transaction as PostgreSQL does.
+const sqliteNowSQL = `(strftime('%Y-%m-%d %H:%M:%f000+00:00', 'now'))`
+
+// sqliteExclusionMessage is what the triggers standing in for the venue
+// exclusion constraint raise; it is reported as PostgreSQL reports the
+// constraint.
+const sqliteExclusionMessage = `conflicting key value violates exclusion constraint "no_venue_double_booking"`
+
+var (
+	pgCast        = regexp.MustCompile(`([\w.$]+|\))::(\w+)`)
+	pgPlaceholder = regexp.MustCompile(`\$(\d+)`)
+	pgLimit       = regexp.MustCompile(`(?i)\bLIMIT (\$\d+)`)
+	pgNow         = regexp.MustCompile(`(?i)\bNOW\(\)`)
+	pgLeast       = regexp.MustCompile(`(?i)\bLEAST\(`)
+	pgTimestamptz = regexp.MustCompile(`(?i)\bTIMESTAMPTZ\b`)
+)
+
+func init() {
+	// The unique index on team aliases calls search_unaccent, so it has to
+	// be deterministic. SQLite's lower() only folds ASCII letters, which is
+	// what is left of most names once unaccented.
+	sqlite.MustRegisterDeterministicScalarFunction("search_unaccent", 1,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			if s, ok := args[0].(string); ok {
+				return unaccent(s), nil
+			}
+			return args[0], nil
+		})
+	// Names sort ignoring case and accents, as in the in-memory store. Only
+	// equal bytes compare equal, so unique constraints are unaffected.
+	sqlite.MustRegisterCollationUtf8("calendar_name", func(a, b string) int {
+		return cmp.Or(strings.Compare(foldName(a), foldName(b)), strings.Compare(a, b))
+	})
+
+	db, err := sql.Open("sqlite", "")
+	if err != nil {
+		panic(err)
+	}
+	sql.Register(sqliteDriverName, sqliteDriver{base: db.Driver()})
+	sqlx.BindDriver(sqliteDriverName, sqlx.DOLLAR)
+}
+
+// newSQLiteConnection opens the SQLite database in the file cfg.DBName,
+// creating it if needed. Writers wait for each other rather than fail, and
+// transactions take the write lock up front, so that two of them cannot
+// deadlock upgrading their read locks.
+func newSQLiteConnection(cfg config.Config) (*sqlx.DB, error) {
+	dsn := "file:" + cfg.DBName + "?" + url.Values{
+		"_pragma": {"foreign_keys(1)", "journal_mode(WAL)", "busy_timeout(5000)"},
+		"_txlock": {"immediate"},
+	}.Encode()
+	db, err := sqlx.Connect(sqliteDriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to db: %w", err)
+	}
+	configurePool(db, cfg)
+	log.Printf("connected to sqlite db %s", cfg.DBName)
+	return db, nil
+}
+
+// translateSQL rewrites a query written for PostgreSQL into SQLite's
+// dialect: $n placeholders become ?n, casts are dropped but for those to
+// float8 that keep integer division from truncating, a NULL limit means no
+// limit, NOW() and LEAST map to their SQLite counterparts and TIMESTAMPTZ
+// columns are declared TIMESTAMP. RETURNING needs no translation; SQLite
+// supports it since 3.35.
+func translateSQL(query string) string {
+	query = pgCast.ReplaceAllStringFunc(query, func(cast string) string {
+		match := pgCast.FindStringSubmatch(cast)
+		if match[1] != ")" && (match[2] == "float8" || match[2] == "numeric") {
+			return "CAST(" + match[1] + " AS REAL)"
+		}
+		return match[1]
+	})
+	query = pgLimit.ReplaceAllString(query, "LIMIT COALESCE($1, -1)")
+	query = pgPlaceholder.ReplaceAllString(query, "?$1")
+	query = pgNow.ReplaceAllLiteralString(query, sqliteNowSQL)
+	query = pgLeast.ReplaceAllLiteralString(query, "MIN(")
+	return pgTimestamptz.ReplaceAllLiteralString(query, "TIMESTAMP")
+}
+
+// translateSQLiteError reports SQLite's constraint violations as the
+// PostgreSQL errors the repositories and services expect, and a database
+// busy with another writer as a serialization failure worth retrying.
+func translateSQLiteError(err error) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+	message := strings.TrimSuffix(sqliteErr.Error(), fmt.Sprintf(" (%d)", sqliteErr.Code()))
+	if _, detail, ok := strings.Cut(message, ": "); ok {
+		message = detail
+	}
+
+	var code string
+	switch sqliteErr.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+		code = "23505"
+	case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+		code = "23503"
+	case sqlite3.SQLITE_CONSTRAINT_CHECK:
+		code = "23514"
+	case sqlite3.SQLITE_CONSTRAINT_NOTNULL:
+		code = "23502"
+	case sqlite3.SQLITE_CONSTRAINT_TRIGGER:
+		code = "P0001"
+		if message == sqliteExclusionMessage {
+			code = "23P01"
+		}
+	default:
+		if sqliteErr.Code()&0xff != sqlite3.SQLITE_BUSY {
+			return err
+		}
+		code = "40001"
+	}
+	return &pgconn.PgError{Severity: "ERROR", Code: code, Message: message}
+}
+
+// sqliteArgs stores times in sqliteTimeLayout.
+func sqliteArgs(args []driver.NamedValue) []driver.NamedValue {
+	for i, arg := range args {
+		if t, ok := arg.Value.(time.Time); ok {
+			args[i].Value = t.UTC().Format(sqliteTimeLayout)
+		}
+	}
+	return args
+}
+
+type sqliteDriver struct {
+	base driver.Driver
+}
+
+func (d sqliteDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteConn{conn: conn}, nil
+}
+
+// sqliteConn is a connection of the underlying driver, which implements the
+// context-aware interfaces it forwards to.
+type sqliteConn struct {
+	conn driver.Conn
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.conn.(driver.ConnPrepareContext).PrepareContext(ctx, translateSQL(query))
+	if err != nil {
+		return nil, translateSQLiteError(err)
+	}
+	return &sqliteStmt{stmt: stmt}, nil
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result, err := c.conn.(driver.ExecerContext).ExecContext(ctx, translateSQL(query), sqliteArgs(args))
+	return result, translateSQLiteError(err)
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.conn.(driver.QueryerContext).QueryContext(ctx, translateSQL(query), sqliteArgs(args))
+	if err != nil {
+		return nil, translateSQLiteError(err)
+	}
+	return newSQLiteRows(rows), nil
+}
+
+func (c *sqliteConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx ignores the isolation level asked for: SQLite transactions are
+// always serializable.
+func (c *sqliteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := c.conn.(driver.ConnBeginTx).BeginTx(ctx, driver.TxOptions{ReadOnly: opts.ReadOnly})
+	return tx, translateSQLiteError(err)
+}
+
+func (c *sqliteConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *sqliteConn) ResetSession(ctx context.Context) error {
+	return c.conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+func (c *sqliteConn) IsValid() bool {
+	return c.conn.(driver.Validator).IsValid()
+}
+
+type sqliteStmt struct {
+	stmt driver.Stmt
+}
+
+func (s *sqliteStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *sqliteStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *sqliteStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("sqlite: Exec without a context is not supported")
+}
+
+func (s *sqliteStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("sqlite: Query without a context is not supported")
+}
+
+func (s *sqliteStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	result, err := s.stmt.(driver.StmtExecContext).ExecContext(ctx, sqliteArgs(args))
+	return result, translateSQLiteError(err)
+}
+
+func (s *sqliteStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.stmt.(driver.StmtQueryContext).QueryContext(ctx, sqliteArgs(args))
+	if err != nil {
+		return nil, translateSQLiteError(err)
+	}
+	return newSQLiteRows(rows), nil
+}
+
+// sqliteRows returns times in UTC. The underlying driver only parses the
+// timestamps of columns declared as such; those of expressions, which have
+// no declared type, are parsed here.
+type sqliteRows struct {
+	rows     driver.Rows
+	untyped  []bool
+	typeName func(index int) string
+}
+
+func newSQLiteRows(rows driver.Rows) *sqliteRows {
+	r := &sqliteRows{rows: rows, typeName: func(int) string { return "" }}
+	if typed, ok := rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		r.typeName = typed.ColumnTypeDatabaseTypeName
+	}
+	r.untyped = make([]bool, len(rows.Columns()))
+	for i := range r.untyped {
+		r.untyped[i] = r.typeName(i) == ""
+	}
+	return r
+}
+
+func (r *sqliteRows) Columns() []string {
+	return r.rows.Columns()
+}
+
+func (r *sqliteRows) Close() error {
+	return r.rows.Close()
+}
+
+func (r *sqliteRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.typeName(index)
+}
+
+func (r *sqliteRows) Next(dest []driver.Value) error {
+	if err := r.rows.Next(dest); err != nil {
+		return translateSQLiteError(err)
+	}
+	for i, value := range dest {
+		switch value := value.(type) {
+		case time.Time:
+			dest[i] = value.UTC()
+		case string:
+			if !r.untyped[i] || len(value) != len(sqliteTimeLayout) {
+				continue
+			}
+			if t, err := time.Parse(sqliteTimeLayout, value); err == nil {
+				dest[i] = t.UTC()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/config"
+	"github.com/vsennikov/sports-event-calendar/infrastructure/repotest"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func newSQLiteTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	db, err := NewConnection(config.Config{
+		DBDriver: "sqlite", DBName: filepath.Join(t.TempDir(), "calendar.db"), DBMaxIdleConns: 2,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	return db
+}
+
+func TestSQLiteRepositories_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db := newSQLiteTestDB(t)
+		return repotest.Repositories{
+			Sports: NewSportRepository(db),
+			Teams:  NewTeamRepository(db),
+			Venues: NewVenueRepository(db),
+			Events: NewEventRepository(db),
+		}
+	})
+}
+
+func TestTranslateSQL(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: "SELECT id FROM sports WHERE id = $1 AND ($2::int = 0 OR version = $2::int)",
+			want:  "SELECT id FROM sports WHERE id = ?1 AND (?2 = 0 OR version = ?2)",
+		},
+		{
+			query: "SELECT AVG(e.attendance::float8 / v.capacity), COALESCE(AVG(e.attendance), 0)::float8 FROM events e",
+			want:  "SELECT AVG(CAST(e.attendance AS REAL) / v.capacity), COALESCE(AVG(e.attendance), 0) FROM events e",
+		},
+		{
+			query: "SELECT id FROM audit_log ORDER BY id LIMIT $1",
+			want:  "SELECT id FROM audit_log ORDER BY id LIMIT COALESCE(?1, -1)",
+		},
+		{
+			query: "UPDATE events SET deleted_at = NOW() WHERE id = $10 RETURNING id",
+			want:  "UPDATE events SET deleted_at = " + sqliteNowSQL + " WHERE id = ?10 RETURNING id",
+		},
+		{
+			query: "SELECT ASIN(LEAST(1, SQRT($1::float8)))",
+			want:  "SELECT ASIN(MIN(1, SQRT(CAST(?1 AS REAL))))",
+		},
+		{
+			query: "CREATE TABLE t (applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW())",
+			want:  "CREATE TABLE t (applied_at TIMESTAMP NOT NULL DEFAULT " + sqliteNowSQL + ")",
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, translateSQL(tt.query))
+	}
+}
+
+func TestSQLiteTimestamps(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteTestDB(t)
+
+	// Stored as text, timestamps still have to order by time across
+	// offsets and fractions of a second.
+	var stored string
+	berlin := time.FixedZone("CEST", 2*60*60)
+	early := time.Date(2026, 5, 2, 16, 0, 0, 500_000_000, berlin)
+	require.NoError(t, db.GetContext(ctx, &stored, "SELECT quote($1)", early))
+	assert.Equal(t, "'2026-05-02 14:00:00.500000+00:00'", stored)
+	var ordered bool
+	require.NoError(t, db.GetContext(ctx, &ordered, "SELECT $1 < $2", early, early.Add(time.Millisecond).UTC()))
+	assert.True(t, ordered)
+
+	var now, original time.Time
+	require.NoError(t, db.GetContext(ctx, &now, "SELECT NOW()"))
+	assert.WithinDuration(t, time.Now(), now, time.Minute)
+	require.NoError(t, db.GetContext(ctx, &original, "SELECT $1", early))
+	assert.True(t, original.Equal(early))
+	assert.Equal(t, time.UTC, original.Location())
+}
+
+func TestSQLiteErrors(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteTestDB(t)
+	sports := NewSportRepository(db)
+
+	_, err := db.ExecContext(ctx, "INSERT INTO audit_log (entity_type, entity_id, action, actor) VALUES ('sport', 1, 'create', 'test')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "DELETE FROM audit_log")
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "P0001", pgErr.Code)
+	assert.Equal(t, "audit_log is append-only", pgErr.Message)
+
+	_, err = sports.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+	require.NoError(t, err)
+	_, err = sports.CreateSport(ctx, services.SportRequest{Name: "Rugby"})
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "23505", pgErr.Code)
+	assert.Equal(t, "UNIQUE constraint failed: sports.name", pgErr.Message)
+}
+
+func TestSQLiteAudit(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteTestDB(t)
+	repos := repotest.Repositories{
+		Sports: NewSportRepository(db), Teams: NewTeamRepository(db),
+		Venues: NewVenueRepository(db), Events: NewEventRepository(db),
+	}
+	sportID, err := repos.Sports.CreateSport(ctx, services.SportRequest{Name: "Football"})
+	require.NoError(t, err)
+	teamIDs := make([]int, 2)
+	for i, name := range []string{"Alpha", "Bravo"} {
+		teamIDs[i], err = repos.Teams.CreateTeam(ctx, services.TeamRequest{Name: name, City: "Berlin", SportID: sportID})
+		require.NoError(t, err)
+	}
+	start := time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC)
+	eventID, err := repos.Events.CreateEvent(ctx, services.CreateEventParams{
+		EventDatetime: start, EndDatetime: start.Add(2 * time.Hour),
+		SportID: sportID, HomeTeamID: teamIDs[0], AwayTeamID: teamIDs[1],
+	})
+	require.NoError(t, err)
+	event, err := repos.Events.GetEventByID(ctx, eventID)
+	require.NoError(t, err)
+	event.AllowVenueOverlap = true
+	require.NoError(t, repos.Events.UpdateEvent(ctx, *event))
+
+	entityType := services.AuditEntityEvent
+	entries, err := NewAuditRepository(db).ListAuditEntries(ctx, services.ListAuditEntriesParams{EntityType: &entityType})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, services.AuditActionUpdate, entries[0].Action)
+	assert.JSONEq(t, `{"allow_venue_overlap": false}`, string(entries[0].Before))
+	assert.JSONEq(t, `{"allow_venue_overlap": true}`, string(entries[0].After))
+	assert.Contains(t, string(entries[1].After), `"event_datetime":"2026-05-02T15:00:00Z"`)
+}
+
+func TestSQLitePurge(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteTestDB(t)
+	sports, teams, events := NewSportRepository(db), NewTeamRepository(db), NewEventRepository(db)
+	series := NewSeriesRepository(db)
+	sportID, err := sports.CreateSport(ctx, services.SportRequest{Name: "Football"})
+	require.NoError(t, err)
+	teamIDs := make([]int, 3)
+	for i, name := range []string{"Alpha", "Bravo", "Charlie"} {
+		teamIDs[i], err = teams.CreateTeam(ctx, services.TeamRequest{Name: name, City: "Berlin", SportID: sportID})
+		require.NoError(t, err)
+	}
+	start := time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC)
+	eventID, err := events.CreateEvent(ctx, services.CreateEventParams{
+		EventDatetime: start, EndDatetime: start.Add(2 * time.Hour),
+		SportID: sportID, HomeTeamID: teamIDs[0], AwayTeamID: teamIDs[1],
+	})
+	require.NoError(t, err)
+	seriesID, err := series.CreateSeries(ctx, services.EventSeries{
+		RRule: "FREQ=WEEKLY", StartDatetime: start, TimeZone: "UTC",
+		SportID: sportID, HomeTeamID: teamIDs[0], AwayTeamID: teamIDs[1],
+	})
+	require.NoError(t, err)
+	require.NoError(t, series.AddException(ctx, seriesID, time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC)))
+	occurrenceID, err := events.CreateEvent(ctx, services.CreateEventParams{
+		EventDatetime: start.AddDate(0, 0, -7), EndDatetime: start.AddDate(0, 0, -7).Add(2 * time.Hour),
+		SportID: sportID, HomeTeamID: teamIDs[0], AwayTeamID: teamIDs[2], SeriesID: &seriesID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, events.DeleteEvent(ctx, eventID))
+	require.NoError(t, series.DeleteSeries(ctx, seriesID))
+	require.NoError(t, teams.DeleteTeam(ctx, teamIDs[1]))
+
+	result, err := NewPurgeRepository(db).PurgeDeleted(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, services.PurgeResult{Events: 1, Series: 1, Teams: 1}, *result)
+	_, err = events.GetEventByID(services.WithDeleted(ctx), eventID)
+	assert.Error(t, err)
+	_, err = teams.GetTeamByID(services.WithDeleted(ctx), teamIDs[1])
+	assert.Error(t, err)
+	_, err = teams.GetTeamByID(ctx, teamIDs[0])
+	assert.NoError(t, err)
+
+	entries, err := NewAuditRepository(db).ListAuditEntries(ctx, services.ListAuditEntriesParams{Limit: 4})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	for i, want := range []struct {
+		entityType string
+		id         int
+		action     string
+	}{
+		{services.AuditEntityTeam, teamIDs[1], services.AuditActionPurge},
+		{services.AuditEntityEvent, occurrenceID, services.AuditActionUpdate},
+		{services.AuditEntitySeries, seriesID, services.AuditActionPurge},
+		{services.AuditEntityEvent, eventID, services.AuditActionPurge},
+	} {
+		assert.Equal(t, want.entityType, entries[i].EntityType)
+		assert.Equal(t, want.id, entries[i].EntityID)
+		assert.Equal(t, want.action, entries[i].Action)
+		assert.Equal(t, services.SystemActor, entries[i].Actor)
+	}
+	assert.Contains(t, string(entries[0].Before), `"name":"Bravo"`)
+	assert.Nil(t, entries[0].After)
+	assert.JSONEq(t, fmt.Sprintf(`{"_series_id": %d}`, seriesID), string(entries[1].Before))
+	assert.JSONEq(t, `{"_series_id": null}`, string(entries[1].After))
+	assert.Contains(t, string(entries[2].Before), `"exceptions":["2026-05-09"]`)
+	assert.Nil(t, entries[3].After)
+}
+
+func TestSQLiteMergeAudit(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteTestDB(t)
+	sports, teams, events := NewSportRepository(db), NewTeamRepository(db), NewEventRepository(db)
+	sportID, err := sports.CreateSport(ctx, services.SportRequest{Name: "Football"})
+	require.NoError(t, err)
+	teamIDs := make([]int, 3)
+	for i, name := range []string{"Alpha", "Alpha FC", "Bravo"} {
+		teamIDs[i], err = teams.CreateTeam(ctx, services.TeamRequest{Name: name, City: "Berlin", SportID: sportID})
+		require.NoError(t, err)
+	}
+	start := time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC)
+	eventID, err := events.CreateEvent(ctx, services.CreateEventParams{
+		EventDatetime: start, EndDatetime: start.Add(2 * time.Hour),
+		SportID: sportID, HomeTeamID: teamIDs[1], AwayTeamID: teamIDs[2],
+	})
+	require.NoError(t, err)
+	require.NoError(t, events.DeleteEvent(ctx, eventID))
+
+	survivor, err := teams.GetTeamByID(ctx, teamIDs[0])
+	require.NoError(t, err)
+	require.NoError(t, teams.MergeTeams(ctx, *survivor, teamIDs[1]))
+
+	entityType := services.AuditEntityEvent
+	entries, err := NewAuditRepository(db).ListAuditEntries(ctx, services.ListAuditEntriesParams{
+		EntityType: &entityType, EntityID: &eventID,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, services.AuditActionUpdate, entries[0].Action)
+	assert.JSONEq(t, fmt.Sprintf(`{"_home_team_id": %d}`, teamIDs[1]), string(entries[0].Before))
+	assert.JSONEq(t, fmt.Sprintf(`{"_home_team_id": %d}`, teamIDs[0]), string(entries[0].After))
+
+	_, err = events.CreateEvent(ctx, services.CreateEventParams{
+		EventDatetime: start, EndDatetime: start.Add(2 * time.Hour),
+		SportID: sportID, HomeTeamID: teamIDs[0], AwayTeamID: teamIDs[2],
+	})
+	require.NoError(t, err)
+	survivor, err = teams.GetTeamByID(ctx, teamIDs[2])
+	require.NoError(t, err)
+	var clash *services.TeamClashError
+	require.ErrorAs(t, teams.MergeTeams(ctx, *survivor, teamIDs[0]), &clash)
+	assert.Len(t, clash.EventIDs, 2)
+}
@@ -8,59 +8,208 @@ import (
 )
 
 type TeamRepository struct {
-		db *sqlx.DB
+		db dbtx
 }
 
 func NewTeamRepository(db *sqlx.DB) *TeamRepository {
 	return &TeamRepository{db: db}
 }
 
+const teamColumns = "id, name, city, _sport_id, short_name, code, deleted_at, version, created_at, updated_at"
+
 func (r *TeamRepository) CreateTeam(ctx context.Context, params services.TeamRequest) (int, error) {
-	query := `INSERT INTO teams (name, city, _sport_id) VALUES ($1, $2, $3) RETURNING id`
+	query := `INSERT INTO teams (name, city, _sport_id, short_name, code) VALUES ($1, $2, $3, $4, $5) RETURNING id`
 	var newID int
 
-	err := r.db.QueryRowContext(ctx, query, params.Name, params.City, params.SportID).Scan(&newID)
-	if err != nil {
-		return 0, err
-	}
-	return newID, nil
+	return auditedWrite(ctx, r.db, services.AuditEntityTeam, services.AuditActionCreate, 0, func(tx *sqlx.Tx) (int, error) {
+		err := tx.QueryRowContext(ctx, query, params.Name, params.City, params.SportID, params.ShortName, params.Code).Scan(&newID)
+		if err != nil {
+			return 0, err
+		}
+		return newID, replaceTeamAliases(ctx, tx, newID, params.SportID, params.Aliases)
+	})
 }
 
 func (r *TeamRepository) GetTeamByID(ctx context.Context, id int) (*services.Team, error) {
-	query := `SELECT id, name, city, _sport_id FROM teams WHERE id = $1`
+	query := "SELECT " + teamColumns + " FROM teams WHERE id = $1 AND " + notDeletedSQL(ctx, "teams")
 	var dbTeam teamDBModel
 
 	if err := r.db.GetContext(ctx, &dbTeam, query, id); err != nil {
 		return nil, err
 	}
-	team := toServiceTeam(dbTeam)
+	aliases, err := r.listAliases(ctx, dbTeam.ID)
+	if err != nil {
+		return nil, err
+	}
+	team := toServiceTeam(dbTeam, aliases)
 	return &team, nil
 }
 
 func (r *TeamRepository) ListTeams(ctx context.Context) ([]services.Team, error) {
-	query := `SELECT id, name, city, _sport_id FROM teams ORDER BY name ASC`
+	query := "SELECT " + teamColumns + " FROM teams WHERE " + notDeletedSQL(ctx, "teams") + " ORDER BY name ASC"
+	return r.selectTeams(ctx, query)
+}
+
+func (r *TeamRepository) CountTeamsBySportID(ctx context.Context, sportID int) (int, error) {
+	query := "SELECT COUNT(*) FROM teams WHERE _sport_id = $1 AND deleted_at IS NULL"
+	var total int
+
+	if err := r.db.GetContext(ctx, &total, query, sportID); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// FindTeamsByName returns the teams whose name, short name, code or one of
+// whose aliases equals name, ignoring case and accents.
+func (r *TeamRepository) FindTeamsByName(ctx context.Context, name string, sportID *int) ([]services.Team, error) {
+	query := "SELECT " + teamColumns + ` FROM teams t
+	WHERE ($2::int IS NULL OR t._sport_id = $2) AND ` + notDeletedSQL(ctx, "t") + `
+	AND (
+		lower(search_unaccent(t.name)) = lower(search_unaccent($1))
+		OR lower(search_unaccent(t.short_name)) = lower(search_unaccent($1))
+		OR lower(t.code) = lower($1)
+		OR EXISTS (
+			SELECT 1 FROM team_aliases a
+			WHERE a._team_id = t.id AND lower(search_unaccent(a.alias)) = lower(search_unaccent($1))
+		)
+	)
+	ORDER BY t.name ASC, t.id ASC`
+	return r.selectTeams(ctx, query, name, sportID)
+}
+
+// UpdateTeam saves a team and replaces its aliases, unless the team has
+// moved past a non-zero team.Version.
+func (r *TeamRepository) UpdateTeam(ctx context.Context, team services.Team) error {
+	query := `UPDATE teams SET name = $1, city = $2, short_name = $3, code = $4 WHERE id = $5` + versionCondition("$6")
+
+	_, err := auditedWrite(ctx, r.db, services.AuditEntityTeam, services.AuditActionUpdate, team.ID, func(tx *sqlx.Tx) (int, error) {
+		err := execVersioned(ctx, tx, services.AuditEntityTeam, "teams", team.ID, team.Version, query,
+			team.Name, team.City, team.ShortName, team.Code, team.ID, team.Version)
+		if err != nil {
+			return 0, err
+		}
+		return team.ID, replaceTeamAliases(ctx, tx, team.ID, team.SportID, team.Aliases)
+	})
+	return err
+}
+
+// DeleteTeam soft-deletes a team; the purge job removes it for good. Its
+// names stay reserved until then.
+func (r *TeamRepository) DeleteTeam(ctx context.Context, id int) error {
+	query := `UPDATE teams SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	_, err := auditedWrite(ctx, r.db, services.AuditEntityTeam, services.AuditActionDelete, id, func(tx *sqlx.Tx) (int, error) {
+		_, err := tx.ExecContext(ctx, query, id)
+		return id, err
+	})
+	return err
+}
+
+func (r *TeamRepository) RestoreTeam(ctx context.Context, id int) error {
+	_, err := auditedWrite(ctx, r.db, services.AuditEntityTeam, services.AuditActionRestore, id, func(tx *sqlx.Tx) (int, error) {
+		return id, restoreRow(ctx, tx, "teams", id)
+	})
+	return err
+}
+
+// MergeTeams re-points the events, series and change history of the
+// duplicate team to the survivor, deletes the duplicate with its aliases and
+// saves the survivor. The duplicate is deleted first so that the survivor can
+// take over its names without breaking the per-sport uniqueness of names.
+// Teams that meet in an event or series, deleted ones included, are not
+// merged: that fails with a TeamClashError naming them.
+func (r *TeamRepository) MergeTeams(ctx context.Context, survivor services.Team, duplicateID int) error {
+	repoint := []string{
+		`UPDATE events SET _home_team_id = $1 WHERE _home_team_id = $2`,
+		`UPDATE events SET _away_team_id = $1 WHERE _away_team_id = $2`,
+		`UPDATE event_series SET _home_team_id = $1 WHERE _home_team_id = $2`,
+		`UPDATE event_series SET _away_team_id = $1 WHERE _away_team_id = $2`,
+		`UPDATE event_changes SET _home_team_id = $1 WHERE _home_team_id = $2`,
+		`UPDATE event_changes SET _away_team_id = $1 WHERE _away_team_id = $2`,
+	}
+
+	return inTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		survivorBefore, err := snapshotEntity(ctx, tx, services.AuditEntityTeam, survivor.ID)
+		if err != nil {
+			return err
+		}
+		duplicateBefore, err := snapshotEntity(ctx, tx, services.AuditEntityTeam, duplicateID)
+		if err != nil {
+			return err
+		}
+		clash := &services.TeamClashError{SurvivorID: survivor.ID, DuplicateID: duplicateID}
+		meet := ` WHERE (_home_team_id = $1 AND _away_team_id = $2) OR (_home_team_id = $2 AND _away_team_id = $1) ORDER BY id`
+		if err := tx.SelectContext(ctx, &clash.EventIDs, `SELECT id FROM events`+meet, survivor.ID, duplicateID); err != nil {
+			return err
+		}
+		if err := tx.SelectContext(ctx, &clash.SeriesIDs, `SELECT id FROM event_series`+meet, survivor.ID, duplicateID); err != nil {
+			return err
+		}
+		if len(clash.EventIDs) > 0 || len(clash.SeriesIDs) > 0 {
+			return clash
+		}
+		eventsBefore, err := snapshotEvents(ctx, tx, "$1 IN (_home_team_id, _away_team_id)", duplicateID)
+		if err != nil {
+			return err
+		}
+		for _, query := range repoint {
+			if _, err := tx.ExecContext(ctx, query, survivor.ID, duplicateID); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM teams WHERE id = $1`, duplicateID); err != nil {
+			return err
+		}
+		query := `UPDATE teams SET name = $1, city = $2, short_name = $3, code = $4 WHERE id = $5`
+		if _, err := tx.ExecContext(ctx, query, survivor.Name, survivor.City, survivor.ShortName, survivor.Code, survivor.ID); err != nil {
+			return err
+		}
+		if err := replaceTeamAliases(ctx, tx, survivor.ID, survivor.SportID, survivor.Aliases); err != nil {
+			return err
+		}
+		return recordMergeAudit(ctx, tx, services.AuditEntityTeam, survivor.ID, duplicateID,
+			survivorBefore, duplicateBefore, eventsBefore)
+	})
+}
+
+func (r *TeamRepository) selectTeams(ctx context.Context, query string, args ...interface{}) ([]services.Team, error) {
 	var dbTeams []teamDBModel
 
-	if err := r.db.SelectContext(ctx, &dbTeams, query); err != nil {
+	if err := r.db.SelectContext(ctx, &dbTeams, query, args...); err != nil {
 		return nil, err
 	}
 	teams := make([]services.Team, 0, len(dbTeams))
 	for _, dbTeam := range dbTeams {
-		teams = append(teams, toServiceTeam(dbTeam))
+		aliases, err := r.listAliases(ctx, dbTeam.ID)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, toServiceTeam(dbTeam, aliases))
 	}
 	return teams, nil
 }
 
-func (r *TeamRepository) UpdateTeam(ctx context.Context, team services.Team) error {
-	query := `UPDATE teams SET name = $1, city = $2 WHERE id = $3`
+func (r *TeamRepository) listAliases(ctx context.Context, teamID int) ([]string, error) {
+	aliases := []string{}
+	query := `SELECT alias FROM team_aliases WHERE _team_id = $1 ORDER BY alias ASC`
 
-	_, err := r.db.ExecContext(ctx, query, team.Name, team.City, team.ID)
-	return err
+	if err := r.db.SelectContext(ctx, &aliases, query, teamID); err != nil {
+		return nil, err
+	}
+	return aliases, nil
 }
 
-func (r *TeamRepository) DeleteTeam(ctx context.Context, id int) error {
-	query := `DELETE FROM teams WHERE id = $1`
-
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+func replaceTeamAliases(ctx context.Context, tx *sqlx.Tx, teamID, sportID int, aliases []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_aliases WHERE _team_id = $1`, teamID); err != nil {
+		return err
+	}
+	for _, alias := range aliases {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO team_aliases (_team_id, _sport_id, alias) VALUES ($1, $2, $3)`, teamID, sportID, alias)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
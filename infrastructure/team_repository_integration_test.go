@@ -3,6 +3,7 @@ package infrastructure
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,7 +25,7 @@ func TestTeamRepository_Integration(t *testing.T) {
 	defer CleanupTestDB(t, db)
 
 	sportRepo := NewSportRepository(db)
-	sportID, err := sportRepo.CreateSport(context.Background(), "Test Sport")
+	sportID, err := sportRepo.CreateSport(context.Background(), services.SportRequest{Name: "Test Sport"})
 	require.NoError(t, err)
 
 	repo := NewTeamRepository(db)
@@ -99,6 +100,117 @@ func TestTeamRepository_Integration(t *testing.T) {
 		assert.Equal(t, "Updated Miami", updatedTeam.City)
 	})
 
+	t.Run("Aliases and FindTeamsByName", func(t *testing.T) {
+		shortName := "Man City"
+		code := "MCI"
+		params := services.TeamRequest{
+			Name:      "Manchester City",
+			City:      "Manchester",
+			SportID:   sportID,
+			ShortName: &shortName,
+			Code:      &code,
+			Aliases:   []string{"Manchester City FC", "Cityzens"},
+		}
+
+		id, err := repo.CreateTeam(ctx, params)
+		require.NoError(t, err)
+
+		team, err := repo.GetTeamByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, sportID, team.SportID)
+		assert.Equal(t, shortName, *team.ShortName)
+		assert.Equal(t, code, *team.Code)
+		assert.Equal(t, []string{"Cityzens", "Manchester City FC"}, team.Aliases)
+
+		for _, name := range []string{"manchester city", "MAN CITY", "mci", "cityzens", "Manchester City FC"} {
+			teams, err := repo.FindTeamsByName(ctx, name, &sportID)
+			require.NoError(t, err, name)
+			require.Len(t, teams, 1, name)
+			assert.Equal(t, id, teams[0].ID, name)
+		}
+
+		otherSportID := sportID + 1000
+		teams, err := repo.FindTeamsByName(ctx, "MCI", &otherSportID)
+		require.NoError(t, err)
+		assert.Empty(t, teams)
+
+		team.Aliases = []string{"Sky Blues"}
+		require.NoError(t, repo.UpdateTeam(ctx, *team))
+		teams, err = repo.FindTeamsByName(ctx, "Cityzens", nil)
+		require.NoError(t, err)
+		assert.Empty(t, teams)
+		teams, err = repo.FindTeamsByName(ctx, "sky blues", nil)
+		require.NoError(t, err)
+		assert.Len(t, teams, 1)
+	})
+
+	t.Run("FindTeamsByName ignores accents", func(t *testing.T) {
+		params := services.TeamRequest{
+			Name:    "Eisbären Berlin",
+			City:    "Berlin",
+			SportID: sportID,
+			Aliases: []string{"EHC Eisbären"},
+		}
+
+		id, err := repo.CreateTeam(ctx, params)
+		require.NoError(t, err)
+
+		teams, err := repo.FindTeamsByName(ctx, "Eisbaren Berlin", &sportID)
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, id, teams[0].ID)
+
+		teams, err = repo.FindTeamsByName(ctx, "ehc eisbaren", &sportID)
+		require.NoError(t, err)
+		assert.Len(t, teams, 1)
+	})
+
+	t.Run("aliases are unique per sport", func(t *testing.T) {
+		_, err := repo.CreateTeam(ctx, services.TeamRequest{
+			Name:    "Eisbaeren Berlin",
+			City:    "Berlin",
+			SportID: sportID,
+			Aliases: []string{"ehc eisbaren"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("MergeTeams", func(t *testing.T) {
+		eventRepo := NewEventRepository(db)
+		survivorID, err := repo.CreateTeam(ctx, services.TeamRequest{Name: "Sturm Graz", City: "Graz", SportID: sportID})
+		require.NoError(t, err)
+		duplicateID, err := repo.CreateTeam(ctx, services.TeamRequest{
+			Name: "SK Sturm Graz", City: "Graz", SportID: sportID, Aliases: []string{"Die Schwarzen"},
+		})
+		require.NoError(t, err)
+		opponentID, err := repo.CreateTeam(ctx, services.TeamRequest{Name: "Rapid Wien", City: "Wien", SportID: sportID})
+		require.NoError(t, err)
+		kickoff := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+		eventID, err := eventRepo.CreateEvent(ctx, services.CreateEventParams{
+			EventDatetime: kickoff,
+			EndDatetime:   kickoff.Add(2 * time.Hour),
+			SportID:       sportID,
+			HomeTeamID:    opponentID,
+			AwayTeamID:    duplicateID,
+		})
+		require.NoError(t, err)
+
+		survivor, err := repo.GetTeamByID(ctx, survivorID)
+		require.NoError(t, err)
+		survivor.Aliases = []string{"SK Sturm Graz", "Die Schwarzen"}
+		require.NoError(t, repo.MergeTeams(ctx, *survivor, duplicateID))
+
+		_, err = repo.GetTeamByID(ctx, duplicateID)
+		assert.Error(t, err)
+		event, err := eventRepo.GetEventByID(ctx, eventID)
+		require.NoError(t, err)
+		assert.Equal(t, survivorID, event.AwayTeam.ID)
+		teams, err := repo.FindTeamsByName(ctx, "die schwarzen", &sportID)
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, survivorID, teams[0].ID)
+	})
+
 	t.Run("DeleteTeam", func(t *testing.T) {
 		params := services.TeamRequest{
 			Name:    "Bulls",
@@ -20,6 +20,7 @@ func SetupTestDB(t *testing.T) *sqlx.DB {
 		DBUser:     getEnvOrDefault("TEST_DB_USER", "postgres"),
 		DBPassword: getEnvOrDefault("TEST_DB_PASSWORD", "postgres"),
 		DBName:     getEnvOrDefault("TEST_DB_NAME", "sports_event_calendar_test"),
+		DBSSLMode:  "disable",
 	}
 
 	db, err := NewConnection(cfg)
@@ -37,12 +38,43 @@ func CleanupTestDB(t *testing.T, db *sqlx.DB) {
 
 	ctx := context.Background()
 
+	// The audit log is append-only, so it is truncated instead
+	_, err := db.ExecContext(ctx, "TRUNCATE audit_log RESTART IDENTITY")
+	if err != nil {
+		t.Logf("Error cleaning up audit log: %v", err)
+	}
+
 	// Delete in reverse order of dependencies
-	_, err := db.ExecContext(ctx, "DELETE FROM events")
+	_, err = db.ExecContext(ctx, "DELETE FROM event_changes")
+	if err != nil {
+		t.Logf("Error cleaning up event changes: %v", err)
+	}
+
+	_, err = db.ExecContext(ctx, "DELETE FROM event_broadcasts")
+	if err != nil {
+		t.Logf("Error cleaning up event broadcasts: %v", err)
+	}
+
+	_, err = db.ExecContext(ctx, "DELETE FROM event_reschedules")
+	if err != nil {
+		t.Logf("Error cleaning up event reschedules: %v", err)
+	}
+
+	_, err = db.ExecContext(ctx, "DELETE FROM events")
 	if err != nil {
 		t.Logf("Error cleaning up events: %v", err)
 	}
 
+	_, err = db.ExecContext(ctx, "DELETE FROM event_series")
+	if err != nil {
+		t.Logf("Error cleaning up event series: %v", err)
+	}
+
+	_, err = db.ExecContext(ctx, "DELETE FROM team_aliases")
+	if err != nil {
+		t.Logf("Error cleaning up team aliases: %v", err)
+	}
+
 	_, err = db.ExecContext(ctx, "DELETE FROM teams")
 	if err != nil {
 		t.Logf("Error cleaning up teams: %v", err)
@@ -59,11 +91,31 @@ func CleanupTestDB(t *testing.T, db *sqlx.DB) {
 	}
 
 	// Reset sequences
+	_, err = db.ExecContext(ctx, "ALTER SEQUENCE event_changes_id_seq RESTART WITH 1")
+	if err != nil {
+		t.Logf("Error resetting event changes sequence: %v", err)
+	}
+
+	_, err = db.ExecContext(ctx, "ALTER SEQUENCE event_broadcasts_id_seq RESTART WITH 1")
+	if err != nil {
+		t.Logf("Error resetting event broadcasts sequence: %v", err)
+	}
+
+	_, err = db.ExecContext(ctx, "ALTER SEQUENCE event_reschedules_id_seq RESTART WITH 1")
+	if err != nil {
+		t.Logf("Error resetting event reschedules sequence: %v", err)
+	}
+
 	_, err = db.ExecContext(ctx, "ALTER SEQUENCE events_id_seq RESTART WITH 1")
 	if err != nil {
 		t.Logf("Error resetting events sequence: %v", err)
 	}
 
+	_, err = db.ExecContext(ctx, "ALTER SEQUENCE event_series_id_seq RESTART WITH 1")
+	if err != nil {
+		t.Logf("Error resetting event series sequence: %v", err)
+	}
+
 	_, err = db.ExecContext(ctx, "ALTER SEQUENCE teams_id_seq RESTART WITH 1")
 	if err != nil {
 		t.Logf("Error resetting teams sequence: %v", err)
@@ -80,56 +132,15 @@ func CleanupTestDB(t *testing.T, db *sqlx.DB) {
 	}
 }
 
-// InitTestSchema initializes the test database schema
+// InitTestSchema migrates the test database to the latest schema
 func InitTestSchema(t *testing.T, db *sqlx.DB) {
 	t.Helper()
 
-	ctx := context.Background()
-
-	schema := `
-	SET TIMEZONE='UTC';
-
-	CREATE TABLE IF NOT EXISTS sports (
-		id SERIAL PRIMARY KEY,
-		name VARCHAR(100) UNIQUE NOT NULL
-	);
-
-	CREATE TABLE IF NOT EXISTS venues (
-		id SERIAL PRIMARY KEY,
-		name VARCHAR(255) NOT NULL,
-		city VARCHAR(100) NOT NULL,
-		country_code CHAR(2) NOT NULL
-	);
-
-	CREATE TABLE IF NOT EXISTS teams (
-		id SERIAL PRIMARY KEY,
-		name VARCHAR(100) NOT NULL,
-		city VARCHAR(100) NOT NULL,
-		_sport_id INTEGER NOT NULL,
-		CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
-		CONSTRAINT uq_team_sport UNIQUE (name, _sport_id)
-	);
-
-	CREATE TABLE IF NOT EXISTS events (
-		id SERIAL PRIMARY KEY,
-		event_datetime TIMESTAMPTZ NOT NULL,
-		description TEXT,
-		home_score INTEGER,
-		away_score INTEGER,
-		_sport_id INTEGER NOT NULL,
-		_venue_id INTEGER,
-		_home_team_id INTEGER NOT NULL,
-		_away_team_id INTEGER NOT NULL,
-		CONSTRAINT fk_sport FOREIGN KEY(_sport_id) REFERENCES sports(id),
-		CONSTRAINT fk_venue FOREIGN KEY(_venue_id) REFERENCES venues(id),
-		CONSTRAINT fk_home_team FOREIGN KEY(_home_team_id) REFERENCES teams(id),
-		CONSTRAINT fk_away_team FOREIGN KEY(_away_team_id) REFERENCES teams(id),
-		CONSTRAINT check_teams_not_equal CHECK (_home_team_id <> _away_team_id)
-	);
-	`
-
-	_, err := db.ExecContext(ctx, schema)
+	migrator, err := NewMigrator(db)
 	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
 		t.Fatalf("Failed to initialize test schema: %v", err)
 	}
 }
@@ -0,0 +1,109 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// maxTxAttempts bounds how often a unit of work is run when it keeps losing
+// serialization conflicts to concurrent transactions.
+const maxTxAttempts = 5
+
+// txRetryBaseDelay is the pause before the first retry; later retries back
+// off exponentially, with jitter so that the losers do not collide again.
+const txRetryBaseDelay = 10 * time.Millisecond
+
+// dbtx is what the repositories query through: the connection pool, or the
+// transaction of a unit of work when the Transactor bound them to one.
+type dbtx interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Transactor runs units of work in SERIALIZABLE transactions, on repositories
+// bound to the transaction.
+type Transactor struct {
+	db *sqlx.DB
+}
+
+func NewTransactor(db *sqlx.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTx runs fn in a transaction, committed when fn returns nil and rolled
+// back otherwise. A transaction aborted by a serialization failure or a
+// deadlock is retried from the start, up to maxTxAttempts times in all.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(repos services.Repositories) error) error {
+	return retrySerializationFailures(ctx, func() error {
+		tx, err := t.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+		if err := fn(repositoriesFor(tx)); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+func repositoriesFor(tx *sqlx.Tx) services.Repositories {
+	return services.Repositories{
+		Events:       &EventRepository{db: tx},
+		Sports:       &SportRepository{db: tx},
+		Teams:        &TeamRepository{db: tx},
+		Venues:       &VenueRepository{db: tx},
+		EventChanges: &EventChangeRepository{db: tx},
+		Reschedules:  &EventRescheduleRepository{db: tx},
+		Series:       &SeriesRepository{db: tx},
+	}
+}
+
+func retrySerializationFailures(ctx context.Context, run func() error) error {
+	delay := txRetryBaseDelay
+	for attempt := 1; ; attempt++ {
+		err := run()
+		if err == nil || attempt == maxTxAttempts || !isSerializationFailure(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay/2 + rand.N(delay)):
+		}
+		delay *= 2
+	}
+}
+
+// isSerializationFailure reports whether err is PostgreSQL giving up on a
+// transaction in favour of a concurrent one, which a retry may get past.
+func isSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == "40001" || pgErr.Code == "40P01")
+}
+
+// inTx runs fn in a transaction of db. When db is already the transaction of
+// a unit of work, fn joins it and the Transactor commits or rolls back.
+func inTx(ctx context.Context, db dbtx, fn func(tx *sqlx.Tx) error) error {
+	if tx, ok := db.(*sqlx.Tx); ok {
+		return fn(tx)
+	}
+	tx, err := db.(*sqlx.DB).BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
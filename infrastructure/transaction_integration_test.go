@@ -0,0 +1,119 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+func TestTransactor_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := SetupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	InitTestSchema(t, db)
+	defer CleanupTestDB(t, db)
+
+	transactor := NewTransactor(db)
+	sportRepo := NewSportRepository(db)
+	ctx := context.Background()
+
+	t.Run("commits the unit of work", func(t *testing.T) {
+		var sportID, teamID int
+		err := transactor.WithinTx(ctx, func(repos services.Repositories) error {
+			var err error
+			sportID, err = repos.Sports.CreateSport(ctx, services.SportRequest{Name: "Committed Sport"})
+			if err != nil {
+				return err
+			}
+			teamID, err = repos.Teams.CreateTeam(ctx, services.TeamRequest{
+				Name: "Committed Team", City: "Vienna", SportID: sportID,
+			})
+			return err
+		})
+		require.NoError(t, err)
+
+		_, err = sportRepo.GetSportById(ctx, sportID)
+		assert.NoError(t, err)
+		_, err = NewTeamRepository(db).GetTeamByID(ctx, teamID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rolls back the writes and their audit entries", func(t *testing.T) {
+		var sportID int
+		failure := errors.New("validation failed")
+		err := transactor.WithinTx(ctx, func(repos services.Repositories) error {
+			var err error
+			sportID, err = repos.Sports.CreateSport(ctx, services.SportRequest{Name: "Rolled Back Sport"})
+			if err != nil {
+				return err
+			}
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+
+		_, err = sportRepo.GetSportById(ctx, sportID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		var entries int
+		require.NoError(t, db.GetContext(ctx, &entries,
+			"SELECT COUNT(*) FROM audit_log WHERE entity_type = $1 AND entity_id = $2",
+			services.AuditEntitySport, sportID))
+		assert.Zero(t, entries)
+	})
+
+	t.Run("concurrent check-then-write units of work serialize", func(t *testing.T) {
+		sportID, err := sportRepo.CreateSport(ctx, services.SportRequest{Name: "Contested Sport"})
+		require.NoError(t, err)
+		teamRepo := NewTeamRepository(db)
+		homeTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{Name: "Home", City: "Graz", SportID: sportID})
+		require.NoError(t, err)
+		awayTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{Name: "Away", City: "Linz", SportID: sportID})
+		require.NoError(t, err)
+
+		// Each unit of work creates an event only when the sport has none yet.
+		var wg sync.WaitGroup
+		errs := make([]error, 4)
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = transactor.WithinTx(ctx, func(repos services.Repositories) error {
+					count, err := repos.Events.CountEventsBySportID(ctx, sportID)
+					if err != nil || count > 0 {
+						return err
+					}
+					kickoff := time.Now().Add(time.Duration(24*(i+1)) * time.Hour)
+					_, err = repos.Events.CreateEvent(ctx, services.CreateEventParams{
+						EventDatetime: kickoff,
+						EndDatetime:   kickoff.Add(2 * time.Hour),
+						SportID:       sportID,
+						HomeTeamID:    homeTeamID,
+						AwayTeamID:    awayTeamID,
+					})
+					return err
+				})
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		count, err := NewEventRepository(db).CountEventsBySportID(ctx, sportID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
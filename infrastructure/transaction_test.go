@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrySerializationFailures(t *testing.T) {
+	serializationFailure := fmt.Errorf("failed to delete sport: %w", &pgconn.PgError{Code: "40001"})
+	deadlock := &pgconn.PgError{Code: "40P01"}
+	uniqueViolation := &pgconn.PgError{Code: "23505"}
+
+	tests := []struct {
+		name          string
+		errs          []error
+		expectedRuns  int
+		expectedError error
+	}{
+		{name: "success", errs: []error{nil}, expectedRuns: 1},
+		{name: "retried after a serialization failure", errs: []error{serializationFailure, nil}, expectedRuns: 2},
+		{name: "retried after a deadlock", errs: []error{deadlock, deadlock, nil}, expectedRuns: 3},
+		{name: "other errors not retried", errs: []error{uniqueViolation}, expectedRuns: 1,
+			expectedError: uniqueViolation},
+		{name: "gives up after the last attempt",
+			errs:         []error{serializationFailure, serializationFailure, serializationFailure, serializationFailure, serializationFailure},
+			expectedRuns: maxTxAttempts, expectedError: serializationFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := 0
+			err := retrySerializationFailures(context.Background(), func() error {
+				runs++
+				return tt.errs[runs-1]
+			})
+
+			assert.Equal(t, tt.expectedRuns, runs)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("stops retrying when the context ends", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		runs := 0
+		err := retrySerializationFailures(ctx, func() error {
+			runs++
+			return serializationFailure
+		})
+
+		assert.Equal(t, 1, runs)
+		assert.ErrorIs(t, err, serializationFailure)
+	})
+}
@@ -2,13 +2,16 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/vsennikov/sports-event-calendar/services"
 )
 
+const venueColumns = "id, name, city, country_code, time_zone, address, postal_code, latitude, longitude, capacity, deleted_at, version, created_at, updated_at"
+
 type VenueRepository struct {
-		db *sqlx.DB
+		db dbtx
 }
 
 func NewVenueRepository(db *sqlx.DB) *VenueRepository {
@@ -16,18 +19,21 @@ func NewVenueRepository(db *sqlx.DB) *VenueRepository {
 }
 
 func (v *VenueRepository) CreateVenue(ctx context.Context, params services.VenueRequest) (int, error) {
-	query := "INSERT INTO venues (name, city, country_code) VALUES ($1, $2, $3) RETURNING id"
+	query := `
+	INSERT INTO venues (name, city, country_code, time_zone, address, postal_code, latitude, longitude, capacity)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 	var newID int
 
-	if err := v.db.QueryRowContext(ctx, query, params.Name, params.City,
-		 params.CountryCode).Scan(&newID); err != nil {
-			return 0, err
-	}
-	return newID, nil
+	return auditedWrite(ctx, v.db, services.AuditEntityVenue, services.AuditActionCreate, 0, func(tx *sqlx.Tx) (int, error) {
+		err := tx.QueryRowContext(ctx, query, params.Name, params.City,
+			params.CountryCode, params.TimeZone, params.Address, params.PostalCode,
+			params.Latitude, params.Longitude, params.Capacity).Scan(&newID)
+		return newID, err
+	})
 }
 
 func (v *VenueRepository) GetVenueById(ctx context.Context, id int) (*services.Venue, error) {
-	query := "SELECT id, name, city, country_code FROM venues WHERE id = $1"
+	query := "SELECT " + venueColumns + " FROM venues WHERE id = $1 AND " + notDeletedSQL(ctx, "venues")
 	var dbModel venueDBModel
 
 	if err := v.db.GetContext(ctx, &dbModel, query, id); err != nil {
@@ -38,7 +44,7 @@ func (v *VenueRepository) GetVenueById(ctx context.Context, id int) (*services.V
 }
 
 func (v *VenueRepository) ListVenues(ctx context.Context) ([]services.Venue, error) {
-	query := "SELECT id, name, city, country_code FROM venues ORDER BY name ASC"
+	query := "SELECT " + venueColumns + " FROM venues WHERE " + notDeletedSQL(ctx, "venues") + " ORDER BY name ASC"
 	var dbModel []venueDBModel
 	
 	if err := v.db.SelectContext(ctx, &dbModel, query); err != nil {
@@ -52,15 +58,108 @@ func (v *VenueRepository) ListVenues(ctx context.Context) ([]services.Venue, err
 }
 
 func (v *VenueRepository) UpdateVenue(ctx context.Context, venue services.Venue) error {
-	query := "UPDATE venues SET name = $1, city = $2, country_code = $3 WHERE id = $4"
+	query := `
+	UPDATE venues SET
+		name = $1, city = $2, country_code = $3, time_zone = $4,
+		address = $5, postal_code = $6, latitude = $7, longitude = $8, capacity = $9
+	WHERE id = $10` + versionCondition("$11")
 
-	_, err := v.db.ExecContext(ctx, query, venue.Name, venue.City, venue.CountryCode, venue.ID)
+	_, err := auditedWrite(ctx, v.db, services.AuditEntityVenue, services.AuditActionUpdate, venue.ID, func(tx *sqlx.Tx) (int, error) {
+		return venue.ID, execVersioned(ctx, tx, services.AuditEntityVenue, "venues", venue.ID, venue.Version, query,
+			venue.Name, venue.City, venue.CountryCode, venue.TimeZone, venue.Address, venue.PostalCode,
+			venue.Latitude, venue.Longitude, venue.Capacity, venue.ID, venue.Version)
+	})
 	return err
 }
 
+// DeleteVenue soft-deletes a venue; the purge job removes it for good.
 func (v *VenueRepository) DeleteVenue(ctx context.Context, id int) error {
-	query := "DELETE FROM venues WHERE id = $1"
+	query := "UPDATE venues SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+
+	_, err := auditedWrite(ctx, v.db, services.AuditEntityVenue, services.AuditActionDelete, id, func(tx *sqlx.Tx) (int, error) {
+		_, err := tx.ExecContext(ctx, query, id)
+		return id, err
+	})
+	return err
+}
 
-	_, err := v.db.ExecContext(ctx, query, id)
+func (v *VenueRepository) RestoreVenue(ctx context.Context, id int) error {
+	_, err := auditedWrite(ctx, v.db, services.AuditEntityVenue, services.AuditActionRestore, id, func(tx *sqlx.Tx) (int, error) {
+		return id, restoreRow(ctx, tx, "venues", id)
+	})
 	return err
 }
+
+// MergeVenues re-points the events, series and reschedule history of the
+// duplicate venue to the survivor, deletes the duplicate and saves the
+// survivor. An event moved onto a booking of the survivor violates the venue
+// exclusion constraint and fails with ErrVenueConflict.
+func (v *VenueRepository) MergeVenues(ctx context.Context, survivor services.Venue, duplicateID int) error {
+	repoint := []string{
+		`UPDATE events SET _venue_id = $1 WHERE _venue_id = $2`,
+		`UPDATE event_series SET _venue_id = $1 WHERE _venue_id = $2`,
+		`UPDATE event_reschedules SET _previous_venue_id = $1 WHERE _previous_venue_id = $2`,
+		`UPDATE event_reschedules SET _new_venue_id = $1 WHERE _new_venue_id = $2`,
+	}
+
+	return inTx(ctx, v.db, func(tx *sqlx.Tx) error {
+		survivorBefore, err := snapshotEntity(ctx, tx, services.AuditEntityVenue, survivor.ID)
+		if err != nil {
+			return err
+		}
+		duplicateBefore, err := snapshotEntity(ctx, tx, services.AuditEntityVenue, duplicateID)
+		if err != nil {
+			return err
+		}
+		eventsBefore, err := snapshotEvents(ctx, tx, "_venue_id = $1", duplicateID)
+		if err != nil {
+			return err
+		}
+		for _, query := range repoint {
+			if _, err := tx.ExecContext(ctx, query, survivor.ID, duplicateID); err != nil {
+				return translateEventWriteError(err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM venues WHERE id = $1`, duplicateID); err != nil {
+			return err
+		}
+		query := `
+		UPDATE venues SET
+			address = $1, postal_code = $2, latitude = $3, longitude = $4, capacity = $5
+		WHERE id = $6`
+		if _, err := tx.ExecContext(ctx, query, survivor.Address, survivor.PostalCode,
+			survivor.Latitude, survivor.Longitude, survivor.Capacity, survivor.ID); err != nil {
+			return err
+		}
+		return recordMergeAudit(ctx, tx, services.AuditEntityVenue, survivor.ID, duplicateID,
+			survivorBefore, duplicateBefore, eventsBefore)
+	})
+}
+
+// ListVenuesNearby returns the venues within radiusKm of point with their
+// distance, nearest first. Venues without coordinates are never returned.
+func (v *VenueRepository) ListVenuesNearby(ctx context.Context, point services.GeoPoint,
+	radiusKm float64) ([]services.Venue, error) {
+	query := fmt.Sprintf(`
+	SELECT * FROM (
+		SELECT %s, %s AS distance_km
+		FROM venues
+		WHERE %s AND %s
+	) nearby
+	WHERE distance_km <= $3::float8
+	ORDER BY distance_km ASC, name ASC`,
+		venueColumns,
+		haversineKmSQL("latitude", "longitude", "$1", "$2"),
+		latitudeBandSQL("latitude", "$1", "$3"),
+		notDeletedSQL(ctx, "venues"))
+	var dbModel []venueDBModel
+
+	if err := v.db.SelectContext(ctx, &dbModel, query, point.Latitude, point.Longitude, radiusKm); err != nil {
+		return nil, err
+	}
+	venues := make([]services.Venue, 0, len(dbModel))
+	for _, dbVenue := range dbModel {
+		venues = append(venues, toServiceVenue(dbVenue))
+	}
+	return venues, nil
+}
@@ -2,7 +2,9 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,6 +45,7 @@ func TestVenueRepository_Integration(t *testing.T) {
 			Name:        "Madison Square Garden",
 			City:        "New York",
 			CountryCode: "US",
+			TimeZone:    "America/New_York",
 		}
 
 		id, err := repo.CreateVenue(ctx, params)
@@ -54,6 +57,7 @@ func TestVenueRepository_Integration(t *testing.T) {
 		assert.Equal(t, "Madison Square Garden", venue.Name)
 		assert.Equal(t, "New York", venue.City)
 		assert.Equal(t, "US", venue.CountryCode)
+		assert.Equal(t, "America/New_York", venue.TimeZone)
 	})
 
 	t.Run("ListVenues", func(t *testing.T) {
@@ -98,6 +102,102 @@ func TestVenueRepository_Integration(t *testing.T) {
 		assert.Equal(t, "CA", updatedVenue.CountryCode)
 	})
 
+	t.Run("ListVenuesNearby", func(t *testing.T) {
+		lat, lon := 48.2188, 16.3906
+		address, postalCode := "Meiereistrasse 7", "1020"
+		nearID, err := repo.CreateVenue(ctx, services.VenueRequest{
+			Name:        "Ernst Happel Stadion",
+			City:        "Vienna",
+			CountryCode: "AT",
+			Address:     &address,
+			PostalCode:  &postalCode,
+			Latitude:    &lat,
+			Longitude:   &lon,
+		})
+		require.NoError(t, err)
+		farLat, farLon := 47.0707, 15.4395
+		_, err = repo.CreateVenue(ctx, services.VenueRequest{
+			Name:        "Merkur Arena",
+			City:        "Graz",
+			CountryCode: "AT",
+			Latitude:    &farLat,
+			Longitude:   &farLon,
+		})
+		require.NoError(t, err)
+
+		venue, err := repo.GetVenueById(ctx, nearID)
+		require.NoError(t, err)
+		require.NotNil(t, venue.Address)
+		assert.Equal(t, "Meiereistrasse 7", *venue.Address)
+		require.NotNil(t, venue.Latitude)
+		assert.Equal(t, lat, *venue.Latitude)
+
+		venues, err := repo.ListVenuesNearby(ctx, services.GeoPoint{Latitude: 48.2082, Longitude: 16.3738}, 25)
+		require.NoError(t, err)
+		require.Len(t, venues, 1)
+		assert.Equal(t, nearID, venues[0].ID)
+		require.NotNil(t, venues[0].DistanceKm)
+		assert.InDelta(t, 1.7, *venues[0].DistanceKm, 0.1)
+	})
+
+	t.Run("MergeVenues", func(t *testing.T) {
+		sportID, err := NewSportRepository(db).CreateSport(ctx, services.SportRequest{Name: "Merge Sport"})
+		require.NoError(t, err)
+		teamRepo := NewTeamRepository(db)
+		homeTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{Name: "Home Team", City: "Munich", SportID: sportID})
+		require.NoError(t, err)
+		awayTeamID, err := teamRepo.CreateTeam(ctx, services.TeamRequest{Name: "Away Team", City: "Berlin", SportID: sportID})
+		require.NoError(t, err)
+		eventRepo := NewEventRepository(db)
+		createEvent := func(venueID int, kickoff time.Time) int {
+			id, err := eventRepo.CreateEvent(ctx, services.CreateEventParams{
+				EventDatetime: kickoff,
+				EndDatetime:   kickoff.Add(2 * time.Hour),
+				SportID:       sportID,
+				VenueID:       &venueID,
+				HomeTeamID:    homeTeamID,
+				AwayTeamID:    awayTeamID,
+			})
+			require.NoError(t, err)
+			return id
+		}
+		survivorID, err := repo.CreateVenue(ctx, services.VenueRequest{Name: "Allianz Arena", City: "Munich", CountryCode: "DE"})
+		require.NoError(t, err)
+		duplicateID, err := repo.CreateVenue(ctx, services.VenueRequest{Name: "Allianz-Arena", City: "München", CountryCode: "DE"})
+		require.NoError(t, err)
+		kickoff := time.Date(2026, 4, 1, 18, 0, 0, 0, time.UTC)
+		createEvent(survivorID, kickoff)
+		eventID := createEvent(duplicateID, kickoff.Add(24*time.Hour))
+
+		t.Run("overlapping bookings", func(t *testing.T) {
+			clashID, err := repo.CreateVenue(ctx, services.VenueRequest{Name: "Allianz Arena", City: "Munich", CountryCode: "DE"})
+			require.NoError(t, err)
+			createEvent(clashID, kickoff.Add(time.Hour))
+
+			survivor, err := repo.GetVenueById(ctx, survivorID)
+			require.NoError(t, err)
+			err = repo.MergeVenues(ctx, *survivor, clashID)
+			assert.True(t, errors.Is(err, services.ErrVenueConflict))
+			_, err = repo.GetVenueById(ctx, clashID)
+			assert.NoError(t, err)
+		})
+
+		survivor, err := repo.GetVenueById(ctx, survivorID)
+		require.NoError(t, err)
+		capacity := 75000
+		survivor.Capacity = &capacity
+		require.NoError(t, repo.MergeVenues(ctx, *survivor, duplicateID))
+
+		_, err = repo.GetVenueById(ctx, duplicateID)
+		assert.Error(t, err)
+		event, err := eventRepo.GetEventByID(ctx, eventID)
+		require.NoError(t, err)
+		assert.Equal(t, survivorID, event.Venue.ID)
+		merged, err := repo.GetVenueById(ctx, survivorID)
+		require.NoError(t, err)
+		assert.Equal(t, capacity, *merged.Capacity)
+	})
+
 	t.Run("DeleteVenue", func(t *testing.T) {
 		params := services.VenueRequest{
 			Name:        "United Center",
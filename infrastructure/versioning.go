@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/vsennikov/sports-event-calendar/services"
+)
+
+// versionCondition is appended to the WHERE clause of an update to make it
+// apply only to the row version given as its parameter, unless that is 0.
+func versionCondition(param string) string {
+	return " AND (" + param + "::int = 0 OR version = " + param + "::int)"
+}
+
+// execVersioned runs an update of the row id of table made conditional with
+// versionCondition. When a version was given and the update matched nothing,
+// it fails with a VersionMismatchError carrying the row's current version,
+// or with sql.ErrNoRows when the row is gone.
+func execVersioned(ctx context.Context, db sqlx.ExtContext, entity, table string, id, version int,
+	query string, args ...interface{}) error {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil || version == 0 {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated > 0 {
+		return err
+	}
+	var current int
+	if err := sqlx.GetContext(ctx, db, &current, "SELECT version FROM "+table+" WHERE id = $1", id); err != nil {
+		return err
+	}
+	return &services.VersionMismatchError{Entity: entity, ID: id, CurrentVersion: current}
+}
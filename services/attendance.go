@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+func validateCapacity(capacity int) error {
+	if capacity <= 0 {
+		return fmt.Errorf("validation error: capacity must be a positive number")
+	}
+	return nil
+}
+
+// validateAttendance checks a recorded attendance against the capacity of
+// the event's venue, unless allowOverCapacity is set.
+func validateAttendance(attendance int, venue Venue, allowOverCapacity bool) error {
+	if attendance < 0 {
+		return fmt.Errorf("validation error: attendance must not be negative")
+	}
+	if venue.Capacity != nil && attendance > *venue.Capacity && !allowOverCapacity {
+		return fmt.Errorf("validation error: attendance %d exceeds the capacity %d of venue %d; set allow_over_capacity to record it anyway",
+			attendance, *venue.Capacity, venue.ID)
+	}
+	return nil
+}
+
+// attendanceStats validates the date range and loads the aggregates for the
+// selected events.
+func attendanceStats(ctx context.Context, repo EventRepositoryInterface,
+	params AttendanceStatsParams) (*AttendanceStats, error) {
+	if params.DateFrom != nil && params.DateTo != nil && !params.DateTo.After(*params.DateFrom) {
+		return nil, fmt.Errorf("validation error: date_to must not be before date_from")
+	}
+	stats, err := repo.GetAttendanceStats(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate attendance: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+const (
+	// SystemActor is the actor of changes made outside a request, such as
+	// by background jobs.
+	SystemActor       = "system"
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+var auditEntityTypes = []string{AuditEntityEvent, AuditEntitySeries, AuditEntitySport, AuditEntityTeam, AuditEntityVenue}
+
+type actorKey struct{}
+
+// WithActor returns a context whose writes are recorded in the audit log as
+// made by actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor of the writes made under ctx,
+// SystemActor when none was set.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
+
+type AuditRepositoryInterface interface {
+	// ListAuditEntries returns the matching audit entries, newest first.
+	ListAuditEntries(ctx context.Context, params ListAuditEntriesParams) ([]AuditEntry, error)
+}
+
+type AuditServiceInterface interface {
+	ListEntries(ctx context.Context, req AuditRequest) ([]AuditEntry, error)
+	GetEventHistory(ctx context.Context, eventID int) ([]AuditEntry, error)
+}
+
+type AuditService struct {
+	auditRepository AuditRepositoryInterface
+	eventRepository EventRepositoryInterface
+}
+
+func NewAuditService(a AuditRepositoryInterface, e EventRepositoryInterface) *AuditService {
+	return &AuditService{auditRepository: a, eventRepository: e}
+}
+
+// ListEntries returns the audit log, newest first, optionally of one entity
+// type or one entity.
+func (s *AuditService) ListEntries(ctx context.Context, req AuditRequest) ([]AuditEntry, error) {
+	if req.EntityType != nil && !slices.Contains(auditEntityTypes, *req.EntityType) {
+		return nil, fmt.Errorf("validation error: entity must be one of event, series, sport, team or venue")
+	}
+	if req.EntityID != nil && req.EntityType == nil {
+		return nil, fmt.Errorf("validation error: id requires entity")
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+	entries, err := s.auditRepository.ListAuditEntries(ctx, ListAuditEntriesParams{
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return entries, nil
+}
+
+// GetEventHistory returns every recorded change of an event, deleted or
+// not, newest first.
+func (s *AuditService) GetEventHistory(ctx context.Context, eventID int) ([]AuditEntry, error) {
+	if _, err := s.eventRepository.GetEventByID(WithDeleted(ctx), eventID); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	entityType := AuditEntityEvent
+	entries, err := s.auditRepository.ListAuditEntries(ctx, ListAuditEntriesParams{
+		EntityType: &entityType,
+		EntityID:   &eventID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event history: %w", err)
+	}
+	return entries, nil
+}
+
+// DiffSnapshots compares two JSON objects of an entity's columns and returns
+// the changed columns with their old and new values. A nil snapshot stands
+// for a row that does not exist; its side of the diff is nil as well.
+func DiffSnapshots(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	if before == nil || after == nil {
+		return before, after
+	}
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for column, value := range after {
+		if !reflect.DeepEqual(before[column], value) {
+			changedBefore[column] = before[column]
+			changedAfter[column] = value
+		}
+	}
+	for column, value := range before {
+		if _, ok := after[column]; !ok {
+			changedBefore[column] = value
+			changedAfter[column] = nil
+		}
+	}
+	return changedBefore, changedAfter
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAuditRepository is a mock for AuditRepositoryInterface
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) ListAuditEntries(ctx context.Context, params ListAuditEntriesParams) ([]AuditEntry, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]AuditEntry), args.Error(1)
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	t.Run("keeps only changed columns", func(t *testing.T) {
+		before := map[string]interface{}{"id": 1.0, "name": "Footbal", "min_rest_minutes": nil}
+		after := map[string]interface{}{"id": 1.0, "name": "Football", "min_rest_minutes": 720.0}
+
+		changedBefore, changedAfter := DiffSnapshots(before, after)
+
+		assert.Equal(t, map[string]interface{}{"name": "Footbal", "min_rest_minutes": nil}, changedBefore)
+		assert.Equal(t, map[string]interface{}{"name": "Football", "min_rest_minutes": 720.0}, changedAfter)
+	})
+
+	t.Run("compares nested values", func(t *testing.T) {
+		before := map[string]interface{}{"aliases": []interface{}{"Spurs"}}
+		after := map[string]interface{}{"aliases": []interface{}{"Spurs"}}
+
+		changedBefore, changedAfter := DiffSnapshots(before, after)
+
+		assert.Empty(t, changedBefore)
+		assert.Empty(t, changedAfter)
+	})
+
+	t.Run("create and hard delete keep the whole row", func(t *testing.T) {
+		row := map[string]interface{}{"id": 1.0, "name": "Football"}
+
+		changedBefore, changedAfter := DiffSnapshots(nil, row)
+		assert.Nil(t, changedBefore)
+		assert.Equal(t, row, changedAfter)
+
+		changedBefore, changedAfter = DiffSnapshots(row, nil)
+		assert.Equal(t, row, changedBefore)
+		assert.Nil(t, changedAfter)
+	})
+}
+
+func TestAuditService_ListEntries(t *testing.T) {
+	event := AuditEntityEvent
+	unknown := "league"
+	id := 7
+
+	tests := []struct {
+		name          string
+		req           AuditRequest
+		expectedLimit int
+		expectedError string
+	}{
+		{name: "defaults the limit", req: AuditRequest{EntityType: &event, EntityID: &id}, expectedLimit: defaultAuditLimit},
+		{name: "caps the limit", req: AuditRequest{Limit: 5000}, expectedLimit: maxAuditLimit},
+		{name: "keeps a valid limit", req: AuditRequest{EntityType: &event, Limit: 20}, expectedLimit: 20},
+		{name: "unknown entity", req: AuditRequest{EntityType: &unknown}, expectedError: "validation error: entity must be"},
+		{name: "id without entity", req: AuditRequest{EntityID: &id}, expectedError: "validation error: id requires entity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditRepo := new(MockAuditRepository)
+			service := NewAuditService(auditRepo, new(MockEventRepository))
+			ctx := context.Background()
+			entries := []AuditEntry{{ID: 1, EntityType: AuditEntityEvent, EntityID: id, Action: AuditActionCreate}}
+
+			if tt.expectedError == "" {
+				auditRepo.On("ListAuditEntries", ctx, ListAuditEntriesParams{
+					EntityType: tt.req.EntityType,
+					EntityID:   tt.req.EntityID,
+					Limit:      tt.expectedLimit,
+				}).Return(entries, nil)
+			}
+
+			result, err := service.ListEntries(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				auditRepo.AssertNotCalled(t, "ListAuditEntries", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entries, result)
+			auditRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuditService_GetEventHistory(t *testing.T) {
+	t.Run("lists every entry of a deleted event", func(t *testing.T) {
+		auditRepo := new(MockAuditRepository)
+		eventRepo := new(MockEventRepository)
+		service := NewAuditService(auditRepo, eventRepo)
+		ctx := context.Background()
+		entityType := AuditEntityEvent
+		eventID := 3
+		entries := []AuditEntry{
+			{ID: 2, EntityType: AuditEntityEvent, EntityID: eventID, Action: AuditActionDelete},
+			{ID: 1, EntityType: AuditEntityEvent, EntityID: eventID, Action: AuditActionCreate},
+		}
+
+		eventRepo.On("GetEventByID", mock.MatchedBy(IncludeDeleted), eventID).Return(&Event{ID: eventID}, nil)
+		auditRepo.On("ListAuditEntries", ctx, ListAuditEntriesParams{
+			EntityType: &entityType,
+			EntityID:   &eventID,
+		}).Return(entries, nil)
+
+		result, err := service.GetEventHistory(ctx, eventID)
+
+		require.NoError(t, err)
+		assert.Equal(t, entries, result)
+		eventRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		auditRepo := new(MockAuditRepository)
+		eventRepo := new(MockEventRepository)
+		service := NewAuditService(auditRepo, eventRepo)
+
+		eventRepo.On("GetEventByID", mock.Anything, 99).Return(nil, sql.ErrNoRows)
+
+		_, err := service.GetEventHistory(context.Background(), 99)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+		auditRepo.AssertNotCalled(t, "ListAuditEntries", mock.Anything, mock.Anything)
+	})
+}
+
+func TestActorFromContext(t *testing.T) {
+	assert.Equal(t, SystemActor, ActorFromContext(context.Background()))
+	assert.Equal(t, "alice", ActorFromContext(WithActor(context.Background(), "alice")))
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type BroadcastRepositoryInterface interface {
+	CreateBroadcast(ctx context.Context, broadcast Broadcast) (int, error)
+	GetBroadcast(ctx context.Context, eventID, id int) (*Broadcast, error)
+	ListBroadcasts(ctx context.Context, eventID int, countryCode *string) ([]Broadcast, error)
+	UpdateBroadcast(ctx context.Context, broadcast Broadcast) error
+	DeleteBroadcast(ctx context.Context, eventID, id int) error
+}
+
+type BroadcastServiceInterface interface {
+	CreateBroadcast(ctx context.Context, eventID int, req CreateBroadcastRequest) (int, error)
+	GetBroadcast(ctx context.Context, eventID, id int) (*Broadcast, error)
+	ListBroadcasts(ctx context.Context, eventID int, countryCode string) ([]Broadcast, error)
+	UpdateBroadcast(ctx context.Context, eventID, id int, req UpdateBroadcastRequest) error
+	DeleteBroadcast(ctx context.Context, eventID, id int) error
+}
+
+type BroadcastService struct {
+	broadcastRepository BroadcastRepositoryInterface
+	eventRepository     EventRepositoryInterface
+}
+
+func NewBroadcastService(b BroadcastRepositoryInterface, e EventRepositoryInterface) *BroadcastService {
+	return &BroadcastService{broadcastRepository: b, eventRepository: e}
+}
+
+// CreateBroadcast adds a broadcast to an event. Without a start_datetime the
+// broadcast starts at kickoff.
+func (s *BroadcastService) CreateBroadcast(ctx context.Context, eventID int, req CreateBroadcastRequest) (int, error) {
+	event, err := s.eventRepository.GetEventByID(ctx, eventID)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	broadcast := Broadcast{
+		EventID:       eventID,
+		Broadcaster:   strings.TrimSpace(req.Broadcaster),
+		CountryCode:   strings.ToUpper(req.CountryCode),
+		StartDatetime: event.EventDatetime,
+	}
+	if req.Channel != nil {
+		broadcast.Channel = emptyToNil(strings.TrimSpace(*req.Channel))
+	}
+	if req.URL != nil {
+		broadcast.URL = emptyToNil(strings.TrimSpace(*req.URL))
+	}
+	if req.StartDatetime != nil {
+		broadcast.StartDatetime = *req.StartDatetime
+	}
+	if err := validateBroadcast(broadcast); err != nil {
+		return 0, err
+	}
+	newID, err := s.broadcastRepository.CreateBroadcast(ctx, broadcast)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create broadcast: %w", err)
+	}
+	return newID, nil
+}
+
+func (s *BroadcastService) GetBroadcast(ctx context.Context, eventID, id int) (*Broadcast, error) {
+	broadcast, err := s.broadcastRepository.GetBroadcast(ctx, eventID, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return broadcast, nil
+}
+
+// ListBroadcasts returns the broadcasts of an event ordered by start time,
+// limited to one country when countryCode is set.
+func (s *BroadcastService) ListBroadcasts(ctx context.Context, eventID int, countryCode string) ([]Broadcast, error) {
+	if _, err := s.eventRepository.GetEventByID(ctx, eventID); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	var country *string
+	if countryCode != "" {
+		normalized, err := normalizeCountryCode(countryCode)
+		if err != nil {
+			return nil, err
+		}
+		country = &normalized
+	}
+	broadcasts, err := s.broadcastRepository.ListBroadcasts(ctx, eventID, country)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list broadcasts: %w", err)
+	}
+	return broadcasts, nil
+}
+
+func (s *BroadcastService) UpdateBroadcast(ctx context.Context, eventID, id int, req UpdateBroadcastRequest) error {
+	broadcast, err := s.broadcastRepository.GetBroadcast(ctx, eventID, id)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if err := checkVersion(ctx, "broadcast", id, broadcast.Version); err != nil {
+		return err
+	}
+	if req.Broadcaster != nil {
+		broadcast.Broadcaster = strings.TrimSpace(*req.Broadcaster)
+	}
+	if req.Channel != nil {
+		broadcast.Channel = emptyToNil(strings.TrimSpace(*req.Channel))
+	}
+	if req.CountryCode != nil {
+		broadcast.CountryCode = strings.ToUpper(*req.CountryCode)
+	}
+	if req.URL != nil {
+		broadcast.URL = emptyToNil(strings.TrimSpace(*req.URL))
+	}
+	if req.StartDatetime != nil {
+		broadcast.StartDatetime = *req.StartDatetime
+	}
+	if err := validateBroadcast(*broadcast); err != nil {
+		return err
+	}
+	if err := s.broadcastRepository.UpdateBroadcast(ctx, *broadcast); err != nil {
+		return fmt.Errorf("failed to update broadcast: %w", err)
+	}
+	return nil
+}
+
+func (s *BroadcastService) DeleteBroadcast(ctx context.Context, eventID, id int) error {
+	broadcast, err := s.broadcastRepository.GetBroadcast(ctx, eventID, id)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if err := checkVersion(ctx, "broadcast", id, broadcast.Version); err != nil {
+		return err
+	}
+	if err := s.broadcastRepository.DeleteBroadcast(ctx, eventID, id); err != nil {
+		return fmt.Errorf("failed to delete broadcast: %w", err)
+	}
+	return nil
+}
+
+func validateBroadcast(broadcast Broadcast) error {
+	if len(broadcast.Broadcaster) < 2 {
+		return fmt.Errorf("validation error: broadcaster must be at least 2 characters long")
+	}
+	if _, err := normalizeCountryCode(broadcast.CountryCode); err != nil {
+		return err
+	}
+	if broadcast.URL != nil {
+		parsed, err := url.ParseRequestURI(*broadcast.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("validation error: url must be an absolute http or https URL")
+		}
+	}
+	return nil
+}
+
+// normalizeCountryCode upper-cases a two-letter ISO 3166 country code.
+func normalizeCountryCode(code string) (string, error) {
+	code = strings.ToUpper(code)
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return "", fmt.Errorf("validation error: country code must be two letters")
+	}
+	return code, nil
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockBroadcastRepository is a mock for BroadcastRepositoryInterface
+type MockBroadcastRepository struct {
+	mock.Mock
+}
+
+func (m *MockBroadcastRepository) CreateBroadcast(ctx context.Context, broadcast Broadcast) (int, error) {
+	args := m.Called(ctx, broadcast)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) GetBroadcast(ctx context.Context, eventID, id int) (*Broadcast, error) {
+	args := m.Called(ctx, eventID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Broadcast), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) ListBroadcasts(ctx context.Context, eventID int, countryCode *string) ([]Broadcast, error) {
+	args := m.Called(ctx, eventID, countryCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Broadcast), args.Error(1)
+}
+
+func (m *MockBroadcastRepository) UpdateBroadcast(ctx context.Context, broadcast Broadcast) error {
+	args := m.Called(ctx, broadcast)
+	return args.Error(0)
+}
+
+func (m *MockBroadcastRepository) DeleteBroadcast(ctx context.Context, eventID, id int) error {
+	args := m.Called(ctx, eventID, id)
+	return args.Error(0)
+}
+
+func TestBroadcastService_CreateBroadcast(t *testing.T) {
+	kickoff := time.Date(2025, 10, 1, 19, 0, 0, 0, time.UTC)
+	preShow := kickoff.Add(-30 * time.Minute)
+	event := &Event{ID: 1, EventDatetime: kickoff}
+
+	tests := []struct {
+		name          string
+		request       CreateBroadcastRequest
+		mockEvent     *Event
+		mockEventErr  error
+		expected      *Broadcast
+		expectedError string
+	}{
+		{
+			name:      "defaults start to kickoff and normalizes country",
+			request:   CreateBroadcastRequest{Broadcaster: " ORF ", Channel: stringPtr("ORF 1"), CountryCode: "at"},
+			mockEvent: event,
+			expected: &Broadcast{EventID: 1, Broadcaster: "ORF", Channel: stringPtr("ORF 1"), CountryCode: "AT",
+				StartDatetime: kickoff},
+		},
+		{
+			name: "explicit start and url",
+			request: CreateBroadcastRequest{Broadcaster: "Sky Sport", CountryCode: "AT",
+				URL: stringPtr("https://sport.sky.at"), StartDatetime: &preShow},
+			mockEvent: event,
+			expected: &Broadcast{EventID: 1, Broadcaster: "Sky Sport", CountryCode: "AT",
+				URL: stringPtr("https://sport.sky.at"), StartDatetime: preShow},
+		},
+		{
+			name:          "event not found",
+			request:       CreateBroadcastRequest{Broadcaster: "ORF", CountryCode: "AT"},
+			mockEventErr:  sql.ErrNoRows,
+			expectedError: "database error",
+		},
+		{
+			name:          "country code wrong length",
+			request:       CreateBroadcastRequest{Broadcaster: "ORF", CountryCode: "AUT"},
+			mockEvent:     event,
+			expectedError: "country code",
+		},
+		{
+			name:          "broadcaster too short",
+			request:       CreateBroadcastRequest{Broadcaster: " X ", CountryCode: "AT"},
+			mockEvent:     event,
+			expectedError: "broadcaster",
+		},
+		{
+			name:          "relative url",
+			request:       CreateBroadcastRequest{Broadcaster: "ORF", CountryCode: "AT", URL: stringPtr("tvthek.orf.at")},
+			mockEvent:     event,
+			expectedError: "url",
+		},
+		{
+			name:          "non-http url",
+			request:       CreateBroadcastRequest{Broadcaster: "ORF", CountryCode: "AT", URL: stringPtr("ftp://orf.at")},
+			mockEvent:     event,
+			expectedError: "url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockBroadcastRepository)
+			mockEventRepo := new(MockEventRepository)
+			service := NewBroadcastService(mockRepo, mockEventRepo)
+
+			mockEventRepo.On("GetEventByID", mock.Anything, 1).Return(tt.mockEvent, tt.mockEventErr)
+			if tt.expected != nil {
+				mockRepo.On("CreateBroadcast", mock.Anything, *tt.expected).Return(7, nil)
+			}
+
+			id, err := service.CreateBroadcast(context.Background(), 1, tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				mockRepo.AssertNotCalled(t, "CreateBroadcast", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 7, id)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBroadcastService_ListBroadcasts(t *testing.T) {
+	broadcasts := []Broadcast{{ID: 1, EventID: 1, Broadcaster: "ORF", CountryCode: "AT"}}
+
+	t.Run("filters by normalized country", func(t *testing.T) {
+		mockRepo := new(MockBroadcastRepository)
+		mockEventRepo := new(MockEventRepository)
+		service := NewBroadcastService(mockRepo, mockEventRepo)
+
+		mockEventRepo.On("GetEventByID", mock.Anything, 1).Return(&Event{ID: 1}, nil)
+		mockRepo.On("ListBroadcasts", mock.Anything, 1, stringPtr("AT")).Return(broadcasts, nil)
+
+		result, err := service.ListBroadcasts(context.Background(), 1, "at")
+
+		require.NoError(t, err)
+		assert.Equal(t, broadcasts, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("without country", func(t *testing.T) {
+		mockRepo := new(MockBroadcastRepository)
+		mockEventRepo := new(MockEventRepository)
+		service := NewBroadcastService(mockRepo, mockEventRepo)
+
+		mockEventRepo.On("GetEventByID", mock.Anything, 1).Return(&Event{ID: 1}, nil)
+		mockRepo.On("ListBroadcasts", mock.Anything, 1, (*string)(nil)).Return(broadcasts, nil)
+
+		_, err := service.ListBroadcasts(context.Background(), 1, "")
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid country", func(t *testing.T) {
+		mockRepo := new(MockBroadcastRepository)
+		mockEventRepo := new(MockEventRepository)
+		service := NewBroadcastService(mockRepo, mockEventRepo)
+
+		mockEventRepo.On("GetEventByID", mock.Anything, 1).Return(&Event{ID: 1}, nil)
+
+		_, err := service.ListBroadcasts(context.Background(), 1, "A1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+	})
+
+	t.Run("event not found", func(t *testing.T) {
+		mockRepo := new(MockBroadcastRepository)
+		mockEventRepo := new(MockEventRepository)
+		service := NewBroadcastService(mockRepo, mockEventRepo)
+
+		mockEventRepo.On("GetEventByID", mock.Anything, 99).Return(nil, sql.ErrNoRows)
+
+		_, err := service.ListBroadcasts(context.Background(), 99, "")
+
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestBroadcastService_UpdateBroadcast(t *testing.T) {
+	existing := func() *Broadcast {
+		return &Broadcast{ID: 3, EventID: 1, Broadcaster: "ORF", Channel: stringPtr("ORF 1"), CountryCode: "AT",
+			StartDatetime: time.Date(2025, 10, 1, 19, 0, 0, 0, time.UTC)}
+	}
+
+	t.Run("updates fields and clears channel", func(t *testing.T) {
+		mockRepo := new(MockBroadcastRepository)
+		service := NewBroadcastService(mockRepo, new(MockEventRepository))
+
+		mockRepo.On("GetBroadcast", mock.Anything, 1, 3).Return(existing(), nil)
+		mockRepo.On("UpdateBroadcast", mock.Anything, mock.MatchedBy(func(b Broadcast) bool {
+			return b.Broadcaster == "ServusTV" && b.Channel == nil && b.CountryCode == "DE"
+		})).Return(nil)
+
+		err := service.UpdateBroadcast(context.Background(), 1, 3,
+			UpdateBroadcastRequest{Broadcaster: stringPtr("ServusTV"), Channel: stringPtr(""), CountryCode: stringPtr("de")})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("broadcast of another event", func(t *testing.T) {
+		mockRepo := new(MockBroadcastRepository)
+		service := NewBroadcastService(mockRepo, new(MockEventRepository))
+
+		mockRepo.On("GetBroadcast", mock.Anything, 2, 3).Return(nil, sql.ErrNoRows)
+
+		err := service.UpdateBroadcast(context.Background(), 2, 3, UpdateBroadcastRequest{Broadcaster: stringPtr("ORF")})
+
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		mockRepo.AssertNotCalled(t, "UpdateBroadcast", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		mockRepo := new(MockBroadcastRepository)
+		service := NewBroadcastService(mockRepo, new(MockEventRepository))
+
+		mockRepo.On("GetBroadcast", mock.Anything, 1, 3).Return(existing(), nil)
+
+		err := service.UpdateBroadcast(context.Background(), 1, 3, UpdateBroadcastRequest{URL: stringPtr("not a url")})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+		mockRepo.AssertNotCalled(t, "UpdateBroadcast", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBroadcastService_DeleteBroadcast(t *testing.T) {
+	t.Run("successful deletion", func(t *testing.T) {
+		mockRepo := new(MockBroadcastRepository)
+		service := NewBroadcastService(mockRepo, new(MockEventRepository))
+
+		mockRepo.On("GetBroadcast", mock.Anything, 1, 3).Return(&Broadcast{ID: 3, EventID: 1}, nil)
+		mockRepo.On("DeleteBroadcast", mock.Anything, 1, 3).Return(nil)
+
+		require.NoError(t, service.DeleteBroadcast(context.Background(), 1, 3))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockBroadcastRepository)
+		service := NewBroadcastService(mockRepo, new(MockEventRepository))
+
+		mockRepo.On("GetBroadcast", mock.Anything, 1, 3).Return(&Broadcast{ID: 3, EventID: 1}, nil)
+		mockRepo.On("DeleteBroadcast", mock.Anything, 1, 3).Return(errors.New("connection lost"))
+
+		err := service.DeleteBroadcast(context.Background(), 1, 3)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete broadcast")
+	})
+}
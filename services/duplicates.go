@@ -0,0 +1,245 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultDuplicateSimilarity is the similarity from which two teams or venues
+// are reported as probable duplicates.
+const DefaultDuplicateSimilarity = 0.7
+
+// nameWeight is the share of the name in the similarity of two teams or
+// venues; the city makes up the rest.
+const nameWeight = 2.0 / 3.0
+
+// normalizeName folds a name for comparison: accents removed, lower case,
+// punctuation turned into single spaces.
+func normalizeName(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'ß':
+			b.WriteString("ss")
+			space = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+			space = false
+		case !space && b.Len() > 0:
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// trigrams returns the set of three-letter sequences of the words of a
+// normalized name, padded like PostgreSQL's pg_trgm does.
+func trigrams(name string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(name) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// nameSimilarity is the share of trigrams two names have in common, from 0
+// for nothing in common to 1 for names equal after normalization.
+func nameSimilarity(a, b string) float64 {
+	a, b = normalizeName(a), normalizeName(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+	ta, tb := trigrams(a), trigrams(b)
+	common := 0
+	for t := range ta {
+		if tb[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+// bestNameSimilarity compares every name of one entity with every name of
+// the other and returns the best match.
+func bestNameSimilarity(a, b []string) float64 {
+	best := 0.0
+	for _, nameA := range a {
+		for _, nameB := range b {
+			best = math.Max(best, nameSimilarity(nameA, nameB))
+		}
+	}
+	return best
+}
+
+func duplicateSimilarity(nameSim, citySim float64) float64 {
+	return roundSimilarity(nameWeight*nameSim + (1-nameWeight)*citySim)
+}
+
+func roundSimilarity(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func validateMinSimilarity(minSimilarity float64) (float64, error) {
+	if minSimilarity == 0 {
+		return DefaultDuplicateSimilarity, nil
+	}
+	if minSimilarity < 0 || minSimilarity > 1 {
+		return 0, fmt.Errorf("validation error: min_similarity must be between 0 and 1")
+	}
+	return minSimilarity, nil
+}
+
+// teamNames lists every name a team is known by except its code, which is
+// too short to compare.
+func teamNames(team Team) []string {
+	names := append([]string{team.Name}, team.Aliases...)
+	if team.ShortName != nil {
+		names = append(names, *team.ShortName)
+	}
+	return names
+}
+
+// findTeamDuplicates pairs the teams of a sport whose names and cities are
+// at least minSimilarity alike, most similar first.
+func findTeamDuplicates(teams []Team, minSimilarity float64) []TeamDuplicateCandidate {
+	candidates := []TeamDuplicateCandidate{}
+	for i, a := range teams {
+		for _, b := range teams[i+1:] {
+			if a.SportID != b.SportID {
+				continue
+			}
+			nameSim := bestNameSimilarity(teamNames(a), teamNames(b))
+			citySim := nameSimilarity(a.City, b.City)
+			similarity := duplicateSimilarity(nameSim, citySim)
+			if similarity < minSimilarity {
+				continue
+			}
+			first, second := a, b
+			if second.ID < first.ID {
+				first, second = second, first
+			}
+			candidates = append(candidates, TeamDuplicateCandidate{
+				First:          first,
+				Second:         second,
+				NameSimilarity: roundSimilarity(nameSim),
+				CitySimilarity: roundSimilarity(citySim),
+				Similarity:     similarity,
+			})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Similarity != candidates[j].Similarity {
+			return candidates[i].Similarity > candidates[j].Similarity
+		}
+		return candidates[i].First.ID < candidates[j].First.ID
+	})
+	return candidates
+}
+
+// findVenueDuplicates pairs the venues of a country whose names and cities
+// are at least minSimilarity alike, most similar first.
+func findVenueDuplicates(venues []Venue, minSimilarity float64) []VenueDuplicateCandidate {
+	candidates := []VenueDuplicateCandidate{}
+	for i, a := range venues {
+		for _, b := range venues[i+1:] {
+			if !strings.EqualFold(a.CountryCode, b.CountryCode) {
+				continue
+			}
+			nameSim := nameSimilarity(a.Name, b.Name)
+			citySim := nameSimilarity(a.City, b.City)
+			similarity := duplicateSimilarity(nameSim, citySim)
+			if similarity < minSimilarity {
+				continue
+			}
+			first, second := a, b
+			if second.ID < first.ID {
+				first, second = second, first
+			}
+			candidates = append(candidates, VenueDuplicateCandidate{
+				First:          first,
+				Second:         second,
+				NameSimilarity: roundSimilarity(nameSim),
+				CitySimilarity: roundSimilarity(citySim),
+				Similarity:     similarity,
+			})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Similarity != candidates[j].Similarity {
+			return candidates[i].Similarity > candidates[j].Similarity
+		}
+		return candidates[i].First.ID < candidates[j].First.ID
+	})
+	return candidates
+}
+
+// mergeNames appends the names to the list unless they are already in it or
+// in skip, comparing case-insensitively.
+func mergeNames(list []string, skip []string, names ...string) []string {
+	seen := make(map[string]bool)
+	for _, name := range append(append([]string{}, skip...), list...) {
+		seen[strings.ToLower(name)] = true
+	}
+	for _, name := range names {
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		list = append(list, name)
+	}
+	return list
+}
+
+// ErrTeamsPlayEachOther is returned when merging two teams would leave an
+// event or series with the same team on both sides.
+var ErrTeamsPlayEachOther = errors.New("teams play each other")
+
+// TeamClashError names the events and series, deleted ones included, in
+// which the two teams of a merge play each other.
+type TeamClashError struct {
+	SurvivorID  int
+	DuplicateID int
+	EventIDs    []int
+	SeriesIDs   []int
+}
+
+func (e *TeamClashError) Error() string {
+	var rows []string
+	if len(e.EventIDs) > 0 {
+		rows = append(rows, "events "+joinIDs(e.EventIDs))
+	}
+	if len(e.SeriesIDs) > 0 {
+		rows = append(rows, "series "+joinIDs(e.SeriesIDs))
+	}
+	return fmt.Sprintf("%s: teams %d and %d meet in %s",
+		ErrTeamsPlayEachOther, e.SurvivorID, e.DuplicateID, strings.Join(rows, " and "))
+}
+
+func (e *TeamClashError) Unwrap() error {
+	return ErrTeamsPlayEachOther
+}
+
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
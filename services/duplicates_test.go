@@ -0,0 +1,94 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "case and accents", input: "Eisbären Berlin", expected: "eisbaren berlin"},
+		{name: "punctuation", input: "  Allianz-Arena (München) ", expected: "allianz arena munchen"},
+		{name: "sharp s", input: "Weißwasser", expected: "weisswasser"},
+		{name: "empty", input: " - ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeName(tt.input))
+		})
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, nameSimilarity("Allianz Arena", "allianz-arena"))
+	assert.Equal(t, 1.0, nameSimilarity("Zürich", "Zurich"))
+	assert.Equal(t, 0.0, nameSimilarity("Berlin", ""))
+	assert.Greater(t, nameSimilarity("Red Bull Salzburg", "FC Red Bull Salzburg"), 0.7)
+	assert.Less(t, nameSimilarity("Red Bull Salzburg", "Vienna Capitals"), 0.2)
+}
+
+func TestFindTeamDuplicates(t *testing.T) {
+	teams := []Team{
+		{ID: 1, Name: "Red Bull Salzburg", City: "Salzburg", SportID: 1},
+		{ID: 2, Name: "Vienna Capitals", City: "Vienna", SportID: 2},
+		{ID: 3, Name: "FC Red Bull Salzburg", City: "Salzburg", SportID: 1},
+		{ID: 4, Name: "Manchester City", City: "Manchester", SportID: 1, ShortName: stringPtr("Man City")},
+		{ID: 5, Name: "Man City", City: "Manchester", SportID: 1},
+		{ID: 6, Name: "Red Bull Salzburg", City: "Salzburg", SportID: 2},
+	}
+
+	candidates := findTeamDuplicates(teams, DefaultDuplicateSimilarity)
+
+	require.Len(t, candidates, 2)
+	assert.Equal(t, 4, candidates[0].First.ID)
+	assert.Equal(t, 5, candidates[0].Second.ID)
+	assert.Equal(t, 1.0, candidates[0].NameSimilarity)
+	assert.Equal(t, 1.0, candidates[0].Similarity)
+	assert.Equal(t, 1, candidates[1].First.ID)
+	assert.Equal(t, 3, candidates[1].Second.ID)
+	assert.Equal(t, 1.0, candidates[1].CitySimilarity)
+}
+
+func TestFindVenueDuplicates(t *testing.T) {
+	venues := []Venue{
+		{ID: 1, Name: "Red Bull Arena", City: "Salzburg", CountryCode: "AT"},
+		{ID: 2, Name: "Red-Bull-Arena", City: "Wals-Siezenheim", CountryCode: "AT"},
+		{ID: 3, Name: "Red Bull Arena", City: "Leipzig", CountryCode: "DE"},
+		{ID: 4, Name: "Red Bull Arena", City: "Salzburg", CountryCode: "at"},
+	}
+
+	t.Run("default similarity", func(t *testing.T) {
+		candidates := findVenueDuplicates(venues, DefaultDuplicateSimilarity)
+
+		require.Len(t, candidates, 1)
+		assert.Equal(t, 1, candidates[0].First.ID)
+		assert.Equal(t, 4, candidates[0].Second.ID)
+		assert.Equal(t, 1.0, candidates[0].Similarity)
+	})
+
+	t.Run("lower similarity", func(t *testing.T) {
+		candidates := findVenueDuplicates(venues, 0.6)
+
+		require.Len(t, candidates, 3)
+		assert.Equal(t, 1.0, candidates[0].Similarity)
+		assert.Equal(t, []int{1, 2}, []int{candidates[1].First.ID, candidates[1].Second.ID})
+		assert.Equal(t, []int{2, 4}, []int{candidates[2].First.ID, candidates[2].Second.ID})
+	})
+}
+
+func TestValidateMinSimilarity(t *testing.T) {
+	minSimilarity, err := validateMinSimilarity(0)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDuplicateSimilarity, minSimilarity)
+
+	_, err = validateMinSimilarity(1.5)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation error")
+}
@@ -17,14 +17,27 @@ type EventRepositoryInterface interface {
 	CountEventsByTeamID(ctx context.Context, teamID int) (int, error)
 	UpdateEvent(ctx context.Context, event Event) error
 	DeleteEvent(ctx context.Context, id int) error
+	// RestoreEvent undeletes a soft-deleted event, failing with sql.ErrNoRows
+	// when there is no such deleted event.
+	RestoreEvent(ctx context.Context, id int) error
+	ListVenueConflicts(ctx context.Context, venueID int, start, end time.Time, excludeEventID int) ([]Event, error)
+	ListTeamEvents(ctx context.Context, teamID int, from, to *time.Time) ([]Event, error)
+	GetAttendanceStats(ctx context.Context, params AttendanceStatsParams) (*AttendanceStats, error)
+}
+
+type EventRescheduleRepositoryInterface interface {
+	RecordReschedule(ctx context.Context, reschedule EventReschedule) error
+	ListReschedules(ctx context.Context, eventID int) ([]EventReschedule, error)
 }
 
 type EventServiceInterface interface {
 	GetEventByID(ctx context.Context, id int) (*Event, error)
-	CreateEvent(ctx context.Context, req EventCreateRequest) (int, error)
+	CreateEvent(ctx context.Context, req EventCreateRequest) (int, []string, error)
 	ListEvents(ctx context.Context, req ListEventsRequest) ([]Event, *Pagination, error)
-	UpdateEvent(ctx context.Context, id int, req UpdateEventRequest) error
+	UpdateEvent(ctx context.Context, id int, req UpdateEventRequest) ([]string, error)
 	DeleteEvent(ctx context.Context, id int) error
+	RestoreEvent(ctx context.Context, id int) (*Event, error)
+	ListReschedules(ctx context.Context, id int) ([]EventReschedule, error)
 }
 
 type EventService struct {
@@ -34,17 +47,39 @@ type EventService struct {
 	sportRepository SportRepositoryInterface
 	teamRepository TeamRepositoryInterface
 	venueRepository VenueRepositoryInterface
+	eventChangeRepository EventChangeRepositoryInterface
+	rescheduleRepository EventRescheduleRepositoryInterface
+	transactor Transactor
 }
 
 func NewEventService(r EventRepositoryInterface, dP, dL int,
-	 s SportRepositoryInterface, t TeamRepositoryInterface, v VenueRepositoryInterface) *EventService {
+	 s SportRepositoryInterface, t TeamRepositoryInterface, v VenueRepositoryInterface,
+	 c EventChangeRepositoryInterface, rr EventRescheduleRepositoryInterface, tx Transactor) *EventService {
 	return &EventService{
 		eventRepository: r,
 		defaultPage: dP,
 		defaultLimit: dL,
 		sportRepository: s,
 		teamRepository: t,
-		venueRepository: v,}
+		venueRepository: v,
+		eventChangeRepository: c,
+		rescheduleRepository: rr,
+		transactor: tx,}
+}
+
+// withRepositories returns a copy of the service working on repos. Its
+// public methods join the unit of work of repos, so that another service can
+// run them as part of its own.
+func (s *EventService) withRepositories(repos Repositories) *EventService {
+	bound := *s
+	bound.transactor = joinedTx{repos: repos}
+	bound.eventRepository = repos.Events
+	bound.sportRepository = repos.Sports
+	bound.teamRepository = repos.Teams
+	bound.venueRepository = repos.Venues
+	bound.eventChangeRepository = repos.EventChanges
+	bound.rescheduleRepository = repos.Reschedules
+	return &bound
 }
 
 func (s *EventService) GetEventByID(ctx context.Context, id int) (*Event, error) {
@@ -55,16 +90,109 @@ func (s *EventService) GetEventByID(ctx context.Context, id int) (*Event, error)
 	return event, nil
 }
 
-func (s *EventService) CreateEvent(ctx context.Context, req EventCreateRequest) (int, error) {
-	if req.EventDatetime.Before(time.Now()) {
-		return 0, fmt.Errorf("cannot create an event in the past")
+// CreateEvent stores a new event and returns its ID together with warnings
+// about rest period conflicts the sport tolerates. The conflict checks and
+// the insert run in one transaction.
+func (s *EventService) CreateEvent(ctx context.Context, req EventCreateRequest) (int, []string, error) {
+	var newID int
+	var warnings []string
+	err := s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		var err error
+		newID, warnings, err = s.withRepositories(repos).createEvent(ctx, req)
+		return err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return newID, warnings, nil
+}
+
+func (s *EventService) createEvent(ctx context.Context, req EventCreateRequest) (int, []string, error) {
+	eventDatetime, err := s.resolveEventDatetime(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	if eventDatetime.Before(time.Now()) {
+		return 0, nil, fmt.Errorf("cannot create an event in the past")
+	}
+	sport, err := s.sportRepository.GetSportById(ctx, req.SportID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("validation error: sport with id %d not found", req.SportID)
+	}
+	endDatetime, err := resolveEndDatetime(eventDatetime, req.EndDatetime, req.DurationMinutes, sport.DefaultDurationMinutes)
+	if err != nil {
+		return 0, nil, err
+	}
+	if req.VenueID != nil && !req.AllowVenueOverlap {
+		if err := s.checkVenueConflicts(ctx, *req.VenueID, eventDatetime, endDatetime, 0); err != nil {
+			return 0, nil, err
+		}
+	}
+	warnings, err := s.checkTeamConflicts(ctx, Event{
+		EventDatetime: eventDatetime,
+		EndDatetime:   endDatetime,
+		Sport:         *sport,
+		HomeTeam:      Team{ID: req.HomeTeamID},
+		AwayTeam:      Team{ID: req.AwayTeamID},
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	params := CreateEventParams{
+		EventDatetime:     eventDatetime,
+		EndDatetime:       endDatetime,
+		Description:       req.Description,
+		SportID:           req.SportID,
+		VenueID:           req.VenueID,
+		HomeTeamID:        req.HomeTeamID,
+		AwayTeamID:        req.AwayTeamID,
+		SeriesID:          req.SeriesID,
+		AllowVenueOverlap: req.AllowVenueOverlap,
 	}
-	params := CreateEventParams(req)
 	newID, err := s.eventRepository.CreateEvent(ctx, params)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create event: %w", err)
+		return 0, nil, fmt.Errorf("failed to create event: %w", err)
+	}
+	createdEvent, err := s.eventRepository.GetEventByID(ctx, newID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("database error while fetching created event: %w", err)
+	}
+	if err := s.recordEventChange(ctx, *createdEvent, EventChangeCreated); err != nil {
+		return 0, nil, err
 	}
-	return newID, nil
+	return newID, warnings, nil
+}
+
+// resolveEventDatetime returns the kickoff instant of a new event, either given
+// directly or as a wall-clock local_datetime in time_zone (falling back to
+// the venue's time zone).
+func (s *EventService) resolveEventDatetime(ctx context.Context, req EventCreateRequest) (time.Time, error) {
+	if req.LocalDatetime != nil && !req.EventDatetime.IsZero() {
+		return time.Time{}, fmt.Errorf("validation error: use either event_datetime or local_datetime, not both")
+	}
+	if req.LocalDatetime == nil {
+		if req.EventDatetime.IsZero() {
+			return time.Time{}, fmt.Errorf("validation error: event_datetime or local_datetime is required")
+		}
+		return req.EventDatetime, nil
+	}
+	var zoneName string
+	if req.TimeZone != nil {
+		zoneName = *req.TimeZone
+	} else if req.VenueID != nil {
+		venue, err := s.venueRepository.GetVenueById(ctx, *req.VenueID)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("validation error: venue with id %d not found", *req.VenueID)
+		}
+		zoneName = venue.TimeZone
+	} else {
+		return time.Time{}, fmt.Errorf("validation error: time_zone is required for local_datetime without a venue")
+	}
+	loc, err := LoadTimeZone(zoneName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("validation error: %w", err)
+	}
+	return parseLocalDatetime(*req.LocalDatetime, loc)
 }
 
 func (s *EventService) ListEvents(ctx context.Context, req ListEventsRequest) ([]Event, *Pagination, error) {
@@ -74,10 +202,32 @@ func (s *EventService) ListEvents(ctx context.Context, req ListEventsRequest) ([
 	if req.Limit <= 0 {
 		req.Limit = s.defaultLimit
 	}
+	if req.Near != nil {
+		if err := validateGeoPoint(*req.Near); err != nil {
+			return nil, nil, err
+		}
+		radiusKm, err := resolveRadiusKm(req.RadiusKm)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.RadiusKm = radiusKm
+	}
+	if req.BroadcastCountry != nil {
+		country, err := normalizeCountryCode(*req.BroadcastCountry)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.BroadcastCountry = &country
+	}
 	offset := (req.Page - 1) * req.Limit
 	repoParams := ListEventsParams{
 		SportID:  req.SportID,
+		SeriesID: req.SeriesID,
 		DateFrom: req.DateFrom,
+		DateTo:   req.DateTo,
+		Near:     req.Near,
+		RadiusKm: req.RadiusKm,
+		BroadcastCountry: req.BroadcastCountry,
 		Limit:    req.Limit,
 		Offset:   offset,
 	}
@@ -98,6 +248,14 @@ func (s *EventService) ListEvents(ctx context.Context, req ListEventsRequest) ([
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list events: %w", err)
 	}
+	if req.Near != nil {
+		for i := range events {
+			if point := venuePoint(events[i].Venue); point != nil {
+				distance := distanceKm(*req.Near, *point)
+				events[i].Venue.DistanceKm = &distance
+			}
+		}
+	}
 	totalPages := int(math.Ceil(float64(totalItems) / float64(req.Limit)))
 	pagination := &Pagination{
 		TotalItems:  totalItems,
@@ -108,13 +266,52 @@ func (s *EventService) ListEvents(ctx context.Context, req ListEventsRequest) ([
 	return events, pagination, nil
 }
 
-func (s *EventService) UpdateEvent(ctx context.Context, id int, req UpdateEventRequest) error {
+// UpdateEvent applies a partial update and returns warnings about rest period
+// conflicts the sport tolerates. The event is read, validated and written,
+// with its reschedule and feed entries, in one transaction.
+func (s *EventService) UpdateEvent(ctx context.Context, id int, req UpdateEventRequest) ([]string, error) {
+	var warnings []string
+	err := s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		var err error
+		warnings, err = s.withRepositories(repos).updateEvent(ctx, id, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+func (s *EventService) updateEvent(ctx context.Context, id int, req UpdateEventRequest) ([]string, error) {
 	existingEvent, err := s.eventRepository.GetEventByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("database error: %w", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
+	if err := checkVersion(ctx, AuditEntityEvent, id, existingEvent.Version); err != nil {
+		return nil, err
+	}
+	previous := *existingEvent
+	previousDatetime := existingEvent.EventDatetime
+	previousEndDatetime := existingEvent.EndDatetime
+	previousVenueID := eventVenueID(*existingEvent)
+	previousAllowVenueOverlap := existingEvent.AllowVenueOverlap
+	previousHomeScore := existingEvent.HomeScore
+	previousAwayScore := existingEvent.AwayScore
+	previousAttendance := existingEvent.Attendance
 	if req.EventDatetime != nil {
 		existingEvent.EventDatetime = *req.EventDatetime
+		existingEvent.EndDatetime = existingEvent.EndDatetime.Add(req.EventDatetime.Sub(previousDatetime))
+	}
+	if req.EndDatetime != nil || req.DurationMinutes != nil {
+		currentMinutes := int(existingEvent.EndDatetime.Sub(existingEvent.EventDatetime).Minutes())
+		endDatetime, err := resolveEndDatetime(existingEvent.EventDatetime, req.EndDatetime, req.DurationMinutes, currentMinutes)
+		if err != nil {
+			return nil, err
+		}
+		existingEvent.EndDatetime = endDatetime
+	}
+	if req.AllowVenueOverlap != nil {
+		existingEvent.AllowVenueOverlap = *req.AllowVenueOverlap
 	}
 	if req.Description != nil {
 		existingEvent.Description = req.Description
@@ -128,46 +325,299 @@ func (s *EventService) UpdateEvent(ctx context.Context, id int, req UpdateEventR
 	if req.SportID != nil {
 		sport, err := s.sportRepository.GetSportById(ctx, *req.SportID)
 		if err != nil {
-			return fmt.Errorf("validation error: sport with id %d not found", *req.SportID)
+			return nil, fmt.Errorf("validation error: sport with id %d not found", *req.SportID)
 		}
 		existingEvent.Sport = *sport
 	}
 	if req.VenueID != nil {
 		venue, err := s.venueRepository.GetVenueById(ctx, *req.VenueID)
 		if err != nil {
-			return fmt.Errorf("validation error: venue with id %d not found", *req.VenueID)
+			return nil, fmt.Errorf("validation error: venue with id %d not found", *req.VenueID)
 		}
 		existingEvent.Venue = *venue
 	}
 	if req.HomeTeamID != nil {
 		team, err := s.teamRepository.GetTeamByID(ctx, *req.HomeTeamID)
 		if err != nil {
-			return fmt.Errorf("validation error: home team with id %d not found", *req.HomeTeamID)
+			return nil, fmt.Errorf("validation error: home team with id %d not found", *req.HomeTeamID)
 		}
 		existingEvent.HomeTeam = *team
 	}
 	if req.AwayTeamID != nil {
 				team, err := s.teamRepository.GetTeamByID(ctx, *req.AwayTeamID)
 		if err != nil {
-			return fmt.Errorf("validation error: away team with id %d not found", *req.AwayTeamID)
+			return nil, fmt.Errorf("validation error: away team with id %d not found", *req.AwayTeamID)
 		}
 		existingEvent.AwayTeam = *team
 	}
+	if req.Attendance != nil {
+		existingEvent.Attendance = req.Attendance
+	}
+	newVenueID := eventVenueID(*existingEvent)
+	attendanceChanged := !intPtrEqual(previousAttendance, existingEvent.Attendance) ||
+		!intPtrEqual(previousVenueID, newVenueID)
+	if existingEvent.Attendance != nil && attendanceChanged {
+		if err := validateAttendance(*existingEvent.Attendance, existingEvent.Venue, req.AllowOverCapacity); err != nil {
+			return nil, err
+		}
+	}
+	bookingChanged := !existingEvent.EventDatetime.Equal(previousDatetime) ||
+		!existingEvent.EndDatetime.Equal(previousEndDatetime) ||
+		!intPtrEqual(previousVenueID, newVenueID) ||
+		existingEvent.AllowVenueOverlap != previousAllowVenueOverlap
+	if bookingChanged && newVenueID != nil && !existingEvent.AllowVenueOverlap {
+		err := s.checkVenueConflicts(ctx, *newVenueID, existingEvent.EventDatetime, existingEvent.EndDatetime, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var warnings []string
+	scheduleChanged := !existingEvent.EventDatetime.Equal(previous.EventDatetime) ||
+		!existingEvent.EndDatetime.Equal(previous.EndDatetime) ||
+		existingEvent.Sport.ID != previous.Sport.ID ||
+		existingEvent.HomeTeam.ID != previous.HomeTeam.ID ||
+		existingEvent.AwayTeam.ID != previous.AwayTeam.ID
+	if scheduleChanged {
+		warnings, err = s.checkTeamConflicts(ctx, *existingEvent)
+		if err != nil {
+			return nil, err
+		}
+	}
 	err = s.eventRepository.UpdateEvent(ctx, *existingEvent)
 	if err != nil {
-		return fmt.Errorf("failed to update event: %w", err)
+		return nil, fmt.Errorf("failed to update event: %w", err)
 	}
-	return nil
+	if !existingEvent.EventDatetime.Equal(previousDatetime) || !intPtrEqual(previousVenueID, newVenueID) {
+		reschedule := EventReschedule{
+			EventID:          id,
+			PreviousDatetime: previousDatetime,
+			NewDatetime:      existingEvent.EventDatetime,
+			PreviousVenueID:  previousVenueID,
+			NewVenueID:       newVenueID,
+			Reason:           req.Reason,
+		}
+		if err := s.rescheduleRepository.RecordReschedule(ctx, reschedule); err != nil {
+			return nil, fmt.Errorf("failed to record reschedule: %w", err)
+		}
+	}
+	if !existingEvent.EventDatetime.Equal(previousDatetime) {
+		if err := s.recordEventChange(ctx, *existingEvent, EventChangeRescheduled); err != nil {
+			return nil, err
+		}
+	}
+	if existingEvent.HomeScore != nil && existingEvent.AwayScore != nil &&
+		(!intPtrEqual(previousHomeScore, existingEvent.HomeScore) ||
+			!intPtrEqual(previousAwayScore, existingEvent.AwayScore)) {
+		if err := s.recordEventChange(ctx, *existingEvent, EventChangeScored); err != nil {
+			return nil, err
+		}
+	}
+	return warnings, nil
 }
 
 func (s *EventService) DeleteEvent(ctx context.Context, id int) error {
-	_, err := s.eventRepository.GetEventByID(ctx, id)
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		return s.withRepositories(repos).deleteEvent(ctx, id)
+	})
+}
+
+func (s *EventService) deleteEvent(ctx context.Context, id int) error {
+	existingEvent, err := s.eventRepository.GetEventByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("database error: %w", err)
 	}
+	if err := checkVersion(ctx, AuditEntityEvent, id, existingEvent.Version); err != nil {
+		return err
+	}
 	err = s.eventRepository.DeleteEvent(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
+	if err := s.recordEventChange(ctx, *existingEvent, EventChangeCancelled); err != nil {
+		return err
+	}
 	return nil
 }
+
+// RestoreEvent undeletes an event, provided its sport, teams and venue are
+// not deleted themselves and its venue slot is still free.
+func (s *EventService) RestoreEvent(ctx context.Context, id int) (*Event, error) {
+	var restoredEvent *Event
+	err := s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		var err error
+		restoredEvent, err = s.withRepositories(repos).restoreEvent(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restoredEvent, nil
+}
+
+func (s *EventService) restoreEvent(ctx context.Context, id int) (*Event, error) {
+	event, err := s.eventRepository.GetEventByID(WithDeleted(ctx), id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if event.DeletedAt == nil {
+		return nil, notDeletedError("event", id)
+	}
+	if _, err := s.sportRepository.GetSportById(ctx, event.Sport.ID); err != nil {
+		return nil, fmt.Errorf("validation error: cannot restore event: sport %d is deleted", event.Sport.ID)
+	}
+	for _, teamID := range []int{event.HomeTeam.ID, event.AwayTeam.ID} {
+		if _, err := s.teamRepository.GetTeamByID(ctx, teamID); err != nil {
+			return nil, fmt.Errorf("validation error: cannot restore event: team %d is deleted", teamID)
+		}
+	}
+	if event.Venue.ID != 0 {
+		if _, err := s.venueRepository.GetVenueById(ctx, event.Venue.ID); err != nil {
+			return nil, fmt.Errorf("validation error: cannot restore event: venue %d is deleted", event.Venue.ID)
+		}
+		if !event.AllowVenueOverlap {
+			if err := s.checkVenueConflicts(ctx, event.Venue.ID, event.EventDatetime, event.EndDatetime, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := s.eventRepository.RestoreEvent(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to restore event: %w", err)
+	}
+	restoredEvent, err := s.eventRepository.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error while fetching restored event: %w", err)
+	}
+	if err := s.recordEventChange(ctx, *restoredEvent, EventChangeCreated); err != nil {
+		return nil, err
+	}
+	return restoredEvent, nil
+}
+
+// resolveEndDatetime returns the end of an event starting at start, given
+// either explicitly or as a duration, falling back to defaultMinutes.
+func resolveEndDatetime(start time.Time, end *time.Time, durationMinutes *int, defaultMinutes int) (time.Time, error) {
+	if end != nil && durationMinutes != nil {
+		return time.Time{}, fmt.Errorf("validation error: use either end_datetime or duration_minutes, not both")
+	}
+	if end != nil {
+		if err := validateEventWindow(start, *end); err != nil {
+			return time.Time{}, err
+		}
+		return *end, nil
+	}
+	minutes := defaultMinutes
+	if durationMinutes != nil {
+		if err := validateDurationMinutes(*durationMinutes); err != nil {
+			return time.Time{}, err
+		}
+		minutes = *durationMinutes
+	}
+	return start.Add(time.Duration(minutes) * time.Minute), nil
+}
+
+// checkVenueConflicts fails with a VenueConflictError when the venue already
+// has an event overlapping start..end, other than the excluded event.
+func (s *EventService) checkVenueConflicts(ctx context.Context, venueID int,
+	start, end time.Time, excludeEventID int) error {
+	conflicts, err := s.eventRepository.ListVenueConflicts(ctx, venueID, start, end, excludeEventID)
+	if err != nil {
+		return fmt.Errorf("failed to check venue availability: %w", err)
+	}
+	if len(conflicts) > 0 {
+		return &VenueConflictError{VenueID: venueID, ConflictingEvent: conflicts[0]}
+	}
+	return nil
+}
+
+// checkTeamConflicts looks for events either team plays within the rest
+// window of the sport around the given event. Depending on the sport's
+// policy the conflicts are returned as warnings or as a TeamConflictError.
+func (s *EventService) checkTeamConflicts(ctx context.Context, event Event) ([]string, error) {
+	// A conflict takes the longer rest of both sports, so events of another
+	// sport may conflict further out than this sport's rest: the window
+	// searched covers the longest rest of any sport.
+	sports, err := s.sportRepository.ListSports(WithDeleted(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team availability: %w", err)
+	}
+	longestRestMinutes := event.Sport.MinRestMinutes
+	for _, sport := range sports {
+		longestRestMinutes = max(longestRestMinutes, sport.MinRestMinutes)
+	}
+	rest := time.Duration(longestRestMinutes) * time.Minute
+	from := event.EventDatetime.Add(-rest)
+	to := event.EndDatetime.Add(rest)
+	var conflicts []TeamConflict
+
+	for _, teamID := range []int{event.HomeTeam.ID, event.AwayTeam.ID} {
+		teamEvents, err := s.eventRepository.ListTeamEvents(ctx, teamID, &from, &to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team availability: %w", err)
+		}
+		for _, other := range teamEvents {
+			if other.ID == event.ID {
+				continue
+			}
+			if conflict, ok := restConflict(teamID, event, other); ok {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	if event.Sport.RestConflictPolicy != RestPolicyWarn {
+		return nil, &TeamConflictError{Conflicts: conflicts}
+	}
+	warnings := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		warnings = append(warnings, conflict.Message())
+	}
+	return warnings, nil
+}
+
+// ListReschedules returns the kickoff and venue changes of an event, oldest
+// first.
+func (s *EventService) ListReschedules(ctx context.Context, id int) ([]EventReschedule, error) {
+	if _, err := s.eventRepository.GetEventByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	reschedules, err := s.rescheduleRepository.ListReschedules(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reschedules: %w", err)
+	}
+	return reschedules, nil
+}
+
+func (s *EventService) recordEventChange(ctx context.Context, event Event, changeType string) error {
+	change := EventChange{
+		EventID:       event.ID,
+		ChangeType:    changeType,
+		EventDatetime: event.EventDatetime,
+		HomeScore:     event.HomeScore,
+		AwayScore:     event.AwayScore,
+		Sport:         event.Sport,
+		HomeTeam:      event.HomeTeam,
+		AwayTeam:      event.AwayTeam,
+	}
+	if err := s.eventChangeRepository.RecordEventChange(ctx, change); err != nil {
+		return fmt.Errorf("failed to record event change: %w", err)
+	}
+	return nil
+}
+
+func intPtrEqual(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// eventVenueID is the venue ID of an event, nil when it has no venue.
+func eventVenueID(event Event) *int {
+	if event.Venue.ID == 0 {
+		return nil
+	}
+	venueID := event.Venue.ID
+	return &venueID
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockEventRepository is a mock implementation of EventRepositoryInterface
@@ -67,13 +68,42 @@ func (m *MockEventRepository) DeleteEvent(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *MockEventRepository) RestoreEvent(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) ListVenueConflicts(ctx context.Context, venueID int, start, end time.Time, excludeEventID int) ([]Event, error) {
+	args := m.Called(ctx, venueID, start, end, excludeEventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Event), args.Error(1)
+}
+
+func (m *MockEventRepository) ListTeamEvents(ctx context.Context, teamID int, from, to *time.Time) ([]Event, error) {
+	args := m.Called(ctx, teamID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Event), args.Error(1)
+}
+
+func (m *MockEventRepository) GetAttendanceStats(ctx context.Context, params AttendanceStatsParams) (*AttendanceStats, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*AttendanceStats), args.Error(1)
+}
+
 // MockSportRepository is a mock implementation of SportRepositoryInterface
 type MockSportRepository struct {
 	mock.Mock
 }
 
-func (m *MockSportRepository) CreateSport(ctx context.Context, name string) (int, error) {
-	args := m.Called(ctx, name)
+func (m *MockSportRepository) CreateSport(ctx context.Context, params SportRequest) (int, error) {
+	args := m.Called(ctx, params)
 	return args.Int(0), args.Error(1)
 }
 
@@ -93,8 +123,8 @@ func (m *MockSportRepository) ListSports(ctx context.Context) ([]Sport, error) {
 	return args.Get(0).([]Sport), args.Error(1)
 }
 
-func (m *MockSportRepository) UpdateSport(ctx context.Context, id int, name string) error {
-	args := m.Called(ctx, id, name)
+func (m *MockSportRepository) UpdateSport(ctx context.Context, sport Sport) error {
+	args := m.Called(ctx, sport)
 	return args.Error(0)
 }
 
@@ -103,6 +133,11 @@ func (m *MockSportRepository) DeleteSport(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *MockSportRepository) RestoreSport(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 // MockTeamRepository is a mock implementation of TeamRepositoryInterface
 type MockTeamRepository struct {
 	mock.Mock
@@ -129,6 +164,19 @@ func (m *MockTeamRepository) ListTeams(ctx context.Context) ([]Team, error) {
 	return args.Get(0).([]Team), args.Error(1)
 }
 
+func (m *MockTeamRepository) CountTeamsBySportID(ctx context.Context, sportID int) (int, error) {
+	args := m.Called(ctx, sportID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTeamRepository) FindTeamsByName(ctx context.Context, name string, sportID *int) ([]Team, error) {
+	args := m.Called(ctx, name, sportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Team), args.Error(1)
+}
+
 func (m *MockTeamRepository) UpdateTeam(ctx context.Context, team Team) error {
 	args := m.Called(ctx, team)
 	return args.Error(0)
@@ -139,6 +187,16 @@ func (m *MockTeamRepository) DeleteTeam(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *MockTeamRepository) RestoreTeam(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) MergeTeams(ctx context.Context, survivor Team, duplicateID int) error {
+	args := m.Called(ctx, survivor, duplicateID)
+	return args.Error(0)
+}
+
 // MockVenueRepository is a mock implementation of VenueRepositoryInterface
 type MockVenueRepository struct {
 	mock.Mock
@@ -165,6 +223,14 @@ func (m *MockVenueRepository) ListVenues(ctx context.Context) ([]Venue, error) {
 	return args.Get(0).([]Venue), args.Error(1)
 }
 
+func (m *MockVenueRepository) ListVenuesNearby(ctx context.Context, point GeoPoint, radiusKm float64) ([]Venue, error) {
+	args := m.Called(ctx, point, radiusKm)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Venue), args.Error(1)
+}
+
 func (m *MockVenueRepository) UpdateVenue(ctx context.Context, venue Venue) error {
 	args := m.Called(ctx, venue)
 	return args.Error(0)
@@ -175,6 +241,60 @@ func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *MockVenueRepository) RestoreVenue(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) MergeVenues(ctx context.Context, survivor Venue, duplicateID int) error {
+	args := m.Called(ctx, survivor, duplicateID)
+	return args.Error(0)
+}
+
+// MockEventChangeRepository is a mock implementation of EventChangeRepositoryInterface
+type MockEventChangeRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventChangeRepository) RecordEventChange(ctx context.Context, change EventChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockEventChangeRepository) ListEventChanges(ctx context.Context, params ListEventChangesParams) ([]EventChange, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]EventChange), args.Error(1)
+}
+
+func (m *MockEventChangeRepository) ListLatestResults(ctx context.Context, params ListEventChangesParams) ([]EventChange, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]EventChange), args.Error(1)
+}
+
+// MockEventRescheduleRepository is a mock implementation of EventRescheduleRepositoryInterface
+type MockEventRescheduleRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventRescheduleRepository) RecordReschedule(ctx context.Context, reschedule EventReschedule) error {
+	args := m.Called(ctx, reschedule)
+	return args.Error(0)
+}
+
+func (m *MockEventRescheduleRepository) ListReschedules(ctx context.Context, eventID int) ([]EventReschedule, error) {
+	args := m.Called(ctx, eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]EventReschedule), args.Error(1)
+}
+
 func TestEventService_GetEventByID(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -212,8 +332,10 @@ func TestEventService_GetEventByID(t *testing.T) {
 			mockSportRepo := new(MockSportRepository)
 			mockTeamRepo := new(MockTeamRepository)
 			mockVenueRepo := new(MockVenueRepository)
+			mockChangeRepo := new(MockEventChangeRepository)
+			mockRescheduleRepo := new(MockEventRescheduleRepository)
 
-			service := NewEventService(mockRepo, 1, 10, mockSportRepo, mockTeamRepo, mockVenueRepo)
+			service := newTestEventService(mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, mockChangeRepo, mockRescheduleRepo)
 
 			mockRepo.On("GetEventByID", mock.Anything, tt.eventID).Return(tt.mockEvent, tt.mockError)
 
@@ -268,6 +390,74 @@ func TestEventService_CreateEvent(t *testing.T) {
 			expectedID:    0,
 			expectedError: true,
 		},
+		{
+			name: "missing datetime",
+			request: EventCreateRequest{
+				SportID:    1,
+				HomeTeamID: 1,
+				AwayTeamID: 2,
+			},
+			mockID:        0,
+			mockError:     nil,
+			expectedID:    0,
+			expectedError: true,
+		},
+		{
+			name: "local datetime with time zone",
+			request: EventCreateRequest{
+				LocalDatetime: stringPtr(time.Now().AddDate(1, 0, 0).Format("2006-01-02") + "T20:00:00"),
+				TimeZone:      stringPtr("Europe/Vienna"),
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockID:        3,
+			mockError:     nil,
+			expectedID:    3,
+			expectedError: false,
+		},
+		{
+			name: "both event datetime and local datetime",
+			request: EventCreateRequest{
+				EventDatetime: time.Now().Add(24 * time.Hour),
+				LocalDatetime: stringPtr("2099-12-10T20:00:00"),
+				TimeZone:      stringPtr("Europe/Vienna"),
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockID:        0,
+			mockError:     nil,
+			expectedID:    0,
+			expectedError: true,
+		},
+		{
+			name: "local datetime with invalid time zone",
+			request: EventCreateRequest{
+				LocalDatetime: stringPtr("2099-12-10T20:00:00"),
+				TimeZone:      stringPtr("Mars/Olympus"),
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockID:        0,
+			mockError:     nil,
+			expectedID:    0,
+			expectedError: true,
+		},
+		{
+			name: "local datetime without zone or venue",
+			request: EventCreateRequest{
+				LocalDatetime: stringPtr("2099-12-10T20:00:00"),
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockID:        0,
+			mockError:     nil,
+			expectedID:    0,
+			expectedError: true,
+		},
 		{
 			name: "database error",
 			request: EventCreateRequest{
@@ -289,14 +479,26 @@ func TestEventService_CreateEvent(t *testing.T) {
 			mockSportRepo := new(MockSportRepository)
 			mockTeamRepo := new(MockTeamRepository)
 			mockVenueRepo := new(MockVenueRepository)
+			mockChangeRepo := new(MockEventChangeRepository)
+			mockRescheduleRepo := new(MockEventRescheduleRepository)
 
-			service := NewEventService(mockRepo, 1, 10, mockSportRepo, mockTeamRepo, mockVenueRepo)
+			service := newTestEventService(mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, mockChangeRepo, mockRescheduleRepo)
 
+			mockSportRepo.On("GetSportById", mock.Anything, tt.request.SportID).
+				Return(&Sport{ID: tt.request.SportID, DefaultDurationMinutes: 120}, nil).Maybe()
+			mockRepo.On("ListTeamEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]Event{}, nil).Maybe()
+			mockSportRepo.On("ListSports", mock.Anything).Return([]Sport{}, nil).Maybe()
 			if !tt.expectedError || tt.name == "database error" {
 				mockRepo.On("CreateEvent", mock.Anything, mock.AnythingOfType("CreateEventParams")).Return(tt.mockID, tt.mockError)
 			}
+			if !tt.expectedError {
+				mockRepo.On("GetEventByID", mock.Anything, tt.mockID).Return(&Event{ID: tt.mockID}, nil)
+				mockChangeRepo.On("RecordEventChange", mock.Anything, mock.MatchedBy(func(c EventChange) bool {
+					return c.EventID == tt.mockID && c.ChangeType == EventChangeCreated
+				})).Return(nil)
+			}
 
-			result, err := service.CreateEvent(context.Background(), tt.request)
+			result, _, err := service.CreateEvent(context.Background(), tt.request)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -308,7 +510,189 @@ func TestEventService_CreateEvent(t *testing.T) {
 
 			if !tt.expectedError || tt.name == "database error" {
 				mockRepo.AssertExpectations(t)
+				mockChangeRepo.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestEventService_CreateEvent_LocalDatetimeUsesVenueTimeZone(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	mockSportRepo := new(MockSportRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	mockVenueRepo := new(MockVenueRepository)
+	mockChangeRepo := new(MockEventChangeRepository)
+	mockRescheduleRepo := new(MockEventRescheduleRepository)
+
+	service := newTestEventService(mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, mockChangeRepo, mockRescheduleRepo)
+
+	venueID := 4
+	expectedKickoff := time.Date(2099, 7, 1, 18, 0, 0, 0, time.UTC)
+	mockVenueRepo.On("GetVenueById", mock.Anything, venueID).Return(&Venue{ID: venueID, TimeZone: "Europe/Vienna"}, nil)
+	mockSportRepo.On("GetSportById", mock.Anything, 1).Return(&Sport{ID: 1, DefaultDurationMinutes: 120}, nil)
+	mockSportRepo.On("ListSports", mock.Anything).Return([]Sport{}, nil)
+	mockRepo.On("ListVenueConflicts", mock.Anything, venueID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), 0).
+		Return([]Event{}, nil)
+	mockRepo.On("ListTeamEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]Event{}, nil)
+	mockRepo.On("CreateEvent", mock.Anything, mock.MatchedBy(func(p CreateEventParams) bool {
+		return p.EventDatetime.Equal(expectedKickoff)
+	})).Return(1, nil)
+	mockRepo.On("GetEventByID", mock.Anything, 1).Return(&Event{ID: 1}, nil)
+	mockChangeRepo.On("RecordEventChange", mock.Anything, mock.AnythingOfType("EventChange")).Return(nil)
+
+	id, _, err := service.CreateEvent(context.Background(), EventCreateRequest{
+		LocalDatetime: stringPtr("2099-07-01T20:00"),
+		SportID:       1,
+		VenueID:       &venueID,
+		HomeTeamID:    1,
+		AwayTeamID:    2,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+	mockRepo.AssertExpectations(t)
+	mockVenueRepo.AssertExpectations(t)
+}
+
+func TestEventService_CreateEvent_VenueBooking(t *testing.T) {
+	venueID := 2
+	kickoff := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Minute)
+	clashing := Event{
+		ID:            7,
+		EventDatetime: kickoff.Add(-time.Hour),
+		EndDatetime:   kickoff.Add(time.Hour),
+		HomeTeam:      Team{ID: 3, Name: "Team C"},
+		AwayTeam:      Team{ID: 4, Name: "Team D"},
+	}
+
+	tests := []struct {
+		name          string
+		request       EventCreateRequest
+		mockSetup     func(*MockEventRepository, *MockSportRepository)
+		expectedEnd   time.Time
+		expectedError error
+	}{
+		{
+			name: "sport default duration",
+			request: EventCreateRequest{
+				EventDatetime: kickoff, SportID: 1, VenueID: &venueID, HomeTeamID: 1, AwayTeamID: 2,
+			},
+			mockSetup: func(r *MockEventRepository, s *MockSportRepository) {
+				s.On("GetSportById", mock.Anything, 1).Return(&Sport{ID: 1, DefaultDurationMinutes: 150}, nil)
+				r.On("ListVenueConflicts", mock.Anything, venueID, kickoff, kickoff.Add(150*time.Minute), 0).
+					Return([]Event{}, nil)
+			},
+			expectedEnd: kickoff.Add(150 * time.Minute),
+		},
+		{
+			name: "explicit duration",
+			request: EventCreateRequest{
+				EventDatetime: kickoff, SportID: 1, VenueID: &venueID, HomeTeamID: 1, AwayTeamID: 2,
+				DurationMinutes: intPtr(90),
+			},
+			mockSetup: func(r *MockEventRepository, s *MockSportRepository) {
+				r.On("ListVenueConflicts", mock.Anything, venueID, kickoff, kickoff.Add(90*time.Minute), 0).
+					Return([]Event{}, nil)
+			},
+			expectedEnd: kickoff.Add(90 * time.Minute),
+		},
+		{
+			name: "venue already booked",
+			request: EventCreateRequest{
+				EventDatetime: kickoff, SportID: 1, VenueID: &venueID, HomeTeamID: 1, AwayTeamID: 2,
+				DurationMinutes: intPtr(90),
+			},
+			mockSetup: func(r *MockEventRepository, s *MockSportRepository) {
+				r.On("ListVenueConflicts", mock.Anything, venueID, kickoff, kickoff.Add(90*time.Minute), 0).
+					Return([]Event{clashing}, nil)
+			},
+			expectedError: ErrVenueConflict,
+		},
+		{
+			name: "override skips the venue check",
+			request: EventCreateRequest{
+				EventDatetime: kickoff, SportID: 1, VenueID: &venueID, HomeTeamID: 1, AwayTeamID: 2,
+				DurationMinutes: intPtr(90), AllowVenueOverlap: true,
+			},
+			mockSetup:   func(r *MockEventRepository, s *MockSportRepository) {},
+			expectedEnd: kickoff.Add(90 * time.Minute),
+		},
+		{
+			name: "explicit end",
+			request: EventCreateRequest{
+				EventDatetime: kickoff, SportID: 1, VenueID: &venueID, HomeTeamID: 1, AwayTeamID: 2,
+				EndDatetime: timePtr(kickoff.Add(100 * time.Minute)),
+			},
+			mockSetup: func(r *MockEventRepository, s *MockSportRepository) {
+				r.On("ListVenueConflicts", mock.Anything, venueID, kickoff, kickoff.Add(100*time.Minute), 0).
+					Return([]Event{}, nil)
+			},
+			expectedEnd: kickoff.Add(100 * time.Minute),
+		},
+		{
+			name: "end before start",
+			request: EventCreateRequest{
+				EventDatetime: kickoff, SportID: 1, HomeTeamID: 1, AwayTeamID: 2,
+				EndDatetime: timePtr(kickoff.Add(-time.Hour)),
+			},
+			mockSetup:     func(r *MockEventRepository, s *MockSportRepository) {},
+			expectedError: errors.New("validation error: end_datetime must be after the event start"),
+		},
+		{
+			name: "end and duration together",
+			request: EventCreateRequest{
+				EventDatetime: kickoff, SportID: 1, HomeTeamID: 1, AwayTeamID: 2,
+				EndDatetime: timePtr(kickoff.Add(time.Hour)), DurationMinutes: intPtr(60),
+			},
+			mockSetup:     func(r *MockEventRepository, s *MockSportRepository) {},
+			expectedError: errors.New("validation error: use either end_datetime or duration_minutes, not both"),
+		},
+		{
+			name: "non-positive duration",
+			request: EventCreateRequest{
+				EventDatetime: kickoff, SportID: 1, HomeTeamID: 1, AwayTeamID: 2,
+				DurationMinutes: intPtr(0),
+			},
+			mockSetup:     func(r *MockEventRepository, s *MockSportRepository) {},
+			expectedError: errors.New("validation error: duration must be a positive number of minutes"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			mockSportRepo := new(MockSportRepository)
+			mockChangeRepo := new(MockEventChangeRepository)
+			service := newTestEventService(mockRepo, mockSportRepo, new(MockTeamRepository),
+				new(MockVenueRepository), mockChangeRepo, new(MockEventRescheduleRepository))
+			tt.mockSetup(mockRepo, mockSportRepo)
+			mockSportRepo.On("GetSportById", mock.Anything, 1).Return(&Sport{ID: 1, DefaultDurationMinutes: 120}, nil).Maybe()
+			mockSportRepo.On("ListSports", mock.Anything).Return([]Sport{}, nil).Maybe()
+			mockRepo.On("ListTeamEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]Event{}, nil).Maybe()
+			if tt.expectedError == nil {
+				mockRepo.On("CreateEvent", mock.Anything, mock.MatchedBy(func(p CreateEventParams) bool {
+					return p.EndDatetime.Equal(tt.expectedEnd) && p.AllowVenueOverlap == tt.request.AllowVenueOverlap
+				})).Return(5, nil)
+				mockRepo.On("GetEventByID", mock.Anything, 5).Return(&Event{ID: 5}, nil)
+				mockChangeRepo.On("RecordEventChange", mock.Anything, mock.AnythingOfType("EventChange")).Return(nil)
+			}
+
+			id, _, err := service.CreateEvent(context.Background(), tt.request)
+
+			if tt.expectedError == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, 5, id)
+			} else if errors.Is(tt.expectedError, ErrVenueConflict) {
+				var conflict *VenueConflictError
+				assert.True(t, errors.Is(err, ErrVenueConflict))
+				assert.True(t, errors.As(err, &conflict))
+				assert.Equal(t, clashing.ID, conflict.ConflictingEvent.ID)
+				assert.Contains(t, err.Error(), "event 7 (Team C vs Team D)")
+			} else {
+				assert.EqualError(t, err, tt.expectedError.Error())
 			}
+			mockRepo.AssertExpectations(t)
+			mockSportRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -384,8 +768,10 @@ func TestEventService_ListEvents(t *testing.T) {
 			mockSportRepo := new(MockSportRepository)
 			mockTeamRepo := new(MockTeamRepository)
 			mockVenueRepo := new(MockVenueRepository)
+			mockChangeRepo := new(MockEventChangeRepository)
+			mockRescheduleRepo := new(MockEventRescheduleRepository)
 
-			service := NewEventService(mockRepo, 1, 10, mockSportRepo, mockTeamRepo, mockVenueRepo)
+			service := newTestEventService(mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, mockChangeRepo, mockRescheduleRepo)
 
 			mockRepo.On("CountEvents", mock.Anything, mock.AnythingOfType("ListEventsParams")).Return(tt.mockCount, tt.mockCountError)
 
@@ -425,10 +811,96 @@ func TestEventService_ListEvents(t *testing.T) {
 	}
 }
 
+func TestEventService_ListEvents_Near(t *testing.T) {
+	vienna := GeoPoint{Latitude: 48.2082, Longitude: 16.3738}
+	newService := func(mockRepo *MockEventRepository) *EventService {
+		return newTestEventService(mockRepo, new(MockSportRepository), new(MockTeamRepository), new(MockVenueRepository),
+			new(MockEventChangeRepository), new(MockEventRescheduleRepository))
+	}
+
+	t.Run("fills venue distances", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := newService(mockRepo)
+		nearby := Event{ID: 1, Venue: Venue{ID: 1, Latitude: float64Ptr(48.2188), Longitude: float64Ptr(16.3906)}}
+		noCoordinates := Event{ID: 2, Venue: Venue{ID: 2}}
+
+		params := mock.MatchedBy(func(p ListEventsParams) bool {
+			return p.Near != nil && *p.Near == vienna && p.RadiusKm == DefaultNearbyRadiusKm
+		})
+		mockRepo.On("CountEvents", mock.Anything, params).Return(2, nil)
+		mockRepo.On("ListEvents", mock.Anything, params).Return([]Event{nearby, noCoordinates}, nil)
+
+		events, _, err := service.ListEvents(context.Background(), ListEventsRequest{Near: &vienna})
+
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		require.NotNil(t, events[0].Venue.DistanceKm)
+		assert.InDelta(t, 1.7, *events[0].Venue.DistanceKm, 0.1)
+		assert.Nil(t, events[1].Venue.DistanceKm)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid point", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := newService(mockRepo)
+
+		_, _, err := service.ListEvents(context.Background(), ListEventsRequest{Near: &GeoPoint{Latitude: -91}})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+		mockRepo.AssertNotCalled(t, "CountEvents", mock.Anything, mock.Anything)
+	})
+
+	t.Run("negative radius", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := newService(mockRepo)
+
+		_, _, err := service.ListEvents(context.Background(), ListEventsRequest{Near: &vienna, RadiusKm: -1})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "radius_km")
+	})
+}
+
+func TestEventService_ListEvents_BroadcastCountry(t *testing.T) {
+	newService := func(mockRepo *MockEventRepository) *EventService {
+		return newTestEventService(mockRepo, new(MockSportRepository), new(MockTeamRepository), new(MockVenueRepository),
+			new(MockEventChangeRepository), new(MockEventRescheduleRepository))
+	}
+
+	t.Run("normalizes country code", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := newService(mockRepo)
+
+		params := mock.MatchedBy(func(p ListEventsParams) bool {
+			return p.BroadcastCountry != nil && *p.BroadcastCountry == "AT"
+		})
+		mockRepo.On("CountEvents", mock.Anything, params).Return(0, nil)
+
+		_, _, err := service.ListEvents(context.Background(), ListEventsRequest{BroadcastCountry: stringPtr("at")})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid country code", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := newService(mockRepo)
+
+		_, _, err := service.ListEvents(context.Background(), ListEventsRequest{BroadcastCountry: stringPtr("AUT")})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+		mockRepo.AssertNotCalled(t, "CountEvents", mock.Anything, mock.Anything)
+	})
+}
+
 func TestEventService_UpdateEvent(t *testing.T) {
+	kickoff := time.Now().Add(24 * time.Hour)
 	existingEvent := &Event{
 		ID:            1,
-		EventDatetime: time.Now().Add(24 * time.Hour),
+		EventDatetime: kickoff,
+		EndDatetime:   kickoff.Add(2 * time.Hour),
 		Description:   stringPtr("Original description"),
 		Sport:         Sport{ID: 1, Name: "Football"},
 		HomeTeam:      Team{ID: 1, Name: "Team A"},
@@ -436,12 +908,14 @@ func TestEventService_UpdateEvent(t *testing.T) {
 	}
 
 	tests := []struct {
-		name          string
-		eventID       int
-		request       UpdateEventRequest
-		mockEvent     *Event
-		mockError     error
-		expectedError bool
+		name             string
+		eventID          int
+		request          UpdateEventRequest
+		mockEvent        *Event
+		mockError        error
+		expectedChange   string
+		expectReschedule bool
+		expectedError    bool
 	}{
 		{
 			name:          "successful update",
@@ -451,6 +925,37 @@ func TestEventService_UpdateEvent(t *testing.T) {
 			mockError:     nil,
 			expectedError: false,
 		},
+		{
+			name:    "reschedule records change",
+			eventID: 1,
+			request: UpdateEventRequest{
+				EventDatetime: timePtr(existingEvent.EventDatetime.Add(48 * time.Hour)),
+				Reason:        stringPtr("Waterlogged pitch"),
+			},
+			mockEvent:        existingEvent,
+			mockError:        nil,
+			expectedChange:   EventChangeRescheduled,
+			expectReschedule: true,
+			expectedError:    false,
+		},
+		{
+			name:             "venue change records reschedule",
+			eventID:          1,
+			request:          UpdateEventRequest{VenueID: intPtr(3)},
+			mockEvent:        existingEvent,
+			mockError:        nil,
+			expectReschedule: true,
+			expectedError:    false,
+		},
+		{
+			name:           "score records change",
+			eventID:        1,
+			request:        UpdateEventRequest{HomeScore: intPtr(2), AwayScore: intPtr(1)},
+			mockEvent:      existingEvent,
+			mockError:      nil,
+			expectedChange: EventChangeScored,
+			expectedError:  false,
+		},
 		{
 			name:          "update sport",
 			eventID:       1,
@@ -475,17 +980,40 @@ func TestEventService_UpdateEvent(t *testing.T) {
 			mockSportRepo := new(MockSportRepository)
 			mockTeamRepo := new(MockTeamRepository)
 			mockVenueRepo := new(MockVenueRepository)
+			mockChangeRepo := new(MockEventChangeRepository)
+			mockRescheduleRepo := new(MockEventRescheduleRepository)
 
-			service := NewEventService(mockRepo, 1, 10, mockSportRepo, mockTeamRepo, mockVenueRepo)
+			service := newTestEventService(mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, mockChangeRepo, mockRescheduleRepo)
 
-			mockRepo.On("GetEventByID", mock.Anything, tt.eventID).Return(tt.mockEvent, tt.mockError)
+			var mockEvent *Event
+			if tt.mockEvent != nil {
+				eventCopy := *tt.mockEvent
+				mockEvent = &eventCopy
+			}
+			mockRepo.On("GetEventByID", mock.Anything, tt.eventID).Return(mockEvent, tt.mockError)
 
 			if tt.mockEvent != nil {
+				if tt.expectedChange != "" {
+					mockChangeRepo.On("RecordEventChange", mock.Anything, mock.MatchedBy(func(c EventChange) bool {
+						return c.ChangeType == tt.expectedChange
+					})).Return(nil)
+				}
+				if tt.expectReschedule {
+					mockRescheduleRepo.On("RecordReschedule", mock.Anything, mock.MatchedBy(func(r EventReschedule) bool {
+						return r.EventID == tt.eventID &&
+							r.PreviousDatetime.Equal(existingEvent.EventDatetime) &&
+							r.PreviousVenueID == nil &&
+							intPtrEqual(r.NewVenueID, tt.request.VenueID) &&
+							r.Reason == tt.request.Reason
+					})).Return(nil)
+				}
 				if tt.request.SportID != nil {
 					mockSportRepo.On("GetSportById", mock.Anything, *tt.request.SportID).Return(&Sport{ID: *tt.request.SportID}, nil)
 				}
 				if tt.request.VenueID != nil {
 					mockVenueRepo.On("GetVenueById", mock.Anything, *tt.request.VenueID).Return(&Venue{ID: *tt.request.VenueID}, nil)
+					mockRepo.On("ListVenueConflicts", mock.Anything, *tt.request.VenueID,
+						existingEvent.EventDatetime, existingEvent.EndDatetime, tt.eventID).Return([]Event{}, nil)
 				}
 				if tt.request.HomeTeamID != nil {
 					mockTeamRepo.On("GetTeamByID", mock.Anything, *tt.request.HomeTeamID).Return(&Team{ID: *tt.request.HomeTeamID}, nil)
@@ -494,9 +1022,11 @@ func TestEventService_UpdateEvent(t *testing.T) {
 					mockTeamRepo.On("GetTeamByID", mock.Anything, *tt.request.AwayTeamID).Return(&Team{ID: *tt.request.AwayTeamID}, nil)
 				}
 				mockRepo.On("UpdateEvent", mock.Anything, mock.AnythingOfType("Event")).Return(nil)
+				mockRepo.On("ListTeamEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]Event{}, nil).Maybe()
+				mockSportRepo.On("ListSports", mock.Anything).Return([]Sport{}, nil).Maybe()
 			}
 
-			err := service.UpdateEvent(context.Background(), tt.eventID, tt.request)
+			_, err := service.UpdateEvent(context.Background(), tt.eventID, tt.request)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -505,10 +1035,329 @@ func TestEventService_UpdateEvent(t *testing.T) {
 			}
 
 			mockRepo.AssertExpectations(t)
+			mockChangeRepo.AssertExpectations(t)
+			mockRescheduleRepo.AssertExpectations(t)
 		})
 	}
 }
 
+func TestEventService_UpdateEvent_Attendance(t *testing.T) {
+	kickoff := time.Now().Add(-3 * time.Hour).UTC().Truncate(time.Minute)
+	arena := Venue{ID: 3, Capacity: intPtr(100)}
+	smallArena := Venue{ID: 4, Capacity: intPtr(50)}
+
+	tests := []struct {
+		name          string
+		venue         Venue
+		attendance    *int
+		request       UpdateEventRequest
+		expectedError string
+	}{
+		{
+			name:    "within capacity",
+			venue:   arena,
+			request: UpdateEventRequest{Attendance: intPtr(100)},
+		},
+		{
+			name:          "over capacity",
+			venue:         arena,
+			request:       UpdateEventRequest{Attendance: intPtr(101)},
+			expectedError: "exceeds the capacity 100 of venue 3",
+		},
+		{
+			name:    "over capacity with override",
+			venue:   arena,
+			request: UpdateEventRequest{Attendance: intPtr(101), AllowOverCapacity: true},
+		},
+		{
+			name:          "negative attendance",
+			venue:         arena,
+			request:       UpdateEventRequest{Attendance: intPtr(-1)},
+			expectedError: "attendance must not be negative",
+		},
+		{
+			name:    "venue without capacity",
+			venue:   Venue{ID: 5},
+			request: UpdateEventRequest{Attendance: intPtr(100000)},
+		},
+		{
+			name:          "moving to a smaller venue",
+			venue:         arena,
+			attendance:    intPtr(80),
+			request:       UpdateEventRequest{VenueID: intPtr(smallArena.ID)},
+			expectedError: "exceeds the capacity 50 of venue 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			mockVenueRepo := new(MockVenueRepository)
+			service := newTestEventService(mockRepo, new(MockSportRepository), new(MockTeamRepository), mockVenueRepo,
+				new(MockEventChangeRepository), new(MockEventRescheduleRepository))
+
+			mockRepo.On("GetEventByID", mock.Anything, 1).Return(&Event{
+				ID:            1,
+				EventDatetime: kickoff,
+				EndDatetime:   kickoff.Add(2 * time.Hour),
+				Attendance:    tt.attendance,
+				Venue:         tt.venue,
+				HomeTeam:      Team{ID: 1},
+				AwayTeam:      Team{ID: 2},
+			}, nil)
+			mockVenueRepo.On("GetVenueById", mock.Anything, smallArena.ID).Return(&smallArena, nil).Maybe()
+			if tt.expectedError == "" {
+				mockRepo.On("UpdateEvent", mock.Anything, mock.MatchedBy(func(e Event) bool {
+					return intPtrEqual(e.Attendance, tt.request.Attendance)
+				})).Return(nil)
+			}
+
+			_, err := service.UpdateEvent(context.Background(), 1, tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "validation error")
+				assert.Contains(t, err.Error(), tt.expectedError)
+				mockRepo.AssertNotCalled(t, "UpdateEvent", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventService_UpdateEvent_VenueBooking(t *testing.T) {
+	venueID := 3
+	kickoff := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Minute)
+	existing := func() *Event {
+		return &Event{
+			ID:            1,
+			EventDatetime: kickoff,
+			EndDatetime:   kickoff.Add(2 * time.Hour),
+			Venue:         Venue{ID: venueID},
+			HomeTeam:      Team{ID: 1},
+			AwayTeam:      Team{ID: 2},
+		}
+	}
+
+	t.Run("moving into a booked slot", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := newTestEventService(mockRepo, new(MockSportRepository), new(MockTeamRepository),
+			new(MockVenueRepository), new(MockEventChangeRepository), new(MockEventRescheduleRepository))
+		moved := kickoff.Add(3 * time.Hour)
+		mockRepo.On("GetEventByID", mock.Anything, 1).Return(existing(), nil)
+		mockRepo.On("ListVenueConflicts", mock.Anything, venueID, moved, moved.Add(2*time.Hour), 1).
+			Return([]Event{{ID: 9, EventDatetime: moved, EndDatetime: moved.Add(time.Hour)}}, nil)
+
+		_, err := service.UpdateEvent(context.Background(), 1, UpdateEventRequest{EventDatetime: &moved})
+
+		assert.True(t, errors.Is(err, ErrVenueConflict))
+		mockRepo.AssertNotCalled(t, "UpdateEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("score update does not re-check the venue", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		mockChangeRepo := new(MockEventChangeRepository)
+		service := newTestEventService(mockRepo, new(MockSportRepository), new(MockTeamRepository),
+			new(MockVenueRepository), mockChangeRepo, new(MockEventRescheduleRepository))
+		mockRepo.On("GetEventByID", mock.Anything, 1).Return(existing(), nil)
+		mockRepo.On("UpdateEvent", mock.Anything, mock.AnythingOfType("Event")).Return(nil)
+		mockChangeRepo.On("RecordEventChange", mock.Anything, mock.AnythingOfType("EventChange")).Return(nil)
+
+		_, err := service.UpdateEvent(context.Background(), 1, UpdateEventRequest{HomeScore: intPtr(1), AwayScore: intPtr(0)})
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "ListVenueConflicts", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("duration change keeps the kickoff", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		mockSportRepo := new(MockSportRepository)
+		service := newTestEventService(mockRepo, mockSportRepo, new(MockTeamRepository),
+			new(MockVenueRepository), new(MockEventChangeRepository), new(MockEventRescheduleRepository))
+		mockRepo.On("GetEventByID", mock.Anything, 1).Return(existing(), nil)
+		mockSportRepo.On("ListSports", mock.Anything).Return([]Sport{}, nil)
+		mockRepo.On("ListVenueConflicts", mock.Anything, venueID, kickoff, kickoff.Add(3*time.Hour), 1).
+			Return([]Event{}, nil)
+		mockRepo.On("ListTeamEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]Event{}, nil)
+		mockRepo.On("UpdateEvent", mock.Anything, mock.MatchedBy(func(e Event) bool {
+			return e.EventDatetime.Equal(kickoff) && e.EndDatetime.Equal(kickoff.Add(3*time.Hour))
+		})).Return(nil)
+
+		_, err := service.UpdateEvent(context.Background(), 1, UpdateEventRequest{DurationMinutes: intPtr(180)})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("end before start is rejected", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := newTestEventService(mockRepo, new(MockSportRepository), new(MockTeamRepository),
+			new(MockVenueRepository), new(MockEventChangeRepository), new(MockEventRescheduleRepository))
+		mockRepo.On("GetEventByID", mock.Anything, 1).Return(existing(), nil)
+
+		_, err := service.UpdateEvent(context.Background(), 1, UpdateEventRequest{EndDatetime: timePtr(kickoff)})
+
+		assert.EqualError(t, err, "validation error: end_datetime must be after the event start")
+		mockRepo.AssertNotCalled(t, "UpdateEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEventService_CreateEvent_TeamRest(t *testing.T) {
+	kickoff := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Minute)
+	previous := Event{
+		ID:            8,
+		EventDatetime: kickoff.Add(-5 * time.Hour),
+		EndDatetime:   kickoff.Add(-3 * time.Hour),
+		HomeTeam:      Team{ID: 1},
+		AwayTeam:      Team{ID: 5},
+	}
+	request := EventCreateRequest{EventDatetime: kickoff, SportID: 1, HomeTeamID: 1, AwayTeamID: 2}
+
+	tests := []struct {
+		name             string
+		sport            Sport
+		previousSport    Sport
+		expectedWarnings int
+		expectedError    error
+	}{
+		{
+			name:          "rejected inside the rest window",
+			sport:         Sport{ID: 1, DefaultDurationMinutes: 120, MinRestMinutes: 24 * 60, RestConflictPolicy: RestPolicyReject},
+			expectedError: ErrTeamConflict,
+		},
+		{
+			name:             "warned inside the rest window",
+			sport:            Sport{ID: 1, DefaultDurationMinutes: 120, MinRestMinutes: 24 * 60, RestConflictPolicy: RestPolicyWarn},
+			expectedWarnings: 1,
+		},
+		{
+			name:  "enough rest",
+			sport: Sport{ID: 1, DefaultDurationMinutes: 120, MinRestMinutes: 60, RestConflictPolicy: RestPolicyReject},
+		},
+		{
+			name:          "rejected inside the longer rest window of the other event's sport",
+			sport:         Sport{ID: 1, DefaultDurationMinutes: 120, MinRestMinutes: 60, RestConflictPolicy: RestPolicyReject},
+			previousSport: Sport{ID: 2, MinRestMinutes: 24 * 60},
+			expectedError: ErrTeamConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			mockSportRepo := new(MockSportRepository)
+			mockChangeRepo := new(MockEventChangeRepository)
+			service := newTestEventService(mockRepo, mockSportRepo, new(MockTeamRepository),
+				new(MockVenueRepository), mockChangeRepo, new(MockEventRescheduleRepository))
+			previous := previous
+			previous.Sport = tt.sport
+			if tt.previousSport.ID != 0 {
+				previous.Sport = tt.previousSport
+			}
+			rest := time.Duration(max(tt.sport.MinRestMinutes, previous.Sport.MinRestMinutes)) * time.Minute
+			from := kickoff.Add(-rest)
+			to := kickoff.Add(2*time.Hour + rest)
+			mockSportRepo.On("GetSportById", mock.Anything, 1).Return(&tt.sport, nil)
+			mockSportRepo.On("ListSports", mock.Anything).Return([]Sport{tt.sport, previous.Sport}, nil)
+			mockRepo.On("ListTeamEvents", mock.Anything, 1, &from, &to).Return([]Event{previous}, nil)
+			mockRepo.On("ListTeamEvents", mock.Anything, 2, &from, &to).Return([]Event{}, nil)
+			if tt.expectedError == nil {
+				mockRepo.On("CreateEvent", mock.Anything, mock.AnythingOfType("CreateEventParams")).Return(9, nil)
+				mockRepo.On("GetEventByID", mock.Anything, 9).Return(&Event{ID: 9}, nil)
+				mockChangeRepo.On("RecordEventChange", mock.Anything, mock.AnythingOfType("EventChange")).Return(nil)
+			}
+
+			id, warnings, err := service.CreateEvent(context.Background(), request)
+
+			if tt.expectedError != nil {
+				var conflict *TeamConflictError
+				assert.True(t, errors.Is(err, tt.expectedError))
+				assert.True(t, errors.As(err, &conflict))
+				assert.Len(t, conflict.Conflicts, 1)
+				assert.Equal(t, previous.ID, conflict.Conflicts[0].Event.ID)
+				assert.Contains(t, err.Error(), "team 1 has only 180 minutes rest between event 8 and the new event")
+				mockRepo.AssertNotCalled(t, "CreateEvent", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 9, id)
+			assert.Len(t, warnings, tt.expectedWarnings)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventService_UpdateEvent_TeamRest(t *testing.T) {
+	kickoff := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Minute)
+	existing := &Event{
+		ID:            1,
+		EventDatetime: kickoff,
+		EndDatetime:   kickoff.Add(2 * time.Hour),
+		Sport:         Sport{ID: 1},
+		HomeTeam:      Team{ID: 1},
+		AwayTeam:      Team{ID: 2},
+	}
+	moved := kickoff.Add(-2 * time.Hour)
+	mockRepo := new(MockEventRepository)
+	mockSportRepo := new(MockSportRepository)
+	service := newTestEventService(mockRepo, mockSportRepo, new(MockTeamRepository),
+		new(MockVenueRepository), new(MockEventChangeRepository), new(MockEventRescheduleRepository))
+	mockRepo.On("GetEventByID", mock.Anything, 1).Return(existing, nil)
+	mockSportRepo.On("ListSports", mock.Anything).Return([]Sport{}, nil)
+	mockRepo.On("ListTeamEvents", mock.Anything, 1, mock.Anything, mock.Anything).Return([]Event{
+		*existing,
+		{ID: 4, EventDatetime: moved.Add(-time.Hour), EndDatetime: moved.Add(time.Hour), HomeTeam: Team{ID: 1}},
+	}, nil)
+	mockRepo.On("ListTeamEvents", mock.Anything, 2, mock.Anything, mock.Anything).Return([]Event{*existing}, nil)
+
+	_, err := service.UpdateEvent(context.Background(), 1, UpdateEventRequest{EventDatetime: &moved})
+
+	var conflict *TeamConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Len(t, conflict.Conflicts, 1)
+	assert.True(t, conflict.Conflicts[0].Overlapping)
+	assert.Equal(t, 4, conflict.Conflicts[0].Event.ID)
+	mockRepo.AssertNotCalled(t, "UpdateEvent", mock.Anything, mock.Anything)
+}
+
+func TestEventService_ListReschedules(t *testing.T) {
+	ctx := context.Background()
+	reschedules := []EventReschedule{
+		{ID: 1, EventID: 1, PreviousDatetime: time.Now(), NewDatetime: time.Now().Add(time.Hour)},
+	}
+
+	t.Run("event found", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		mockRescheduleRepo := new(MockEventRescheduleRepository)
+		service := newTestEventService(mockRepo, new(MockSportRepository), new(MockTeamRepository),
+			new(MockVenueRepository), new(MockEventChangeRepository), mockRescheduleRepo)
+		mockRepo.On("GetEventByID", ctx, 1).Return(&Event{ID: 1}, nil)
+		mockRescheduleRepo.On("ListReschedules", ctx, 1).Return(reschedules, nil)
+
+		result, err := service.ListReschedules(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, reschedules, result)
+	})
+
+	t.Run("event not found", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		mockRescheduleRepo := new(MockEventRescheduleRepository)
+		service := newTestEventService(mockRepo, new(MockSportRepository), new(MockTeamRepository),
+			new(MockVenueRepository), new(MockEventChangeRepository), mockRescheduleRepo)
+		mockRepo.On("GetEventByID", ctx, 999).Return(nil, sql.ErrNoRows)
+
+		result, err := service.ListReschedules(ctx, 999)
+
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+		assert.Nil(t, result)
+		mockRescheduleRepo.AssertNotCalled(t, "ListReschedules", mock.Anything, mock.Anything)
+	})
+}
+
 func TestEventService_DeleteEvent(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -542,13 +1391,18 @@ func TestEventService_DeleteEvent(t *testing.T) {
 			mockSportRepo := new(MockSportRepository)
 			mockTeamRepo := new(MockTeamRepository)
 			mockVenueRepo := new(MockVenueRepository)
+			mockChangeRepo := new(MockEventChangeRepository)
+			mockRescheduleRepo := new(MockEventRescheduleRepository)
 
-			service := NewEventService(mockRepo, 1, 10, mockSportRepo, mockTeamRepo, mockVenueRepo)
+			service := newTestEventService(mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, mockChangeRepo, mockRescheduleRepo)
 
 			mockRepo.On("GetEventByID", mock.Anything, tt.eventID).Return(tt.mockEvent, tt.mockGetError)
 
 			if tt.mockEvent != nil {
 				mockRepo.On("DeleteEvent", mock.Anything, tt.eventID).Return(tt.mockDelError)
+				mockChangeRepo.On("RecordEventChange", mock.Anything, mock.MatchedBy(func(c EventChange) bool {
+					return c.EventID == tt.eventID && c.ChangeType == EventChangeCancelled
+				})).Return(nil)
 			}
 
 			err := service.DeleteEvent(context.Background(), tt.eventID)
@@ -564,6 +1418,104 @@ func TestEventService_DeleteEvent(t *testing.T) {
 	}
 }
 
+func TestEventService_RestoreEvent(t *testing.T) {
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 4, 1, 18, 0, 0, 0, time.UTC)
+	deletedEvent := func() *Event {
+		return &Event{
+			ID:            1,
+			EventDatetime: start,
+			EndDatetime:   start.Add(2 * time.Hour),
+			Sport:         Sport{ID: 1},
+			Venue:         Venue{ID: 5},
+			HomeTeam:      Team{ID: 10},
+			AwayTeam:      Team{ID: 11},
+			DeletedAt:     &deletedAt,
+		}
+	}
+	withDeleted := mock.MatchedBy(IncludeDeleted)
+	withoutDeleted := mock.MatchedBy(func(ctx context.Context) bool { return !IncludeDeleted(ctx) })
+
+	newService := func() (*EventService, *MockEventRepository, *MockSportRepository, *MockTeamRepository,
+		*MockVenueRepository, *MockEventChangeRepository) {
+		mockRepo := new(MockEventRepository)
+		mockSportRepo := new(MockSportRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockVenueRepo := new(MockVenueRepository)
+		mockChangeRepo := new(MockEventChangeRepository)
+		service := newTestEventService(mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, mockChangeRepo,
+			new(MockEventRescheduleRepository))
+		return service, mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, mockChangeRepo
+	}
+
+	t.Run("successful restore", func(t *testing.T) {
+		service, mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, mockChangeRepo := newService()
+		restored := deletedEvent()
+		restored.DeletedAt = nil
+
+		mockRepo.On("GetEventByID", withDeleted, 1).Return(deletedEvent(), nil).Once()
+		mockSportRepo.On("GetSportById", mock.Anything, 1).Return(&Sport{ID: 1}, nil)
+		mockTeamRepo.On("GetTeamByID", mock.Anything, 10).Return(&Team{ID: 10}, nil)
+		mockTeamRepo.On("GetTeamByID", mock.Anything, 11).Return(&Team{ID: 11}, nil)
+		mockVenueRepo.On("GetVenueById", mock.Anything, 5).Return(&Venue{ID: 5}, nil)
+		mockRepo.On("ListVenueConflicts", mock.Anything, 5, start, start.Add(2*time.Hour), 1).Return([]Event{}, nil)
+		mockRepo.On("RestoreEvent", mock.Anything, 1).Return(nil)
+		mockRepo.On("GetEventByID", withoutDeleted, 1).Return(restored, nil).Once()
+		mockChangeRepo.On("RecordEventChange", mock.Anything, mock.MatchedBy(func(c EventChange) bool {
+			return c.EventID == 1 && c.ChangeType == EventChangeCreated
+		})).Return(nil)
+
+		event, err := service.RestoreEvent(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Nil(t, event.DeletedAt)
+		mockRepo.AssertExpectations(t)
+		mockChangeRepo.AssertExpectations(t)
+	})
+
+	t.Run("event not deleted", func(t *testing.T) {
+		service, mockRepo, _, _, _, _ := newService()
+		live := deletedEvent()
+		live.DeletedAt = nil
+		mockRepo.On("GetEventByID", withDeleted, 1).Return(live, nil)
+
+		_, err := service.RestoreEvent(context.Background(), 1)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+		mockRepo.AssertNotCalled(t, "RestoreEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("team deleted", func(t *testing.T) {
+		service, mockRepo, mockSportRepo, mockTeamRepo, _, _ := newService()
+		mockRepo.On("GetEventByID", withDeleted, 1).Return(deletedEvent(), nil)
+		mockSportRepo.On("GetSportById", mock.Anything, 1).Return(&Sport{ID: 1}, nil)
+		mockTeamRepo.On("GetTeamByID", mock.Anything, 10).Return(nil, sql.ErrNoRows)
+
+		_, err := service.RestoreEvent(context.Background(), 1)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "team 10 is deleted")
+		mockRepo.AssertNotCalled(t, "RestoreEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("venue slot taken", func(t *testing.T) {
+		service, mockRepo, mockSportRepo, mockTeamRepo, mockVenueRepo, _ := newService()
+		mockRepo.On("GetEventByID", withDeleted, 1).Return(deletedEvent(), nil)
+		mockSportRepo.On("GetSportById", mock.Anything, 1).Return(&Sport{ID: 1}, nil)
+		mockTeamRepo.On("GetTeamByID", mock.Anything, mock.Anything).Return(&Team{}, nil)
+		mockVenueRepo.On("GetVenueById", mock.Anything, 5).Return(&Venue{ID: 5}, nil)
+		mockRepo.On("ListVenueConflicts", mock.Anything, 5, mock.Anything, mock.Anything, 1).
+			Return([]Event{{ID: 2}}, nil)
+
+		_, err := service.RestoreEvent(context.Background(), 1)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrVenueConflict))
+		mockRepo.AssertNotCalled(t, "RestoreEvent", mock.Anything, mock.Anything)
+	})
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -572,3 +1524,7 @@ func stringPtr(s string) *string {
 func intPtr(i int) *int {
 	return &i
 }
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+const maxFeedLimit = 200
+
+type EventChangeRepositoryInterface interface {
+	RecordEventChange(ctx context.Context, change EventChange) error
+	ListEventChanges(ctx context.Context, params ListEventChangesParams) ([]EventChange, error)
+	ListLatestResults(ctx context.Context, params ListEventChangesParams) ([]EventChange, error)
+}
+
+type FeedServiceInterface interface {
+	ListResults(ctx context.Context, req FeedRequest) ([]EventChange, error)
+	ListChanges(ctx context.Context, req FeedRequest) ([]EventChange, error)
+}
+
+type FeedService struct {
+	eventChangeRepository EventChangeRepositoryInterface
+	defaultLimit          int
+}
+
+func NewFeedService(c EventChangeRepositoryInterface, dL int) *FeedService {
+	return &FeedService{eventChangeRepository: c, defaultLimit: dL}
+}
+
+func (s *FeedService) ListResults(ctx context.Context, req FeedRequest) ([]EventChange, error) {
+	params := s.toListParams(req, []string{EventChangeScored})
+	results, err := s.eventChangeRepository.ListLatestResults(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list results: %w", err)
+	}
+	return results, nil
+}
+
+func (s *FeedService) ListChanges(ctx context.Context, req FeedRequest) ([]EventChange, error) {
+	params := s.toListParams(req, []string{
+		EventChangeCreated,
+		EventChangeRescheduled,
+		EventChangeCancelled,
+	})
+	changes, err := s.eventChangeRepository.ListEventChanges(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event changes: %w", err)
+	}
+	return changes, nil
+}
+
+func (s *FeedService) toListParams(req FeedRequest, changeTypes []string) ListEventChangesParams {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = s.defaultLimit
+	}
+	if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+	return ListEventChangesParams{
+		ChangeTypes: changeTypes,
+		SportID:     req.SportID,
+		TeamID:      req.TeamID,
+		Limit:       limit,
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFeedService_ListResults(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       FeedRequest
+		mockResults   []EventChange
+		mockError     error
+		expectedLimit int
+		expectedError bool
+	}{
+		{
+			name:    "successful list with default limit",
+			request: FeedRequest{},
+			mockResults: []EventChange{
+				{ID: 1, EventID: 1, ChangeType: EventChangeScored, ChangedAt: time.Now()},
+			},
+			mockError:     nil,
+			expectedLimit: 50,
+			expectedError: false,
+		},
+		{
+			name:          "limit is capped",
+			request:       FeedRequest{Limit: 1000},
+			mockResults:   []EventChange{},
+			mockError:     nil,
+			expectedLimit: maxFeedLimit,
+			expectedError: false,
+		},
+		{
+			name:          "database error",
+			request:       FeedRequest{Limit: 10},
+			mockResults:   nil,
+			mockError:     errors.New("database error"),
+			expectedLimit: 10,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventChangeRepository)
+			service := NewFeedService(mockRepo, 50)
+
+			mockRepo.On("ListLatestResults", mock.Anything, mock.MatchedBy(func(p ListEventChangesParams) bool {
+				return p.Limit == tt.expectedLimit &&
+					len(p.ChangeTypes) == 1 && p.ChangeTypes[0] == EventChangeScored
+			})).Return(tt.mockResults, tt.mockError)
+
+			results, err := service.ListResults(context.Background(), tt.request)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, results)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, len(tt.mockResults), len(results))
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFeedService_ListChanges(t *testing.T) {
+	sportID := 1
+	teamID := 2
+
+	tests := []struct {
+		name          string
+		request       FeedRequest
+		mockChanges   []EventChange
+		mockError     error
+		expectedError bool
+	}{
+		{
+			name:    "successful list with filters",
+			request: FeedRequest{SportID: &sportID, TeamID: &teamID},
+			mockChanges: []EventChange{
+				{ID: 2, EventID: 1, ChangeType: EventChangeRescheduled},
+				{ID: 1, EventID: 1, ChangeType: EventChangeCreated},
+			},
+			mockError:     nil,
+			expectedError: false,
+		},
+		{
+			name:          "database error",
+			request:       FeedRequest{},
+			mockChanges:   nil,
+			mockError:     errors.New("database error"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventChangeRepository)
+			service := NewFeedService(mockRepo, 50)
+
+			mockRepo.On("ListEventChanges", mock.Anything, mock.MatchedBy(func(p ListEventChangesParams) bool {
+				return p.SportID == tt.request.SportID && p.TeamID == tt.request.TeamID &&
+					assert.ElementsMatch(t, []string{EventChangeCreated, EventChangeRescheduled, EventChangeCancelled}, p.ChangeTypes)
+			})).Return(tt.mockChanges, tt.mockError)
+
+			changes, err := service.ListChanges(context.Background(), tt.request)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, changes)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, len(tt.mockChanges), len(changes))
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// EarthRadiusKm is the mean Earth radius used for distances.
+	EarthRadiusKm = 6371.0
+
+	// DefaultNearbyRadiusKm is used by nearby searches without a radius.
+	DefaultNearbyRadiusKm = 25.0
+	// MaxNearbyRadiusKm caps the radius of nearby searches.
+	MaxNearbyRadiusKm = 500.0
+)
+
+// validateCoordinates checks an optional latitude/longitude pair: both or
+// neither must be given, and each must be within range.
+func validateCoordinates(latitude, longitude *float64) error {
+	if (latitude == nil) != (longitude == nil) {
+		return fmt.Errorf("validation error: latitude and longitude must be given together")
+	}
+	if latitude == nil {
+		return nil
+	}
+	return validateGeoPoint(GeoPoint{Latitude: *latitude, Longitude: *longitude})
+}
+
+func validateGeoPoint(point GeoPoint) error {
+	if math.IsNaN(point.Latitude) || point.Latitude < -90 || point.Latitude > 90 {
+		return fmt.Errorf("validation error: latitude must be between -90 and 90")
+	}
+	if math.IsNaN(point.Longitude) || point.Longitude < -180 || point.Longitude > 180 {
+		return fmt.Errorf("validation error: longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// resolveRadiusKm applies the default radius and rejects radii outside
+// 0..MaxNearbyRadiusKm.
+func resolveRadiusKm(radiusKm float64) (float64, error) {
+	if radiusKm == 0 {
+		return DefaultNearbyRadiusKm, nil
+	}
+	if math.IsNaN(radiusKm) || radiusKm < 0 || radiusKm > MaxNearbyRadiusKm {
+		return 0, fmt.Errorf("validation error: radius_km must be between 0 and %g", MaxNearbyRadiusKm)
+	}
+	return radiusKm, nil
+}
+
+// venuePoint returns the coordinates of a venue, nil when they are unknown.
+func venuePoint(venue Venue) *GeoPoint {
+	if venue.Latitude == nil || venue.Longitude == nil {
+		return nil
+	}
+	return &GeoPoint{Latitude: *venue.Latitude, Longitude: *venue.Longitude}
+}
+
+// distanceKm is the great-circle distance between two points using the
+// haversine formula, matching the SQL used by the repositories.
+func distanceKm(a, b GeoPoint) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LastModifiedSeries names series to LastModified; the other entity types go
+// by their AuditEntity* names.
+const LastModifiedSeries = "series"
+
+type LastModifiedRepositoryInterface interface {
+	// LastModified returns when a record of the entity type was last
+	// created, changed or deleted, or the zero time when there are none.
+	LastModified(ctx context.Context, entityType string) (time.Time, error)
+}
+
+type LastModifiedServiceInterface interface {
+	LastModified(ctx context.Context, entityType string) (time.Time, error)
+}
+
+// LastModifiedService dates the lists of records for conditional requests.
+// It looks at all records of a type, not only the listed ones, so that a
+// record leaving a list by a change or a delete dates it too.
+type LastModifiedService struct {
+	lastModifiedRepository LastModifiedRepositoryInterface
+}
+
+func NewLastModifiedService(r LastModifiedRepositoryInterface) *LastModifiedService {
+	return &LastModifiedService{lastModifiedRepository: r}
+}
+
+func (s *LastModifiedService) LastModified(ctx context.Context, entityType string) (time.Time, error) {
+	lastModified, err := s.lastModifiedRepository.LastModified(ctx, entityType)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("database error: %w", err)
+	}
+	return lastModified, nil
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+
+	// maxRecurrencePeriods bounds the expansion of open-ended rules.
+	maxRecurrencePeriods = 5000
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RecurrenceDay is a BYDAY entry; Ordinal is only meaningful for monthly
+// rules ("2TU" is the second Tuesday, "-1FR" the last Friday, 0 every one).
+type RecurrenceDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// RecurrenceRule is the supported subset of an RFC 5545 RRULE:
+// FREQ=WEEKLY|MONTHLY with INTERVAL, BYDAY and either COUNT or UNTIL.
+type RecurrenceRule struct {
+	Freq     string
+	Interval int
+	ByDay    []RecurrenceDay
+	Count    int
+	Until    *time.Time
+}
+
+// ParseRRule parses rule text such as "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10".
+// A date-only UNTIL is interpreted as the end of that day in loc.
+func ParseRRule(text string, loc *time.Location) (*RecurrenceRule, error) {
+	rule := &RecurrenceRule{Interval: 1}
+	text = strings.TrimPrefix(strings.TrimSpace(text), "RRULE:")
+	if text == "" {
+		return nil, fmt.Errorf("rrule must not be empty")
+	}
+	for _, part := range strings.Split(text, ";") {
+		key, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(value)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("rrule INTERVAL must be a positive integer")
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("rrule COUNT must be a positive integer")
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRRuleUntil(value, loc)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				byDay, err := parseRecurrenceDay(day)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, byDay)
+			}
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				return nil, fmt.Errorf("rrule WKST other than MO is not supported")
+			}
+		default:
+			return nil, fmt.Errorf("rrule part %s is not supported", key)
+		}
+	}
+	if rule.Freq != FreqWeekly && rule.Freq != FreqMonthly {
+		return nil, fmt.Errorf("rrule FREQ must be WEEKLY or MONTHLY")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("rrule must not contain both COUNT and UNTIL")
+	}
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Freq == FreqWeekly {
+			return nil, fmt.Errorf("rrule BYDAY ordinals are only allowed for MONTHLY rules")
+		}
+	}
+	return rule, nil
+}
+
+func parseRRuleUntil(value string, loc *time.Location) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	if until, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return until, nil
+	}
+	if day, err := time.ParseInLocation("20060102", value, loc); err == nil {
+		return day.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("rrule UNTIL must look like 20060102 or 20060102T150405Z")
+}
+
+func parseRecurrenceDay(value string) (RecurrenceDay, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) < 2 {
+		return RecurrenceDay{}, fmt.Errorf("invalid rrule BYDAY value %q", value)
+	}
+	weekday, ok := rruleWeekdays[value[len(value)-2:]]
+	if !ok {
+		return RecurrenceDay{}, fmt.Errorf("invalid rrule BYDAY value %q", value)
+	}
+	day := RecurrenceDay{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return RecurrenceDay{}, fmt.Errorf("invalid rrule BYDAY value %q", value)
+		}
+		day.Ordinal = ordinal
+	}
+	return day, nil
+}
+
+// String renders the rule back into RRULE text.
+func (r RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, day.String())
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (d RecurrenceDay) String() string {
+	name := strings.ToUpper(d.Weekday.String()[:2])
+	if d.Ordinal != 0 {
+		return strconv.Itoa(d.Ordinal) + name
+	}
+	return name
+}
+
+// Occurrences expands the rule from start (the first occurrence, whose
+// location governs wall-clock times across DST changes) up to and including
+// windowEnd. COUNT is applied before any exception dates are removed, as in
+// RFC 5545.
+func (r RecurrenceRule) Occurrences(start, windowEnd time.Time) []time.Time {
+	var occurrences []time.Time
+	end := windowEnd
+	if r.Until != nil && r.Until.Before(end) {
+		end = *r.Until
+	}
+	emitted := 0
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		candidates := r.periodCandidates(start, period)
+		if len(candidates) == 0 && r.periodStart(start, period).After(end) {
+			break
+		}
+		for _, candidate := range candidates {
+			if candidate.Before(start) {
+				continue
+			}
+			if candidate.After(end) {
+				return occurrences
+			}
+			occurrences = append(occurrences, candidate)
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return occurrences
+			}
+		}
+	}
+	return occurrences
+}
+
+// periodStart is midnight of the first day of the n-th week or month.
+func (r RecurrenceRule) periodStart(start time.Time, n int) time.Time {
+	loc := start.Location()
+	if r.Freq == FreqMonthly {
+		return time.Date(start.Year(), start.Month()+time.Month(n*r.Interval), 1, 0, 0, 0, 0, loc)
+	}
+	mondayOffset := (int(start.Weekday()) + 6) % 7
+	return time.Date(start.Year(), start.Month(), start.Day()-mondayOffset+7*n*r.Interval, 0, 0, 0, 0, loc)
+}
+
+func (r RecurrenceRule) periodCandidates(start time.Time, n int) []time.Time {
+	loc := start.Location()
+	periodStart := r.periodStart(start, n)
+	atClock := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, loc)
+	}
+	var candidates []time.Time
+
+	if r.Freq == FreqWeekly {
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []RecurrenceDay{{Weekday: start.Weekday()}}
+		}
+		for _, day := range days {
+			offset := (int(day.Weekday) + 6) % 7
+			candidates = append(candidates, atClock(periodStart.Year(), periodStart.Month(), periodStart.Day()+offset))
+		}
+	} else {
+		year, month := periodStart.Year(), periodStart.Month()
+		daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+		if len(r.ByDay) == 0 {
+			if start.Day() <= daysInMonth {
+				candidates = append(candidates, atClock(year, month, start.Day()))
+			}
+		}
+		for _, byDay := range r.ByDay {
+			var matches []int
+			for day := 1; day <= daysInMonth; day++ {
+				if time.Date(year, month, day, 0, 0, 0, 0, loc).Weekday() == byDay.Weekday {
+					matches = append(matches, day)
+				}
+			}
+			switch {
+			case byDay.Ordinal > 0 && byDay.Ordinal <= len(matches):
+				matches = matches[byDay.Ordinal-1 : byDay.Ordinal]
+			case byDay.Ordinal < 0 && -byDay.Ordinal <= len(matches):
+				matches = matches[len(matches)+byDay.Ordinal : len(matches)+byDay.Ordinal+1]
+			case byDay.Ordinal != 0:
+				matches = nil
+			}
+			for _, day := range matches {
+				candidates = append(candidates, atClock(year, month, day))
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	return dedupeTimes(candidates)
+}
+
+func dedupeTimes(times []time.Time) []time.Time {
+	var result []time.Time
+	for _, t := range times {
+		if len(result) == 0 || !t.Equal(result[len(result)-1]) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// ShiftWeekdays moves every BYDAY entry by the given number of days, used
+// when all following occurrences of a series move to another day.
+func (r *RecurrenceRule) ShiftWeekdays(days int) {
+	for i := range r.ByDay {
+		r.ByDay[i].Weekday = time.Weekday(((int(r.ByDay[i].Weekday)+days)%7 + 7) % 7)
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name          string
+		rrule         string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "weekly with byday and count",
+			rrule:    "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10",
+			expected: "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10",
+		},
+		{
+			name:     "monthly with ordinal byday and until",
+			rrule:    "RRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR;UNTIL=20261231T230000Z",
+			expected: "FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR;UNTIL=20261231T230000Z",
+		},
+		{
+			name:          "daily is not supported",
+			rrule:         "FREQ=DAILY",
+			expectedError: true,
+		},
+		{
+			name:          "count and until together",
+			rrule:         "FREQ=WEEKLY;COUNT=3;UNTIL=20261231",
+			expectedError: true,
+		},
+		{
+			name:          "ordinal in weekly rule",
+			rrule:         "FREQ=WEEKLY;BYDAY=2TU",
+			expectedError: true,
+		},
+		{
+			name:          "unknown part",
+			rrule:         "FREQ=WEEKLY;BYSETPOS=1",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rrule, time.UTC)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, rule)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, rule.String())
+			}
+		})
+	}
+}
+
+func TestRecurrenceRule_Occurrences(t *testing.T) {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		rrule     string
+		start     time.Time
+		windowEnd time.Time
+		expected  []string
+	}{
+		{
+			name:      "weekly keeps local time across DST change",
+			rrule:     "FREQ=WEEKLY;COUNT=3",
+			start:     time.Date(2025, 10, 14, 19, 0, 0, 0, vienna),
+			windowEnd: time.Date(2026, 1, 1, 0, 0, 0, 0, vienna),
+			expected: []string{
+				"2025-10-14T19:00:00+02:00",
+				"2025-10-21T19:00:00+02:00",
+				"2025-10-28T19:00:00+01:00",
+			},
+		},
+		{
+			name:      "weekly byday with interval",
+			rrule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			start:     time.Date(2025, 12, 4, 18, 30, 0, 0, time.UTC),
+			windowEnd: time.Date(2025, 12, 22, 0, 0, 0, 0, time.UTC),
+			expected: []string{
+				"2025-12-04T18:30:00Z",
+				"2025-12-15T18:30:00Z",
+				"2025-12-18T18:30:00Z",
+			},
+		},
+		{
+			name:      "monthly skips months without the day",
+			rrule:     "FREQ=MONTHLY;COUNT=3",
+			start:     time.Date(2026, 1, 31, 20, 0, 0, 0, time.UTC),
+			windowEnd: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []string{
+				"2026-01-31T20:00:00Z",
+				"2026-03-31T20:00:00Z",
+				"2026-05-31T20:00:00Z",
+			},
+		},
+		{
+			name:      "monthly last friday until",
+			rrule:     "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20260301",
+			start:     time.Date(2026, 1, 30, 19, 0, 0, 0, time.UTC),
+			windowEnd: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []string{
+				"2026-01-30T19:00:00Z",
+				"2026-02-27T19:00:00Z",
+			},
+		},
+		{
+			name:      "window cuts open-ended rule",
+			rrule:     "FREQ=WEEKLY",
+			start:     time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+			windowEnd: time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC),
+			expected: []string{
+				"2026-03-02T10:00:00Z",
+				"2026-03-09T10:00:00Z",
+				"2026-03-16T10:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rrule, tt.start.Location())
+			require.NoError(t, err)
+
+			occurrences := rule.Occurrences(tt.start, tt.windowEnd)
+
+			formatted := make([]string, 0, len(occurrences))
+			for _, o := range occurrences {
+				formatted = append(formatted, o.Format(time.RFC3339))
+			}
+			assert.Equal(t, tt.expected, formatted)
+		})
+	}
+}
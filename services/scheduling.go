@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultEventDurationMinutes is used for sports created without an explicit
+// default duration.
+const DefaultEventDurationMinutes = 120
+
+// ErrVenueConflict is returned when an event would overlap another booking
+// at the same venue.
+var ErrVenueConflict = errors.New("venue conflict")
+
+// VenueConflictError names the event an attempted booking clashes with.
+type VenueConflictError struct {
+	VenueID          int
+	ConflictingEvent Event
+}
+
+func (e *VenueConflictError) Error() string {
+	return fmt.Sprintf("%s: venue %d is already booked by event %d (%s vs %s) from %s to %s",
+		ErrVenueConflict,
+		e.VenueID,
+		e.ConflictingEvent.ID,
+		e.ConflictingEvent.HomeTeam.Name,
+		e.ConflictingEvent.AwayTeam.Name,
+		e.ConflictingEvent.EventDatetime.UTC().Format(time.RFC3339),
+		e.ConflictingEvent.EndDatetime.UTC().Format(time.RFC3339),
+	)
+}
+
+func (e *VenueConflictError) Unwrap() error {
+	return ErrVenueConflict
+}
+
+// validateEventWindow checks that an event ends after it starts.
+func validateEventWindow(start, end time.Time) error {
+	if !end.After(start) {
+		return fmt.Errorf("validation error: end_datetime must be after the event start")
+	}
+	return nil
+}
+
+func validateDurationMinutes(minutes int) error {
+	if minutes <= 0 {
+		return fmt.Errorf("validation error: duration must be a positive number of minutes")
+	}
+	return nil
+}
+
+// Rest conflict policies of a sport.
+const (
+	RestPolicyReject = "reject"
+	RestPolicyWarn   = "warn"
+)
+
+// ErrTeamConflict is returned when a team would play inside its rest window
+// and the sport rejects such conflicts.
+var ErrTeamConflict = errors.New("team conflict")
+
+// TeamConflict is a pair of events of one team that are closer together
+// than the minimum rest period, or overlap outright.
+type TeamConflict struct {
+	TeamID           int
+	Event            Event
+	ConflictingEvent Event
+	GapMinutes       int
+	MinRestMinutes   int
+	Overlapping      bool
+}
+
+func (c TeamConflict) Message() string {
+	if c.Overlapping {
+		return fmt.Sprintf("team %d plays %s and %s at overlapping times",
+			c.TeamID, eventLabel(c.Event), eventLabel(c.ConflictingEvent))
+	}
+	return fmt.Sprintf("team %d has only %d minutes rest between %s and %s (minimum %d)",
+		c.TeamID, c.GapMinutes, eventLabel(c.Event), eventLabel(c.ConflictingEvent), c.MinRestMinutes)
+}
+
+// eventLabel names an event in conflict messages; events that are not
+// stored yet have no ID.
+func eventLabel(event Event) string {
+	if event.ID == 0 {
+		return "the new event"
+	}
+	return fmt.Sprintf("event %d", event.ID)
+}
+
+// TeamConflictError lists the rest period violations a schedule change
+// would cause.
+type TeamConflictError struct {
+	Conflicts []TeamConflict
+}
+
+func (e *TeamConflictError) Error() string {
+	messages := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		messages = append(messages, conflict.Message())
+	}
+	return fmt.Sprintf("%s: %s", ErrTeamConflict, strings.Join(messages, "; "))
+}
+
+func (e *TeamConflictError) Unwrap() error {
+	return ErrTeamConflict
+}
+
+// restConflict reports whether a team playing both events gets less rest
+// than either sport requires. The events are ordered by kickoff first.
+func restConflict(teamID int, a, b Event) (TeamConflict, bool) {
+	if b.EventDatetime.Before(a.EventDatetime) {
+		a, b = b, a
+	}
+	minRest := max(a.Sport.MinRestMinutes, b.Sport.MinRestMinutes)
+	gap := b.EventDatetime.Sub(a.EndDatetime)
+	if gap >= time.Duration(minRest)*time.Minute {
+		return TeamConflict{}, false
+	}
+	return TeamConflict{
+		TeamID:           teamID,
+		Event:            a,
+		ConflictingEvent: b,
+		GapMinutes:       int(gap.Minutes()),
+		MinRestMinutes:   minRest,
+		Overlapping:      gap < 0,
+	}, true
+}
+
+// findRestConflicts returns every conflicting pair among a team's events.
+func findRestConflicts(teamID int, events []Event) []TeamConflict {
+	conflicts := []TeamConflict{}
+	for i := range events {
+		for j := i + 1; j < len(events); j++ {
+			if conflict, ok := restConflict(teamID, events[i], events[j]); ok {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+	return conflicts
+}
+
+func validateRestRules(minRestMinutes int, policy string) error {
+	if minRestMinutes < 0 {
+		return fmt.Errorf("validation error: min_rest_minutes must not be negative")
+	}
+	if policy != RestPolicyReject && policy != RestPolicyWarn {
+		return fmt.Errorf("validation error: rest_conflict_policy must be %q or %q", RestPolicyReject, RestPolicyWarn)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	DefaultSearchLimit = 10
+	maxSearchLimit     = 50
+	maxSearchTerms     = 8
+)
+
+type SearchRepositoryInterface interface {
+	SearchEvents(ctx context.Context, query string, limit int) ([]SearchHit, error)
+	SearchTeams(ctx context.Context, query string, limit int) ([]SearchHit, error)
+	SearchVenues(ctx context.Context, query string, limit int) ([]SearchHit, error)
+}
+
+type SearchServiceInterface interface {
+	Search(ctx context.Context, req SearchRequest) (*SearchResults, error)
+}
+
+type SearchService struct {
+	searchRepository SearchRepositoryInterface
+}
+
+func NewSearchService(r SearchRepositoryInterface) *SearchService {
+	return &SearchService{searchRepository: r}
+}
+
+// Search finds the events, teams and venues matching every word of req.Query,
+// each word also matching as a prefix. Every group holds up to req.Limit hits,
+// best match first.
+func (s *SearchService) Search(ctx context.Context, req SearchRequest) (*SearchResults, error) {
+	query, err := prefixSearchQuery(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	events, err := s.searchRepository.SearchEvents(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search events: %w", err)
+	}
+	teams, err := s.searchRepository.SearchTeams(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search teams: %w", err)
+	}
+	venues, err := s.searchRepository.SearchVenues(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search venues: %w", err)
+	}
+	return &SearchResults{Events: events, Teams: teams, Venues: venues}, nil
+}
+
+// prefixSearchQuery turns free text into a tsquery matching documents that
+// contain every word, or a word starting with it: "red bu" becomes
+// "red:* & bu:*". Punctuation separates words, so the result never holds
+// tsquery operators from the input.
+func prefixSearchQuery(text string) (string, error) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "", fmt.Errorf("validation error: q must contain at least one letter or digit")
+	}
+	if len(words) > maxSearchTerms {
+		return "", fmt.Errorf("validation error: q must not contain more than %d words", maxSearchTerms)
+	}
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, word+":*")
+	}
+	return strings.Join(terms, " & "), nil
+}
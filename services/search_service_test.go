@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockSearchRepository is a mock for SearchRepositoryInterface
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) SearchEvents(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]SearchHit), args.Error(1)
+}
+
+func (m *MockSearchRepository) SearchTeams(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]SearchHit), args.Error(1)
+}
+
+func (m *MockSearchRepository) SearchVenues(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]SearchHit), args.Error(1)
+}
+
+func TestPrefixSearchQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expected      string
+		expectedError bool
+	}{
+		{name: "single word", text: "Eisbären", expected: "eisbären:*"},
+		{name: "several words", text: "  red   BULL ", expected: "red:* & bull:*"},
+		{name: "tsquery operators are separators", text: "paris|!saint-germain:*", expected: "paris:* & saint:* & germain:*"},
+		{name: "digits", text: "U19", expected: "u19:*"},
+		{name: "empty", text: "   ", expectedError: true},
+		{name: "only punctuation", text: "&|!()", expectedError: true},
+		{name: "too many words", text: "a b c d e f g h i", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := prefixSearchQuery(tt.text)
+
+			if tt.expectedError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "validation error")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, query)
+		})
+	}
+}
+
+func TestSearchService_Search(t *testing.T) {
+	t.Run("groups hits by type", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		service := NewSearchService(mockRepo)
+		teams := []SearchHit{{Type: SearchHitTeam, ID: 3, Title: "Eisbären Berlin", Rank: 0.6}}
+
+		mockRepo.On("SearchEvents", mock.Anything, "eisbaren:*", DefaultSearchLimit).Return([]SearchHit{}, nil)
+		mockRepo.On("SearchTeams", mock.Anything, "eisbaren:*", DefaultSearchLimit).Return(teams, nil)
+		mockRepo.On("SearchVenues", mock.Anything, "eisbaren:*", DefaultSearchLimit).Return([]SearchHit{}, nil)
+
+		results, err := service.Search(context.Background(), SearchRequest{Query: "Eisbaren"})
+
+		require.NoError(t, err)
+		assert.Empty(t, results.Events)
+		assert.Equal(t, teams, results.Teams)
+		assert.Empty(t, results.Venues)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("caps the limit", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		service := NewSearchService(mockRepo)
+
+		mockRepo.On("SearchEvents", mock.Anything, "vienna:*", maxSearchLimit).Return([]SearchHit{}, nil)
+		mockRepo.On("SearchTeams", mock.Anything, "vienna:*", maxSearchLimit).Return([]SearchHit{}, nil)
+		mockRepo.On("SearchVenues", mock.Anything, "vienna:*", maxSearchLimit).Return([]SearchHit{}, nil)
+
+		_, err := service.Search(context.Background(), SearchRequest{Query: "Vienna", Limit: 500})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		service := NewSearchService(mockRepo)
+
+		_, err := service.Search(context.Background(), SearchRequest{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+		mockRepo.AssertNotCalled(t, "SearchEvents", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		service := NewSearchService(mockRepo)
+
+		mockRepo.On("SearchEvents", mock.Anything, "vienna:*", DefaultSearchLimit).Return([]SearchHit{}, nil)
+		mockRepo.On("SearchTeams", mock.Anything, "vienna:*", DefaultSearchLimit).Return(nil, errors.New("connection lost"))
+
+		_, err := service.Search(context.Background(), SearchRequest{Query: "vienna"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to search teams")
+	})
+}
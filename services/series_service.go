@@ -0,0 +1,568 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type SeriesRepositoryInterface interface {
+	CreateSeries(ctx context.Context, series EventSeries) (int, error)
+	GetSeriesByID(ctx context.Context, id int) (*EventSeries, error)
+	ListSeries(ctx context.Context) ([]EventSeries, error)
+	UpdateSeries(ctx context.Context, series EventSeries) error
+	DeleteSeries(ctx context.Context, id int) error
+	RestoreSeries(ctx context.Context, id int) error
+	AddException(ctx context.Context, seriesID int, date time.Time) error
+	MoveSeriesEvents(ctx context.Context, fromSeriesID, toSeriesID int, from time.Time) error
+}
+
+type SeriesServiceInterface interface {
+	CreateSeries(ctx context.Context, req CreateSeriesRequest) (int, error)
+	GetSeriesByID(ctx context.Context, id int) (*EventSeries, error)
+	ListSeries(ctx context.Context) ([]EventSeries, error)
+	MaterializeSeries(ctx context.Context, id int) (int, error)
+	AddException(ctx context.Context, id int, req SeriesExceptionRequest) error
+	UpdateOccurrence(ctx context.Context, seriesID, eventID int, scope string, req UpdateEventRequest) error
+	DeleteOccurrence(ctx context.Context, seriesID, eventID int, scope string) error
+	DeleteSeries(ctx context.Context, id int) error
+	RestoreSeries(ctx context.Context, id int) (*EventSeries, error)
+}
+
+// SeriesService manages recurring event series. Occurrences are ordinary
+// events carrying the series ID; they are materialized up to a rolling
+// horizon and created, updated and deleted through the EventService so that
+// all event validation and change tracking applies to them.
+type SeriesService struct {
+	seriesRepository SeriesRepositoryInterface
+	eventRepository  EventRepositoryInterface
+	venueRepository  VenueRepositoryInterface
+	eventService     EventServiceInterface
+	horizonDays      int
+	transactor       Transactor
+}
+
+func NewSeriesService(sr SeriesRepositoryInterface, er EventRepositoryInterface,
+	vr VenueRepositoryInterface, es EventServiceInterface, horizonDays int, tx Transactor) *SeriesService {
+	return &SeriesService{
+		seriesRepository: sr,
+		eventRepository:  er,
+		venueRepository:  vr,
+		eventService:     es,
+		horizonDays:      horizonDays,
+		transactor:       tx,
+	}
+}
+
+// withRepositories returns a copy of the service working on repos. An
+// EventService is bound to repos too, so that the occurrences it creates and
+// deletes are part of the same unit of work.
+func (s *SeriesService) withRepositories(repos Repositories) *SeriesService {
+	bound := *s
+	bound.seriesRepository = repos.Series
+	bound.eventRepository = repos.Events
+	bound.venueRepository = repos.Venues
+	if eventService, ok := s.eventService.(*EventService); ok {
+		bound.eventService = eventService.withRepositories(repos)
+	}
+	return &bound
+}
+
+// CreateSeries stores a series and materializes its occurrences in one
+// transaction, so that an occurrence that cannot be scheduled leaves no
+// series behind.
+func (s *SeriesService) CreateSeries(ctx context.Context, req CreateSeriesRequest) (int, error) {
+	if req.HomeTeamID == req.AwayTeamID {
+		return 0, fmt.Errorf("validation error: home and away team must differ")
+	}
+	zoneName := DefaultTimeZone
+	if req.TimeZone != nil {
+		zoneName = *req.TimeZone
+	} else if req.VenueID != nil {
+		venue, err := s.venueRepository.GetVenueById(ctx, *req.VenueID)
+		if err != nil {
+			return 0, fmt.Errorf("validation error: venue with id %d not found", *req.VenueID)
+		}
+		zoneName = venue.TimeZone
+	}
+	loc, err := LoadTimeZone(zoneName)
+	if err != nil {
+		return 0, fmt.Errorf("validation error: %w", err)
+	}
+	start, err := parseLocalDatetime(req.LocalDatetime, loc)
+	if err != nil {
+		return 0, err
+	}
+	rule, err := ParseRRule(req.RRule, loc)
+	if err != nil {
+		return 0, fmt.Errorf("validation error: %w", err)
+	}
+	exceptionDates := make([]time.Time, 0, len(req.ExceptionDates))
+	for _, value := range req.ExceptionDates {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return 0, fmt.Errorf("validation error: exception date %q must look like 2006-01-02", value)
+		}
+		exceptionDates = append(exceptionDates, date)
+	}
+	series := EventSeries{
+		RRule:          rule.String(),
+		StartDatetime:  start,
+		TimeZone:       zoneName,
+		Description:    req.Description,
+		SportID:        req.SportID,
+		VenueID:        req.VenueID,
+		HomeTeamID:     req.HomeTeamID,
+		AwayTeamID:     req.AwayTeamID,
+		ExceptionDates: exceptionDates,
+	}
+	var newID int
+	err = s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		bound := s.withRepositories(repos)
+		created := series
+		id, err := bound.seriesRepository.CreateSeries(ctx, created)
+		if err != nil {
+			return fmt.Errorf("failed to create series: %w", err)
+		}
+		created.ID = id
+		if _, err := bound.materialize(ctx, &created); err != nil {
+			return err
+		}
+		newID = id
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+func (s *SeriesService) GetSeriesByID(ctx context.Context, id int) (*EventSeries, error) {
+	series, err := s.seriesRepository.GetSeriesByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return series, nil
+}
+
+func (s *SeriesService) ListSeries(ctx context.Context) ([]EventSeries, error) {
+	seriesList, err := s.seriesRepository.ListSeries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+	return seriesList, nil
+}
+
+// MaterializeSeries creates the series' occurrences up to the current
+// horizon, all of them or none.
+func (s *SeriesService) MaterializeSeries(ctx context.Context, id int) (int, error) {
+	var created int
+	err := s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		var err error
+		created, err = s.withRepositories(repos).materializeSeries(ctx, id)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created, nil
+}
+
+func (s *SeriesService) materializeSeries(ctx context.Context, id int) (int, error) {
+	series, err := s.seriesRepository.GetSeriesByID(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return s.materialize(ctx, series)
+}
+
+// materialize creates the future occurrences between the series' previous
+// horizon and the current one, so occurrences that were deleted or moved
+// individually are never created twice.
+func (s *SeriesService) materialize(ctx context.Context, series *EventSeries) (int, error) {
+	loc, err := LoadTimeZone(series.TimeZone)
+	if err != nil {
+		return 0, fmt.Errorf("validation error: %w", err)
+	}
+	rule, err := ParseRRule(series.RRule, loc)
+	if err != nil {
+		return 0, fmt.Errorf("validation error: %w", err)
+	}
+	now := time.Now()
+	windowEnd := now.AddDate(0, 0, s.horizonDays)
+	if series.MaterializedUntil != nil && !series.MaterializedUntil.Before(windowEnd) {
+		return 0, nil
+	}
+	excluded := make(map[string]bool, len(series.ExceptionDates))
+	for _, date := range series.ExceptionDates {
+		excluded[date.Format("2006-01-02")] = true
+	}
+	created := 0
+	for _, occurrence := range rule.Occurrences(series.StartDatetime.In(loc), windowEnd) {
+		if series.MaterializedUntil != nil && !occurrence.After(*series.MaterializedUntil) {
+			continue
+		}
+		if !occurrence.After(now) || excluded[occurrence.Format("2006-01-02")] {
+			continue
+		}
+		_, _, err := s.eventService.CreateEvent(ctx, EventCreateRequest{
+			EventDatetime: occurrence,
+			Description:   series.Description,
+			SportID:       series.SportID,
+			VenueID:       series.VenueID,
+			HomeTeamID:    series.HomeTeamID,
+			AwayTeamID:    series.AwayTeamID,
+			SeriesID:      &series.ID,
+		})
+		if err != nil {
+			return created, fmt.Errorf("failed to create occurrence at %s: %w", occurrence.Format(time.RFC3339), err)
+		}
+		created++
+	}
+	series.MaterializedUntil = &windowEnd
+	if err := s.seriesRepository.UpdateSeries(ctx, *series); err != nil {
+		return created, fmt.Errorf("failed to update series: %w", err)
+	}
+	return created, nil
+}
+
+// AddException records the date and deletes the occurrences on it in one
+// transaction.
+func (s *SeriesService) AddException(ctx context.Context, id int, req SeriesExceptionRequest) error {
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		return s.withRepositories(repos).addException(ctx, id, req)
+	})
+}
+
+func (s *SeriesService) addException(ctx context.Context, id int, req SeriesExceptionRequest) error {
+	series, err := s.seriesRepository.GetSeriesByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	loc, err := LoadTimeZone(series.TimeZone)
+	if err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	date, err := time.ParseInLocation("2006-01-02", req.Date, loc)
+	if err != nil {
+		return fmt.Errorf("validation error: exception date %q must look like 2006-01-02", req.Date)
+	}
+	if err := s.seriesRepository.AddException(ctx, id, date); err != nil {
+		return fmt.Errorf("failed to add series exception: %w", err)
+	}
+	nextDay := date.AddDate(0, 0, 1)
+	events, err := s.listSeriesEvents(ctx, id, date, &nextDay)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := s.eventService.DeleteEvent(ctx, event.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateOccurrence updates one occurrence, or it and the following ones by
+// splitting the series, in one transaction.
+func (s *SeriesService) UpdateOccurrence(ctx context.Context, seriesID, eventID int,
+	scope string, req UpdateEventRequest) error {
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		return s.withRepositories(repos).updateOccurrence(ctx, seriesID, eventID, scope, req)
+	})
+}
+
+func (s *SeriesService) updateOccurrence(ctx context.Context, seriesID, eventID int,
+	scope string, req UpdateEventRequest) error {
+	series, event, err := s.loadOccurrence(ctx, seriesID, eventID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(ctx, AuditEntityEvent, eventID, event.Version); err != nil {
+		return err
+	}
+	ctx = withoutExpectedVersion(ctx)
+	switch scope {
+	case "", SeriesScopeThis:
+		_, err := s.eventService.UpdateEvent(ctx, eventID, req)
+		return err
+	case SeriesScopeFollowing:
+	default:
+		return fmt.Errorf("validation error: scope must be %q or %q", SeriesScopeThis, SeriesScopeFollowing)
+	}
+	if req.HomeScore != nil || req.AwayScore != nil {
+		return fmt.Errorf("validation error: scores can only be set on a single occurrence")
+	}
+	loc, err := LoadTimeZone(series.TimeZone)
+	if err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	rule, err := ParseRRule(series.RRule, loc)
+	if err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	shift, dayDelta := noShift, 0
+	if req.EventDatetime != nil {
+		shift, dayDelta = wallClockShift(event.EventDatetime, *req.EventDatetime, loc)
+	}
+
+	target := *series
+	tail := *rule
+	tail.ByDay = append([]RecurrenceDay(nil), rule.ByDay...)
+	split := event.EventDatetime.After(series.StartDatetime)
+	if split {
+		cut := event.EventDatetime.Add(-time.Second)
+		if rule.Count > 0 {
+			prior := len(rule.Occurrences(series.StartDatetime.In(loc), cut))
+			tail.Count = max(rule.Count-prior, 1)
+		}
+		head := *rule
+		head.Count = 0
+		head.Until = &cut
+		series.RRule = head.String()
+
+		target.ID = 0
+		target.StartDatetime = event.EventDatetime
+		target.ExceptionDates = nil
+		for _, date := range series.ExceptionDates {
+			if !date.Before(civilDate(event.EventDatetime.In(loc))) {
+				target.ExceptionDates = append(target.ExceptionDates, date)
+			}
+		}
+	}
+	tail.ShiftWeekdays(dayDelta)
+	if tail.Until != nil {
+		until := shift(*tail.Until, loc)
+		tail.Until = &until
+	}
+	target.RRule = tail.String()
+	target.StartDatetime = shift(target.StartDatetime, loc)
+	if target.MaterializedUntil != nil {
+		materializedUntil := shift(*target.MaterializedUntil, loc)
+		target.MaterializedUntil = &materializedUntil
+	}
+	applySeriesTemplate(&target, req)
+
+	if split {
+		newID, err := s.seriesRepository.CreateSeries(ctx, target)
+		if err != nil {
+			return fmt.Errorf("failed to split series: %w", err)
+		}
+		if err := s.seriesRepository.MoveSeriesEvents(ctx, series.ID, newID, event.EventDatetime); err != nil {
+			return fmt.Errorf("failed to split series: %w", err)
+		}
+		if err := s.seriesRepository.UpdateSeries(ctx, *series); err != nil {
+			return fmt.Errorf("failed to update series: %w", err)
+		}
+		target.ID = newID
+	} else if err := s.seriesRepository.UpdateSeries(ctx, target); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+
+	events, err := s.listSeriesEvents(ctx, target.ID, event.EventDatetime, nil)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		occurrenceReq := req
+		if req.EventDatetime != nil {
+			shifted := shift(e.EventDatetime, loc)
+			occurrenceReq.EventDatetime = &shifted
+		}
+		if _, err := s.eventService.UpdateEvent(ctx, e.ID, occurrenceReq); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteOccurrence deletes one occurrence, or it and the following ones by
+// ending the series, in one transaction.
+func (s *SeriesService) DeleteOccurrence(ctx context.Context, seriesID, eventID int, scope string) error {
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		return s.withRepositories(repos).deleteOccurrence(ctx, seriesID, eventID, scope)
+	})
+}
+
+func (s *SeriesService) deleteOccurrence(ctx context.Context, seriesID, eventID int, scope string) error {
+	series, event, err := s.loadOccurrence(ctx, seriesID, eventID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(ctx, AuditEntityEvent, eventID, event.Version); err != nil {
+		return err
+	}
+	ctx = withoutExpectedVersion(ctx)
+	loc, err := LoadTimeZone(series.TimeZone)
+	if err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	switch scope {
+	case "", SeriesScopeThis:
+		if err := s.eventService.DeleteEvent(ctx, eventID); err != nil {
+			return err
+		}
+		if err := s.seriesRepository.AddException(ctx, seriesID, civilDate(event.EventDatetime.In(loc))); err != nil {
+			return fmt.Errorf("failed to add series exception: %w", err)
+		}
+		return nil
+	case SeriesScopeFollowing:
+	default:
+		return fmt.Errorf("validation error: scope must be %q or %q", SeriesScopeThis, SeriesScopeFollowing)
+	}
+	events, err := s.listSeriesEvents(ctx, seriesID, event.EventDatetime, nil)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if err := s.eventService.DeleteEvent(ctx, e.ID); err != nil {
+			return err
+		}
+	}
+	if !event.EventDatetime.After(series.StartDatetime) {
+		if err := s.seriesRepository.DeleteSeries(ctx, seriesID); err != nil {
+			return fmt.Errorf("failed to delete series: %w", err)
+		}
+		return nil
+	}
+	rule, err := ParseRRule(series.RRule, loc)
+	if err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	cut := event.EventDatetime.Add(-time.Second)
+	rule.Count = 0
+	rule.Until = &cut
+	series.RRule = rule.String()
+	if err := s.seriesRepository.UpdateSeries(ctx, *series); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+	return nil
+}
+
+// DeleteSeries removes the series and its upcoming occurrences, in one
+// transaction; past occurrences are kept as standalone events.
+func (s *SeriesService) DeleteSeries(ctx context.Context, id int) error {
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		return s.withRepositories(repos).deleteSeries(ctx, id)
+	})
+}
+
+func (s *SeriesService) deleteSeries(ctx context.Context, id int) error {
+	series, err := s.seriesRepository.GetSeriesByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if err := checkVersion(ctx, "series", id, series.Version); err != nil {
+		return err
+	}
+	ctx = withoutExpectedVersion(ctx)
+	events, err := s.listSeriesEvents(ctx, id, time.Now(), nil)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := s.eventService.DeleteEvent(ctx, event.ID); err != nil {
+			return err
+		}
+	}
+	if err := s.seriesRepository.DeleteSeries(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete series: %w", err)
+	}
+	return nil
+}
+
+// RestoreSeries undeletes a series. The occurrences deleted along with it
+// stay deleted; they can be restored one by one as events.
+func (s *SeriesService) RestoreSeries(ctx context.Context, id int) (*EventSeries, error) {
+	series, err := s.seriesRepository.GetSeriesByID(WithDeleted(ctx), id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if series.DeletedAt == nil {
+		return nil, notDeletedError("series", id)
+	}
+	if err := s.seriesRepository.RestoreSeries(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	series.DeletedAt = nil
+	series.Version++
+	return series, nil
+}
+
+func (s *SeriesService) loadOccurrence(ctx context.Context, seriesID, eventID int) (*EventSeries, *Event, error) {
+	series, err := s.seriesRepository.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("database error: %w", err)
+	}
+	event, err := s.eventRepository.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("database error: %w", err)
+	}
+	if event.SeriesID == nil || *event.SeriesID != seriesID {
+		return nil, nil, fmt.Errorf("validation error: event %d is not part of series %d", eventID, seriesID)
+	}
+	return series, event, nil
+}
+
+func (s *SeriesService) listSeriesEvents(ctx context.Context, seriesID int,
+	from time.Time, to *time.Time) ([]Event, error) {
+	params := ListEventsParams{SeriesID: &seriesID, StartFrom: &from, DateTo: to}
+	total, err := s.eventRepository.CountEvents(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count series events: %w", err)
+	}
+	if total == 0 {
+		return nil, nil
+	}
+	params.Limit = total
+	events, err := s.eventRepository.ListEvents(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series events: %w", err)
+	}
+	return events, nil
+}
+
+func applySeriesTemplate(series *EventSeries, req UpdateEventRequest) {
+	if req.Description != nil {
+		series.Description = req.Description
+	}
+	if req.SportID != nil {
+		series.SportID = *req.SportID
+	}
+	if req.VenueID != nil {
+		series.VenueID = req.VenueID
+	}
+	if req.HomeTeamID != nil {
+		series.HomeTeamID = *req.HomeTeamID
+	}
+	if req.AwayTeamID != nil {
+		series.AwayTeamID = *req.AwayTeamID
+	}
+}
+
+func noShift(t time.Time, _ *time.Location) time.Time {
+	return t
+}
+
+// wallClockShift returns a function moving any occurrence by the same number
+// of calendar days and the same change of local time of day as from -> to,
+// so a 19:00 -> 20:00 move stays at 20:00 across DST changes.
+func wallClockShift(from, to time.Time, loc *time.Location) (func(time.Time, *time.Location) time.Time, int) {
+	fromLocal, toLocal := from.In(loc), to.In(loc)
+	dayDelta := int(civilDate(toLocal).Sub(civilDate(fromLocal)).Hours() / 24)
+	clockDelta := secondsOfDay(toLocal) - secondsOfDay(fromLocal)
+	return func(t time.Time, loc *time.Location) time.Time {
+		l := t.In(loc)
+		return time.Date(l.Year(), l.Month(), l.Day()+dayDelta,
+			l.Hour(), l.Minute(), l.Second()+clockDelta, l.Nanosecond(), loc)
+	}, dayDelta
+}
+
+// civilDate is the calendar date of t as midnight UTC.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func secondsOfDay(t time.Time) int {
+	return t.Hour()*3600 + t.Minute()*60 + t.Second()
+}
@@ -0,0 +1,434 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeriesRepository) CreateSeries(ctx context.Context, series EventSeries) (int, error) {
+	args := m.Called(ctx, series)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSeriesRepository) GetSeriesByID(ctx context.Context, id int) (*EventSeries, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*EventSeries), args.Error(1)
+}
+
+func (m *MockSeriesRepository) ListSeries(ctx context.Context) ([]EventSeries, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]EventSeries), args.Error(1)
+}
+
+func (m *MockSeriesRepository) UpdateSeries(ctx context.Context, series EventSeries) error {
+	args := m.Called(ctx, series)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) DeleteSeries(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) RestoreSeries(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) AddException(ctx context.Context, seriesID int, date time.Time) error {
+	args := m.Called(ctx, seriesID, date)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) MoveSeriesEvents(ctx context.Context, fromSeriesID, toSeriesID int, from time.Time) error {
+	args := m.Called(ctx, fromSeriesID, toSeriesID, from)
+	return args.Error(0)
+}
+
+type MockEventServiceForSeries struct {
+	mock.Mock
+}
+
+func (m *MockEventServiceForSeries) GetEventByID(ctx context.Context, id int) (*Event, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Event), args.Error(1)
+}
+
+func (m *MockEventServiceForSeries) CreateEvent(ctx context.Context, req EventCreateRequest) (int, []string, error) {
+	args := m.Called(ctx, req)
+	if args.Get(1) == nil {
+		return args.Int(0), nil, args.Error(2)
+	}
+	return args.Int(0), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *MockEventServiceForSeries) ListEvents(ctx context.Context, req ListEventsRequest) ([]Event, *Pagination, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]Event), args.Get(1).(*Pagination), args.Error(2)
+}
+
+func (m *MockEventServiceForSeries) UpdateEvent(ctx context.Context, id int, req UpdateEventRequest) ([]string, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockEventServiceForSeries) DeleteEvent(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockEventServiceForSeries) RestoreEvent(ctx context.Context, id int) (*Event, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Event), args.Error(1)
+}
+
+func (m *MockEventServiceForSeries) ListReschedules(ctx context.Context, id int) ([]EventReschedule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]EventReschedule), args.Error(1)
+}
+
+func TestSeriesService_CreateSeries(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().AddDate(0, 0, 7).UTC().Truncate(time.Hour)
+	localStart := start.Format("2006-01-02T15:04")
+
+	tests := []struct {
+		name            string
+		request         CreateSeriesRequest
+		mockSetup       func(*MockSeriesRepository, *MockVenueRepository, *MockEventServiceForSeries)
+		expectedID      int
+		expectedError   bool
+		expectedErrText string
+	}{
+		{
+			name: "successful creation materializes occurrences",
+			request: CreateSeriesRequest{
+				RRule:         "FREQ=WEEKLY;COUNT=3",
+				LocalDatetime: localStart,
+				TimeZone:      stringPtr("UTC"),
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockSetup: func(sr *MockSeriesRepository, vr *MockVenueRepository, es *MockEventServiceForSeries) {
+				sr.On("CreateSeries", ctx, mock.MatchedBy(func(s EventSeries) bool {
+					return s.RRule == "FREQ=WEEKLY;COUNT=3" && s.StartDatetime.Equal(start) && s.TimeZone == "UTC"
+				})).Return(5, nil)
+				for i := 0; i < 3; i++ {
+					occurrence := start.AddDate(0, 0, 7*i)
+					es.On("CreateEvent", ctx, mock.MatchedBy(func(req EventCreateRequest) bool {
+						return req.EventDatetime.Equal(occurrence) && req.SeriesID != nil && *req.SeriesID == 5
+					})).Return(10+i, nil, nil).Once()
+				}
+				sr.On("UpdateSeries", ctx, mock.MatchedBy(func(s EventSeries) bool {
+					return s.ID == 5 && s.MaterializedUntil != nil
+				})).Return(nil)
+			},
+			expectedID: 5,
+		},
+		{
+			name: "time zone taken from venue",
+			request: CreateSeriesRequest{
+				RRule:         "FREQ=MONTHLY;COUNT=1",
+				LocalDatetime: start.Format("2006-01-02T15:04"),
+				VenueID:       intPtr(3),
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockSetup: func(sr *MockSeriesRepository, vr *MockVenueRepository, es *MockEventServiceForSeries) {
+				vr.On("GetVenueById", ctx, 3).Return(&Venue{ID: 3, TimeZone: "Europe/Vienna"}, nil)
+				sr.On("CreateSeries", ctx, mock.MatchedBy(func(s EventSeries) bool {
+					return s.TimeZone == "Europe/Vienna"
+				})).Return(6, nil)
+				es.On("CreateEvent", ctx, mock.Anything).Return(20, nil, nil).Once()
+				sr.On("UpdateSeries", ctx, mock.Anything).Return(nil)
+			},
+			expectedID: 6,
+		},
+		{
+			name: "same home and away team",
+			request: CreateSeriesRequest{
+				RRule:         "FREQ=WEEKLY",
+				LocalDatetime: localStart,
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    1,
+			},
+			mockSetup:       func(*MockSeriesRepository, *MockVenueRepository, *MockEventServiceForSeries) {},
+			expectedError:   true,
+			expectedErrText: "validation error: home and away team must differ",
+		},
+		{
+			name: "unsupported rrule",
+			request: CreateSeriesRequest{
+				RRule:         "FREQ=DAILY",
+				LocalDatetime: localStart,
+				SportID:       1,
+				HomeTeamID:    1,
+				AwayTeamID:    2,
+			},
+			mockSetup:       func(*MockSeriesRepository, *MockVenueRepository, *MockEventServiceForSeries) {},
+			expectedError:   true,
+			expectedErrText: "validation error: rrule FREQ must be WEEKLY or MONTHLY",
+		},
+		{
+			name: "invalid exception date",
+			request: CreateSeriesRequest{
+				RRule:          "FREQ=WEEKLY",
+				LocalDatetime:  localStart,
+				ExceptionDates: []string{"25/12/2026"},
+				SportID:        1,
+				HomeTeamID:     1,
+				AwayTeamID:     2,
+			},
+			mockSetup:       func(*MockSeriesRepository, *MockVenueRepository, *MockEventServiceForSeries) {},
+			expectedError:   true,
+			expectedErrText: "validation error: exception date \"25/12/2026\" must look like 2006-01-02",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seriesRepo := new(MockSeriesRepository)
+			venueRepo := new(MockVenueRepository)
+			eventService := new(MockEventServiceForSeries)
+			tt.mockSetup(seriesRepo, venueRepo, eventService)
+
+			service := newTestSeriesService(seriesRepo, new(MockEventRepository), venueRepo, eventService, 180)
+			id, err := service.CreateSeries(ctx, tt.request)
+
+			if tt.expectedError {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedErrText, err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedID, id)
+			}
+			seriesRepo.AssertExpectations(t)
+			venueRepo.AssertExpectations(t)
+			eventService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSeriesService_MaterializeSeries(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().AddDate(0, 0, -14).UTC().Truncate(time.Hour)
+	materializedUntil := time.Now().AddDate(0, 0, 2)
+
+	t.Run("only occurrences beyond the previous horizon are created", func(t *testing.T) {
+		seriesRepo := new(MockSeriesRepository)
+		eventService := new(MockEventServiceForSeries)
+		series := &EventSeries{
+			ID:                7,
+			RRule:             "FREQ=WEEKLY",
+			StartDatetime:     start,
+			TimeZone:          "UTC",
+			MaterializedUntil: &materializedUntil,
+			SportID:           1,
+			HomeTeamID:        1,
+			AwayTeamID:        2,
+		}
+		seriesRepo.On("GetSeriesByID", ctx, 7).Return(series, nil)
+		eventService.On("CreateEvent", ctx, mock.MatchedBy(func(req EventCreateRequest) bool {
+			return req.EventDatetime.After(materializedUntil)
+		})).Return(1, nil, nil).Times(3)
+		seriesRepo.On("UpdateSeries", ctx, mock.Anything).Return(nil)
+
+		service := newTestSeriesService(seriesRepo, new(MockEventRepository), new(MockVenueRepository), eventService, 21)
+		created, err := service.MaterializeSeries(ctx, 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, created)
+		seriesRepo.AssertExpectations(t)
+		eventService.AssertExpectations(t)
+	})
+
+	t.Run("already materialized", func(t *testing.T) {
+		seriesRepo := new(MockSeriesRepository)
+		eventService := new(MockEventServiceForSeries)
+		farFuture := time.Now().AddDate(1, 0, 0)
+		seriesRepo.On("GetSeriesByID", ctx, 7).Return(&EventSeries{
+			ID:                7,
+			RRule:             "FREQ=WEEKLY",
+			StartDatetime:     start,
+			TimeZone:          "UTC",
+			MaterializedUntil: &farFuture,
+		}, nil)
+
+		service := newTestSeriesService(seriesRepo, new(MockEventRepository), new(MockVenueRepository), eventService, 180)
+		created, err := service.MaterializeSeries(ctx, 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, created)
+		eventService.AssertNotCalled(t, "CreateEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("series not found", func(t *testing.T) {
+		seriesRepo := new(MockSeriesRepository)
+		seriesRepo.On("GetSeriesByID", ctx, 99).Return(nil, sql.ErrNoRows)
+
+		service := newTestSeriesService(seriesRepo, new(MockEventRepository), new(MockVenueRepository),
+			new(MockEventServiceForSeries), 180)
+		_, err := service.MaterializeSeries(ctx, 99)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+	})
+}
+
+func TestSeriesService_DeleteOccurrence(t *testing.T) {
+	ctx := context.Background()
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	require.NoError(t, err)
+	start := time.Date(2026, 3, 3, 19, 0, 0, 0, vienna)
+	occurrence := time.Date(2026, 3, 17, 19, 0, 0, 0, vienna)
+	series := func() *EventSeries {
+		return &EventSeries{
+			ID:            3,
+			RRule:         "FREQ=WEEKLY;COUNT=10",
+			StartDatetime: start,
+			TimeZone:      "Europe/Vienna",
+			SportID:       1,
+			HomeTeamID:    1,
+			AwayTeamID:    2,
+		}
+	}
+
+	t.Run("this occurrence adds an exception", func(t *testing.T) {
+		seriesRepo := new(MockSeriesRepository)
+		eventRepo := new(MockEventRepository)
+		eventService := new(MockEventServiceForSeries)
+		seriesRepo.On("GetSeriesByID", ctx, 3).Return(series(), nil)
+		eventRepo.On("GetEventByID", ctx, 40).Return(&Event{ID: 40, EventDatetime: occurrence, SeriesID: intPtr(3)}, nil)
+		eventService.On("DeleteEvent", ctx, 40).Return(nil)
+		seriesRepo.On("AddException", ctx, 3, time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)).Return(nil)
+
+		service := newTestSeriesService(seriesRepo, eventRepo, new(MockVenueRepository), eventService, 180)
+		err := service.DeleteOccurrence(ctx, 3, 40, "")
+
+		require.NoError(t, err)
+		seriesRepo.AssertExpectations(t)
+		eventService.AssertExpectations(t)
+	})
+
+	t.Run("following occurrences truncate the series", func(t *testing.T) {
+		seriesRepo := new(MockSeriesRepository)
+		eventRepo := new(MockEventRepository)
+		eventService := new(MockEventServiceForSeries)
+		seriesRepo.On("GetSeriesByID", ctx, 3).Return(series(), nil)
+		eventRepo.On("GetEventByID", ctx, 40).Return(&Event{ID: 40, EventDatetime: occurrence, SeriesID: intPtr(3)}, nil)
+		eventRepo.On("CountEvents", ctx, mock.Anything).Return(2, nil)
+		eventRepo.On("ListEvents", ctx, mock.Anything).Return([]Event{{ID: 40}, {ID: 41}}, nil)
+		eventService.On("DeleteEvent", ctx, 40).Return(nil)
+		eventService.On("DeleteEvent", ctx, 41).Return(nil)
+		seriesRepo.On("UpdateSeries", ctx, mock.MatchedBy(func(s EventSeries) bool {
+			return s.RRule == "FREQ=WEEKLY;UNTIL=20260317T175959Z"
+		})).Return(nil)
+
+		service := newTestSeriesService(seriesRepo, eventRepo, new(MockVenueRepository), eventService, 180)
+		err := service.DeleteOccurrence(ctx, 3, 40, SeriesScopeFollowing)
+
+		require.NoError(t, err)
+		seriesRepo.AssertExpectations(t)
+		eventService.AssertExpectations(t)
+	})
+
+	t.Run("event from another series", func(t *testing.T) {
+		seriesRepo := new(MockSeriesRepository)
+		eventRepo := new(MockEventRepository)
+		seriesRepo.On("GetSeriesByID", ctx, 3).Return(series(), nil)
+		eventRepo.On("GetEventByID", ctx, 40).Return(&Event{ID: 40, EventDatetime: occurrence, SeriesID: intPtr(4)}, nil)
+
+		service := newTestSeriesService(seriesRepo, eventRepo, new(MockVenueRepository), new(MockEventServiceForSeries), 180)
+		err := service.DeleteOccurrence(ctx, 3, 40, "")
+
+		require.Error(t, err)
+		assert.Equal(t, "validation error: event 40 is not part of series 3", err.Error())
+	})
+}
+
+func TestSeriesService_UpdateOccurrence(t *testing.T) {
+	ctx := context.Background()
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	require.NoError(t, err)
+	start := time.Date(2026, 3, 3, 19, 0, 0, 0, vienna)
+	occurrence := time.Date(2026, 3, 17, 19, 0, 0, 0, vienna)
+	later := time.Date(2026, 3, 31, 19, 0, 0, 0, vienna)
+
+	seriesRepo := new(MockSeriesRepository)
+	eventRepo := new(MockEventRepository)
+	eventService := new(MockEventServiceForSeries)
+	seriesRepo.On("GetSeriesByID", ctx, 3).Return(&EventSeries{
+		ID:            3,
+		RRule:         "FREQ=WEEKLY;BYDAY=TU;COUNT=10",
+		StartDatetime: start,
+		TimeZone:      "Europe/Vienna",
+		SportID:       1,
+		HomeTeamID:    1,
+		AwayTeamID:    2,
+	}, nil)
+	eventRepo.On("GetEventByID", ctx, 40).Return(&Event{ID: 40, EventDatetime: occurrence, SeriesID: intPtr(3)}, nil)
+	// Tuesday 19:00 -> Wednesday 20:00 for this and all following occurrences.
+	moved := time.Date(2026, 3, 18, 20, 0, 0, 0, vienna)
+	seriesRepo.On("CreateSeries", ctx, mock.MatchedBy(func(s EventSeries) bool {
+		return s.RRule == "FREQ=WEEKLY;BYDAY=WE;COUNT=8" && s.StartDatetime.Equal(moved)
+	})).Return(9, nil)
+	seriesRepo.On("MoveSeriesEvents", ctx, 3, 9, occurrence).Return(nil)
+	seriesRepo.On("UpdateSeries", ctx, mock.MatchedBy(func(s EventSeries) bool {
+		return s.ID == 3 && s.RRule == "FREQ=WEEKLY;BYDAY=TU;UNTIL=20260317T175959Z"
+	})).Return(nil)
+	eventRepo.On("CountEvents", ctx, mock.Anything).Return(2, nil)
+	eventRepo.On("ListEvents", ctx, mock.Anything).Return([]Event{
+		{ID: 40, EventDatetime: occurrence},
+		{ID: 41, EventDatetime: later},
+	}, nil)
+	eventService.On("UpdateEvent", ctx, 40, mock.MatchedBy(func(req UpdateEventRequest) bool {
+		return req.EventDatetime.Equal(moved)
+	})).Return(nil, nil)
+	eventService.On("UpdateEvent", ctx, 41, mock.MatchedBy(func(req UpdateEventRequest) bool {
+		// 31 March is after the DST change, the local time stays 20:00.
+		return req.EventDatetime.Equal(time.Date(2026, 4, 1, 20, 0, 0, 0, vienna))
+	})).Return(nil, nil)
+
+	service := newTestSeriesService(seriesRepo, eventRepo, new(MockVenueRepository), eventService, 180)
+	err = service.UpdateOccurrence(ctx, 3, 40, SeriesScopeFollowing, UpdateEventRequest{EventDatetime: &moved})
+
+	require.NoError(t, err)
+	seriesRepo.AssertExpectations(t)
+	eventRepo.AssertExpectations(t)
+	eventService.AssertExpectations(t)
+}
@@ -1,10 +1,29 @@
 package services
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Sport struct {
-	ID   int
-	Name string
+	ID                     int
+	Name                   string
+	DefaultDurationMinutes int
+	// MinRestMinutes is the minimum gap a team needs between two events.
+	MinRestMinutes int
+	// RestConflictPolicy decides whether scheduling a team inside its rest
+	// window is rejected or only warned about.
+	RestConflictPolicy string
+	// DeletedAt is set on soft-deleted sports, which reads only return when
+	// asked to include deleted rows. The same holds for the other entities.
+	DeletedAt *time.Time
+	// Version starts at 1 and grows with every update of the row; writes
+	// can be made conditional on it. The other entities are versioned alike.
+	Version int
+	// CreatedAt and UpdatedAt are kept by the database, as for the other
+	// entities; events only carry their own.
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type Venue struct {
@@ -12,20 +31,59 @@ type Venue struct {
 	Name        string
 	City        string
 	CountryCode string
+	TimeZone    string
+	Address     *string
+	PostalCode  *string
+	Latitude    *float64
+	Longitude   *float64
+	Capacity    *int
+	// DistanceKm is the distance from the searched point, set only by
+	// nearby searches.
+	DistanceKm *float64
+	DeletedAt  *time.Time
+	Version    int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 type Team struct {
 	ID   int
 	Name string
 	City string
+	// SportID, ShortName, Code and Aliases are filled by the team
+	// repository; events only carry ShortName and Code of their teams.
+	SportID   int
+	ShortName *string
+	Code      *string
+	Aliases   []string
+	DeletedAt *time.Time
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type Event struct {
 	ID            int
 	EventDatetime time.Time
+	EndDatetime   time.Time
 	Description   *string
 	HomeScore     *int
 	AwayScore     *int
+	Attendance    *int
+	SeriesID      *int
+	// AllowVenueOverlap lets the event share its venue with overlapping
+	// events, e.g. on different pitches of one ground.
+	AllowVenueOverlap bool
+	// OriginalDatetime is the kickoff before the first reschedule, nil when
+	// the event was never moved.
+	OriginalDatetime *time.Time
+	// Broadcasts lists where the event can be watched; filled by GetEventByID
+	// and ListEvents.
+	Broadcasts []Broadcast
+	DeletedAt  *time.Time
+	Version    int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 
 	Sport    Sport
 	Venue    Venue
@@ -35,25 +93,43 @@ type Event struct {
 
 type ListEventsParams struct {
 	SportID  *int
+	SeriesID *int
+	// DateFrom and DateTo select the events overlapping that range.
 	DateFrom *time.Time
-	Limit    int
-	Offset   int
+	DateTo   *time.Time // exclusive upper bound
+	// StartFrom selects the events kicking off at or after it.
+	StartFrom *time.Time
+	// Near and RadiusKm select the events at venues within RadiusKm of Near.
+	Near     *GeoPoint
+	RadiusKm float64
+	// BroadcastCountry selects the events broadcast in that country.
+	BroadcastCountry *string
+	Limit            int
+	Offset           int
 }
 
 type CreateEventParams struct {
 	EventDatetime time.Time
+	EndDatetime   time.Time
 	Description   *string
 	SportID       int
 	VenueID       *int
 	HomeTeamID    int
 	AwayTeamID    int
+	SeriesID      *int
+	AllowVenueOverlap bool
 }
 
 type ListEventsRequest struct {
 	SportID  *int
+	SeriesID *int
 	DateFrom *time.Time
-	Page     int
-	Limit    int
+	DateTo   *time.Time
+	Near     *GeoPoint
+	RadiusKm float64
+	BroadcastCountry *string
+	Page             int
+	Limit            int
 }
 
 type Pagination struct {
@@ -64,12 +140,18 @@ type Pagination struct {
 }
 
 type EventCreateRequest struct {
-	EventDatetime time.Time `json:"event_datetime" binding:"required"`
+	EventDatetime time.Time `json:"event_datetime"`
+	LocalDatetime *string   `json:"local_datetime"`
+	TimeZone      *string   `json:"time_zone"`
 	Description   *string   `json:"description"`
 	SportID       int       `json:"sport_id" binding:"required"`
 	VenueID       *int      `json:"venue_id"`
 	HomeTeamID    int       `json:"home_team_id" binding:"required"`
 	AwayTeamID    int       `json:"away_team_id" binding:"required"`
+	EndDatetime   *time.Time `json:"end_datetime"`
+	DurationMinutes   *int  `json:"duration_minutes"`
+	AllowVenueOverlap bool  `json:"allow_venue_overlap"`
+	SeriesID      *int      `json:"-"`
 }
 
 type UpdateEventRequest struct {
@@ -81,44 +163,323 @@ type UpdateEventRequest struct {
 	VenueID       *int       `json:"venue_id"`
 	HomeTeamID    *int       `json:"home_team_id"`
 	AwayTeamID    *int       `json:"away_team_id"`
+	EndDatetime   *time.Time `json:"end_datetime"`
+	DurationMinutes   *int   `json:"duration_minutes"`
+	AllowVenueOverlap *bool  `json:"allow_venue_overlap"`
+	Attendance    *int       `json:"attendance"`
+	// AllowOverCapacity accepts an attendance above the venue's capacity.
+	AllowOverCapacity bool   `json:"allow_over_capacity"`
+	Reason        *string    `json:"reason"`
 }
 
 type SportRequest struct {
-	Name string `json:"name" binding:"required"`
+	Name                   string  `json:"name" binding:"required"`
+	DefaultDurationMinutes *int    `json:"default_duration_minutes"`
+	MinRestMinutes         *int    `json:"min_rest_minutes"`
+	RestConflictPolicy     *string `json:"rest_conflict_policy"`
 }
 
 type VenueRequest struct {
 	Name string
 	City string
 	CountryCode string
+	TimeZone string
+	Address *string
+	PostalCode *string
+	Latitude *float64
+	Longitude *float64
+	Capacity *int
 }
 
 type CreateVenueRequest struct {
-	Name        string `json:"name" binding:"required"`
-	City        string `json:"city" binding:"required"`
-	CountryCode string `json:"country_code" binding:"required"`
+	Name        string   `json:"name" binding:"required"`
+	City        string   `json:"city" binding:"required"`
+	CountryCode string   `json:"country_code" binding:"required"`
+	TimeZone    string   `json:"time_zone"`
+	Address     *string  `json:"address"`
+	PostalCode  *string  `json:"postal_code"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	Capacity    *int     `json:"capacity"`
 }
 
 type UpdateVenueRequest struct {
-	Name        *string `json:"name"`
-	City        *string `json:"city"`
-	CountryCode *string `json:"country_code"`
+	Name        *string  `json:"name"`
+	City        *string  `json:"city"`
+	CountryCode *string  `json:"country_code"`
+	TimeZone    *string  `json:"time_zone"`
+	Address     *string  `json:"address"`
+	PostalCode  *string  `json:"postal_code"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	Capacity    *int     `json:"capacity"`
+}
+
+// GeoPoint is a WGS84 coordinate in decimal degrees.
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
 }
 
 type TeamRequest struct {
-	Name    string
-	City    string
-	SportID int
+	Name      string
+	City      string
+	SportID   int
+	ShortName *string
+	Code      *string
+	Aliases   []string
 }
 
 type CreateTeamRequest struct {
-	Name    string `json:"name" binding:"required"`
-	City    string `json:"city" binding:"required"`
-	SportID int    `json:"sport_id" binding:"required"`
+	Name      string   `json:"name" binding:"required"`
+	City      string   `json:"city" binding:"required"`
+	SportID   int      `json:"sport_id" binding:"required"`
+	ShortName *string  `json:"short_name"`
+	Code      *string  `json:"code"`
+	Aliases   []string `json:"aliases"`
 }
 
 type UpdateTeamRequest struct {
-	Name    *string `json:"name"`
-	City    *string `json:"city"`
-	SportID *int    `json:"sport_id"`
+	Name      *string   `json:"name"`
+	City      *string   `json:"city"`
+	SportID   *int      `json:"sport_id"`
+	ShortName *string   `json:"short_name"`
+	Code      *string   `json:"code"`
+	// Aliases replaces the team's aliases; an empty list removes them all.
+	Aliases   *[]string `json:"aliases"`
+}
+
+const (
+	EventChangeCreated     = "created"
+	EventChangeRescheduled = "rescheduled"
+	EventChangeCancelled   = "cancelled"
+	EventChangeScored      = "scored"
+)
+
+type EventChange struct {
+	ID            int
+	EventID       int
+	ChangeType    string
+	ChangedAt     time.Time
+	EventDatetime time.Time
+	HomeScore     *int
+	AwayScore     *int
+
+	Sport    Sport
+	HomeTeam Team
+	AwayTeam Team
+}
+
+type ListEventChangesParams struct {
+	ChangeTypes []string
+	SportID     *int
+	TeamID      *int
+	Limit       int
+}
+
+type FeedRequest struct {
+	SportID *int
+	TeamID  *int
+	Limit   int
+}
+
+const (
+	SeriesScopeThis      = "this"
+	SeriesScopeFollowing = "following"
+)
+
+type EventSeries struct {
+	ID                int
+	RRule             string
+	StartDatetime     time.Time
+	TimeZone          string
+	MaterializedUntil *time.Time
+	Description       *string
+	SportID           int
+	VenueID           *int
+	HomeTeamID        int
+	AwayTeamID        int
+	ExceptionDates    []time.Time
+	DeletedAt         *time.Time
+	Version           int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type CreateSeriesRequest struct {
+	RRule          string   `json:"rrule" binding:"required"`
+	LocalDatetime  string   `json:"local_datetime" binding:"required"`
+	TimeZone       *string  `json:"time_zone"`
+	ExceptionDates []string `json:"exception_dates"`
+	Description    *string  `json:"description"`
+	SportID        int      `json:"sport_id" binding:"required"`
+	VenueID        *int     `json:"venue_id"`
+	HomeTeamID     int      `json:"home_team_id" binding:"required"`
+	AwayTeamID     int      `json:"away_team_id" binding:"required"`
+}
+
+type SeriesExceptionRequest struct {
+	Date string `json:"date" binding:"required"`
+}
+
+// EventReschedule is one change of an event's kickoff time and/or venue.
+type EventReschedule struct {
+	ID               int
+	EventID          int
+	PreviousDatetime time.Time
+	NewDatetime      time.Time
+	PreviousVenueID  *int
+	NewVenueID       *int
+	Reason           *string
+	ChangedAt        time.Time
+}
+
+// AttendanceStatsParams selects the events aggregated into AttendanceStats:
+// those at VenueID or involving TeamID that kick off in DateFrom..DateTo.
+type AttendanceStatsParams struct {
+	VenueID  *int
+	TeamID   *int
+	DateFrom *time.Time
+	DateTo   *time.Time // exclusive upper bound
+}
+
+// AttendanceStats aggregates the events with a recorded attendance.
+type AttendanceStats struct {
+	EventCount        int
+	TotalAttendance   int
+	AverageAttendance float64
+	PeakAttendance    int
+	PeakEventID       *int
+	// AverageUtilization is the mean share of venue capacity filled, over
+	// the events at venues with a known capacity.
+	AverageUtilization *float64
+}
+
+// Broadcast is a TV channel or streaming service showing an event in one
+// country.
+type Broadcast struct {
+	ID            int
+	EventID       int
+	Broadcaster   string
+	Channel       *string
+	CountryCode   string
+	URL           *string
+	StartDatetime time.Time
+	Version       int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type CreateBroadcastRequest struct {
+	Broadcaster   string     `json:"broadcaster" binding:"required"`
+	Channel       *string    `json:"channel"`
+	CountryCode   string     `json:"country_code" binding:"required"`
+	URL           *string    `json:"url"`
+	StartDatetime *time.Time `json:"start_datetime"`
+}
+
+type UpdateBroadcastRequest struct {
+	Broadcaster   *string    `json:"broadcaster"`
+	Channel       *string    `json:"channel"`
+	CountryCode   *string    `json:"country_code"`
+	URL           *string    `json:"url"`
+	StartDatetime *time.Time `json:"start_datetime"`
+}
+
+const (
+	SearchHitEvent = "event"
+	SearchHitTeam  = "team"
+	SearchHitVenue = "venue"
+)
+
+type SearchRequest struct {
+	Query string
+	Limit int
+}
+
+// SearchHit is one entity matching a search. Title and Detail are the
+// entity's display text: for events the fixture and description, for teams
+// and venues the name and city.
+type SearchHit struct {
+	Type          string
+	ID            int
+	Title         string
+	Detail        *string
+	EventDatetime *time.Time
+	Rank          float64
+}
+
+type SearchResults struct {
+	Events []SearchHit
+	Teams  []SearchHit
+	Venues []SearchHit
+}
+
+// TeamDuplicateCandidate is a pair of teams of one sport that probably are
+// the same team entered twice. First is the older of the two.
+type TeamDuplicateCandidate struct {
+	First          Team
+	Second         Team
+	NameSimilarity float64
+	CitySimilarity float64
+	Similarity     float64
+}
+
+// VenueDuplicateCandidate is a pair of venues of one country that probably
+// are the same venue entered twice. First is the older of the two.
+type VenueDuplicateCandidate struct {
+	First          Venue
+	Second         Venue
+	NameSimilarity float64
+	CitySimilarity float64
+	Similarity     float64
+}
+
+type MergeRequest struct {
+	DuplicateID int `json:"duplicate_id" binding:"required"`
+}
+
+const (
+	AuditEntityEvent  = "event"
+	AuditEntitySeries = "series"
+	AuditEntitySport  = "sport"
+	AuditEntityTeam   = "team"
+	AuditEntityVenue  = "venue"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionMerge   = "merge"
+	AuditActionPurge   = "purge"
+)
+
+// AuditEntry records one change of an entity: who made it, when, and the
+// values of the changed columns before and after it. Before is null for a
+// creation and After for a hard delete.
+type AuditEntry struct {
+	ID         int
+	EntityType string
+	EntityID   int
+	Action     string
+	Actor      string
+	ChangedAt  time.Time
+	Before     json.RawMessage
+	After      json.RawMessage
+}
+
+// ListAuditEntriesParams filters the audit log; a Limit of 0 returns every
+// matching entry.
+type ListAuditEntriesParams struct {
+	EntityType *string
+	EntityID   *int
+	Limit      int
+}
+
+type AuditRequest struct {
+	EntityType *string
+	EntityID   *int
+	Limit      int
 }
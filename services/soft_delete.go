@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultRetentionDays is how long soft-deleted rows are kept before the
+// purge job removes them for good.
+const DefaultRetentionDays = 30
+
+type includeDeletedKey struct{}
+
+// WithDeleted returns a context under which repository reads also return
+// soft-deleted rows. Reads exclude them by default.
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+// IncludeDeleted reports whether repository reads under ctx return
+// soft-deleted rows.
+func IncludeDeleted(ctx context.Context) bool {
+	include, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return include
+}
+
+type PurgeRepositoryInterface interface {
+	// PurgeDeleted hard-deletes the rows soft-deleted before the cutoff that
+	// no remaining row references.
+	PurgeDeleted(ctx context.Context, before time.Time) (*PurgeResult, error)
+}
+
+// PurgeResult counts the rows removed by a purge, per entity.
+type PurgeResult struct {
+	Events int
+	Series int
+	Teams  int
+	Venues int
+	Sports int
+}
+
+// PurgeService removes soft-deleted rows once they are older than the
+// retention period.
+type PurgeService struct {
+	purgeRepository PurgeRepositoryInterface
+	retention       time.Duration
+}
+
+func NewPurgeService(r PurgeRepositoryInterface, retentionDays int) *PurgeService {
+	if retentionDays <= 0 {
+		retentionDays = DefaultRetentionDays
+	}
+	return &PurgeService{
+		purgeRepository: r,
+		retention:       time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// Purge hard-deletes the rows soft-deleted more than the retention period
+// before now.
+func (s *PurgeService) Purge(ctx context.Context, now time.Time) (*PurgeResult, error) {
+	result, err := s.purgeRepository.PurgeDeleted(ctx, now.Add(-s.retention))
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted rows: %w", err)
+	}
+	return result, nil
+}
+
+// notDeletedError explains that a restore was asked for a row that is not
+// deleted.
+func notDeletedError(entity string, id int) error {
+	return fmt.Errorf("validation error: %s %d is not deleted", entity, id)
+}
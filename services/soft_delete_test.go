@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPurgeRepository struct {
+	mock.Mock
+}
+
+func (m *MockPurgeRepository) PurgeDeleted(ctx context.Context, before time.Time) (*PurgeResult, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PurgeResult), args.Error(1)
+}
+
+func TestWithDeleted(t *testing.T) {
+	assert.False(t, IncludeDeleted(context.Background()))
+	assert.True(t, IncludeDeleted(WithDeleted(context.Background())))
+}
+
+func TestPurgeService_Purge(t *testing.T) {
+	now := time.Date(2026, 5, 31, 3, 0, 0, 0, time.UTC)
+
+	t.Run("purges rows older than the retention period", func(t *testing.T) {
+		mockRepo := new(MockPurgeRepository)
+		service := NewPurgeService(mockRepo, 7)
+		mockRepo.On("PurgeDeleted", mock.Anything, now.AddDate(0, 0, -7)).Return(&PurgeResult{Events: 3, Teams: 1}, nil)
+
+		result, err := service.Purge(context.Background(), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Events)
+		assert.Equal(t, 1, result.Teams)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("defaults the retention period", func(t *testing.T) {
+		mockRepo := new(MockPurgeRepository)
+		service := NewPurgeService(mockRepo, 0)
+		mockRepo.On("PurgeDeleted", mock.Anything, now.AddDate(0, 0, -DefaultRetentionDays)).Return(&PurgeResult{}, nil)
+
+		_, err := service.Purge(context.Background(), now)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockPurgeRepository)
+		service := NewPurgeService(mockRepo, 7)
+		mockRepo.On("PurgeDeleted", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		_, err := service.Purge(context.Background(), now)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to purge deleted rows")
+	})
+}
@@ -8,11 +8,12 @@ import (
 )
 
 type SportRepositoryInterface interface {
-	CreateSport(ctx context.Context, name string) (int, error)
+	CreateSport(ctx context.Context, params SportRequest) (int, error)
 	GetSportById(ctx context.Context, id int) (*Sport, error)
 	ListSports(ctx context.Context) ([]Sport, error)
-	UpdateSport(ctx context.Context, id int, name string) error
+	UpdateSport(ctx context.Context, sport Sport) error
 	DeleteSport(ctx context.Context, id int) error
+	RestoreSport(ctx context.Context, id int) error
 }
 
 type SportServiceInterface interface {
@@ -21,22 +22,35 @@ type SportServiceInterface interface {
 	ListSports(ctx context.Context) ([]Sport, error)
 	UpdateSport(ctx context.Context, id int, req SportRequest) error
 	DeleteSport(ctx context.Context, id int) error
+	RestoreSport(ctx context.Context, id int) (*Sport, error)
 }
 
 type SportService struct {
 	sportRepository SportRepositoryInterface
 	eventRepository EventRepositoryInterface
+	transactor Transactor
 }
 
-func NewSportService(r SportRepositoryInterface, e EventRepositoryInterface) *SportService{
-	return &SportService{sportRepository: r, eventRepository: e}
+func NewSportService(r SportRepositoryInterface, e EventRepositoryInterface, tx Transactor) *SportService{
+	return &SportService{sportRepository: r, eventRepository: e, transactor: tx}
 }
 
 func (s *SportService) CreateSport(ctx context.Context, req SportRequest) (int, error) {
 	if len(req.Name) < 3 {
 		return 0, fmt.Errorf("sport name must be at least 3 characters long")
 	}
-	newID, err := s.sportRepository.CreateSport(ctx, req.Name)
+	durationMinutes, err := sportDurationMinutes(req)
+	if err != nil {
+		return 0, err
+	}
+	req.DefaultDurationMinutes = &durationMinutes
+	minRestMinutes, restConflictPolicy, err := sportRestRules(req)
+	if err != nil {
+		return 0, err
+	}
+	req.MinRestMinutes = &minRestMinutes
+	req.RestConflictPolicy = &restConflictPolicy
+	newID, err := s.sportRepository.CreateSport(ctx, req)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create sport: %w", err)
 	}
@@ -62,32 +76,130 @@ func (s *SportService) ListSports(ctx context.Context) ([]Sport, error) {
 	return sports, nil
 }
 
+// UpdateSport replaces the name of a sport and those scheduling rules the
+// request sets; the rules it leaves out keep their stored values. The sport
+// is read and written in one transaction, so that the rules kept are the
+// current ones.
 func (s *SportService) UpdateSport(ctx context.Context, id int, req SportRequest) error {
 	if len(req.Name) < 3 {
 		return fmt.Errorf("sport name must be at least 3 characters long")
 	}
-	sportToUpdate := Sport{
-		ID: id,
-		Name: req.Name,
-	}
-	err := s.sportRepository.UpdateSport(ctx, sportToUpdate.ID, sportToUpdate.Name)
-	if err != nil {
-		return fmt.Errorf("failed to update sport: %w", err)
-	}
-	return nil
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		existingSport, err := repos.Sports.GetSportById(ctx, id)
+		if err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+		if err := checkVersion(ctx, AuditEntitySport, id, existingSport.Version); err != nil {
+			return err
+		}
+		if req.DefaultDurationMinutes == nil {
+			req.DefaultDurationMinutes = &existingSport.DefaultDurationMinutes
+		}
+		if req.MinRestMinutes == nil {
+			req.MinRestMinutes = &existingSport.MinRestMinutes
+		}
+		if req.RestConflictPolicy == nil {
+			req.RestConflictPolicy = &existingSport.RestConflictPolicy
+		}
+		durationMinutes, err := sportDurationMinutes(req)
+		if err != nil {
+			return err
+		}
+		minRestMinutes, restConflictPolicy, err := sportRestRules(req)
+		if err != nil {
+			return err
+		}
+		expectedVersion, _ := ExpectedVersion(ctx)
+		sportToUpdate := Sport{
+			ID: id,
+			Name: req.Name,
+			DefaultDurationMinutes: durationMinutes,
+			MinRestMinutes: minRestMinutes,
+			RestConflictPolicy: restConflictPolicy,
+			Version: expectedVersion,
+		}
+		err = repos.Sports.UpdateSport(ctx, sportToUpdate)
+		if err != nil {
+			return fmt.Errorf("failed to update sport: %w", err)
+		}
+		return nil
+	})
 }
 
+// DeleteSport deletes a sport no event uses, checking and deleting in one
+// transaction so that no event can take the sport up in between.
 func (s *SportService) DeleteSport(ctx context.Context, id int) error {
-	count, err := s.eventRepository.CountEventsBySportID(ctx, id)
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		if _, ok := ExpectedVersion(ctx); ok {
+			sport, err := repos.Sports.GetSportById(ctx, id)
+			if err != nil {
+				return fmt.Errorf("database error: %w", err)
+			}
+			if err := checkVersion(ctx, AuditEntitySport, id, sport.Version); err != nil {
+				return err
+			}
+		}
+		count, err := repos.Events.CountEventsBySportID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check event usage: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("cannot delete sport: it is currently used by %d events", count)
+		}
+		count, err = repos.Teams.CountTeamsBySportID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check team usage: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("cannot delete sport: it is currently used by %d teams", count)
+		}
+		err = repos.Sports.DeleteSport(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete sport: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *SportService) RestoreSport(ctx context.Context, id int) (*Sport, error) {
+	sport, err := s.sportRepository.GetSportById(WithDeleted(ctx), id)
 	if err != nil {
-		return fmt.Errorf("failed to check event usage: %w", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
-	if count > 0 {
-		return fmt.Errorf("cannot delete sport: it is currently used by %d events", count)
+	if sport.DeletedAt == nil {
+		return nil, notDeletedError("sport", id)
 	}
-	err = s.sportRepository.DeleteSport(ctx, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete sport: %w", err)
+	if err := s.sportRepository.RestoreSport(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
 	}
-	return nil
-}
\ No newline at end of file
+	sport.DeletedAt = nil
+	sport.Version++
+	return sport, nil
+}
+
+func sportDurationMinutes(req SportRequest) (int, error) {
+	if req.DefaultDurationMinutes == nil {
+		return DefaultEventDurationMinutes, nil
+	}
+	if err := validateDurationMinutes(*req.DefaultDurationMinutes); err != nil {
+		return 0, err
+	}
+	return *req.DefaultDurationMinutes, nil
+}
+
+// sportRestRules returns the rest window and conflict policy of a sport
+// request, defaulting to no minimum rest and rejecting overlaps.
+func sportRestRules(req SportRequest) (int, string, error) {
+	minRestMinutes := 0
+	restConflictPolicy := RestPolicyReject
+	if req.MinRestMinutes != nil {
+		minRestMinutes = *req.MinRestMinutes
+	}
+	if req.RestConflictPolicy != nil {
+		restConflictPolicy = *req.RestConflictPolicy
+	}
+	if err := validateRestRules(minRestMinutes, restConflictPolicy); err != nil {
+		return 0, "", err
+	}
+	return minRestMinutes, restConflictPolicy, nil
+}
@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockEventRepositoryForSport is a mock for EventRepositoryInterface used in SportService tests
@@ -66,13 +68,42 @@ func (m *MockEventRepositoryForSport) DeleteEvent(ctx context.Context, id int) e
 	return args.Error(0)
 }
 
+func (m *MockEventRepositoryForSport) RestoreEvent(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockEventRepositoryForSport) ListVenueConflicts(ctx context.Context, venueID int, start, end time.Time, excludeEventID int) ([]Event, error) {
+	args := m.Called(ctx, venueID, start, end, excludeEventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Event), args.Error(1)
+}
+
+func (m *MockEventRepositoryForSport) ListTeamEvents(ctx context.Context, teamID int, from, to *time.Time) ([]Event, error) {
+	args := m.Called(ctx, teamID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Event), args.Error(1)
+}
+
+func (m *MockEventRepositoryForSport) GetAttendanceStats(ctx context.Context, params AttendanceStatsParams) (*AttendanceStats, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*AttendanceStats), args.Error(1)
+}
+
 // MockSportRepositoryForService is a mock for SportRepositoryInterface
 type MockSportRepositoryForService struct {
 	mock.Mock
 }
 
-func (m *MockSportRepositoryForService) CreateSport(ctx context.Context, name string) (int, error) {
-	args := m.Called(ctx, name)
+func (m *MockSportRepositoryForService) CreateSport(ctx context.Context, params SportRequest) (int, error) {
+	args := m.Called(ctx, params)
 	return args.Int(0), args.Error(1)
 }
 
@@ -92,8 +123,8 @@ func (m *MockSportRepositoryForService) ListSports(ctx context.Context) ([]Sport
 	return args.Get(0).([]Sport), args.Error(1)
 }
 
-func (m *MockSportRepositoryForService) UpdateSport(ctx context.Context, id int, name string) error {
-	args := m.Called(ctx, id, name)
+func (m *MockSportRepositoryForService) UpdateSport(ctx context.Context, sport Sport) error {
+	args := m.Called(ctx, sport)
 	return args.Error(0)
 }
 
@@ -102,6 +133,11 @@ func (m *MockSportRepositoryForService) DeleteSport(ctx context.Context, id int)
 	return args.Error(0)
 }
 
+func (m *MockSportRepositoryForService) RestoreSport(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func TestSportService_CreateSport(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -119,6 +155,22 @@ func TestSportService_CreateSport(t *testing.T) {
 			expectedID:    1,
 			expectedError: false,
 		},
+		{
+			name:          "custom default duration",
+			request:      SportRequest{Name: "Ice Hockey", DefaultDurationMinutes: intPtr(150)},
+			mockID:        2,
+			mockError:     nil,
+			expectedID:    2,
+			expectedError: false,
+		},
+		{
+			name:          "non-positive default duration",
+			request:      SportRequest{Name: "Basketball", DefaultDurationMinutes: intPtr(0)},
+			mockID:        0,
+			mockError:     nil,
+			expectedID:    0,
+			expectedError: true,
+		},
 		{
 			name:          "name too short",
 			request:      SportRequest{Name: "AB"},
@@ -142,10 +194,17 @@ func TestSportService_CreateSport(t *testing.T) {
 			mockRepo := new(MockSportRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForSport)
 
-			service := NewSportService(mockRepo, mockEventRepo)
+			service := newTestSportService(mockRepo, mockEventRepo)
 
 			if !tt.expectedError || tt.name == "database error" {
-				mockRepo.On("CreateSport", mock.Anything, tt.request.Name).Return(tt.mockID, tt.mockError)
+				expectedDuration := DefaultEventDurationMinutes
+				if tt.request.DefaultDurationMinutes != nil {
+					expectedDuration = *tt.request.DefaultDurationMinutes
+				}
+				mockRepo.On("CreateSport", mock.Anything, mock.MatchedBy(func(p SportRequest) bool {
+					return p.Name == tt.request.Name && *p.DefaultDurationMinutes == expectedDuration &&
+						*p.MinRestMinutes == 0 && *p.RestConflictPolicy == RestPolicyReject
+				})).Return(tt.mockID, tt.mockError)
 			}
 
 			result, err := service.CreateSport(context.Background(), tt.request)
@@ -194,7 +253,7 @@ func TestSportService_GetSportByID(t *testing.T) {
 			mockRepo := new(MockSportRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForSport)
 
-			service := NewSportService(mockRepo, mockEventRepo)
+			service := newTestSportService(mockRepo, mockEventRepo)
 
 			mockRepo.On("GetSportById", mock.Anything, tt.sportID).Return(tt.mockSport, tt.mockError)
 
@@ -250,7 +309,7 @@ func TestSportService_ListSports(t *testing.T) {
 			mockRepo := new(MockSportRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForSport)
 
-			service := NewSportService(mockRepo, mockEventRepo)
+			service := newTestSportService(mockRepo, mockEventRepo)
 
 			mockRepo.On("ListSports", mock.Anything).Return(tt.mockSports, tt.mockError)
 
@@ -271,34 +330,90 @@ func TestSportService_ListSports(t *testing.T) {
 }
 
 func TestSportService_UpdateSport(t *testing.T) {
+	storedSport := &Sport{
+		ID:                     1,
+		Name:                   "Football",
+		DefaultDurationMinutes: 105,
+		MinRestMinutes:         1440,
+		RestConflictPolicy:     RestPolicyWarn,
+	}
 	tests := []struct {
-		name          string
-		sportID       int
-		request       SportRequest
-		mockError     error
-		expectedError bool
+		name             string
+		sportID          int
+		request          SportRequest
+		mockGetError     error
+		mockError        error
+		expectedDuration int
+		expectedMinRest  int
+		expectedPolicy   string
+		expectUpdate     bool
+		expectedError    bool
 	}{
 		{
-			name:          "successful update",
-			sportID:       1,
-			request:       SportRequest{Name: "Updated Football"},
-			mockError:     nil,
-			expectedError: false,
+			name:             "omitted rules keep their stored values",
+			sportID:          1,
+			request:          SportRequest{Name: "Updated Football"},
+			expectedDuration: 105,
+			expectedMinRest:  1440,
+			expectedPolicy:   RestPolicyWarn,
+			expectUpdate:     true,
+		},
+		{
+			name:    "rules set by the request replace the stored ones",
+			sportID: 1,
+			request: SportRequest{
+				Name:                   "Updated Football",
+				DefaultDurationMinutes: intPtr(120),
+				MinRestMinutes:         intPtr(2880),
+				RestConflictPolicy:     stringPtr(RestPolicyReject),
+			},
+			expectedDuration: 120,
+			expectedMinRest:  2880,
+			expectedPolicy:   RestPolicyReject,
+			expectUpdate:     true,
+		},
+		{
+			name:    "unknown rest conflict policy",
+			sportID: 1,
+			request: SportRequest{
+				Name:               "Updated Football",
+				RestConflictPolicy: stringPtr("ignore"),
+			},
+			expectedError: true,
+		},
+		{
+			name:    "negative rest window",
+			sportID: 1,
+			request: SportRequest{
+				Name:           "Updated Football",
+				MinRestMinutes: intPtr(-60),
+			},
+			expectedError: true,
 		},
 		{
 			name:          "name too short",
 			sportID:       1,
 			request:       SportRequest{Name: "AB"},
-			mockError:     nil,
 			expectedError: true,
 		},
 		{
-			name:          "database error",
+			name:          "sport not found",
 			sportID:       1,
 			request:       SportRequest{Name: "Updated Football"},
-			mockError:     errors.New("database error"),
+			mockGetError:  sql.ErrNoRows,
 			expectedError: true,
 		},
+		{
+			name:             "database error",
+			sportID:          1,
+			request:          SportRequest{Name: "Updated Football"},
+			mockError:        errors.New("database error"),
+			expectedDuration: 105,
+			expectedMinRest:  1440,
+			expectedPolicy:   RestPolicyWarn,
+			expectUpdate:     true,
+			expectedError:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -306,10 +421,22 @@ func TestSportService_UpdateSport(t *testing.T) {
 			mockRepo := new(MockSportRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForSport)
 
-			service := NewSportService(mockRepo, mockEventRepo)
+			service := newTestSportService(mockRepo, mockEventRepo)
 
-			if !tt.expectedError || tt.name == "database error" {
-				mockRepo.On("UpdateSport", mock.Anything, tt.sportID, tt.request.Name).Return(tt.mockError)
+			if tt.mockGetError != nil {
+				mockRepo.On("GetSportById", mock.Anything, tt.sportID).Return(nil, tt.mockGetError)
+			} else {
+				stored := *storedSport
+				mockRepo.On("GetSportById", mock.Anything, tt.sportID).Return(&stored, nil).Maybe()
+			}
+			if tt.expectUpdate {
+				mockRepo.On("UpdateSport", mock.Anything, Sport{
+					ID:                     tt.sportID,
+					Name:                   tt.request.Name,
+					DefaultDurationMinutes: tt.expectedDuration,
+					MinRestMinutes:         tt.expectedMinRest,
+					RestConflictPolicy:     tt.expectedPolicy,
+				}).Return(tt.mockError)
 			}
 
 			err := service.UpdateSport(context.Background(), tt.sportID, tt.request)
@@ -319,10 +446,7 @@ func TestSportService_UpdateSport(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 			}
-
-			if !tt.expectedError || tt.name == "database error" {
-				mockRepo.AssertExpectations(t)
-			}
+			mockRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -332,6 +456,7 @@ func TestSportService_DeleteSport(t *testing.T) {
 		name          string
 		sportID       int
 		eventCount    int
+		teamCount     int
 		countError    error
 		deleteError   error
 		expectedError bool
@@ -352,6 +477,15 @@ func TestSportService_DeleteSport(t *testing.T) {
 			deleteError:   nil,
 			expectedError: true,
 		},
+		{
+			name:          "sport used by teams",
+			sportID:       1,
+			eventCount:    0,
+			teamCount:     2,
+			countError:    nil,
+			deleteError:   nil,
+			expectedError: true,
+		},
 		{
 			name:          "count error",
 			sportID:       1,
@@ -366,12 +500,17 @@ func TestSportService_DeleteSport(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockSportRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForSport)
+			mockTeamRepo := new(MockTeamRepositoryForService)
 
-			service := NewSportService(mockRepo, mockEventRepo)
+			service := NewSportService(mockRepo, mockEventRepo,
+				noTx{repos: Repositories{Sports: mockRepo, Events: mockEventRepo, Teams: mockTeamRepo}})
 
 			mockEventRepo.On("CountEventsBySportID", mock.Anything, tt.sportID).Return(tt.eventCount, tt.countError)
 
 			if tt.countError == nil && tt.eventCount == 0 {
+				mockTeamRepo.On("CountTeamsBySportID", mock.Anything, tt.sportID).Return(tt.teamCount, nil)
+			}
+			if tt.countError == nil && tt.eventCount == 0 && tt.teamCount == 0 {
 				mockRepo.On("DeleteSport", mock.Anything, tt.sportID).Return(tt.deleteError)
 			}
 
@@ -384,10 +523,59 @@ func TestSportService_DeleteSport(t *testing.T) {
 			}
 
 			mockEventRepo.AssertExpectations(t)
-			if tt.countError == nil && tt.eventCount == 0 {
-				mockRepo.AssertExpectations(t)
-			}
+			mockTeamRepo.AssertExpectations(t)
+			mockRepo.AssertExpectations(t)
 		})
 	}
 }
 
+func TestSportService_RestoreSport(t *testing.T) {
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		mockSport     *Sport
+		mockGetError  error
+		expectRestore bool
+		expectedError string
+	}{
+		{
+			name:          "successful restore",
+			mockSport:     &Sport{ID: 1, Name: "Football", DeletedAt: &deletedAt},
+			expectRestore: true,
+		},
+		{
+			name:          "sport not deleted",
+			mockSport:     &Sport{ID: 1, Name: "Football"},
+			expectedError: "validation error: sport 1 is not deleted",
+		},
+		{
+			name:          "sport not found",
+			mockGetError:  sql.ErrNoRows,
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSportRepositoryForService)
+			service := newTestSportService(mockRepo, new(MockEventRepositoryForSport))
+
+			mockRepo.On("GetSportById", mock.MatchedBy(IncludeDeleted), 1).Return(tt.mockSport, tt.mockGetError)
+			if tt.expectRestore {
+				mockRepo.On("RestoreSport", mock.Anything, 1).Return(nil)
+			}
+
+			sport, err := service.RestoreSport(context.Background(), 1)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				mockRepo.AssertNotCalled(t, "RestoreSport", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Nil(t, sport.DeletedAt)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -3,31 +3,55 @@ package services
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 )
 
 type TeamRepositoryInterface interface{
 	CreateTeam(ctx context.Context, params TeamRequest) (int, error)
 	GetTeamByID(ctx context.Context, id int) (*Team, error)
 	ListTeams(ctx context.Context) ([]Team, error)
+	CountTeamsBySportID(ctx context.Context, sportID int) (int, error)
+	// FindTeamsByName matches name against the name, short name, code and
+	// aliases of the teams, ignoring case and accents.
+	FindTeamsByName(ctx context.Context, name string, sportID *int) ([]Team, error)
 	UpdateTeam(ctx context.Context, team Team) error
 	DeleteTeam(ctx context.Context, id int) error
+	RestoreTeam(ctx context.Context, id int) error
+	// MergeTeams re-points everything referencing the duplicate team to the
+	// survivor, deletes the duplicate and saves the survivor, in one
+	// transaction.
+	MergeTeams(ctx context.Context, survivor Team, duplicateID int) error
 }
 
 type TeamServiceInterface interface{
 	CreateTeam(ctx context.Context, req CreateTeamRequest) (int, error)
 	GetTeamByID(ctx context.Context, id int) (*Team, error)
 	ListTeams(ctx context.Context) ([]Team, error)
+	FindTeamsByName(ctx context.Context, name string, sportID *int) ([]Team, error)
 	UpdateTeam(ctx context.Context, id int, req UpdateTeamRequest) error
 	DeleteTeam(ctx context.Context, id int) error
+	RestoreTeam(ctx context.Context, id int) (*Team, error)
+	ListConflicts(ctx context.Context, id int) ([]TeamConflict, error)
+	GetAttendanceStats(ctx context.Context, id int, from, to *time.Time) (*AttendanceStats, error)
+	ListDuplicateCandidates(ctx context.Context, minSimilarity float64) ([]TeamDuplicateCandidate, error)
+	MergeTeams(ctx context.Context, survivorID, duplicateID int) (*Team, error)
 }
 
 type TeamService struct{
 	teamRepository TeamRepositoryInterface
 	eventRepository EventRepositoryInterface
+	transactor Transactor
 }
 
-func NewTeamService (t TeamRepositoryInterface, e EventRepositoryInterface) *TeamService{
-	return &TeamService{teamRepository: t, eventRepository: e}
+func NewTeamService (t TeamRepositoryInterface, e EventRepositoryInterface, tx Transactor) *TeamService{
+	return &TeamService{teamRepository: t, eventRepository: e, transactor: tx}
+}
+
+// withRepositories returns a copy of the service working on repos.
+func (s *TeamService) withRepositories(repos Repositories) *TeamService {
+	return &TeamService{teamRepository: repos.Teams, eventRepository: repos.Events, transactor: s.transactor}
 }
 
 func (s *TeamService) CreateTeam(ctx context.Context, req CreateTeamRequest) (int, error) {
@@ -36,7 +60,25 @@ func (s *TeamService) CreateTeam(ctx context.Context, req CreateTeamRequest) (in
 	} else if len (req.City) < 3 {
 		return 0, fmt.Errorf("team city name must be at least 3 characters long")
 	}
-	params := TeamRequest(req)
+	team := Team{
+		Name:      req.Name,
+		City:      req.City,
+		SportID:   req.SportID,
+		ShortName: req.ShortName,
+		Code:      req.Code,
+		Aliases:   req.Aliases,
+	}
+	if err := s.validateTeamIdentifiers(ctx, &team); err != nil {
+		return 0, err
+	}
+	params := TeamRequest{
+		Name:      team.Name,
+		City:      team.City,
+		SportID:   team.SportID,
+		ShortName: team.ShortName,
+		Code:      team.Code,
+		Aliases:   team.Aliases,
+	}
 	newID, err := s.teamRepository.CreateTeam(ctx, params)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create team: %w", err)
@@ -60,11 +102,36 @@ func (s *TeamService) ListTeams(ctx context.Context) ([]Team, error) {
 	return teams, nil
 }
 
+// FindTeamsByName looks teams up by any of their names, optionally within
+// one sport.
+func (s *TeamService) FindTeamsByName(ctx context.Context, name string, sportID *int) ([]Team, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("validation error: name must not be empty")
+	}
+	teams, err := s.teamRepository.FindTeamsByName(ctx, name, sportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find teams: %w", err)
+	}
+	return teams, nil
+}
+
+// UpdateTeam applies a partial update. Reading the team, checking that its
+// names stay unique and saving it run in one transaction.
 func (s *TeamService) UpdateTeam(ctx context.Context, id int, req UpdateTeamRequest) error {
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		return s.withRepositories(repos).updateTeam(ctx, id, req)
+	})
+}
+
+func (s *TeamService) updateTeam(ctx context.Context, id int, req UpdateTeamRequest) error {
 	existingTeam, err := s.teamRepository.GetTeamByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("database error: %w", err)
 	}
+	if err := checkVersion(ctx, AuditEntityTeam, id, existingTeam.Version); err != nil {
+		return err
+	}
 	if req.Name != nil {
 		if len(*req.Name) < 3 {
 			return fmt.Errorf("team name must be at least 3 characters long")
@@ -77,6 +144,18 @@ func (s *TeamService) UpdateTeam(ctx context.Context, id int, req UpdateTeamRequ
 		}
 		existingTeam.City = *req.City
 	}
+	if req.ShortName != nil {
+		existingTeam.ShortName = req.ShortName
+	}
+	if req.Code != nil {
+		existingTeam.Code = req.Code
+	}
+	if req.Aliases != nil {
+		existingTeam.Aliases = *req.Aliases
+	}
+	if err := s.validateTeamIdentifiers(ctx, existingTeam); err != nil {
+		return err
+	}
 	err = s.teamRepository.UpdateTeam(ctx, *existingTeam)
 	if err != nil {
 		return fmt.Errorf("failed to update team: %w", err)
@@ -84,17 +163,222 @@ func (s *TeamService) UpdateTeam(ctx context.Context, id int, req UpdateTeamRequ
 	return nil
 }
 
+// DeleteTeam deletes a team that plays in no event, atomically with the
+// check.
 func (s *TeamService) DeleteTeam(ctx context.Context, id int) error {
-	count, err := s.eventRepository.CountEventsByTeamID(ctx, id)
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		if _, ok := ExpectedVersion(ctx); ok {
+			team, err := repos.Teams.GetTeamByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("database error: %w", err)
+			}
+			if err := checkVersion(ctx, AuditEntityTeam, id, team.Version); err != nil {
+				return err
+			}
+		}
+		count, err := repos.Events.CountEventsByTeamID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check event usage: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("cannot delete team: it is currently used by %d events", count)
+		}
+		err = repos.Teams.DeleteTeam(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete team: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *TeamService) RestoreTeam(ctx context.Context, id int) (*Team, error) {
+	team, err := s.teamRepository.GetTeamByID(WithDeleted(ctx), id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if team.DeletedAt == nil {
+		return nil, notDeletedError("team", id)
+	}
+	if err := s.teamRepository.RestoreTeam(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	team.DeletedAt = nil
+	team.Version++
+	return team, nil
+}
+
+// ListConflicts returns the pairs of events of a team scheduled closer
+// together than their sports' minimum rest period.
+func (s *TeamService) ListConflicts(ctx context.Context, id int) ([]TeamConflict, error) {
+	if _, err := s.teamRepository.GetTeamByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	events, err := s.eventRepository.ListTeamEvents(ctx, id, nil, nil)
 	if err != nil {
-		return fmt.Errorf("failed to check event usage: %w", err)
+		return nil, fmt.Errorf("failed to list team events: %w", err)
 	}
-	if count > 0 {
-		return fmt.Errorf("cannot delete team: it is currently used by %d events", count)
+	return findRestConflicts(id, events), nil
+}
+
+// GetAttendanceStats aggregates the recorded attendance of the team's home
+// and away events kicking off in from..to.
+func (s *TeamService) GetAttendanceStats(ctx context.Context, id int, from, to *time.Time) (*AttendanceStats, error) {
+	if _, err := s.teamRepository.GetTeamByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
 	}
-	err = s.teamRepository.DeleteTeam(ctx, id)
+	return attendanceStats(ctx, s.eventRepository, AttendanceStatsParams{TeamID: &id, DateFrom: from, DateTo: to})
+}
+
+// ListDuplicateCandidates returns the pairs of teams of a sport whose names
+// and cities are at least minSimilarity alike (0 means the default), most
+// similar first.
+func (s *TeamService) ListDuplicateCandidates(ctx context.Context, minSimilarity float64) ([]TeamDuplicateCandidate, error) {
+	minSimilarity, err := validateMinSimilarity(minSimilarity)
 	if err != nil {
-		return fmt.Errorf("failed to delete team: %w", err)
+		return nil, err
+	}
+	teams, err := s.teamRepository.ListTeams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	return findTeamDuplicates(teams, minSimilarity), nil
+}
+
+// MergeTeams moves the events and series of the duplicate team to the
+// survivor and deletes the duplicate. The names of the duplicate become
+// aliases of the survivor, which takes over its short name and code when it
+// has none of its own.
+//
+// The checks and the merge run in one transaction, so that no event between
+// the two teams can be scheduled in between.
+func (s *TeamService) MergeTeams(ctx context.Context, survivorID, duplicateID int) (*Team, error) {
+	if survivorID == duplicateID {
+		return nil, fmt.Errorf("validation error: a team cannot be merged into itself")
+	}
+	var survivor *Team
+	err := s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		var err error
+		survivor, err = s.withRepositories(repos).mergeTeams(ctx, survivorID, duplicateID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return survivor, nil
+}
+
+func (s *TeamService) mergeTeams(ctx context.Context, survivorID, duplicateID int) (*Team, error) {
+	survivor, err := s.teamRepository.GetTeamByID(ctx, survivorID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if err := checkVersion(ctx, AuditEntityTeam, survivorID, survivor.Version); err != nil {
+		return nil, err
+	}
+	duplicate, err := s.teamRepository.GetTeamByID(ctx, duplicateID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if survivor.SportID != duplicate.SportID {
+		return nil, fmt.Errorf("validation error: teams %d and %d belong to different sports", survivorID, duplicateID)
+	}
+	// Deleted events are repointed too and must not clash either; series are
+	// checked by the repository as it repoints them.
+	events, err := s.eventRepository.ListTeamEvents(WithDeleted(ctx), duplicateID, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team events: %w", err)
+	}
+	clash := &TeamClashError{SurvivorID: survivorID, DuplicateID: duplicateID}
+	for _, event := range events {
+		if event.HomeTeam.ID == survivorID || event.AwayTeam.ID == survivorID {
+			clash.EventIDs = append(clash.EventIDs, event.ID)
+		}
+	}
+	if len(clash.EventIDs) > 0 {
+		return nil, clash
+	}
+	if survivor.ShortName == nil {
+		survivor.ShortName = duplicate.ShortName
+	}
+	if survivor.Code == nil {
+		survivor.Code = duplicate.Code
+	}
+	primaryNames := []string{survivor.Name}
+	if survivor.ShortName != nil {
+		primaryNames = append(primaryNames, *survivor.ShortName)
+	}
+	survivor.Aliases = mergeNames(survivor.Aliases, primaryNames, teamNames(*duplicate)...)
+	if err := s.validateTeamIdentifiers(ctx, survivor, duplicateID); err != nil {
+		return nil, err
+	}
+	if err := s.teamRepository.MergeTeams(ctx, *survivor, duplicateID); err != nil {
+		return nil, fmt.Errorf("failed to merge teams: %w", err)
+	}
+	return survivor, nil
+}
+
+// validateTeamIdentifiers normalizes the short name, code and aliases of a
+// team and checks that none of its names is used by another team of the same
+// sport, apart from the teams being merged into it. Empty values clear the
+// short name and code.
+func (s *TeamService) validateTeamIdentifiers(ctx context.Context, team *Team, mergedIDs ...int) error {
+	if team.ShortName != nil {
+		team.ShortName = emptyToNil(strings.TrimSpace(*team.ShortName))
+	}
+	if team.ShortName != nil && (len(*team.ShortName) < 2 || len(*team.ShortName) > 50) {
+		return fmt.Errorf("validation error: short name must be between 2 and 50 characters long")
+	}
+	if team.Code != nil {
+		team.Code = emptyToNil(strings.ToUpper(strings.TrimSpace(*team.Code)))
+	}
+	if team.Code != nil && !isTeamCode(*team.Code) {
+		return fmt.Errorf("validation error: code must be three letters")
+	}
+	aliases := make([]string, 0, len(team.Aliases))
+	seen := map[string]bool{strings.ToLower(team.Name): true}
+	for _, alias := range team.Aliases {
+		alias = strings.TrimSpace(alias)
+		if len(alias) < 2 || len(alias) > 100 {
+			return fmt.Errorf("validation error: aliases must be between 2 and 100 characters long")
+		}
+		if seen[strings.ToLower(alias)] {
+			continue
+		}
+		seen[strings.ToLower(alias)] = true
+		aliases = append(aliases, alias)
+	}
+	team.Aliases = aliases
+
+	names := append([]string{team.Name}, team.Aliases...)
+	if team.ShortName != nil {
+		names = append(names, *team.ShortName)
+	}
+	if team.Code != nil {
+		names = append(names, *team.Code)
+	}
+	// Deleted teams keep their names until they are purged.
+	for _, name := range names {
+		matches, err := s.teamRepository.FindTeamsByName(WithDeleted(ctx), name, &team.SportID)
+		if err != nil {
+			return fmt.Errorf("failed to check team names: %w", err)
+		}
+		for _, match := range matches {
+			if match.ID != team.ID && !slices.Contains(mergedIDs, match.ID) {
+				return fmt.Errorf("validation error: %q is already used by team %d", name, match.ID)
+			}
+		}
 	}
 	return nil
 }
+
+func isTeamCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockTeamRepositoryForService is a mock for TeamRepositoryInterface
@@ -36,6 +38,19 @@ func (m *MockTeamRepositoryForService) ListTeams(ctx context.Context) ([]Team, e
 	return args.Get(0).([]Team), args.Error(1)
 }
 
+func (m *MockTeamRepositoryForService) CountTeamsBySportID(ctx context.Context, sportID int) (int, error) {
+	args := m.Called(ctx, sportID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTeamRepositoryForService) FindTeamsByName(ctx context.Context, name string, sportID *int) ([]Team, error) {
+	args := m.Called(ctx, name, sportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Team), args.Error(1)
+}
+
 func (m *MockTeamRepositoryForService) UpdateTeam(ctx context.Context, team Team) error {
 	args := m.Called(ctx, team)
 	return args.Error(0)
@@ -46,6 +61,16 @@ func (m *MockTeamRepositoryForService) DeleteTeam(ctx context.Context, id int) e
 	return args.Error(0)
 }
 
+func (m *MockTeamRepositoryForService) RestoreTeam(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepositoryForService) MergeTeams(ctx context.Context, survivor Team, duplicateID int) error {
+	args := m.Called(ctx, survivor, duplicateID)
+	return args.Error(0)
+}
+
 // MockEventRepositoryForTeam is a mock for EventRepositoryInterface used in TeamService tests
 type MockEventRepositoryForTeam struct {
 	mock.Mock
@@ -102,6 +127,35 @@ func (m *MockEventRepositoryForTeam) DeleteEvent(ctx context.Context, id int) er
 	return args.Error(0)
 }
 
+func (m *MockEventRepositoryForTeam) RestoreEvent(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockEventRepositoryForTeam) ListVenueConflicts(ctx context.Context, venueID int, start, end time.Time, excludeEventID int) ([]Event, error) {
+	args := m.Called(ctx, venueID, start, end, excludeEventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Event), args.Error(1)
+}
+
+func (m *MockEventRepositoryForTeam) ListTeamEvents(ctx context.Context, teamID int, from, to *time.Time) ([]Event, error) {
+	args := m.Called(ctx, teamID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Event), args.Error(1)
+}
+
+func (m *MockEventRepositoryForTeam) GetAttendanceStats(ctx context.Context, params AttendanceStatsParams) (*AttendanceStats, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*AttendanceStats), args.Error(1)
+}
+
 func TestTeamService_CreateTeam(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -150,8 +204,9 @@ func TestTeamService_CreateTeam(t *testing.T) {
 			mockRepo := new(MockTeamRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForTeam)
 
-			service := NewTeamService(mockRepo, mockEventRepo)
+			service := newTestTeamService(mockRepo, mockEventRepo)
 
+			mockRepo.On("FindTeamsByName", mock.Anything, mock.Anything, mock.Anything).Return([]Team{}, nil).Maybe()
 			if !tt.expectedError || tt.name == "database error" {
 				mockRepo.On("CreateTeam", mock.Anything, mock.AnythingOfType("TeamRequest")).Return(tt.mockID, tt.mockError)
 			}
@@ -202,7 +257,7 @@ func TestTeamService_GetTeamByID(t *testing.T) {
 			mockRepo := new(MockTeamRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForTeam)
 
-			service := NewTeamService(mockRepo, mockEventRepo)
+			service := newTestTeamService(mockRepo, mockEventRepo)
 
 			mockRepo.On("GetTeamByID", mock.Anything, tt.teamID).Return(tt.mockTeam, tt.mockError)
 
@@ -258,7 +313,7 @@ func TestTeamService_ListTeams(t *testing.T) {
 			mockRepo := new(MockTeamRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForTeam)
 
-			service := NewTeamService(mockRepo, mockEventRepo)
+			service := newTestTeamService(mockRepo, mockEventRepo)
 
 			mockRepo.On("ListTeams", mock.Anything).Return(tt.mockTeams, tt.mockError)
 
@@ -332,9 +387,10 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 			mockRepo := new(MockTeamRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForTeam)
 
-			service := NewTeamService(mockRepo, mockEventRepo)
+			service := newTestTeamService(mockRepo, mockEventRepo)
 
 			mockRepo.On("GetTeamByID", mock.Anything, tt.teamID).Return(tt.mockTeam, tt.mockError)
+			mockRepo.On("FindTeamsByName", mock.Anything, mock.Anything, mock.Anything).Return([]Team{}, nil).Maybe()
 
 			if tt.mockTeam != nil && !tt.expectedError {
 				mockRepo.On("UpdateTeam", mock.Anything, mock.AnythingOfType("Team")).Return(nil)
@@ -353,6 +409,143 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 	}
 }
 
+func TestTeamService_CreateTeam_Identifiers(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       CreateTeamRequest
+		nameOwners    map[string]int
+		expected      *TeamRequest
+		expectedError string
+	}{
+		{
+			name: "normalizes short name, code and aliases",
+			request: CreateTeamRequest{Name: "Manchester City", City: "Manchester", SportID: 1,
+				ShortName: stringPtr(" Man City "), Code: stringPtr("mci"),
+				Aliases: []string{"Man City FC", "man city fc", " Manchester City ", "City"}},
+			expected: &TeamRequest{Name: "Manchester City", City: "Manchester", SportID: 1,
+				ShortName: stringPtr("Man City"), Code: stringPtr("MCI"), Aliases: []string{"Man City FC", "City"}},
+		},
+		{
+			name:     "empty short name and code are cleared",
+			request:  CreateTeamRequest{Name: "Lakers", City: "Los Angeles", SportID: 1, ShortName: stringPtr(""), Code: stringPtr(" ")},
+			expected: &TeamRequest{Name: "Lakers", City: "Los Angeles", SportID: 1, Aliases: []string{}},
+		},
+		{
+			name:          "code not three letters",
+			request:       CreateTeamRequest{Name: "Lakers", City: "Los Angeles", SportID: 1, Code: stringPtr("LA1")},
+			expectedError: "code",
+		},
+		{
+			name:          "alias too short",
+			request:       CreateTeamRequest{Name: "Lakers", City: "Los Angeles", SportID: 1, Aliases: []string{"L"}},
+			expectedError: "aliases",
+		},
+		{
+			name:          "alias used by another team",
+			request:       CreateTeamRequest{Name: "Manchester United", City: "Manchester", SportID: 1, Aliases: []string{"Man City"}},
+			nameOwners:    map[string]int{"Man City": 2},
+			expectedError: "already used by team 2",
+		},
+		{
+			name:          "code used by another team",
+			request:       CreateTeamRequest{Name: "Manchester United", City: "Manchester", SportID: 1, Code: stringPtr("MCI")},
+			nameOwners:    map[string]int{"MCI": 2},
+			expectedError: "already used by team 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTeamRepositoryForService)
+			service := newTestTeamService(mockRepo, new(MockEventRepositoryForTeam))
+
+			for name, ownerID := range tt.nameOwners {
+				mockRepo.On("FindTeamsByName", mock.Anything, name, intPtr(1)).Return([]Team{{ID: ownerID}}, nil)
+			}
+			mockRepo.On("FindTeamsByName", mock.Anything, mock.Anything, intPtr(1)).Return([]Team{}, nil).Maybe()
+			if tt.expected != nil {
+				mockRepo.On("CreateTeam", mock.Anything, *tt.expected).Return(5, nil)
+			}
+
+			id, err := service.CreateTeam(context.Background(), tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "validation error")
+				assert.Contains(t, err.Error(), tt.expectedError)
+				mockRepo.AssertNotCalled(t, "CreateTeam", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 5, id)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_UpdateTeam_Aliases(t *testing.T) {
+	t.Run("replaces aliases and keeps own names", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		service := newTestTeamService(mockRepo, new(MockEventRepositoryForTeam))
+		existing := &Team{ID: 2, Name: "Manchester City", City: "Manchester", SportID: 1, Code: stringPtr("MCI"),
+			Aliases: []string{"Man City"}}
+
+		mockRepo.On("GetTeamByID", mock.Anything, 2).Return(existing, nil)
+		mockRepo.On("FindTeamsByName", mock.Anything, mock.Anything, intPtr(1)).Return([]Team{{ID: 2}}, nil)
+		mockRepo.On("UpdateTeam", mock.Anything, mock.MatchedBy(func(team Team) bool {
+			return len(team.Aliases) == 1 && team.Aliases[0] == "City" && *team.Code == "MCI"
+		})).Return(nil)
+
+		err := service.UpdateTeam(context.Background(), 2, UpdateTeamRequest{Aliases: &[]string{"City"}})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("clears aliases", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		service := newTestTeamService(mockRepo, new(MockEventRepositoryForTeam))
+		existing := &Team{ID: 2, Name: "Manchester City", City: "Manchester", SportID: 1, Aliases: []string{"Man City"}}
+
+		mockRepo.On("GetTeamByID", mock.Anything, 2).Return(existing, nil)
+		mockRepo.On("FindTeamsByName", mock.Anything, mock.Anything, intPtr(1)).Return([]Team{}, nil)
+		mockRepo.On("UpdateTeam", mock.Anything, mock.MatchedBy(func(team Team) bool {
+			return len(team.Aliases) == 0
+		})).Return(nil)
+
+		err := service.UpdateTeam(context.Background(), 2, UpdateTeamRequest{Aliases: &[]string{}})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestTeamService_FindTeamsByName(t *testing.T) {
+	t.Run("trims the name", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		service := newTestTeamService(mockRepo, new(MockEventRepositoryForTeam))
+		teams := []Team{{ID: 2, Name: "Manchester City"}}
+
+		mockRepo.On("FindTeamsByName", mock.Anything, "Man City", (*int)(nil)).Return(teams, nil)
+
+		result, err := service.FindTeamsByName(context.Background(), " Man City ", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, teams, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		service := newTestTeamService(mockRepo, new(MockEventRepositoryForTeam))
+
+		_, err := service.FindTeamsByName(context.Background(), "  ", nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+	})
+}
+
 func TestTeamService_DeleteTeam(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -393,7 +586,7 @@ func TestTeamService_DeleteTeam(t *testing.T) {
 			mockRepo := new(MockTeamRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForTeam)
 
-			service := NewTeamService(mockRepo, mockEventRepo)
+			service := newTestTeamService(mockRepo, mockEventRepo)
 
 			mockEventRepo.On("CountEventsByTeamID", mock.Anything, tt.teamID).Return(tt.eventCount, tt.countError)
 
@@ -417,3 +610,206 @@ func TestTeamService_DeleteTeam(t *testing.T) {
 	}
 }
 
+
+func TestTeamService_ListConflicts(t *testing.T) {
+	kickoff := time.Date(2026, 5, 2, 18, 0, 0, 0, time.UTC)
+	football := Sport{ID: 1, MinRestMinutes: 48 * 60}
+	event := func(id int, start time.Time) Event {
+		return Event{ID: id, EventDatetime: start, EndDatetime: start.Add(2 * time.Hour), Sport: football}
+	}
+
+	t.Run("reports events inside the rest window", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		mockEventRepo := new(MockEventRepositoryForTeam)
+		service := newTestTeamService(mockRepo, mockEventRepo)
+		mockRepo.On("GetTeamByID", mock.Anything, 1).Return(&Team{ID: 1}, nil)
+		mockEventRepo.On("ListTeamEvents", mock.Anything, 1, (*time.Time)(nil), (*time.Time)(nil)).Return([]Event{
+			event(10, kickoff),
+			event(11, kickoff.Add(time.Hour)),
+			event(12, kickoff.Add(24*time.Hour)),
+			event(13, kickoff.Add(7*24*time.Hour)),
+		}, nil)
+
+		conflicts, err := service.ListConflicts(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, conflicts, 3)
+		assert.Equal(t, 10, conflicts[0].Event.ID)
+		assert.Equal(t, 11, conflicts[0].ConflictingEvent.ID)
+		assert.True(t, conflicts[0].Overlapping)
+		assert.Equal(t, 12, conflicts[1].ConflictingEvent.ID)
+		assert.Equal(t, 22*60, conflicts[1].GapMinutes)
+		assert.Equal(t, 48*60, conflicts[1].MinRestMinutes)
+		assert.False(t, conflicts[1].Overlapping)
+		assert.Equal(t, 11, conflicts[2].Event.ID)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		mockEventRepo := new(MockEventRepositoryForTeam)
+		service := newTestTeamService(mockRepo, mockEventRepo)
+		mockRepo.On("GetTeamByID", mock.Anything, 99).Return(nil, sql.ErrNoRows)
+
+		conflicts, err := service.ListConflicts(context.Background(), 99)
+
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+		assert.Nil(t, conflicts)
+		mockEventRepo.AssertNotCalled(t, "ListTeamEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTeamService_GetAttendanceStats(t *testing.T) {
+	t.Run("aggregates the team's events", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		mockEventRepo := new(MockEventRepositoryForTeam)
+		service := newTestTeamService(mockRepo, mockEventRepo)
+		stats := &AttendanceStats{EventCount: 1, TotalAttendance: 6800, AverageAttendance: 6800, PeakAttendance: 6800}
+		mockRepo.On("GetTeamByID", mock.Anything, 4).Return(&Team{ID: 4}, nil)
+		mockEventRepo.On("GetAttendanceStats", mock.Anything, mock.MatchedBy(func(p AttendanceStatsParams) bool {
+			return p.TeamID != nil && *p.TeamID == 4 && p.VenueID == nil
+		})).Return(stats, nil)
+
+		result, err := service.GetAttendanceStats(context.Background(), 4, nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, stats, result)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		mockEventRepo := new(MockEventRepositoryForTeam)
+		service := newTestTeamService(mockRepo, mockEventRepo)
+		mockRepo.On("GetTeamByID", mock.Anything, 99).Return(nil, sql.ErrNoRows)
+
+		result, err := service.GetAttendanceStats(context.Background(), 99, nil, nil)
+
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+		assert.Nil(t, result)
+	})
+}
+
+func TestTeamService_ListDuplicateCandidates(t *testing.T) {
+	mockRepo := new(MockTeamRepositoryForService)
+	service := newTestTeamService(mockRepo, new(MockEventRepositoryForTeam))
+	teams := []Team{
+		{ID: 1, Name: "Red Bull Salzburg", City: "Salzburg", SportID: 1},
+		{ID: 2, Name: "FC Red Bull Salzburg", City: "Salzburg", SportID: 1},
+	}
+
+	mockRepo.On("ListTeams", mock.Anything).Return(teams, nil)
+
+	candidates, err := service.ListDuplicateCandidates(context.Background(), 0)
+
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, 1, candidates[0].First.ID)
+	assert.Equal(t, 2, candidates[0].Second.ID)
+
+	_, err = service.ListDuplicateCandidates(context.Background(), -1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation error")
+}
+
+func TestTeamService_MergeTeams(t *testing.T) {
+	survivor := func() *Team {
+		return &Team{ID: 1, Name: "Red Bull Salzburg", City: "Salzburg", SportID: 1, Aliases: []string{"Salzburg"}}
+	}
+	duplicate := func() *Team {
+		return &Team{ID: 2, Name: "FC Red Bull Salzburg", City: "Salzburg", SportID: 1,
+			ShortName: stringPtr("RB Salzburg"), Code: stringPtr("RBS"), Aliases: []string{"salzburg", "Austria Salzburg"}}
+	}
+
+	t.Run("takes over names of the duplicate", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		mockEventRepo := new(MockEventRepositoryForTeam)
+		service := newTestTeamService(mockRepo, mockEventRepo)
+
+		mockRepo.On("GetTeamByID", mock.Anything, 1).Return(survivor(), nil)
+		mockRepo.On("GetTeamByID", mock.Anything, 2).Return(duplicate(), nil)
+		mockEventRepo.On("ListTeamEvents", mock.Anything, 2, (*time.Time)(nil), (*time.Time)(nil)).
+			Return([]Event{{ID: 9, HomeTeam: Team{ID: 2}, AwayTeam: Team{ID: 3}}}, nil)
+		mockRepo.On("FindTeamsByName", mock.Anything, mock.Anything, intPtr(1)).Return([]Team{{ID: 2}}, nil)
+		mockRepo.On("MergeTeams", mock.Anything, mock.MatchedBy(func(team Team) bool {
+			return team.ID == 1 && *team.ShortName == "RB Salzburg" && *team.Code == "RBS" &&
+				assert.ObjectsAreEqual([]string{"Salzburg", "FC Red Bull Salzburg", "Austria Salzburg"}, team.Aliases)
+		}), 2).Return(nil)
+
+		team, err := service.MergeTeams(context.Background(), 1, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Red Bull Salzburg", team.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name          string
+		duplicate     *Team
+		duplicateID   int
+		expectedError string
+	}{
+		{
+			name:          "same team",
+			duplicateID:   1,
+			expectedError: "cannot be merged into itself",
+		},
+		{
+			name:          "different sports",
+			duplicate:     &Team{ID: 2, Name: "Red Bull Salzburg", City: "Salzburg", SportID: 2},
+			duplicateID:   2,
+			expectedError: "different sports",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTeamRepositoryForService)
+			mockEventRepo := new(MockEventRepositoryForTeam)
+			service := newTestTeamService(mockRepo, mockEventRepo)
+
+			mockRepo.On("GetTeamByID", mock.Anything, 1).Return(survivor(), nil).Maybe()
+			if tt.duplicate != nil {
+				mockRepo.On("GetTeamByID", mock.Anything, tt.duplicateID).Return(tt.duplicate, nil)
+			}
+			_, err := service.MergeTeams(context.Background(), 1, tt.duplicateID)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "validation error")
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockRepo.AssertNotCalled(t, "MergeTeams", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("teams play each other", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		mockEventRepo := new(MockEventRepositoryForTeam)
+		service := newTestTeamService(mockRepo, mockEventRepo)
+
+		mockRepo.On("GetTeamByID", mock.Anything, 1).Return(survivor(), nil)
+		mockRepo.On("GetTeamByID", mock.Anything, 2).Return(duplicate(), nil)
+		mockEventRepo.On("ListTeamEvents", mock.MatchedBy(IncludeDeleted), 2, (*time.Time)(nil), (*time.Time)(nil)).
+			Return([]Event{
+				{ID: 9, HomeTeam: Team{ID: 2}, AwayTeam: Team{ID: 1}},
+				{ID: 10, HomeTeam: Team{ID: 2}, AwayTeam: Team{ID: 3}},
+				{ID: 11, HomeTeam: Team{ID: 1}, AwayTeam: Team{ID: 2}, DeletedAt: &time.Time{}},
+			}, nil)
+
+		_, err := service.MergeTeams(context.Background(), 1, 2)
+
+		assert.ErrorIs(t, err, ErrTeamsPlayEachOther)
+		assert.EqualError(t, err, "teams play each other: teams 1 and 2 meet in events 9, 11")
+		mockRepo.AssertNotCalled(t, "MergeTeams", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing duplicate", func(t *testing.T) {
+		mockRepo := new(MockTeamRepositoryForService)
+		service := newTestTeamService(mockRepo, new(MockEventRepositoryForTeam))
+
+		mockRepo.On("GetTeamByID", mock.Anything, 1).Return(survivor(), nil)
+		mockRepo.On("GetTeamByID", mock.Anything, 2).Return(nil, sql.ErrNoRows)
+
+		_, err := service.MergeTeams(context.Background(), 1, 2)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
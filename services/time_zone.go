@@ -0,0 +1,35 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+const DefaultTimeZone = "UTC"
+
+// LoadTimeZone resolves an IANA time zone name such as "Europe/Vienna".
+// An empty name resolves to UTC; the host-dependent "Local" zone is rejected.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("invalid time zone %q", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q", name)
+	}
+	return loc, nil
+}
+
+// parseLocalDatetime reads a wall-clock time without offset, such as
+// "2025-12-10T20:00:00", in the given location.
+func parseLocalDatetime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if localTime, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return localTime, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("validation error: local_datetime must look like 2006-01-02T15:04:05")
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Repositories are the repositories a unit of work runs against, all bound
+// to its transaction.
+type Repositories struct {
+	Events       EventRepositoryInterface
+	Sports       SportRepositoryInterface
+	Teams        TeamRepositoryInterface
+	Venues       VenueRepositoryInterface
+	EventChanges EventChangeRepositoryInterface
+	Reschedules  EventRescheduleRepositoryInterface
+	Series       SeriesRepositoryInterface
+}
+
+// Transactor runs several repository calls atomically, so that what a
+// service reads and validates cannot change before it writes.
+type Transactor interface {
+	// WithinTx runs fn in a transaction, committed when fn returns nil and
+	// rolled back otherwise. When the transaction loses a serialization
+	// conflict fn may run again, so it must not act outside repos.
+	WithinTx(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+// joinedTx is the Transactor of a service bound to a running unit of work:
+// the units of work the service starts join that one instead of opening
+// transactions of their own.
+type joinedTx struct {
+	repos Repositories
+}
+
+func (t joinedTx) WithinTx(ctx context.Context, fn func(repos Repositories) error) error {
+	return fn(t.repos)
+}
+
+type writeTrackerKey struct{}
+
+// TrackWrites returns a context that remembers whether a write was made
+// under it, so that reads after the write can be served by the primary
+// database rather than by a replica that may not have it yet.
+func TrackWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, writeTrackerKey{}, new(atomic.Bool))
+}
+
+// RecordWrite notes that a write was made under ctx. It does nothing when
+// ctx does not track writes.
+func RecordWrite(ctx context.Context) {
+	if written, ok := ctx.Value(writeTrackerKey{}).(*atomic.Bool); ok {
+		written.Store(true)
+	}
+}
+
+// ReadsFromPrimary reports whether reads under ctx have to see its writes,
+// i.e. whether a write was made under it.
+func ReadsFromPrimary(ctx context.Context) bool {
+	written, ok := ctx.Value(writeTrackerKey{}).(*atomic.Bool)
+	return ok && written.Load()
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// noTx runs units of work straight on its repositories, without a
+// transaction, counting how many it ran.
+type noTx struct {
+	repos Repositories
+	units *int
+}
+
+func (t noTx) WithinTx(ctx context.Context, fn func(repos Repositories) error) error {
+	if t.units != nil {
+		*t.units++
+	}
+	return fn(t.repos)
+}
+
+func newTestSportService(r SportRepositoryInterface, e EventRepositoryInterface) *SportService {
+	return NewSportService(r, e, noTx{repos: Repositories{Sports: r, Events: e}})
+}
+
+func newTestVenueService(v VenueRepositoryInterface, e EventRepositoryInterface) *VenueService {
+	return NewVenueService(v, e, noTx{repos: Repositories{Venues: v, Events: e}})
+}
+
+func newTestTeamService(t TeamRepositoryInterface, e EventRepositoryInterface) *TeamService {
+	return NewTeamService(t, e, noTx{repos: Repositories{Teams: t, Events: e}})
+}
+
+func newTestSeriesService(sr SeriesRepositoryInterface, er EventRepositoryInterface, vr VenueRepositoryInterface,
+	es EventServiceInterface, horizonDays int) *SeriesService {
+	return NewSeriesService(sr, er, vr, es, horizonDays, noTx{repos: Repositories{Series: sr, Events: er, Venues: vr}})
+}
+
+func newTestEventService(r EventRepositoryInterface, s SportRepositoryInterface, t TeamRepositoryInterface,
+	v VenueRepositoryInterface, c EventChangeRepositoryInterface, rr EventRescheduleRepositoryInterface) *EventService {
+	return NewEventService(r, 1, 10, s, t, v, c, rr, noTx{repos: Repositories{
+		Events: r, Sports: s, Teams: t, Venues: v, EventChanges: c, Reschedules: rr,
+	}})
+}
+
+// The services must run their multi-step writes on the repositories of the
+// unit of work, never on their own, which would escape the transaction.
+func TestUnitsOfWork(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("DeleteSport counts and deletes in one unit of work", func(t *testing.T) {
+		txSportRepo := new(MockSportRepositoryForService)
+		txEventRepo := new(MockEventRepositoryForSport)
+		txTeamRepo := new(MockTeamRepositoryForService)
+		units := 0
+		service := NewSportService(new(MockSportRepositoryForService), new(MockEventRepositoryForSport),
+			noTx{repos: Repositories{Sports: txSportRepo, Events: txEventRepo, Teams: txTeamRepo}, units: &units})
+		txEventRepo.On("CountEventsBySportID", ctx, 1).Return(0, nil)
+		txTeamRepo.On("CountTeamsBySportID", ctx, 1).Return(0, nil)
+		txSportRepo.On("DeleteSport", ctx, 1).Return(nil)
+
+		require.NoError(t, service.DeleteSport(ctx, 1))
+		assert.Equal(t, 1, units)
+		txEventRepo.AssertExpectations(t)
+		txTeamRepo.AssertExpectations(t)
+		txSportRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdateEvent reads and writes in one unit of work", func(t *testing.T) {
+		txEventRepo := new(MockEventRepository)
+		units := 0
+		service := NewEventService(new(MockEventRepository), 1, 10, new(MockSportRepository),
+			new(MockTeamRepository), new(MockVenueRepository), new(MockEventChangeRepository),
+			new(MockEventRescheduleRepository), noTx{repos: Repositories{
+				Events: txEventRepo, Sports: new(MockSportRepository), Teams: new(MockTeamRepository),
+				Venues: new(MockVenueRepository), EventChanges: new(MockEventChangeRepository),
+				Reschedules: new(MockEventRescheduleRepository),
+			}, units: &units})
+		description := "Derby"
+		txEventRepo.On("GetEventByID", ctx, 1).Return(&Event{ID: 1}, nil)
+		txEventRepo.On("UpdateEvent", ctx, mock.MatchedBy(func(event Event) bool {
+			return event.Description != nil && *event.Description == description
+		})).Return(nil)
+
+		_, err := service.UpdateEvent(ctx, 1, UpdateEventRequest{Description: &description})
+		require.NoError(t, err)
+		assert.Equal(t, 1, units)
+		txEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("MergeTeams checks and merges in one unit of work", func(t *testing.T) {
+		txTeamRepo := new(MockTeamRepositoryForService)
+		txEventRepo := new(MockEventRepositoryForTeam)
+		units := 0
+		service := NewTeamService(new(MockTeamRepositoryForService), new(MockEventRepositoryForTeam),
+			noTx{repos: Repositories{Teams: txTeamRepo, Events: txEventRepo}, units: &units})
+		txTeamRepo.On("GetTeamByID", ctx, 1).Return(&Team{ID: 1, Name: "Red Bull Salzburg", SportID: 1}, nil)
+		txTeamRepo.On("GetTeamByID", ctx, 2).Return(&Team{ID: 2, Name: "FC Salzburg", SportID: 1}, nil)
+		txEventRepo.On("ListTeamEvents", mock.MatchedBy(IncludeDeleted), 2, (*time.Time)(nil), (*time.Time)(nil)).Return([]Event{}, nil)
+		txTeamRepo.On("FindTeamsByName", mock.Anything, mock.Anything, mock.Anything).Return([]Team{}, nil)
+		txTeamRepo.On("MergeTeams", ctx, mock.AnythingOfType("Team"), 2).Return(nil)
+
+		_, err := service.MergeTeams(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 1, units)
+		txTeamRepo.AssertExpectations(t)
+		txEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("DeleteSeries deletes the series and its occurrences in one unit of work", func(t *testing.T) {
+		txSeriesRepo := new(MockSeriesRepository)
+		txEventRepo := new(MockEventRepository)
+		txChangeRepo := new(MockEventChangeRepository)
+		units := 0
+		tx := noTx{repos: Repositories{
+			Events: txEventRepo, Sports: new(MockSportRepository), Teams: new(MockTeamRepository),
+			Venues: new(MockVenueRepository), EventChanges: txChangeRepo,
+			Reschedules: new(MockEventRescheduleRepository), Series: txSeriesRepo,
+		}, units: &units}
+		eventService := NewEventService(new(MockEventRepository), 1, 10, new(MockSportRepository),
+			new(MockTeamRepository), new(MockVenueRepository), new(MockEventChangeRepository),
+			new(MockEventRescheduleRepository), tx)
+		service := NewSeriesService(new(MockSeriesRepository), new(MockEventRepository), new(MockVenueRepository),
+			eventService, 180, tx)
+		txSeriesRepo.On("GetSeriesByID", mock.Anything, 3).Return(&EventSeries{ID: 3}, nil)
+		txEventRepo.On("CountEvents", mock.Anything, mock.Anything).Return(1, nil)
+		txEventRepo.On("ListEvents", mock.Anything, mock.Anything).Return([]Event{{ID: 40}}, nil)
+		txEventRepo.On("GetEventByID", mock.Anything, 40).Return(&Event{ID: 40}, nil)
+		txEventRepo.On("DeleteEvent", mock.Anything, 40).Return(nil)
+		txChangeRepo.On("RecordEventChange", mock.Anything, mock.AnythingOfType("EventChange")).Return(nil)
+		txSeriesRepo.On("DeleteSeries", mock.Anything, 3).Return(nil)
+
+		require.NoError(t, service.DeleteSeries(ctx, 3))
+		assert.Equal(t, 1, units)
+		txEventRepo.AssertExpectations(t)
+		txChangeRepo.AssertExpectations(t)
+		txSeriesRepo.AssertExpectations(t)
+	})
+
+	t.Run("AddException records the date and deletes its occurrences in one unit of work", func(t *testing.T) {
+		txSeriesRepo := new(MockSeriesRepository)
+		txEventRepo := new(MockEventRepository)
+		txChangeRepo := new(MockEventChangeRepository)
+		units := 0
+		tx := noTx{repos: Repositories{
+			Events: txEventRepo, Sports: new(MockSportRepository), Teams: new(MockTeamRepository),
+			Venues: new(MockVenueRepository), EventChanges: txChangeRepo,
+			Reschedules: new(MockEventRescheduleRepository), Series: txSeriesRepo,
+		}, units: &units}
+		eventService := NewEventService(new(MockEventRepository), 1, 10, new(MockSportRepository),
+			new(MockTeamRepository), new(MockVenueRepository), new(MockEventChangeRepository),
+			new(MockEventRescheduleRepository), tx)
+		service := NewSeriesService(new(MockSeriesRepository), new(MockEventRepository), new(MockVenueRepository),
+			eventService, 180, tx)
+		txSeriesRepo.On("GetSeriesByID", mock.Anything, 3).Return(&EventSeries{ID: 3, TimeZone: "UTC"}, nil)
+		txSeriesRepo.On("AddException", mock.Anything, 3, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)).Return(nil)
+		txEventRepo.On("CountEvents", mock.Anything, mock.Anything).Return(1, nil)
+		txEventRepo.On("ListEvents", mock.Anything, mock.Anything).Return([]Event{{ID: 40}}, nil)
+		txEventRepo.On("GetEventByID", mock.Anything, 40).Return(&Event{ID: 40}, nil)
+		txEventRepo.On("DeleteEvent", mock.Anything, 40).Return(nil)
+		txChangeRepo.On("RecordEventChange", mock.Anything, mock.AnythingOfType("EventChange")).Return(nil)
+
+		require.NoError(t, service.AddException(ctx, 3, SeriesExceptionRequest{Date: "2026-06-01"}))
+		assert.Equal(t, 1, units)
+		txEventRepo.AssertExpectations(t)
+		txChangeRepo.AssertExpectations(t)
+		txSeriesRepo.AssertExpectations(t)
+	})
+
+	t.Run("failing unit of work reports its error", func(t *testing.T) {
+		txEventRepo := new(MockEventRepositoryForVenue)
+		failure := errors.New("connection reset")
+		service := NewVenueService(new(MockVenueRepositoryForService), new(MockEventRepositoryForVenue),
+			noTx{repos: Repositories{Venues: new(MockVenueRepositoryForService), Events: txEventRepo}})
+		txEventRepo.On("CountEventsByVenueId", ctx, 1).Return(0, failure)
+
+		err := service.DeleteVenue(ctx, 1)
+		assert.ErrorIs(t, err, failure)
+	})
+}
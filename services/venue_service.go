@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 type VenueRepositoryInterface interface {
@@ -11,6 +12,12 @@ type VenueRepositoryInterface interface {
 	ListVenues(ctx context.Context) ([]Venue, error)
 	UpdateVenue(ctx context.Context, venue Venue) error
 	DeleteVenue(ctx context.Context, id int) error
+	RestoreVenue(ctx context.Context, id int) error
+	ListVenuesNearby(ctx context.Context, point GeoPoint, radiusKm float64) ([]Venue, error)
+	// MergeVenues re-points everything referencing the duplicate venue to
+	// the survivor, deletes the duplicate and saves the survivor, in one
+	// transaction.
+	MergeVenues(ctx context.Context, survivor Venue, duplicateID int) error
 }
 
 type VenueServiceInterface interface {
@@ -19,15 +26,21 @@ type VenueServiceInterface interface {
 	ListVenues(ctx context.Context) ([]Venue, error)
 	UpdateVenue(ctx context.Context, id int, req UpdateVenueRequest) error
 	DeleteVenue(ctx context.Context, id int) error
+	RestoreVenue(ctx context.Context, id int) (*Venue, error)
+	ListVenuesNearby(ctx context.Context, point GeoPoint, radiusKm float64) ([]Venue, error)
+	GetAttendanceStats(ctx context.Context, id int, from, to *time.Time) (*AttendanceStats, error)
+	ListDuplicateCandidates(ctx context.Context, minSimilarity float64) ([]VenueDuplicateCandidate, error)
+	MergeVenues(ctx context.Context, survivorID, duplicateID int) (*Venue, error)
 }
 
 type VenueService struct {
 	venueRepository VenueRepositoryInterface
 	eventRepository EventRepositoryInterface
+	transactor Transactor
 }
 
-func NewVenueService(v VenueRepositoryInterface, e EventRepositoryInterface) *VenueService{
-	return &VenueService{venueRepository: v, eventRepository: e}
+func NewVenueService(v VenueRepositoryInterface, e EventRepositoryInterface, tx Transactor) *VenueService{
+	return &VenueService{venueRepository: v, eventRepository: e, transactor: tx}
 }
 
 func (s *VenueService) CreateVenue(ctx context.Context, req CreateVenueRequest) (int, error) {
@@ -38,6 +51,20 @@ func (s *VenueService) CreateVenue(ctx context.Context, req CreateVenueRequest)
 	} else if len (req.CountryCode) != 2 {
 		return 0, fmt.Errorf("venue city code must be 2 characters long")
 	}
+	if req.TimeZone == "" {
+		req.TimeZone = DefaultTimeZone
+	}
+	if _, err := LoadTimeZone(req.TimeZone); err != nil {
+		return 0, fmt.Errorf("venue time zone must be a valid IANA time zone name")
+	}
+	if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+		return 0, err
+	}
+	if req.Capacity != nil {
+		if err := validateCapacity(*req.Capacity); err != nil {
+			return 0, err
+		}
+	}
 	params := VenueRequest(req)
 	newID, err := s.venueRepository.CreateVenue(ctx, params)
 	if err != nil {
@@ -67,6 +94,9 @@ func (s *VenueService) UpdateVenue(ctx context.Context, id int, req UpdateVenueR
 	if err != nil {
 		return fmt.Errorf("database error: %w", err)
 	}
+	if err := checkVersion(ctx, AuditEntityVenue, id, existingVenue.Version); err != nil {
+		return err
+	}
 	if req.Name != nil {
 		if len(*req.Name) < 3 {
 			return fmt.Errorf("venue name must be at least 3 characters long")
@@ -85,6 +115,34 @@ func (s *VenueService) UpdateVenue(ctx context.Context, id int, req UpdateVenueR
 		}
 		existingVenue.CountryCode = *req.CountryCode
 	}
+	if req.TimeZone != nil {
+		if _, err := LoadTimeZone(*req.TimeZone); err != nil || *req.TimeZone == "" {
+			return fmt.Errorf("venue time zone must be a valid IANA time zone name")
+		}
+		existingVenue.TimeZone = *req.TimeZone
+	}
+	if req.Address != nil {
+		existingVenue.Address = emptyToNil(*req.Address)
+	}
+	if req.PostalCode != nil {
+		existingVenue.PostalCode = emptyToNil(*req.PostalCode)
+	}
+	if req.Latitude != nil || req.Longitude != nil {
+		if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+			return err
+		}
+		existingVenue.Latitude = req.Latitude
+		existingVenue.Longitude = req.Longitude
+	}
+	if req.Capacity != nil {
+		if *req.Capacity == 0 {
+			existingVenue.Capacity = nil
+		} else if err := validateCapacity(*req.Capacity); err != nil {
+			return err
+		} else {
+			existingVenue.Capacity = req.Capacity
+		}
+	}
 	err = s.venueRepository.UpdateVenue(ctx, *existingVenue)
 	if err != nil {
 		return fmt.Errorf("failed to update venue: %w", err)
@@ -92,17 +150,149 @@ func (s *VenueService) UpdateVenue(ctx context.Context, id int, req UpdateVenueR
 	return nil
 }
 
+// DeleteVenue deletes a venue nothing is booked into; the count and the
+// delete share a transaction, so a booking cannot slip in between.
 func (s *VenueService) DeleteVenue(ctx context.Context, id int) error {
-	count, err := s.eventRepository.CountEventsByVenueId(ctx, id)
+	return s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		if _, ok := ExpectedVersion(ctx); ok {
+			venue, err := repos.Venues.GetVenueById(ctx, id)
+			if err != nil {
+				return fmt.Errorf("database error: %w", err)
+			}
+			if err := checkVersion(ctx, AuditEntityVenue, id, venue.Version); err != nil {
+				return err
+			}
+		}
+		count, err := repos.Events.CountEventsByVenueId(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check event usage: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("cannot delete venue: it is currently used by %d events", count)
+		}
+		err = repos.Venues.DeleteVenue(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete venue: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *VenueService) RestoreVenue(ctx context.Context, id int) (*Venue, error) {
+	venue, err := s.venueRepository.GetVenueById(WithDeleted(ctx), id)
 	if err != nil {
-		return fmt.Errorf("failed to check event usage: %w", err)
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if venue.DeletedAt == nil {
+		return nil, notDeletedError("venue", id)
+	}
+	if err := s.venueRepository.RestoreVenue(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	venue.DeletedAt = nil
+	venue.Version++
+	return venue, nil
+}
+
+// ListVenuesNearby returns the venues within radiusKm of point, nearest
+// first.
+func (s *VenueService) ListVenuesNearby(ctx context.Context, point GeoPoint, radiusKm float64) ([]Venue, error) {
+	if err := validateGeoPoint(point); err != nil {
+		return nil, err
 	}
-	if count > 0 {
-		return fmt.Errorf("cannot delete venue: it is currently used by %d events", count)
+	radiusKm, err := resolveRadiusKm(radiusKm)
+	if err != nil {
+		return nil, err
 	}
-	err = s.venueRepository.DeleteVenue(ctx, id)
+	venues, err := s.venueRepository.ListVenuesNearby(ctx, point, radiusKm)
 	if err != nil {
-		return fmt.Errorf("failed to delete venue: %w", err)
+		return nil, fmt.Errorf("failed to list nearby venues: %w", err)
 	}
-	return nil
+	return venues, nil
+}
+
+// GetAttendanceStats aggregates the recorded attendance of the events at a
+// venue kicking off in from..to.
+func (s *VenueService) GetAttendanceStats(ctx context.Context, id int, from, to *time.Time) (*AttendanceStats, error) {
+	if _, err := s.venueRepository.GetVenueById(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return attendanceStats(ctx, s.eventRepository, AttendanceStatsParams{VenueID: &id, DateFrom: from, DateTo: to})
+}
+
+// ListDuplicateCandidates returns the pairs of venues of a country whose
+// names and cities are at least minSimilarity alike (0 means the default),
+// most similar first.
+func (s *VenueService) ListDuplicateCandidates(ctx context.Context, minSimilarity float64) ([]VenueDuplicateCandidate, error) {
+	minSimilarity, err := validateMinSimilarity(minSimilarity)
+	if err != nil {
+		return nil, err
+	}
+	venues, err := s.venueRepository.ListVenues(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list venues: %w", err)
+	}
+	return findVenueDuplicates(venues, minSimilarity), nil
+}
+
+// MergeVenues moves the events, series and reschedule history of the
+// duplicate venue to the survivor and deletes the duplicate. The survivor
+// takes over the address, postal code, coordinates and capacity of the
+// duplicate where it has none of its own. Moving an event onto a booking of
+// the survivor fails with ErrVenueConflict.
+//
+// The venues are read and merged in one transaction, so that the survivor
+// saved is the one read.
+func (s *VenueService) MergeVenues(ctx context.Context, survivorID, duplicateID int) (*Venue, error) {
+	if survivorID == duplicateID {
+		return nil, fmt.Errorf("validation error: a venue cannot be merged into itself")
+	}
+	var survivor *Venue
+	err := s.transactor.WithinTx(ctx, func(repos Repositories) error {
+		var err error
+		survivor, err = mergeVenues(ctx, repos.Venues, survivorID, duplicateID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return survivor, nil
+}
+
+func mergeVenues(ctx context.Context, venues VenueRepositoryInterface, survivorID, duplicateID int) (*Venue, error) {
+	survivor, err := venues.GetVenueById(ctx, survivorID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if err := checkVersion(ctx, AuditEntityVenue, survivorID, survivor.Version); err != nil {
+		return nil, err
+	}
+	duplicate, err := venues.GetVenueById(ctx, duplicateID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if survivor.Address == nil {
+		survivor.Address = duplicate.Address
+	}
+	if survivor.PostalCode == nil {
+		survivor.PostalCode = duplicate.PostalCode
+	}
+	if survivor.Latitude == nil {
+		survivor.Latitude = duplicate.Latitude
+		survivor.Longitude = duplicate.Longitude
+	}
+	if survivor.Capacity == nil {
+		survivor.Capacity = duplicate.Capacity
+	}
+	if err := venues.MergeVenues(ctx, *survivor, duplicateID); err != nil {
+		return nil, fmt.Errorf("failed to merge venues: %w", err)
+	}
+	return survivor, nil
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
 }
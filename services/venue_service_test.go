@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -36,6 +37,14 @@ func (m *MockVenueRepositoryForService) ListVenues(ctx context.Context) ([]Venue
 	return args.Get(0).([]Venue), args.Error(1)
 }
 
+func (m *MockVenueRepositoryForService) ListVenuesNearby(ctx context.Context, point GeoPoint, radiusKm float64) ([]Venue, error) {
+	args := m.Called(ctx, point, radiusKm)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Venue), args.Error(1)
+}
+
 func (m *MockVenueRepositoryForService) UpdateVenue(ctx context.Context, venue Venue) error {
 	args := m.Called(ctx, venue)
 	return args.Error(0)
@@ -46,6 +55,16 @@ func (m *MockVenueRepositoryForService) DeleteVenue(ctx context.Context, id int)
 	return args.Error(0)
 }
 
+func (m *MockVenueRepositoryForService) RestoreVenue(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepositoryForService) MergeVenues(ctx context.Context, survivor Venue, duplicateID int) error {
+	args := m.Called(ctx, survivor, duplicateID)
+	return args.Error(0)
+}
+
 // MockEventRepositoryForVenue is a mock for EventRepositoryInterface used in VenueService tests
 type MockEventRepositoryForVenue struct {
 	mock.Mock
@@ -102,6 +121,35 @@ func (m *MockEventRepositoryForVenue) DeleteEvent(ctx context.Context, id int) e
 	return args.Error(0)
 }
 
+func (m *MockEventRepositoryForVenue) RestoreEvent(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockEventRepositoryForVenue) ListVenueConflicts(ctx context.Context, venueID int, start, end time.Time, excludeEventID int) ([]Event, error) {
+	args := m.Called(ctx, venueID, start, end, excludeEventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Event), args.Error(1)
+}
+
+func (m *MockEventRepositoryForVenue) ListTeamEvents(ctx context.Context, teamID int, from, to *time.Time) ([]Event, error) {
+	args := m.Called(ctx, teamID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Event), args.Error(1)
+}
+
+func (m *MockEventRepositoryForVenue) GetAttendanceStats(ctx context.Context, params AttendanceStatsParams) (*AttendanceStats, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*AttendanceStats), args.Error(1)
+}
+
 func TestVenueService_CreateVenue(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -143,6 +191,54 @@ func TestVenueService_CreateVenue(t *testing.T) {
 			expectedID:    0,
 			expectedError: true,
 		},
+		{
+			name:          "successful creation with time zone",
+			request:      CreateVenueRequest{Name: "Staples Center", City: "Los Angeles", CountryCode: "US", TimeZone: "America/Los_Angeles"},
+			mockID:        2,
+			mockError:     nil,
+			expectedID:    2,
+			expectedError: false,
+		},
+		{
+			name:          "invalid time zone",
+			request:      CreateVenueRequest{Name: "Staples Center", City: "Los Angeles", CountryCode: "US", TimeZone: "Mars/Olympus"},
+			mockID:        0,
+			mockError:     nil,
+			expectedID:    0,
+			expectedError: true,
+		},
+		{
+			name:          "successful creation with coordinates",
+			request:      CreateVenueRequest{Name: "Staples Center", City: "Los Angeles", CountryCode: "US", Latitude: float64Ptr(34.043), Longitude: float64Ptr(-118.267)},
+			mockID:        3,
+			mockError:     nil,
+			expectedID:    3,
+			expectedError: false,
+		},
+		{
+			name:          "latitude without longitude",
+			request:      CreateVenueRequest{Name: "Staples Center", City: "Los Angeles", CountryCode: "US", Latitude: float64Ptr(34.043)},
+			mockID:        0,
+			mockError:     nil,
+			expectedID:    0,
+			expectedError: true,
+		},
+		{
+			name:          "latitude out of range",
+			request:      CreateVenueRequest{Name: "Staples Center", City: "Los Angeles", CountryCode: "US", Latitude: float64Ptr(91), Longitude: float64Ptr(-118.267)},
+			mockID:        0,
+			mockError:     nil,
+			expectedID:    0,
+			expectedError: true,
+		},
+		{
+			name:          "non-positive capacity",
+			request:      CreateVenueRequest{Name: "Staples Center", City: "Los Angeles", CountryCode: "US", Capacity: intPtr(0)},
+			mockID:        0,
+			mockError:     nil,
+			expectedID:    0,
+			expectedError: true,
+		},
 		{
 			name:          "database error",
 			request:      CreateVenueRequest{Name: "Staples Center", City: "Los Angeles", CountryCode: "US"},
@@ -158,7 +254,7 @@ func TestVenueService_CreateVenue(t *testing.T) {
 			mockRepo := new(MockVenueRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForVenue)
 
-			service := NewVenueService(mockRepo, mockEventRepo)
+			service := newTestVenueService(mockRepo, mockEventRepo)
 
 			if !tt.expectedError || tt.name == "database error" {
 				mockRepo.On("CreateVenue", mock.Anything, mock.AnythingOfType("VenueRequest")).Return(tt.mockID, tt.mockError)
@@ -210,7 +306,7 @@ func TestVenueService_GetVenueByID(t *testing.T) {
 			mockRepo := new(MockVenueRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForVenue)
 
-			service := NewVenueService(mockRepo, mockEventRepo)
+			service := newTestVenueService(mockRepo, mockEventRepo)
 
 			mockRepo.On("GetVenueById", mock.Anything, tt.venueID).Return(tt.mockVenue, tt.mockError)
 
@@ -266,7 +362,7 @@ func TestVenueService_ListVenues(t *testing.T) {
 			mockRepo := new(MockVenueRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForVenue)
 
-			service := NewVenueService(mockRepo, mockEventRepo)
+			service := newTestVenueService(mockRepo, mockEventRepo)
 
 			mockRepo.On("ListVenues", mock.Anything).Return(tt.mockVenues, tt.mockError)
 
@@ -349,7 +445,7 @@ func TestVenueService_UpdateVenue(t *testing.T) {
 			mockRepo := new(MockVenueRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForVenue)
 
-			service := NewVenueService(mockRepo, mockEventRepo)
+			service := newTestVenueService(mockRepo, mockEventRepo)
 
 			mockRepo.On("GetVenueById", mock.Anything, tt.venueID).Return(tt.mockVenue, tt.mockError)
 
@@ -410,7 +506,7 @@ func TestVenueService_DeleteVenue(t *testing.T) {
 			mockRepo := new(MockVenueRepositoryForService)
 			mockEventRepo := new(MockEventRepositoryForVenue)
 
-			service := NewVenueService(mockRepo, mockEventRepo)
+			service := newTestVenueService(mockRepo, mockEventRepo)
 
 			mockEventRepo.On("CountEventsByVenueId", mock.Anything, tt.venueID).Return(tt.eventCount, tt.countError)
 
@@ -434,3 +530,195 @@ func TestVenueService_DeleteVenue(t *testing.T) {
 	}
 }
 
+func TestVenueService_ListVenuesNearby(t *testing.T) {
+	vienna := GeoPoint{Latitude: 48.2082, Longitude: 16.3738}
+
+	tests := []struct {
+		name           string
+		point          GeoPoint
+		radiusKm       float64
+		expectedRadius float64
+		mockVenues     []Venue
+		mockError      error
+		expectedError  bool
+	}{
+		{
+			name:           "default radius",
+			point:          vienna,
+			radiusKm:       0,
+			expectedRadius: DefaultNearbyRadiusKm,
+			mockVenues:     []Venue{{ID: 1, Name: "Ernst Happel Stadion"}},
+		},
+		{
+			name:           "explicit radius",
+			point:          vienna,
+			radiusKm:       5,
+			expectedRadius: 5,
+			mockVenues:     []Venue{},
+		},
+		{
+			name:          "radius too large",
+			point:         vienna,
+			radiusKm:      MaxNearbyRadiusKm + 1,
+			expectedError: true,
+		},
+		{
+			name:          "longitude out of range",
+			point:         GeoPoint{Latitude: 48.2, Longitude: 181},
+			expectedError: true,
+		},
+		{
+			name:           "database error",
+			point:          vienna,
+			expectedRadius: DefaultNearbyRadiusKm,
+			mockError:      errors.New("database error"),
+			expectedError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockVenueRepositoryForService)
+			mockEventRepo := new(MockEventRepositoryForVenue)
+
+			service := newTestVenueService(mockRepo, mockEventRepo)
+
+			if tt.expectedRadius != 0 {
+				mockRepo.On("ListVenuesNearby", mock.Anything, tt.point, tt.expectedRadius).Return(tt.mockVenues, tt.mockError)
+			}
+
+			result, err := service.ListVenuesNearby(context.Background(), tt.point, tt.radiusKm)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, len(tt.mockVenues), len(result))
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVenueService_GetAttendanceStats(t *testing.T) {
+	from := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	stats := &AttendanceStats{EventCount: 2, TotalAttendance: 50000, AverageAttendance: 25000, PeakAttendance: 29520, PeakEventID: intPtr(1)}
+
+	t.Run("aggregates the venue's events", func(t *testing.T) {
+		mockRepo := new(MockVenueRepositoryForService)
+		mockEventRepo := new(MockEventRepositoryForVenue)
+		service := newTestVenueService(mockRepo, mockEventRepo)
+
+		mockRepo.On("GetVenueById", mock.Anything, 1).Return(&Venue{ID: 1}, nil)
+		mockEventRepo.On("GetAttendanceStats", mock.Anything, mock.MatchedBy(func(p AttendanceStatsParams) bool {
+			return p.VenueID != nil && *p.VenueID == 1 && p.TeamID == nil && p.DateFrom == &from && p.DateTo == &to
+		})).Return(stats, nil)
+
+		result, err := service.GetAttendanceStats(context.Background(), 1, &from, &to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, stats, result)
+		mockEventRepo.AssertExpectations(t)
+	})
+
+	t.Run("venue not found", func(t *testing.T) {
+		mockRepo := new(MockVenueRepositoryForService)
+		mockEventRepo := new(MockEventRepositoryForVenue)
+		service := newTestVenueService(mockRepo, mockEventRepo)
+
+		mockRepo.On("GetVenueById", mock.Anything, 999).Return(nil, sql.ErrNoRows)
+
+		_, err := service.GetAttendanceStats(context.Background(), 999, nil, nil)
+
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		mockEventRepo.AssertNotCalled(t, "GetAttendanceStats", mock.Anything, mock.Anything)
+	})
+
+	t.Run("inverted date range", func(t *testing.T) {
+		mockRepo := new(MockVenueRepositoryForService)
+		mockEventRepo := new(MockEventRepositoryForVenue)
+		service := newTestVenueService(mockRepo, mockEventRepo)
+
+		mockRepo.On("GetVenueById", mock.Anything, 1).Return(&Venue{ID: 1}, nil)
+
+		_, err := service.GetAttendanceStats(context.Background(), 1, &to, &from)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+		mockEventRepo.AssertNotCalled(t, "GetAttendanceStats", mock.Anything, mock.Anything)
+	})
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestVenueService_ListDuplicateCandidates(t *testing.T) {
+	mockRepo := new(MockVenueRepositoryForService)
+	service := newTestVenueService(mockRepo, new(MockEventRepositoryForVenue))
+	venues := []Venue{
+		{ID: 1, Name: "Allianz Arena", City: "München", CountryCode: "DE"},
+		{ID: 2, Name: "Allianz-Arena", City: "Munchen", CountryCode: "DE"},
+		{ID: 3, Name: "Olympiastadion", City: "Berlin", CountryCode: "DE"},
+	}
+
+	mockRepo.On("ListVenues", mock.Anything).Return(venues, nil)
+
+	candidates, err := service.ListDuplicateCandidates(context.Background(), 0.9)
+
+	assert.NoError(t, err)
+	if assert.Len(t, candidates, 1) {
+		assert.Equal(t, 1, candidates[0].First.ID)
+		assert.Equal(t, 2, candidates[0].Second.ID)
+		assert.Equal(t, 1.0, candidates[0].Similarity)
+	}
+}
+
+func TestVenueService_MergeVenues(t *testing.T) {
+	t.Run("fills missing details from the duplicate", func(t *testing.T) {
+		mockRepo := new(MockVenueRepositoryForService)
+		service := newTestVenueService(mockRepo, new(MockEventRepositoryForVenue))
+		survivor := &Venue{ID: 1, Name: "Allianz Arena", City: "München", CountryCode: "DE", Capacity: intPtr(75000)}
+		duplicate := &Venue{ID: 2, Name: "Allianz-Arena", City: "Munchen", CountryCode: "DE", Capacity: intPtr(70000),
+			Address: stringPtr("Werner-Heisenberg-Allee 25"), Latitude: float64Ptr(48.2188), Longitude: float64Ptr(11.6247)}
+
+		mockRepo.On("GetVenueById", mock.Anything, 1).Return(survivor, nil)
+		mockRepo.On("GetVenueById", mock.Anything, 2).Return(duplicate, nil)
+		mockRepo.On("MergeVenues", mock.Anything, mock.MatchedBy(func(venue Venue) bool {
+			return venue.ID == 1 && *venue.Capacity == 75000 && *venue.Address == "Werner-Heisenberg-Allee 25" &&
+				*venue.Latitude == 48.2188 && *venue.Longitude == 11.6247
+		}), 2).Return(nil)
+
+		venue, err := service.MergeVenues(context.Background(), 1, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Allianz Arena", venue.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("same venue", func(t *testing.T) {
+		mockRepo := new(MockVenueRepositoryForService)
+		service := newTestVenueService(mockRepo, new(MockEventRepositoryForVenue))
+
+		_, err := service.MergeVenues(context.Background(), 1, 1)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation error")
+	})
+
+	t.Run("overlapping bookings", func(t *testing.T) {
+		mockRepo := new(MockVenueRepositoryForService)
+		service := newTestVenueService(mockRepo, new(MockEventRepositoryForVenue))
+
+		mockRepo.On("GetVenueById", mock.Anything, 1).Return(&Venue{ID: 1}, nil)
+		mockRepo.On("GetVenueById", mock.Anything, 2).Return(&Venue{ID: 2}, nil)
+		mockRepo.On("MergeVenues", mock.Anything, mock.Anything, 2).Return(ErrVenueConflict)
+
+		_, err := service.MergeVenues(context.Background(), 1, 2)
+
+		assert.ErrorIs(t, err, ErrVenueConflict)
+	})
+}
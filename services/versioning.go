@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrVersionMismatch is matched by every VersionMismatchError.
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// VersionMismatchError rejects a write that was conditional on a version the
+// entity has since moved past.
+type VersionMismatchError struct {
+	Entity         string
+	ID             int
+	CurrentVersion int
+}
+
+func (e *VersionMismatchError) Error() string {
+	return fmt.Sprintf("%s %d was modified: it is at version %d", e.Entity, e.ID, e.CurrentVersion)
+}
+
+func (e *VersionMismatchError) Is(target error) bool {
+	return target == ErrVersionMismatch
+}
+
+type expectedVersionKey struct{}
+
+// WithExpectedVersion returns a context under which the update or deletion
+// of an entity only goes ahead while the entity is at version, as asked for
+// by an If-Match header.
+func WithExpectedVersion(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, version)
+}
+
+// ExpectedVersion returns the version set by WithExpectedVersion, if any.
+func ExpectedVersion(ctx context.Context) (int, bool) {
+	version, ok := ctx.Value(expectedVersionKey{}).(int)
+	return version, ok
+}
+
+// withoutExpectedVersion drops the expected version of ctx, for the writes a
+// service fans out to entities other than the one it was asked about.
+func withoutExpectedVersion(ctx context.Context) context.Context {
+	if _, ok := ExpectedVersion(ctx); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, expectedVersionKey{}, nil)
+}
+
+// checkVersion fails with a VersionMismatchError when ctx expects the entity
+// at another version than current.
+func checkVersion(ctx context.Context, entity string, id, current int) error {
+	if expected, ok := ExpectedVersion(ctx); ok && expected != current {
+		return &VersionMismatchError{Entity: entity, ID: id, CurrentVersion: current}
+	}
+	return nil
+}
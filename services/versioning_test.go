@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		current int
+		wantErr bool
+	}{
+		{name: "no expected version", ctx: context.Background(), current: 4},
+		{name: "expected version matches", ctx: WithExpectedVersion(context.Background(), 4), current: 4},
+		{name: "expected version is stale", ctx: WithExpectedVersion(context.Background(), 3), current: 4, wantErr: true},
+		{name: "expected version dropped", ctx: withoutExpectedVersion(WithExpectedVersion(context.Background(), 3)), current: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVersion(tt.ctx, AuditEntityTeam, 7, tt.current)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrVersionMismatch)
+			assert.Equal(t, "team 7 was modified: it is at version 4", err.Error())
+		})
+	}
+}
+
+func TestVersionPreconditions(t *testing.T) {
+	ctx := WithExpectedVersion(context.Background(), 2)
+
+	t.Run("UpdateSport writes at the expected version", func(t *testing.T) {
+		sportRepo := new(MockSportRepositoryForService)
+		sportRepo.On("GetSportById", ctx, 1).Return(&Sport{ID: 1, DefaultDurationMinutes: 120, RestConflictPolicy: RestPolicyReject, Version: 2}, nil)
+		sportRepo.On("UpdateSport", ctx, mock.MatchedBy(func(sport Sport) bool {
+			return sport.ID == 1 && sport.Version == 2
+		})).Return(nil)
+
+		err := newTestSportService(sportRepo, new(MockEventRepositoryForSport)).
+			UpdateSport(ctx, 1, SportRequest{Name: "Futsal"})
+		require.NoError(t, err)
+		sportRepo.AssertExpectations(t)
+	})
+
+	t.Run("DeleteSport of a modified sport", func(t *testing.T) {
+		sportRepo := new(MockSportRepositoryForService)
+		sportRepo.On("GetSportById", ctx, 1).Return(&Sport{ID: 1, Version: 3}, nil)
+
+		err := newTestSportService(sportRepo, new(MockEventRepositoryForSport)).DeleteSport(ctx, 1)
+		var mismatch *VersionMismatchError
+		require.ErrorAs(t, err, &mismatch)
+		assert.Equal(t, 3, mismatch.CurrentVersion)
+		sportRepo.AssertNotCalled(t, "DeleteSport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("MergeTeams into a modified survivor", func(t *testing.T) {
+		teamRepo := new(MockTeamRepositoryForService)
+		teamRepo.On("GetTeamByID", ctx, 1).Return(&Team{ID: 1, SportID: 1, Version: 5}, nil)
+
+		_, err := newTestTeamService(teamRepo, new(MockEventRepositoryForTeam)).MergeTeams(ctx, 1, 2)
+		assert.ErrorIs(t, err, ErrVersionMismatch)
+		teamRepo.AssertNotCalled(t, "MergeTeams", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("MergeVenues into a modified survivor", func(t *testing.T) {
+		venueRepo := new(MockVenueRepositoryForService)
+		venueRepo.On("GetVenueById", ctx, 1).Return(&Venue{ID: 1, Version: 5}, nil)
+
+		_, err := newTestVenueService(venueRepo, new(MockEventRepositoryForVenue)).MergeVenues(ctx, 1, 2)
+		assert.ErrorIs(t, err, ErrVersionMismatch)
+		venueRepo.AssertNotCalled(t, "MergeVenues", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("UpdateEvent of a modified event", func(t *testing.T) {
+		eventRepo := new(MockEventRepository)
+		eventRepo.On("GetEventByID", ctx, 5).Return(&Event{ID: 5, Version: 1}, nil)
+		description := "Derby"
+
+		service := newTestEventService(eventRepo, new(MockSportRepository), new(MockTeamRepository),
+			new(MockVenueRepository), new(MockEventChangeRepository), new(MockEventRescheduleRepository))
+		_, err := service.UpdateEvent(ctx, 5, UpdateEventRequest{Description: &description})
+		assert.ErrorIs(t, err, ErrVersionMismatch)
+		eventRepo.AssertNotCalled(t, "UpdateEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DeleteSeries checks the series, not its occurrences", func(t *testing.T) {
+		seriesRepo := new(MockSeriesRepository)
+		eventRepo := new(MockEventRepository)
+		eventService := new(MockEventServiceForSeries)
+		unconditional := mock.MatchedBy(func(ctx context.Context) bool {
+			_, ok := ExpectedVersion(ctx)
+			return !ok
+		})
+		seriesRepo.On("GetSeriesByID", ctx, 3).Return(&EventSeries{ID: 3, Version: 2}, nil)
+		eventRepo.On("CountEvents", unconditional, mock.Anything).Return(1, nil)
+		eventRepo.On("ListEvents", unconditional, mock.Anything).Return([]Event{{ID: 40, Version: 6}}, nil)
+		eventService.On("DeleteEvent", unconditional, 40).Return(nil)
+		seriesRepo.On("DeleteSeries", unconditional, 3).Return(nil)
+
+		service := newTestSeriesService(seriesRepo, eventRepo, new(MockVenueRepository), eventService, 180)
+		require.NoError(t, service.DeleteSeries(ctx, 3))
+		eventService.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+	})
+
+	t.Run("DeleteOccurrence of a modified occurrence", func(t *testing.T) {
+		seriesRepo := new(MockSeriesRepository)
+		eventRepo := new(MockEventRepository)
+		eventService := new(MockEventServiceForSeries)
+		seriesRepo.On("GetSeriesByID", ctx, 3).Return(&EventSeries{ID: 3, TimeZone: "UTC"}, nil)
+		eventRepo.On("GetEventByID", ctx, 40).Return(&Event{
+			ID: 40, EventDatetime: time.Date(2026, 3, 17, 19, 0, 0, 0, time.UTC), SeriesID: intPtr(3), Version: 4,
+		}, nil)
+
+		service := newTestSeriesService(seriesRepo, eventRepo, new(MockVenueRepository), eventService, 180)
+		err := service.DeleteOccurrence(ctx, 3, 40, "")
+		assert.ErrorIs(t, err, ErrVersionMismatch)
+		eventService.AssertNotCalled(t, "DeleteEvent", mock.Anything, mock.Anything)
+	})
+}
@@ -14,6 +14,7 @@ export function buildEventsUrl() {
 
     if (state.currentDateFilter) {
         url.searchParams.set('date_from', state.currentDateFilter);
+        url.searchParams.set('tz', Intl.DateTimeFormat().resolvedOptions().timeZone);
     }
 
     if (state.currentSportFilter) {